@@ -0,0 +1,323 @@
+// Package main - adopt.go
+//
+// PURPOSE:
+// This file implements `seed adopt`, which brings seed's agentic docs to a
+// repository that already exists. It's responsible for:
+// - Detecting the languages (from build files), the build/test commands
+//   (Makefile targets, package.json scripts, else the stack's defaults) and
+//   a description (the README's first paragraph)
+// - Writing only the docs and skills that are missing: AGENTS.md,
+//   DECISIONS.md, TODO.md, LEARNINGS.md, README.md when there's none, and
+//   skills/
+// - Recording an adopted manifest, so status, upgrade and --sync stay
+//   within what adopt wrote
+//
+// DESIGN PATTERNS:
+// - Detection stands in for the wizard: the language comes from build
+//   files rather than a dev container image, and the commands go straight
+//   into AGENTS.md
+// - Existing files are never touched, as with --sync; the plan lists them
+// - Plan/apply split like rename.go and upgrade.go: planAdopt is read-only
+//
+// USAGE:
+// plan, err := planAdopt(scaffolder, dir, adoptOptions{})
+// err = applyAdopt(dir, plan)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// adoptDocs are the files adopt may write besides skills/.
+var adoptDocs = []string{"AGENTS.md", "DECISIONS.md", "TODO.md", "LEARNINGS.md", "README.md"}
+
+// languageMarkers are the build files that identify each stack, in catalog
+// order (glob patterns, relative to the repository root).
+var languageMarkers = []struct {
+	Language string
+	Patterns []string
+}{
+	{"go", []string{"go.mod"}},
+	{"node", []string{"package.json"}},
+	{"python", []string{"pyproject.toml", "setup.py", "requirements.txt"}},
+	{"rust", []string{"Cargo.toml"}},
+	{"java", []string{"pom.xml", "build.gradle", "build.gradle.kts"}},
+	{"dotnet", []string{"*.sln", "*.csproj"}},
+	{"cpp", []string{"CMakeLists.txt"}},
+}
+
+// makeTargets matches the Makefile targets worth listing as commands.
+var makeTargets = regexp.MustCompile(`(?m)^(build|test|lint|check|fmt|format|run|dev)\s*:`)
+
+// adoptOptions are the flags of `seed adopt`.
+type adoptOptions struct {
+	Name        string // Project name ("" for the directory name)
+	Description string // Description ("" to take it from the README)
+	NoSkills    bool
+}
+
+// detection is what adopt found in a repository.
+type detection struct {
+	Languages   []string          // Stack IDs, in catalog order; the first is the project's
+	Markers     map[string]string // Build file each language was detected from
+	Commands    []string          // Build, test and lint commands
+	Description string            // The README's first paragraph ("" when none)
+}
+
+// adoptPlan is what adopting a repository would write.
+type adoptPlan struct {
+	Answers  WizardData
+	Detected detection
+	Create   []RenderedFile // Missing docs and skills
+	Existing []string       // Docs and skills that exist and are left alone
+}
+
+// detectRepository inspects the repository at dir.
+func detectRepository(dir string) (detection, error) {
+	d := detection{Markers: map[string]string{}}
+	for _, m := range languageMarkers {
+		for _, pattern := range m.Patterns {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				return d, err
+			}
+			if len(matches) > 0 {
+				d.Languages = append(d.Languages, m.Language)
+				d.Markers[m.Language] = filepath.Base(matches[0])
+				break
+			}
+		}
+	}
+	commands, err := detectCommands(dir, d.Languages)
+	if err != nil {
+		return d, err
+	}
+	d.Commands = commands
+	if raw, err := os.ReadFile(filepath.Join(dir, "README.md")); err == nil {
+		d.Description = readmeDescription(string(raw))
+	}
+	return d, nil
+}
+
+// detectCommands lists the repository's own commands: Makefile targets and
+// package.json scripts, else the build and test commands of the first
+// detected stack.
+func detectCommands(dir string, languages []string) ([]string, error) {
+	var commands []string
+	if raw, err := os.ReadFile(filepath.Join(dir, "Makefile")); err == nil {
+		for _, m := range makeTargets.FindAllStringSubmatch(string(raw), -1) {
+			commands = append(commands, "make "+m[1])
+		}
+	}
+
+	if raw, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if err := json.Unmarshal(raw, &pkg); err != nil {
+			return nil, fmt.Errorf("invalid package.json: %w", err)
+		}
+		runner := "npm run "
+		switch {
+		case fileExists(filepath.Join(dir, "pnpm-lock.yaml")):
+			runner = "pnpm "
+		case fileExists(filepath.Join(dir, "yarn.lock")):
+			runner = "yarn "
+		}
+		for _, script := range []string{"build", "test", "lint", "dev", "start"} {
+			if _, ok := pkg.Scripts[script]; !ok {
+				continue
+			}
+			if runner == "npm run " && (script == "test" || script == "start") {
+				commands = append(commands, "npm "+script)
+			} else {
+				commands = append(commands, runner+script)
+			}
+		}
+	}
+
+	if len(commands) == 0 && len(languages) > 0 {
+		switch st := stackFor(languages[0]); {
+		case languages[0] == "java" && fileExists(filepath.Join(dir, "pom.xml")):
+			commands = []string{"mvn package", "mvn test"}
+		case st != nil:
+			commands = []string{st.Build, st.Test}
+		}
+	}
+	return slices.Compact(commands), nil
+}
+
+// readmeDescription returns the first prose paragraph of a README: not a
+// heading, badge, HTML or code. Line breaks are kept.
+func readmeDescription(readme string) string {
+	var lines []string
+	fenced := false
+	for _, line := range strings.Split(strings.ReplaceAll(readme, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+			continue
+		}
+		if trimmed == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		if !fenced && len(lines) > 0 && strings.Trim(trimmed, "=-") == "" {
+			lines = nil // What came before was a setext heading
+			continue
+		}
+		if fenced || !isReadmeProse(trimmed) {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	description := strings.Join(lines, "\n")
+	if validateDescription(description) != nil {
+		// Too long for the answer: the first sentence still introduces it
+		description = TemplateData{Description: description}.Tagline()
+	}
+	return description
+}
+
+// isReadmeProse reports whether a README line is prose: not a heading,
+// badge, HTML tag or rule.
+func isReadmeProse(line string) bool {
+	for _, prefix := range []string{"#", "<", "![", "[!["} {
+		if strings.HasPrefix(line, prefix) {
+			return false
+		}
+	}
+	return strings.Trim(line, "-=*_ ") != ""
+}
+
+// planAdopt plans adopting the repository at dir.
+func planAdopt(s *Scaffolder, dir string, opts adoptOptions) (adoptPlan, error) {
+	var plan adoptPlan
+	if _, err := readManifest(dir); err == nil {
+		return plan, fmt.Errorf("%s is already set up by seed; see `seed status`", dir)
+	} else if !errors.Is(err, errNoManifest) {
+		return plan, err
+	}
+	detected, err := detectRepository(dir)
+	if err != nil {
+		return plan, err
+	}
+	plan.Detected = detected
+
+	name := opts.Name
+	if name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return plan, err
+		}
+		name = filepath.Base(abs)
+	}
+	if err := validateProjectName(name); err != nil {
+		return plan, fmt.Errorf("%w (pass --name)", err)
+	}
+	description := strings.TrimSpace(opts.Description)
+	if description == "" {
+		description = detected.Description
+	}
+	if err := validateDescription(description); err != nil {
+		return plan, fmt.Errorf("%w (pass --description)", err)
+	}
+
+	plan.Answers = WizardData{
+		ProjectName: name,
+		Description: description,
+		Commands:    detected.Commands,
+		NoSkills:    opts.NoSkills,
+		Adopted:     true,
+	}
+	if len(detected.Languages) > 0 {
+		plan.Answers.Language = detected.Languages[0]
+	}
+
+	data := plan.Answers.ToTemplateData()
+	data.Year = seedNow().Year()
+	files, err := s.Render(data)
+	if err != nil {
+		return plan, fmt.Errorf("failed to render project: %w", err)
+	}
+	skills, err := projectSkillFiles(data)
+	if err != nil {
+		return plan, err
+	}
+	files, err = dropIgnored(dir, append(files, skills...))
+	if err != nil {
+		return plan, err
+	}
+	for _, f := range files {
+		if !slices.Contains(adoptDocs, f.Path) && !strings.HasPrefix(f.Path, "skills/") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Path))); err == nil {
+			plan.Existing = append(plan.Existing, f.Path)
+			continue
+		} else if !os.IsNotExist(err) {
+			return plan, fmt.Errorf("failed to check %s: %w", f.Path, err)
+		}
+		plan.Create = append(plan.Create, f)
+	}
+	return plan, nil
+}
+
+// applyAdopt writes the missing files and records them in a new manifest.
+func applyAdopt(dir string, plan adoptPlan) error {
+	if err := writeFiles(dir, plan.Create); err != nil {
+		return err
+	}
+	m := newManifest(plan.Answers, seedNow().Year(), plan.Create)
+	if cfg, _ := loadConfig(); cfg.MinSeedVersion != "" {
+		m.MinSeedVersion = cfg.MinSeedVersion
+	}
+	return writeManifest(dir, m)
+}
+
+// adoptedFiles narrows a fresh render of an adopted project to what adopt
+// manages: the files it wrote, and skills added since.
+func adoptedFiles(m Manifest, files []RenderedFile) []RenderedFile {
+	return slices.DeleteFunc(files, func(f RenderedFile) bool {
+		_, tracked := m.File(f.Path)
+		return !tracked && !strings.HasPrefix(f.Path, "skills/")
+	})
+}
+
+// formatAdoptPlan describes what adopting would do.
+func formatAdoptPlan(dir string, plan adoptPlan) string {
+	var b strings.Builder
+	d := plan.Detected
+	b.WriteString(T("adopt.adopting", dir, plan.Answers.ProjectName))
+	if len(d.Languages) > 0 {
+		var found []string
+		for _, lang := range d.Languages {
+			found = append(found, fmt.Sprintf("%s (%s)", stackFor(lang).Label, d.Markers[lang]))
+		}
+		b.WriteString(T("adopt.detected", strings.Join(found, ", ")))
+	}
+	b.WriteString(":\n\n")
+	for _, f := range plan.Create {
+		fmt.Fprintf(&b, "  %-11s %s\n", T("change.create"), f.Path)
+	}
+	for _, path := range plan.Existing {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  %-11s %s", T("change.keep"), T("adopt.exists", path))) + "\n")
+	}
+	if len(plan.Answers.Commands) > 0 {
+		b.WriteString("\n" + T("adopt.commands", strings.Join(plan.Answers.Commands, ", ")) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDetectRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		languages []string
+		commands  []string
+	}{
+		{"empty", nil, nil, nil},
+		{"go with a Makefile", map[string]string{"go.mod": "module x\n", "Makefile": "build:\n\tgo build\n\ntest: build\n\tgo test ./...\n\nrelease:\n"}, []string{"go"}, []string{"make build", "make test"}},
+		{"go alone", map[string]string{"go.mod": "module x\n"}, []string{"go"}, []string{"go build ./...", "go test ./..."}},
+		{"npm scripts", map[string]string{"package.json": `{"scripts": {"test": "vitest", "build": "tsc", "start": "node ."}}`}, []string{"node"}, []string{"npm run build", "npm test", "npm start"}},
+		{"pnpm scripts", map[string]string{"package.json": `{"scripts": {"lint": "eslint ."}}`, "pnpm-lock.yaml": ""}, []string{"node"}, []string{"pnpm lint"}},
+		{"python", map[string]string{"requirements.txt": "requests\n"}, []string{"python"}, []string{"pip install -e .", "pytest"}},
+		{"maven", map[string]string{"pom.xml": "<project/>"}, []string{"java"}, []string{"mvn package", "mvn test"}},
+		{"several languages", map[string]string{"Cargo.toml": "[package]\n", "app.csproj": "<Project/>", "package.json": "{}"}, []string{"node", "rust", "dotnet"}, []string{"npm run build", "npm test"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeTestFile(t, filepath.Join(dir, name), content)
+			}
+			d, err := detectRepository(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(d.Languages, tt.languages) {
+				t.Errorf("languages = %v, want %v", d.Languages, tt.languages)
+			}
+			if !slices.Equal(d.Commands, tt.commands) {
+				t.Errorf("commands = %v, want %v", d.Commands, tt.commands)
+			}
+		})
+	}
+}
+
+func TestReadmeDescription(t *testing.T) {
+	tests := []struct {
+		name   string
+		readme string
+		want   string
+	}{
+		{"title and badges", "# tool\n\n[![CI](https://x/badge.svg)](https://x)\n\nPrunes stale branches.\nRuns nightly.\n\n## Install\n", "Prunes stale branches.\nRuns nightly."},
+		{"setext title", "tool\n====\n\nPrunes stale branches.\n", "Prunes stale branches."},
+		{"html header", "<p align=\"center\"><img src=\"logo.png\"></p>\n\nA logo-first README.\n", "A logo-first README."},
+		{"code before prose", "# tool\n\n```\nmake\n```\n\nBuilt with make.\n", "Built with make."},
+		{"headings only", "# tool\n\n## Usage\n", ""},
+		{"too long", "# tool\n\nFirst sentence. " + strings.Repeat("More words here. ", 40) + "\n", "First sentence."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readmeDescription(tt.readme); got != tt.want {
+				t.Errorf("readmeDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdoptRepository(t *testing.T) {
+	isolateConfig(t)
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "legacy")
+	readme := "# legacy\n\nAn old service nobody documented.\n"
+	writeTestFile(t, filepath.Join(dir, "README.md"), readme)
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module legacy\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n")
+
+	plan, err := planAdopt(s, dir, adoptOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Answers.ProjectName != "legacy" || plan.Answers.Language != "go" || plan.Answers.Description != "An old service nobody documented." {
+		t.Errorf("answers not detected: %+v", plan.Answers)
+	}
+	var created []string
+	for _, f := range plan.Create {
+		created = append(created, f.Path)
+	}
+	for _, want := range []string{"AGENTS.md", "DECISIONS.md", "TODO.md", "LEARNINGS.md", "skills/entropy-guard.md"} {
+		if !slices.Contains(created, want) {
+			t.Errorf("%s should be created: %v", want, created)
+		}
+	}
+	for _, unwanted := range []string{"README.md", ".gitignore", ".editorconfig"} {
+		if slices.Contains(created, unwanted) {
+			t.Errorf("%s shouldn't be created: %v", unwanted, created)
+		}
+	}
+	if !slices.Equal(plan.Existing, []string{"README.md"}) {
+		t.Errorf("existing = %v, want [README.md]", plan.Existing)
+	}
+
+	if err := applyAdopt(dir, plan); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(raw) != readme {
+		t.Errorf("README.md was changed:\n%s", raw)
+	}
+	agents, err := os.ReadFile(filepath.Join(dir, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(agents), "## Commands\n\n- `go build ./...`\n- `go test ./...`\n") || strings.Contains(string(agents), "[Add build, test") {
+		t.Errorf("AGENTS.md should list the detected commands instead of the placeholder:\n%s", agents)
+	}
+
+	// Later commands only look at what adopt manages
+	status, err := projectStatus(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Clean() {
+		t.Errorf("adopted project should be clean:\n%s", formatStatus(status))
+	}
+	sync, err := planSync(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sync.Added) > 0 {
+		t.Errorf("--sync shouldn't add scaffold files to an adopted project: %v", sync.Added)
+	}
+
+	if _, err := planAdopt(s, dir, adoptOptions{}); err == nil {
+		t.Error("adopting twice should fail")
+	}
+}
+
+func TestAdoptNeedsDescription(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "bare")
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module bare\n")
+	if _, err := planAdopt(s, dir, adoptOptions{}); err == nil || !strings.Contains(err.Error(), "--description") {
+		t.Errorf("expected a missing description error, got %v", err)
+	}
+	plan, err := planAdopt(s, dir, adoptOptions{Description: "A bare module.", NoSkills: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range plan.Create {
+		if strings.HasPrefix(f.Path, "skills/") {
+			t.Errorf("--no-skills should leave out %s", f.Path)
+		}
+	}
+	if !slices.ContainsFunc(plan.Create, func(f RenderedFile) bool { return f.Path == "README.md" }) {
+		t.Error("a missing README.md should be created")
+	}
+}
@@ -0,0 +1,232 @@
+// Package main - archive.go
+//
+// PURPOSE:
+// This file writes a rendered project to a single archive file instead of a
+// directory. It's responsible for:
+// - Choosing the archive format from the output file extension
+// - Writing .tar.gz/.tgz and .zip archives from in-memory RenderedFiles
+// - Reading a .tar.gz back into memory (bundles), keeping
+//   only regular files under the archive's root directory
+//
+// DESIGN PATTERNS:
+// - Consumes the output of Scaffolder.Render; knows nothing about templates
+// - Standard library only (archive/tar, archive/zip, compress/gzip)
+//
+// USAGE:
+// files, _ := scaffolder.Render(data)
+// err := writeArchive("myproject.tar.gz", "myproject", files)
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// archiveFormat identifies a supported archive type.
+type archiveFormat string
+
+const (
+	archiveTarGz archiveFormat = "tar.gz"
+	archiveZip   archiveFormat = "zip"
+)
+
+// detectArchiveFormat picks the archive format from the output file name.
+// Supported extensions: .tar.gz, .tgz, .zip.
+func detectArchiveFormat(name string) (archiveFormat, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return archiveTarGz, nil
+	case strings.HasSuffix(lower, ".zip"):
+		return archiveZip, nil
+	default:
+		return "", fmt.Errorf("unsupported archive extension for %s (use .tar.gz, .tgz or .zip)", name)
+	}
+}
+
+// archiveRootName derives the top-level directory name stored inside the
+// archive from the archive file name, e.g. "out/myapp.tar.gz" -> "myapp".
+func archiveRootName(name string) string {
+	base := path.Base(strings.ReplaceAll(name, "\\", "/"))
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			return base[:len(base)-len(ext)]
+		}
+	}
+	return base
+}
+
+// writeArchive writes files into a new archive at archivePath. Every entry is
+// placed under rootDir so extracting the archive produces a single project
+// directory. Refuses to overwrite an existing file.
+func writeArchive(archivePath, rootDir string, files []RenderedFile) error {
+	format, err := detectArchiveFormat(archivePath)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return fmt.Errorf("%s already exists", archivePath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", archivePath, err)
+	}
+
+	switch format {
+	case archiveZip:
+		err = writeZip(out, rootDir, files)
+	default:
+		err = writeTarGz(out, rootDir, files)
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath) // don't leave a truncated archive behind
+		return fmt.Errorf("failed to write %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// archiveDirs returns every directory (including rootDir) that needs an entry
+// so archive tools recreate the tree with sensible permissions. Order follows
+// first appearance in files, parents before children.
+func archiveDirs(rootDir string, files []RenderedFile) []string {
+	seen := map[string]bool{}
+	dirs := []string{rootDir}
+	seen[rootDir] = true
+	for _, f := range files {
+		var parts []string
+		for dir := path.Dir(f.Path); dir != "."; dir = path.Dir(dir) {
+			parts = append([]string{dir}, parts...)
+		}
+		for _, dir := range parts {
+			full := path.Join(rootDir, dir)
+			if !seen[full] {
+				seen[full] = true
+				dirs = append(dirs, full)
+			}
+		}
+	}
+	return dirs
+}
+
+func writeTarGz(w io.Writer, rootDir string, files []RenderedFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := seedNow()
+
+	for _, dir := range archiveDirs(rootDir, files) {
+		hdr := &tar.Header{Typeflag: tar.TypeDir, Name: dir + "/", Mode: 0755, ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(rootDir, f.Path),
+			Mode:     int64(f.Mode.Perm()),
+			Size:     int64(len(f.Content)),
+			ModTime:  now,
+		}
+		if isSymlink(f) {
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, string(f.Content), 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if isSymlink(f) {
+			continue
+		}
+		if _, err := tw.Write(f.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeZip(w io.Writer, rootDir string, files []RenderedFile) error {
+	zw := zip.NewWriter(w)
+	now := seedNow()
+
+	for _, dir := range archiveDirs(rootDir, files) {
+		hdr := &zip.FileHeader{Name: dir + "/", Modified: now}
+		hdr.SetMode(os.ModeDir | 0755)
+		if _, err := zw.CreateHeader(hdr); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		hdr := &zip.FileHeader{Name: path.Join(rootDir, f.Path), Method: zip.Deflate, Modified: now}
+		hdr.SetMode(f.Mode)
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.Content); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// readTarGz returns the regular files in a tarball, keyed by path below
+// rootDir. Entries outside rootDir, or larger than maxFileSize, are errors.
+func readTarGz(raw []byte, rootDir string, maxFileSize int64) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		rel, ok := archiveEntryPath(rootDir, hdr.Name)
+		if !ok {
+			return nil, fmt.Errorf("unexpected entry %s", hdr.Name)
+		}
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", rel, maxFileSize)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[rel] = content
+	}
+}
+
+// archiveEntryPath returns name relative to rootDir, rejecting entries
+// outside it.
+func archiveEntryPath(rootDir, name string) (string, bool) {
+	clean := path.Clean(name)
+	rel, ok := strings.CutPrefix(clean, rootDir+"/")
+	return rel, ok && rel != ".." && !strings.HasPrefix(rel, "../")
+}
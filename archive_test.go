@@ -0,0 +1,149 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func renderTestProject(t *testing.T) []RenderedFile {
+	t.Helper()
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatalf("NewScaffolder: %v", err)
+	}
+	files, err := s.Render(TemplateData{
+		ProjectName:         "test-archive",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ChatTools:           knownAITools,
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	return files
+}
+
+func TestDetectArchiveFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    archiveFormat
+		wantErr bool
+	}{
+		{"project.tar.gz", archiveTarGz, false},
+		{"project.TGZ", archiveTarGz, false},
+		{"project.zip", archiveZip, false},
+		{"project.rar", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectArchiveFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error mismatch: got %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("format: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchiveRootName(t *testing.T) {
+	for in, want := range map[string]string{
+		"myapp.tar.gz":    "myapp",
+		"out/myapp.tgz":   "myapp",
+		"dist/My App.zip": "My App",
+		"no-extension":    "no-extension",
+	} {
+		if got := archiveRootName(in); got != want {
+			t.Errorf("archiveRootName(%q): got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteArchiveTarGz(t *testing.T) {
+	files := renderTestProject(t)
+	archivePath := filepath.Join(t.TempDir(), "project.tar.gz")
+	if err := writeArchive(archivePath, "project", files); err != nil {
+		t.Fatalf("writeArchive: %v", err)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+
+	entries := map[string]*tar.Header{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		entries[hdr.Name] = hdr
+	}
+
+	for _, want := range []string{"project/", "project/.devcontainer/", "project/README.md", "project/.devcontainer/devcontainer.json"} {
+		if _, ok := entries[want]; !ok {
+			t.Errorf("archive should contain %s", want)
+		}
+	}
+	if hdr := entries["project/.devcontainer/setup.sh"]; hdr == nil || hdr.Mode&0111 == 0 {
+		t.Error("setup.sh should be stored as executable")
+	}
+}
+
+func TestWriteArchiveZip(t *testing.T) {
+	files := renderTestProject(t)
+	archivePath := filepath.Join(t.TempDir(), "project.zip")
+	if err := writeArchive(archivePath, "project", files); err != nil {
+		t.Fatalf("writeArchive: %v", err)
+	}
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	defer zr.Close()
+
+	var readme string
+	for _, f := range zr.File {
+		if f.Name == "project/README.md" {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("open README.md: %v", err)
+			}
+			raw, _ := io.ReadAll(rc)
+			rc.Close()
+			readme = string(raw)
+		}
+	}
+	if !strings.Contains(readme, "test-archive") {
+		t.Error("zipped README.md should contain the project name")
+	}
+}
+
+func TestWriteArchiveRefusesOverwrite(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "project.zip")
+	if err := os.WriteFile(archivePath, []byte("existing"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	err := writeArchive(archivePath, "project", renderTestProject(t))
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected 'already exists' error, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package main - assets.go
+//
+// PURPOSE:
+// This file handles pack output that isn't rendered text. It's responsible
+// for:
+// - Assets: files in a pack's template directory without a .tmpl suffix
+//   (images, binary fixtures) are copied to the same path byte for byte
+// - Symbolic links: a template whose header says "link: <target>" becomes a
+//   link to target instead of a file
+// - Writing links into project directories and reading them back, so
+//   status, diff and upgrade compare a link's target rather than whatever
+//   it points at
+//
+// DESIGN PATTERNS:
+// - A link is a RenderedFile with os.ModeSymlink in its mode and the target
+//   as its content, as git and zip store links; hashing, the manifest and
+//   three-way merges then work on links unchanged
+// - Assets bypass text/template, stamps, license headers and contents
+//   lists, any of which would corrupt binary content
+// - Link targets must be relative and stay inside the project, so a pack
+//   can't plant a link to ~/.ssh
+//
+// USAGE:
+// assets, err := s.templates.Assets()
+// content, err := readGenerated(dir, "CLAUDE.md") // a link's target
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// templateSuffix marks the files of a pack that are templates; the rest
+// are assets.
+const templateSuffix = ".tmpl"
+
+// isSymlink reports whether f is a symbolic link (its content is the target).
+func isSymlink(f RenderedFile) bool {
+	return f.Mode&os.ModeSymlink != 0
+}
+
+// newSymlink returns a link at p pointing to target.
+func newSymlink(p, target string) (RenderedFile, error) {
+	if err := validateLinkTarget(p, target); err != nil {
+		return RenderedFile{}, err
+	}
+	return RenderedFile{Path: p, Content: []byte(target), Mode: os.ModeSymlink | 0777}, nil
+}
+
+// validateLinkTarget rejects targets that are absolute or lead out of the
+// project from the link at p.
+func validateLinkTarget(p, target string) error {
+	if target == "" || path.IsAbs(target) || filepath.IsAbs(target) || strings.Contains(target, `\`) {
+		return fmt.Errorf("link %s: target %q must be a relative, slash-separated path", p, target)
+	}
+	resolved := path.Join(path.Dir(p), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("link %s: target %q leads out of the project", p, target)
+	}
+	return nil
+}
+
+// Assets returns the pack's files that aren't templates, under their path
+// relative to the template directory, in path order.
+func (ts *templateSet) Assets() ([]RenderedFile, error) {
+	var assets []RenderedFile
+	err := fs.WalkDir(ts.fsys, ts.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasSuffix(p, templateSuffix) {
+			return err
+		}
+		content, err := fs.ReadFile(ts.fsys, p)
+		if err != nil {
+			return fmt.Errorf("failed to read asset %s: %w", p, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := os.FileMode(0644)
+		if info.Mode().Perm()&0111 != 0 {
+			mode = 0755
+		}
+		rel := strings.TrimPrefix(p, ts.dir+"/")
+		assets = append(assets, RenderedFile{Path: rel, Content: content, Mode: mode})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pack assets: %w", err)
+	}
+	return assets, nil
+}
+
+// isBinary reports whether content isn't text: it holds a NUL byte or isn't
+// valid UTF-8.
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+// writeSymlinkAtomic creates (or replaces) the link name pointing to target.
+func writeSymlinkAtomic(name, target string) error {
+	tmp := filepath.Join(filepath.Dir(name), "."+filepath.Base(name)+".tmp-"+strconv.FormatUint(rand.Uint64(), 36))
+	if err := os.Symlink(filepath.FromSlash(target), tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// readGenerated reads the generated file p in dir as it's recorded: a
+// link's target, or a file's content.
+func readGenerated(dir, p string) ([]byte, error) {
+	full := filepath.Join(dir, filepath.FromSlash(p))
+	info, err := os.Lstat(full)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(full)
+		return []byte(filepath.ToSlash(target)), err
+	}
+	return os.ReadFile(full)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// assetPack is a pack with a binary asset, an executable asset and a
+// template that becomes a link.
+func assetPack() fstest.MapFS {
+	return fstest.MapFS{
+		"pack/README.md.tmpl":  {Data: []byte("# {{.ProjectName}}\n")},
+		"pack/CLAUDE.md.tmpl":  {Data: []byte("{{/* seed\nlink: AGENTS.md\n*/}}\n")},
+		"pack/docs/logo.png":   {Data: []byte{0x89, 'P', 'N', 'G', 0, 0xff, 0x00, '{', '{'}},
+		"pack/bin/tool":        {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+		"pack/escape.md.tmpl":  {Data: []byte("{{/* seed\nlink: ../../etc/passwd\n*/}}\n")},
+		"pack/content.md.tmpl": {Data: []byte("{{/* seed\nlink: AGENTS.md\n*/}}\nnot empty\n")},
+	}
+}
+
+func TestAssets(t *testing.T) {
+	pack := assetPack()
+	assets, err := templateSetFor("asset-pack", pack, "pack").Assets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 2 {
+		t.Fatalf("expected 2 assets, got %+v", assets)
+	}
+	for _, f := range assets {
+		want := pack["pack/"+f.Path]
+		if want == nil {
+			t.Fatalf("unexpected asset %s", f.Path)
+		}
+		if !bytes.Equal(f.Content, want.Data) {
+			t.Errorf("%s: content changed: %q", f.Path, f.Content)
+		}
+	}
+	if assets[0].Path != "bin/tool" || assets[0].Mode != 0755 {
+		t.Errorf("bin/tool should keep its executable bit, got %s %v", assets[0].Path, assets[0].Mode)
+	}
+	if assets[1].Path != "docs/logo.png" || assets[1].Mode != 0644 {
+		t.Errorf("docs/logo.png should be 0644, got %s %v", assets[1].Path, assets[1].Mode)
+	}
+	if !isBinary(assets[1].Content) || isBinary(assets[0].Content) {
+		t.Error("isBinary should tell the PNG from the script")
+	}
+
+	// The embedded templates have no assets
+	embedded, err := templateSetFor("embedded", templatesFS, "templates").Assets()
+	if err != nil || len(embedded) != 0 {
+		t.Fatalf("expected no embedded assets, got %d (%v)", len(embedded), err)
+	}
+}
+
+func TestSymlinkTemplates(t *testing.T) {
+	s := &Scaffolder{templates: templateSetFor("asset-pack", assetPack(), "pack")}
+	data := TemplateData{ProjectName: "demo"}
+
+	link, err := s.renderFile("CLAUDE.md.tmpl", "CLAUDE.md", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isSymlink(link) || string(link.Content) != "AGENTS.md" {
+		t.Fatalf("expected a link to AGENTS.md, got %+v", link)
+	}
+	if string(stampFile(link).Content) != "AGENTS.md" {
+		t.Error("stamping should leave links alone")
+	}
+	if _, err := s.renderFile("escape.md.tmpl", "escape.md", data); err == nil || !strings.Contains(err.Error(), "out of the project") {
+		t.Errorf("expected an escaping link to be refused, got %v", err)
+	}
+	if _, err := s.renderFile("content.md.tmpl", "content.md", data); err == nil {
+		t.Error("expected a link template with content to be refused")
+	}
+
+	dir := t.TempDir()
+	files := []RenderedFile{
+		{Path: "AGENTS.md", Content: []byte("# Agents\n"), Mode: 0644},
+		link,
+	}
+	if err := writeFiles(dir, files); err != nil {
+		t.Fatal(err)
+	}
+	target, err := os.Readlink(filepath.Join(dir, "CLAUDE.md"))
+	if err != nil || target != "AGENTS.md" {
+		t.Fatalf("expected CLAUDE.md -> AGENTS.md on disk, got %q (%v)", target, err)
+	}
+	if got, err := readGenerated(dir, "CLAUDE.md"); err != nil || string(got) != "AGENTS.md" {
+		t.Errorf("readGenerated should return the link's target, got %q (%v)", got, err)
+	}
+	// Writing again replaces the link rather than failing
+	if err := writeFiles(dir, files); err != nil {
+		t.Fatalf("rewriting a link: %v", err)
+	}
+}
+
+func TestValidateLinkTarget(t *testing.T) {
+	tests := []struct {
+		link, target string
+		ok           bool
+	}{
+		{"CLAUDE.md", "AGENTS.md", true},
+		{"docs/agents.md", "../AGENTS.md", true},
+		{"docs/a/b.md", "../../README.md", true},
+		{"CLAUDE.md", "../AGENTS.md", false},
+		{"docs/agents.md", "../../AGENTS.md", false},
+		{"CLAUDE.md", "/etc/passwd", false},
+		{"CLAUDE.md", `docs\AGENTS.md`, false},
+		{"CLAUDE.md", "", false},
+	}
+	for _, tt := range tests {
+		if err := validateLinkTarget(tt.link, tt.target); (err == nil) != tt.ok {
+			t.Errorf("validateLinkTarget(%q, %q): got %v, want ok=%v", tt.link, tt.target, err, tt.ok)
+		}
+	}
+}
+
+func TestWriteArchiveSymlink(t *testing.T) {
+	link, err := newSymlink("CLAUDE.md", "AGENTS.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []RenderedFile{{Path: "AGENTS.md", Content: []byte("# Agents\n"), Mode: 0644}, link}
+	var buf bytes.Buffer
+	if err := writeTarGz(&buf, "project", files); err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			t.Fatal("archive has no project/CLAUDE.md")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name == "project/CLAUDE.md" {
+			if hdr.Typeflag != tar.TypeSymlink || hdr.Linkname != "AGENTS.md" || hdr.Size != 0 {
+				t.Fatalf("expected a symlink entry to AGENTS.md, got %+v", hdr)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package main - audit.go
+//
+// PURPOSE:
+// This file keeps an audit log of scaffolding runs for organizations that
+// need to know where generated repositories came from. It's responsible for:
+// - Building one entry per scaffold: who, when, where, which seed and
+//   template version, and a hash of the answers
+// - Appending it to a local JSON-lines file or POSTing it to an HTTPS
+//   endpoint (config "auditLog")
+//
+// DESIGN PATTERNS:
+// - Off unless auditLog is set, usually by the org config, whose value wins
+//   over the user's so it can't be redirected locally
+// - Answers are hashed, not copied: the entry proves which answers produced
+//   a project (compare with its .seed/manifest.json) without holding them
+// - Runs after the project is written, so a failure is reported, not fatal
+//
+// USAGE:
+// if err := recordAudit("wizard", targetDir, wizardData); err != nil { ... }
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// auditTimeout bounds the POST to a remote audit log.
+const auditTimeout = 10 * time.Second
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time            time.Time `json:"time"`
+	User            string    `json:"user"`
+	GitEmail        string    `json:"gitEmail,omitempty"`
+	Host            string    `json:"host"`
+	Mode            string    `json:"mode"`   // wizard, batch or archive
+	Target          string    `json:"target"` // Absolute project directory or archive path
+	SeedVersion     string    `json:"seedVersion"`
+	TemplateVersion int       `json:"templateVersion"`
+	AnswersSHA256   string    `json:"answersSha256"` // sha256 of the answers as recorded in the manifest
+}
+
+// recordAudit appends an entry for a scaffold of target to the configured
+// audit log. It does nothing when no audit log is configured.
+func recordAudit(mode, target string, answers WizardData) error {
+	cfg, _ := loadConfig()
+	if cfg.AuditLog == "" {
+		return nil
+	}
+	entry, err := newAuditEntry(mode, target, answers)
+	if err != nil {
+		return err
+	}
+	if err := writeAudit(cfg.AuditLog, entry); err != nil {
+		return fmt.Errorf("audit log %s: %w", cfg.AuditLog, err)
+	}
+	return nil
+}
+
+// newAuditEntry describes a scaffold of target from answers, now.
+func newAuditEntry(mode, target string, answers WizardData) (auditEntry, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return auditEntry{}, err
+	}
+	raw, err := json.Marshal(answers)
+	if err != nil {
+		return auditEntry{}, err
+	}
+	entry := auditEntry{
+		Time:            time.Now().UTC().Truncate(time.Second),
+		Mode:            mode,
+		Target:          abs,
+		SeedVersion:     Version,
+		TemplateVersion: templateVersion,
+		AnswersSHA256:   hashContent(raw),
+	}
+	if u, err := user.Current(); err == nil {
+		entry.User = u.Username
+	}
+	entry.Host, _ = os.Hostname()
+	if email, err := runCommand("", commandTimeout(probeCommandTimeout), "git", "config", "user.email"); err == nil {
+		entry.GitEmail = strings.TrimSpace(email)
+	}
+	return entry, nil
+}
+
+// writeAudit sends entry to dest: POSTed as JSON to an https:// URL, or
+// appended as one JSON line to a file.
+func writeAudit(dest string, entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(dest, "https://") {
+		client, err := httpClient(auditTimeout)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Post(dest, "application/json", bytes.NewReader(line))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("endpoint returned %s", resp.Status)
+		}
+		return nil
+	}
+	if strings.Contains(dest, "://") {
+		return errors.New("use an https:// URL or a file path")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	// One write per entry: appends of a single line don't interleave
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAuditFile(t *testing.T) {
+	dir := isolateConfig(t)
+	logPath := filepath.Join(dir, "audit", "seed.jsonl")
+	if err := saveUserConfig(userConfig{AuditLog: logPath}); err != nil {
+		t.Fatal(err)
+	}
+
+	target := filepath.Join(dir, "proj")
+	report, err := scaffoldProject(target, WizardData{ProjectName: "audited", Description: "Audit test"}, false, map[string]struct{}{}, newPlainProgress(io.Discard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []string{"wizard", "batch"} {
+		if err := recordAudit(mode, target, report.Answers); err != nil {
+			t.Fatalf("recordAudit: %v", err)
+		}
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 || entries[0].Mode != "wizard" || entries[1].Mode != "batch" {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	// The hash matches the answers recorded in the project's manifest
+	m, err := readManifest(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := json.Marshal(m.Answers)
+	e := entries[0]
+	if e.AnswersSHA256 != hashContent(raw) {
+		t.Errorf("answers hash %s doesn't match the manifest's answers", e.AnswersSHA256)
+	}
+	if e.Target != target || e.SeedVersion != Version || e.TemplateVersion != templateVersion || e.Time.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestRecordAuditEndpoint(t *testing.T) {
+	isolateConfig(t)
+	var got auditEntry
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv(caBundleEnv, writeServerCA(t, srv))
+
+	if err := saveUserConfig(userConfig{AuditLog: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordAudit("archive", "out.tar.gz", WizardData{ProjectName: "p"}); err != nil {
+		t.Fatalf("recordAudit: %v", err)
+	}
+	if got.Mode != "archive" || filepath.Base(got.Target) != "out.tar.gz" {
+		t.Errorf("endpoint received %+v", got)
+	}
+
+	if err := saveUserConfig(userConfig{AuditLog: "http://insecure.example.com/audit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordAudit("archive", "out.tar.gz", WizardData{}); err == nil {
+		t.Error("expected plain http to be refused")
+	}
+}
+
+func TestRecordAuditOff(t *testing.T) {
+	isolateConfig(t)
+	if err := recordAudit("wizard", t.TempDir(), WizardData{}); err != nil {
+		t.Errorf("recordAudit without an audit log: %v", err)
+	}
+}
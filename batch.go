@@ -0,0 +1,207 @@
+// Package main - batch.go
+//
+// PURPOSE:
+// This file implements batch scaffolding (`seed --batch spec.json`).
+// It's responsible for:
+// - Loading and validating a batch spec listing several projects
+// - Scaffolding each project non-interactively with its recorded answers
+// - Reporting per-project status and an overall summary
+//
+// DESIGN PATTERNS:
+// - Answers reuse WizardData's JSON form, so a spec entry mirrors the wizard
+// - One failing project doesn't stop the others; failures are summarised at the end
+// - Delegates the actual work to scaffoldProject (same path as the wizard flow)
+//
+// USAGE:
+// spec, err := loadBatchSpec("workshop.json")
+// err = runBatchSpec(spec, os.Stdout)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BatchSpec is the top-level structure of a batch spec file.
+//
+// Example:
+//
+//	{
+//	  "projects": [
+//	    {"name": "alice", "path": "workshop/alice", "answers": {"description": "Alice's sandbox", "license": "MIT", "initGit": true}}
+//	  ]
+//	}
+type BatchSpec struct {
+	Projects []BatchProject `json:"projects"`
+}
+
+// BatchProject is one project to scaffold.
+// Name overrides answers.projectName; if both are empty the target
+// directory's base name is used, just like the wizard's default.
+type BatchProject struct {
+	Name    string     `json:"name,omitempty"`
+	Path    string     `json:"path"`
+	Answers WizardData `json:"answers"`
+}
+
+// batchResult records the outcome for one project.
+type batchResult struct {
+	Name   string
+	Path   string
+	Report scaffoldReport
+	Err    error
+
+	Duplicates []string // Known projects sharing this one's slug (knownprojects.go)
+}
+
+// loadBatchSpec reads and validates a batch spec. Relative project paths are
+// resolved against the spec file's directory so specs are portable.
+func loadBatchSpec(specPath string) (BatchSpec, error) {
+	var spec BatchSpec
+
+	raw, err := os.ReadFile(specPath)
+	if err != nil {
+		return spec, fmt.Errorf("failed to read batch spec: %w", err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return spec, fmt.Errorf("invalid batch spec %s: %w", specPath, err)
+	}
+	if len(spec.Projects) == 0 {
+		return spec, fmt.Errorf("batch spec %s lists no projects", specPath)
+	}
+
+	// Config (usually the org's) supplies a default license, required
+	// components and the source allowlist
+	cfg, _ := loadConfig()
+
+	baseDir := filepath.Dir(specPath)
+	seen := map[string]int{}
+	for i := range spec.Projects {
+		p := &spec.Projects[i]
+		if strings.TrimSpace(p.Path) == "" {
+			return spec, fmt.Errorf("project %d: path is required", i+1)
+		}
+		if !filepath.IsAbs(p.Path) {
+			p.Path = filepath.Join(baseDir, p.Path)
+		}
+		p.Path = filepath.Clean(p.Path)
+
+		if prev, dup := seen[p.Path]; dup {
+			return spec, fmt.Errorf("project %d: path %s is already used by project %d", i+1, p.Path, prev)
+		}
+		seen[p.Path] = i + 1
+
+		switch {
+		case p.Name != "":
+			p.Answers.ProjectName = p.Name
+		case p.Answers.ProjectName == "":
+			p.Answers.ProjectName = filepath.Base(p.Path)
+		}
+		p.Name = strings.TrimSpace(p.Answers.ProjectName)
+		if err := completeAnswers(&p.Answers, cfg); err != nil {
+			return spec, fmt.Errorf("project %d (%s): %w", i+1, p.Name, err)
+		}
+	}
+
+	return spec, nil
+}
+
+// completeAnswers prepares answers written by hand (not through the wizard):
+// it fills in config's defaults and required components, then validates.
+func completeAnswers(w *WizardData, cfg userConfig) error {
+	w.ProjectName = strings.TrimSpace(w.ProjectName)
+	w.Description = strings.TrimSpace(w.Description)
+	if w.License == "" {
+		w.License = cfg.License
+	}
+	if w.Footer == "" {
+		w.Footer = brandFooter(cfg)
+	}
+	if w.ImageRegistry == "" {
+		w.ImageRegistry = cfg.ImageRegistry
+	}
+	w.ReadmeOmit = unionStrings(cfg.ReadmeOmit, w.ReadmeOmit)
+	if err := requireComponents(w, cfg.Require); err != nil {
+		return err
+	}
+	if err := checkSourceAllowed(cfg, sourceDotfiles, dotfilesURL(w.DotfilesRepo)); err != nil {
+		return err
+	}
+	return w.Validate()
+}
+
+// runBatchSpec scaffolds every project in spec, printing one status line per
+// project and a summary. Target directories must be missing or empty; batch
+// mode never prompts.
+func runBatchSpec(spec BatchSpec, out io.Writer) error {
+	results := make([]batchResult, 0, len(spec.Projects))
+	for _, p := range spec.Projects {
+		result := batchResult{Name: p.Name, Path: p.Path, Duplicates: duplicateWarnings(p.Answers.ProjectName, p.Path)}
+		result.Report, result.Err = scaffoldBatchProject(p)
+		results = append(results, result)
+
+		if result.Err != nil {
+			fmt.Fprintln(out, T("batch.failed", result.Name, result.Path, result.Err))
+			if errors.Is(result.Err, errInterrupted) {
+				break // The remaining projects aren't started
+			}
+			continue
+		}
+		recordScaffold("batch", p.Answers, false)
+
+		done := "batch.done"
+		if len(result.Report.GitActions) > 0 {
+			done = "batch.doneGit"
+		}
+		fmt.Fprintln(out, successStyle.Render("✓")+" "+T(done, result.Name, result.Path, len(result.Report.Created)))
+		for _, v := range result.Report.PolicyViolations {
+			fmt.Fprintln(out, "  "+warnStyle.Render(T("policy.reportOnly", v.Detail)))
+		}
+		for _, w := range result.Duplicates {
+			fmt.Fprintln(out, "  "+warnStyle.Render("! "+w))
+		}
+		if err := recordAudit("batch", p.Path, result.Report.Answers); err != nil {
+			fmt.Fprintln(out, "  "+warnStyle.Render(T("audit.failed", err)))
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, T("batch.summary", len(results)-failed, len(spec.Projects)))
+	if len(results) < len(spec.Projects) {
+		return fmt.Errorf("%w: %d of %d projects not started", errInterrupted, len(spec.Projects)-len(results), len(spec.Projects))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects failed", failed, len(results))
+	}
+	return nil
+}
+
+// scaffoldBatchProject validates the target and scaffolds a single project.
+func scaffoldBatchProject(p BatchProject) (scaffoldReport, error) {
+	if _, err := targetDirectoryExists(p.Path); err != nil {
+		return scaffoldReport{}, err
+	}
+	if err := guardProjectSize(p.Answers, false); err != nil {
+		return scaffoldReport{}, err
+	}
+	before, err := snapshotProjectFiles(p.Path)
+	if err != nil {
+		return scaffoldReport{}, fmt.Errorf("failed to inspect existing files: %w", err)
+	}
+	return scaffoldProject(p.Path, p.Answers, false, before, newPlainProgress(io.Discard))
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSpec(t *testing.T, dir, content string) string {
+	t.Helper()
+	specPath := filepath.Join(dir, "spec.json")
+	if err := os.WriteFile(specPath, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return specPath
+}
+
+func TestLoadBatchSpec(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeSpec(t, dir, `{
+  "projects": [
+    {"name": "alice", "path": "alice", "answers": {"description": "Alice's sandbox", "license": "MIT"}},
+    {"path": "bob", "answers": {"description": "Bob's sandbox"}}
+  ]
+}`)
+
+	spec, err := loadBatchSpec(specPath)
+	if err != nil {
+		t.Fatalf("loadBatchSpec: %v", err)
+	}
+	if len(spec.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(spec.Projects))
+	}
+	if got := spec.Projects[0].Path; got != filepath.Join(dir, "alice") {
+		t.Errorf("relative path should resolve against spec dir, got %q", got)
+	}
+	if got := spec.Projects[0].Answers.ProjectName; got != "alice" {
+		t.Errorf("name should populate projectName, got %q", got)
+	}
+	if got := spec.Projects[1].Answers.ProjectName; got != "bob" {
+		t.Errorf("missing name should default to directory base name, got %q", got)
+	}
+}
+
+func TestLoadBatchSpecErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"no projects", `{"projects": []}`, "no projects"},
+		{"missing path", `{"projects": [{"answers": {"description": "x"}}]}`, "path is required"},
+		{"missing description", `{"projects": [{"path": "a", "answers": {}}]}`, "description is required"},
+		{"duplicate path", `{"projects": [{"path": "a", "answers": {"description": "x"}}, {"path": "./a", "answers": {"description": "y"}}]}`, "already used"},
+		{"unknown license", `{"projects": [{"path": "a", "answers": {"description": "x", "license": "GPL"}}]}`, "unknown license"},
+		{"unknown field", `{"projects": [{"path": "a", "answer": {}}]}`, "unknown field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specPath := writeSpec(t, t.TempDir(), tt.spec)
+			_, err := loadBatchSpec(specPath)
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestRunBatchSpecReportsPerProject(t *testing.T) {
+	dir := t.TempDir()
+
+	// Make the second project's target non-empty so it fails
+	occupied := filepath.Join(dir, "occupied")
+	os.MkdirAll(occupied, 0755)
+	os.WriteFile(filepath.Join(occupied, "existing.txt"), []byte("hello"), 0644)
+
+	specPath := writeSpec(t, dir, `{
+  "projects": [
+    {"name": "first", "path": "first", "answers": {"description": "First project"}},
+    {"name": "occupied", "path": "occupied", "answers": {"description": "Should fail"}},
+    {"name": "third", "path": "third", "answers": {"description": "Third project"}}
+  ]
+}`)
+	spec, err := loadBatchSpec(specPath)
+	if err != nil {
+		t.Fatalf("loadBatchSpec: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = runBatchSpec(spec, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 projects failed") {
+		t.Fatalf("expected summary error, got %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "✗ occupied") {
+		t.Errorf("failed project should be reported, got:\n%s", output)
+	}
+	if !strings.Contains(output, "2 of 3 projects scaffolded") {
+		t.Errorf("summary line missing, got:\n%s", output)
+	}
+
+	// Projects after the failure still get scaffolded
+	for _, name := range []string{"first", "third"} {
+		if _, err := os.Stat(filepath.Join(dir, name, "AGENTS.md")); err != nil {
+			t.Errorf("%s/AGENTS.md should exist: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, name, "skills", "entropy-guard.md")); err != nil {
+			t.Errorf("%s should have skills installed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package main - branding.go
+//
+// PURPOSE:
+// This file lets an organization brand seed without patching it. It's
+// responsible for:
+// - The name and emoji seed introduces itself with in banners
+// - The footer line added to generated docs (README.md, AGENTS.md, ...)
+//
+// DESIGN PATTERNS:
+// - Set in config ("branding"), usually the org config; each field the user
+//   sets wins over the org's
+// - The footer is resolved when a project is scaffolded and recorded in its
+//   answers, so seed upgrade and seed status re-render the same footer
+//
+// USAGE:
+// fmt.Println(T("banner.start", brandName(), version))
+// data.Footer = brandFooter(cfg)
+
+package main
+
+import "strings"
+
+// Default brand.
+const (
+	defaultBrandName  = "Seed"
+	defaultBrandEmoji = "🌱"
+)
+
+// branding is the "branding" section of config.
+type branding struct {
+	Name   string `json:"name,omitempty"`   // Replaces "Seed" in banners, e.g. "Acme seed"
+	Emoji  string `json:"emoji,omitempty"`  // Replaces 🌱 in banners
+	Footer string `json:"footer,omitempty"` // Last line of generated docs; {version} becomes the seed version
+}
+
+// brandName returns the emoji and name banners start with, e.g. "🌱 Seed".
+func brandName() string {
+	cfg, _ := loadConfig()
+	name, emoji := defaultBrandName, defaultBrandEmoji
+	if b := cfg.Branding; b != nil {
+		if b.Name != "" {
+			name = b.Name
+		}
+		if b.Emoji != "" {
+			emoji = b.Emoji
+		}
+	}
+	return emoji + " " + name
+}
+
+// brandFooter returns the footer for generated docs ("" for none), with
+// {version} filled in.
+func brandFooter(cfg userConfig) string {
+	if cfg.Branding == nil {
+		return ""
+	}
+	return strings.ReplaceAll(strings.TrimSpace(cfg.Branding.Footer), "{version}", displayVersion())
+}
+
+// mergeBranding layers the user's branding over the org's, field by field.
+func mergeBranding(org, user *branding) *branding {
+	switch {
+	case org == nil:
+		return user
+	case user == nil:
+		return org
+	}
+	merged := *user
+	if merged.Name == "" {
+		merged.Name = org.Name
+	}
+	if merged.Emoji == "" {
+		merged.Emoji = org.Emoji
+	}
+	if merged.Footer == "" {
+		merged.Footer = org.Footer
+	}
+	return &merged
+}
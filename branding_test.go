@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBrandName(t *testing.T) {
+	isolateConfig(t)
+	if got := brandName(); got != "🌱 Seed" {
+		t.Errorf("default brandName() = %q", got)
+	}
+	if err := saveUserConfig(userConfig{Branding: &branding{Name: "Acme seed", Emoji: "🏭"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := renderStartBanner("1.2.0"), "🏭 Acme seed 1.2.0 - Simple project scaffolding. Setup wizard:"; got != want {
+		t.Errorf("renderStartBanner() = %q, want %q", got, want)
+	}
+}
+
+func TestMergeBranding(t *testing.T) {
+	org := &branding{Name: "Acme seed", Emoji: "🏭", Footer: "Scaffolded with Acme seed {version}"}
+	user := &branding{Emoji: "🌵"}
+	want := &branding{Name: "Acme seed", Emoji: "🌵", Footer: "Scaffolded with Acme seed {version}"}
+	if got := mergeBranding(org, user); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeBranding() = %+v, want %+v", got, want)
+	}
+	if got := mergeBranding(nil, user); got != user {
+		t.Errorf("mergeBranding(nil, user) = %+v", got)
+	}
+}
+
+func TestBrandFooter(t *testing.T) {
+	dir := isolateConfig(t)
+	cfg := userConfig{Branding: &branding{Footer: "Scaffolded with Acme seed v{version}"}}
+	footer := brandFooter(cfg)
+	if want := "Scaffolded with Acme seed v" + displayVersion(); footer != want {
+		t.Fatalf("brandFooter() = %q, want %q", footer, want)
+	}
+
+	target := filepath.Join(dir, "proj")
+	answers := WizardData{ProjectName: "branded", Description: "Branding test", Footer: footer}
+	if _, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range []string{"README.md", "AGENTS.md", "DECISIONS.md", "TODO.md", "LEARNINGS.md"} {
+		content, err := os.ReadFile(filepath.Join(target, doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(string(content), "\n---\n\n"+footer+"\n") {
+			t.Errorf("%s doesn't end with the footer:\n%s", doc, content)
+		}
+	}
+
+	// Recorded with the answers, so status sees no drift
+	m, err := readManifest(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Answers.Footer != footer {
+		t.Errorf("manifest footer = %q", m.Answers.Footer)
+	}
+}
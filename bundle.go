@@ -0,0 +1,302 @@
+// Package main - bundle.go
+//
+// PURPOSE:
+// This file implements `seed bundle create/import`, which carries what seed
+// would otherwise fetch into an air-gapped environment. It's responsible for:
+// - Packing the org config in effect into a .tar.gz with a bundle.json
+//   describing it (format, seed version, sha256 of every file)
+// - Importing a bundle into seed's config directory, after checking it,
+//   where it stands in for SEED_ORG_CONFIG
+// - Refusing imports nothing vouches for: bundle.json only shows a bundle is
+//   consistent with itself, so import needs the --sha256 printed by create,
+//   or the org config's own SEED_ORG_CONFIG_SHA256 pin or trustedKeys
+//   signature (carried in the bundle as config.json.minisig)
+//
+// DESIGN PATTERNS:
+// - Templates and skills are embedded in the seed binary, so a bundle records
+//   the seed version it was made with rather than copying them; import warns
+//   when the versions differ. Template packs will be added under packs/ when
+//   seed has them
+// - Written with writeArchive, so bundles are ordinary tarballs
+// - SEED_ORG_CONFIG still wins over an imported bundle
+//
+// USAGE:
+// seed bundle create seed-bundle.tar.gz
+// seed bundle import seed-bundle.tar.gz [--sha256 <digest>]
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	bundleFormat       = 1                // Bumped when the layout changes incompatibly
+	bundleRoot         = "seed-bundle"    // Top-level directory inside the archive
+	bundleManifestFile = "bundle.json"    // Describes the bundle
+	bundleConfigFile   = "config.json"    // The org config
+	bundleDir          = "bundle"         // Where an imported bundle lives in seed's config directory
+	bundleMaxFileSize  = orgConfigMaxSize // Larger entries are rejected on import
+)
+
+// bundleSigFile is the org config's minisign signature, bundled when the
+// user's config lists trustedKeys.
+const bundleSigFile = bundleConfigFile + minisignSigSuffix
+
+const bundleUsage = "seed bundle create <file.tar.gz> | seed bundle import <file.tar.gz> [--sha256 <digest>]"
+
+// bundleManifest is bundle.json.
+type bundleManifest struct {
+	Format      int               `json:"format"`
+	SeedVersion string            `json:"seedVersion"` // Version whose templates and skills the bundle goes with
+	CreatedAt   time.Time         `json:"createdAt"`
+	Source      string            `json:"source,omitempty"` // Where the org config came from
+	Files       map[string]string `json:"files"`            // Path → sha256 of every other file
+}
+
+// runBundle handles `seed bundle create|import`.
+func runBundle(args []string) error {
+	if len(args) == 0 {
+		return usageError{msg: T("args.bundleCommand"), usage: bundleUsage}
+	}
+	switch args[0] {
+	case "create":
+		if len(args) != 2 {
+			return usageError{msg: T("args.bundleCreateNeedsFile"), usage: bundleUsage}
+		}
+		sum, err := createBundle(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render("✓") + " " + T("bundle.created", args[1]))
+		fmt.Println(dimStyle.Render(T("bundle.createdSum", sum)))
+		return nil
+	case "import":
+		file, pin, err := parseBundleImportArgs(args[1:])
+		if err != nil {
+			return err
+		}
+		m, err := importBundle(file, pin)
+		if err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render("✓") + " " + T("bundle.imported", m.Source, m.CreatedAt.Local().Format("2006-01-02 15:04")))
+		if m.SeedVersion != Version {
+			fmt.Println(warnStyle.Render(T("bundle.otherVersion", m.SeedVersion, Version)))
+		}
+		return nil
+	}
+	return usageError{msg: T("args.unknownCommand", "bundle", args[0]), usage: bundleUsage}
+}
+
+// parseBundleImportArgs parses `<file> [--sha256 <digest>]`.
+func parseBundleImportArgs(args []string) (file, pin string, err error) {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--sha256":
+			if i+1 >= len(args) {
+				return "", "", usageError{msg: T("args.needsValue", "--sha256"), usage: bundleUsage}
+			}
+			i++
+			pin = args[i]
+		case strings.HasPrefix(arg, "--sha256="):
+			pin = strings.TrimPrefix(arg, "--sha256=")
+		case strings.HasPrefix(arg, "-"):
+			return "", "", usageError{msg: T("args.unknownFlag", arg), usage: bundleUsage}
+		case file == "":
+			file = arg
+		default:
+			return "", "", usageError{msg: T("args.tooMany"), usage: bundleUsage}
+		}
+	}
+	if file == "" {
+		return "", "", usageError{msg: T("args.bundleImportNeedsFile"), usage: bundleUsage}
+	}
+	return file, pin, nil
+}
+
+// createBundle writes the org config in effect to a bundle at archivePath
+// and returns the bundle's sha256.
+func createBundle(archivePath string) (string, error) {
+	if format, err := detectArchiveFormat(archivePath); err != nil || format != archiveTarGz {
+		return "", fmt.Errorf("bundles are .tar.gz files, not %s", filepath.Base(archivePath))
+	}
+	org := loadOrgConfig()
+	if org.Source == "" {
+		return "", fmt.Errorf("nothing to bundle: set %s to the org config to carry over", orgConfigEnv)
+	}
+	// Fetched again rather than copied from the cache, which reformats it:
+	// a pin or signature only matches the bytes as published
+	config, err := fetchOrgConfig(org.Source)
+	if err != nil {
+		return "", fmt.Errorf("org config %s: %w", org.Source, err)
+	}
+	contents := map[string][]byte{bundleConfigFile: config}
+	// The signature is fetched only when the user trusts keys, and bundled so
+	// the other side can check the config against the same keys
+	err = verifyOrgConfigWith(config, func() ([]byte, error) {
+		sig, err := fetchOrgConfig(signatureSource(org.Source))
+		contents[bundleSigFile] = sig
+		return sig, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("org config %s: %w", org.Source, err)
+	}
+
+	sums := map[string]string{}
+	var files []RenderedFile
+	for _, name := range []string{bundleConfigFile, bundleSigFile} {
+		if content, ok := contents[name]; ok {
+			sum := sha256.Sum256(content)
+			sums[name] = hex.EncodeToString(sum[:])
+			files = append(files, RenderedFile{Path: name, Content: content, Mode: 0644})
+		}
+	}
+	manifest, err := json.MarshalIndent(bundleManifest{
+		Format:      bundleFormat,
+		SeedVersion: Version,
+		CreatedAt:   time.Now().UTC(),
+		Source:      org.Source,
+		Files:       sums,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	files = append([]RenderedFile{{Path: bundleManifestFile, Content: append(manifest, '\n'), Mode: 0644}}, files...)
+	if err := writeArchive(archivePath, bundleRoot, files); err != nil {
+		return "", err
+	}
+
+	raw, err := os.ReadFile(archivePath)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// importBundle checks the bundle at archivePath (against pin, when given,
+// and the org config's own pin or signature) and installs it in seed's
+// config directory, replacing any earlier one.
+func importBundle(archivePath, pin string) (bundleManifest, error) {
+	var m bundleManifest
+	raw, err := os.ReadFile(archivePath)
+	if err != nil {
+		return m, err
+	}
+	if pin != "" {
+		if err := verifySHA256(raw, pin); err != nil {
+			return m, fmt.Errorf("%s: %w", archivePath, err)
+		}
+	}
+	files, err := readBundleFiles(raw)
+	if err != nil {
+		return m, fmt.Errorf("%s: %w", archivePath, err)
+	}
+	if err := json.Unmarshal(files[bundleManifestFile], &m); err != nil {
+		return m, fmt.Errorf("%s: invalid %s: %w", archivePath, bundleManifestFile, err)
+	}
+	if m.Format != bundleFormat {
+		return m, fmt.Errorf("%s: bundle format %d isn't supported by this seed (expects %d)", archivePath, m.Format, bundleFormat)
+	}
+	for name, want := range m.Files {
+		content, ok := files[name]
+		if !ok {
+			return m, fmt.Errorf("%s: %s is missing", archivePath, name)
+		}
+		if err := verifySHA256(content, want); err != nil {
+			return m, fmt.Errorf("%s: %s: %w", archivePath, name, err)
+		}
+	}
+	user, _ := loadUserConfig()
+	if pin == "" && os.Getenv(orgConfigSHA256Env) == "" && len(user.TrustedKeys) == 0 {
+		return m, fmt.Errorf("%s: nothing vouches for this bundle; pass --sha256 with the digest `seed bundle create` printed, or set %s or trustedKeys", archivePath, orgConfigSHA256Env)
+	}
+	err = verifyOrgConfigWith(files[bundleConfigFile], func() ([]byte, error) {
+		if sig, ok := files[bundleSigFile]; ok {
+			return sig, nil
+		}
+		return nil, fmt.Errorf("the bundle has no %s", bundleSigFile)
+	})
+	if err != nil {
+		return m, fmt.Errorf("%s: org config: %w", archivePath, err)
+	}
+	var cfg userConfig
+	if err := json.Unmarshal(files[bundleConfigFile], &cfg); err != nil {
+		return m, fmt.Errorf("%s: invalid %s: %w", archivePath, bundleConfigFile, err)
+	}
+	if err := validateRequire(cfg.Require); err != nil {
+		return m, fmt.Errorf("%s: %w", archivePath, err)
+	}
+
+	dir, err := importedBundleDir()
+	if err != nil {
+		return m, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return m, err
+	}
+	// Config first: the manifest marks a complete import. The signature is
+	// kept for verifyOrgConfig when the cached copy expires
+	for _, name := range []string{bundleConfigFile, bundleSigFile, bundleManifestFile} {
+		target := filepath.Join(dir, name)
+		content, ok := files[name]
+		if !ok {
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return m, err
+			}
+			continue
+		}
+		if err := writeFileAtomic(target, content, 0644); err != nil {
+			return m, err
+		}
+	}
+	// Replace the cached copy of an earlier import; this one was just verified
+	cache := orgConfigCache{Source: filepath.Join(dir, bundleConfigFile), FetchedAt: time.Now(), Config: files[bundleConfigFile]}
+	if err := writeOrgConfigCache(cache); err != nil {
+		debugf("org config cache: %v", err)
+	}
+	return m, nil
+}
+
+// readBundleFiles returns the regular files in a bundle tarball, keyed by
+// path below the bundle root.
+func readBundleFiles(raw []byte) (map[string][]byte, error) {
+	files, err := readTarGz(raw, bundleRoot, bundleMaxFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle: %w", err)
+	}
+	if files[bundleManifestFile] == nil {
+		return nil, fmt.Errorf("not a bundle: no %s", bundleManifestFile)
+	}
+	return files, nil
+}
+
+// importedBundleDir returns where an imported bundle is kept.
+func importedBundleDir() (string, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, bundleDir), nil
+}
+
+// importedOrgConfig returns the path of an imported bundle's org config, or
+// "" when none has been imported.
+func importedOrgConfig() string {
+	dir, err := importedBundleDir()
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(dir, bundleManifestFile)); err != nil {
+		return ""
+	}
+	return filepath.Join(dir, bundleConfigFile)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	dir := isolateConfig(t)
+	source := filepath.Join(dir, "org.json")
+	if err := os.WriteFile(source, []byte(`{"license": "Apache-2.0", "require": ["git"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useOrgConfig(t, source)
+
+	bundle := filepath.Join(dir, "seed-bundle.tar.gz")
+	sum, err := createBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := createBundle(filepath.Join(dir, "seed-bundle.zip")); err == nil {
+		t.Error("expected zip bundles to be rejected")
+	}
+
+	// On the air-gapped side: no SEED_ORG_CONFIG, a fresh config directory
+	isolateConfig(t)
+	useOrgConfig(t, "")
+	if _, err := importBundle(bundle, strings.Repeat("0", 64)); err == nil {
+		t.Fatal("expected a pin mismatch")
+	}
+	m, err := importBundle(bundle, sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Source != source || m.SeedVersion != Version {
+		t.Errorf("manifest = %+v", m)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.License != "Apache-2.0" || len(cfg.Require) != 1 {
+		t.Errorf("expected the bundled org config to be in effect, got %+v", cfg)
+	}
+}
+
+func TestImportBundleRejectsTampering(t *testing.T) {
+	dir := isolateConfig(t)
+	bundle := filepath.Join(dir, "bad.tar.gz")
+	files := []RenderedFile{
+		{Path: bundleManifestFile, Content: []byte(`{"format": 1, "files": {"config.json": "` + strings.Repeat("0", 64) + `"}}`), Mode: 0644},
+		{Path: bundleConfigFile, Content: []byte(`{"license": "MIT"}`), Mode: 0644},
+	}
+	if err := writeArchive(bundle, bundleRoot, files); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := importBundle(bundle, ""); err == nil || !strings.Contains(err.Error(), "sha256 mismatch") {
+		t.Errorf("expected a checksum error, got %v", err)
+	}
+	if importedOrgConfig() != "" {
+		t.Error("a rejected bundle must not be installed")
+	}
+}
+
+func TestImportBundleNeedsTrust(t *testing.T) {
+	dir := isolateConfig(t)
+	source := filepath.Join(dir, "org.json")
+	content := []byte(`{"license": "Apache-2.0"}`)
+	if err := os.WriteFile(source, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	key := newMinisignTestKey(t, 1)
+	if err := os.WriteFile(source+minisignSigSuffix, key.sign(content, "org config"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveUserConfig(userConfig{TrustedKeys: []string{key.pub}}); err != nil {
+		t.Fatal(err)
+	}
+	useOrgConfig(t, source)
+	bundle := filepath.Join(dir, "seed-bundle.tar.gz")
+	if _, err := createBundle(bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a pin or trusted keys, nothing vouches for the bundle
+	isolateConfig(t)
+	useOrgConfig(t, "")
+	if _, err := importBundle(bundle, ""); err == nil || !strings.Contains(err.Error(), "--sha256") {
+		t.Errorf("expected an unpinned import to be refused, got %v", err)
+	}
+	t.Setenv(orgConfigSHA256Env, strings.Repeat("0", 64))
+	if _, err := importBundle(bundle, ""); err == nil || !strings.Contains(err.Error(), "sha256 mismatch") {
+		t.Errorf("expected SEED_ORG_CONFIG_SHA256 to be checked, got %v", err)
+	}
+	if importedOrgConfig() != "" {
+		t.Fatal("a rejected bundle must not be installed")
+	}
+	t.Setenv(orgConfigSHA256Env, "")
+
+	// The same keys on this side check the bundled signature, now and when
+	// the cached copy expires
+	if err := saveUserConfig(userConfig{TrustedKeys: []string{key.pub}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := importBundle(bundle, ""); err != nil {
+		t.Fatal(err)
+	}
+	if state := fetchOrgConfigCached(importedOrgConfig(), time.Now().Add(2*orgConfigTTL)); state.Err != nil || state.Config.License != "Apache-2.0" {
+		t.Errorf("expected the imported config to verify after the cache expires, got %+v", state)
+	}
+	if err := saveUserConfig(userConfig{TrustedKeys: []string{newMinisignTestKey(t, 2).pub}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := importBundle(bundle, ""); err == nil || !strings.Contains(err.Error(), "org config") {
+		t.Errorf("expected a signature from an untrusted key to be refused, got %v", err)
+	}
+}
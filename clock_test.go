@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{"2026-03-04T05:06:07Z", time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC), true},
+		{"2026-03-04T07:06:07+02:00", time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC), true},
+		{"2026-03-04", time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), true},
+		{"1767225600", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"yesterday", time.Time{}, false},
+		{"-5", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, err := parseClock(tt.value)
+		if (err == nil) != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseClock(%q) = %v, %v, want %v (ok %t)", tt.value, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+// setClock fixes seedNow for the rest of the test.
+func setClock(t *testing.T, at time.Time) {
+	t.Helper()
+	saved := fixedClock
+	fixedClock = at
+	t.Cleanup(func() { fixedClock = saved })
+}
+
+func TestSeedNow(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1767225600")
+	if got := seedNow(); !got.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("seedNow() with SOURCE_DATE_EPOCH = %v", got)
+	}
+	at := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	setClock(t, at)
+	if got := seedNow(); !got.Equal(at) {
+		t.Errorf("--clock should win over SOURCE_DATE_EPOCH, got %v", got)
+	}
+	if env := gitDateEnv(); !slices.Contains(env, "GIT_COMMITTER_DATE=2030-06-01T12:00:00Z") {
+		t.Errorf("gitDateEnv() = %v", env)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	fixedClock = time.Time{}
+	if env := gitDateEnv(); env != nil {
+		t.Errorf("gitDateEnv() without a fixed clock = %v", env)
+	}
+}
+
+func TestFixedClockOutputIsByteStable(t *testing.T) {
+	setClock(t, time.Date(2031, 2, 3, 4, 5, 6, 0, time.UTC))
+	answers := WizardData{
+		ProjectName:         "stable",
+		Description:         "Byte-stable output",
+		Language:            "go",
+		License:             "MIT",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ChatTools:           []string{"claude", "codex"},
+		ForwardEnv:          []string{"NPM_TOKEN", "AWS_PROFILE"},
+		Secrets:             []string{"STRIPE_KEY"},
+		Mounts:              []string{"cache:/cache"},
+		AgentExtensions:     []string{"anthropics.claude-code"},
+		ExtensionsVolume:    "stable-0123abcd-vscode-extensions",
+	}
+	if err := answers.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var archives [2][]byte
+	for i := range archives {
+		files, err := renderProjectFiles(answers.ToTemplateData())
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifest, err := manifestFile(newManifest(answers, seedNow().Year(), files))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, format := range []string{"tar.gz", "zip"} {
+			path := filepath.Join(t.TempDir(), "out."+format)
+			if err := writeArchive(path, "stable", append(files, manifest)); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			archives[i] = append(archives[i], raw...)
+		}
+
+		var m Manifest
+		if err := json.Unmarshal(manifest.Content, &m); err != nil {
+			t.Fatal(err)
+		}
+		if m.Year != 2031 || !m.GeneratedAt.Equal(fixedClock) {
+			t.Errorf("manifest year %d, generatedAt %v, want the fixed clock", m.Year, m.GeneratedAt)
+		}
+		if license := renderedContent(files, "LICENSE"); !bytes.Contains([]byte(license), []byte("2031")) {
+			t.Errorf("LICENSE should carry the fixed year:\n%s", license)
+		}
+	}
+	if !bytes.Equal(archives[0], archives[1]) {
+		t.Error("identical answers and clock should give byte-identical archives")
+	}
+}
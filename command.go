@@ -0,0 +1,142 @@
+// Package main - command.go
+//
+// PURPOSE:
+// This file runs external commands (git, gh, docker, ...) for seed. It's
+// responsible for:
+// - Bounding every command with a timeout, so a command waiting on input
+//   seed can't see (GPG pinentry, a credential prompt) fails instead of
+//   hanging seed forever
+// - Capturing stderr and putting it in the error, since output is otherwise
+//   hidden behind seed's own progress display
+//
+// DESIGN PATTERNS:
+// - One entry point (runCommand, or runCommandInput to feed stdin) for
+//   every external process
+// - Timeout precedence: SEED_COMMAND_TIMEOUT, then config.json
+//   "commandTimeout" (or the org config's), then the caller's default
+//
+// USAGE:
+// out, err := runCommand(dir, commandTimeout(defaultCommandTimeout), "git", "init")
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Default limits. Scaffolding commands may do real work (a commit with
+// hooks); doctor probes should answer almost immediately.
+const (
+	defaultCommandTimeout = 60 * time.Second
+	probeCommandTimeout   = 10 * time.Second
+)
+
+// maxStderrLines caps how much of a command's stderr goes into an error.
+const maxStderrLines = 10
+
+// errCommandTimeout is wrapped by the error of a command that was killed
+// for running past its timeout.
+var errCommandTimeout = errors.New("timed out")
+
+// commandTimeout returns the configured command timeout, or fallback when
+// none is set (or the setting doesn't parse as a positive duration).
+func commandTimeout(fallback time.Duration) time.Duration {
+	cfg, _ := loadConfig()
+	return commandTimeoutFrom(cfg, fallback)
+}
+
+// commandTimeoutFrom returns SEED_COMMAND_TIMEOUT, else cfg's
+// commandTimeout, else fallback.
+func commandTimeoutFrom(cfg userConfig, fallback time.Duration) time.Duration {
+	if d, ok := parseTimeout(os.Getenv("SEED_COMMAND_TIMEOUT")); ok {
+		return d
+	}
+	if d, ok := parseTimeout(cfg.CommandTimeout); ok {
+		return d
+	}
+	return fallback
+}
+
+// parseTimeout parses a Go duration ("90s", "2m") or a bare number of seconds.
+func parseTimeout(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		d, err = time.ParseDuration(value + "s")
+	}
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// runCommand runs name with args in dir (the current directory when empty)
+// and returns its stdout. Stdin is not connected. On failure the error
+// includes the tail of stderr; on timeout the process is killed.
+func runCommand(dir string, timeout time.Duration, name string, args ...string) (string, error) {
+	return runCommandInput(dir, timeout, nil, name, args...)
+}
+
+// runCommandInput is runCommand with stdin connected to input, for
+// commands that filter it (formatters).
+func runCommandInput(dir string, timeout time.Duration, input []byte, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = commandEnv()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	// Children that inherit the pipes (e.g. gpg-agent) mustn't keep Wait
+	// blocked after the process is killed
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	// Log only the subcommand: later arguments can hold names or messages
+	// that crash reports must not include
+	subcommand := ""
+	if len(args) > 0 {
+		subcommand = args[0]
+	}
+	debugf("%s %s: %v", name, subcommand, err)
+	switch {
+	case err == nil:
+		return stdout.String(), nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return stdout.String(), fmt.Errorf("%w after %s (it may be waiting for input, e.g. a GPG passphrase; set SEED_COMMAND_TIMEOUT to allow longer)", errCommandTimeout, timeout)
+	}
+	if detail := stderrTail(stderr.String()); detail != "" {
+		return stdout.String(), fmt.Errorf("%w: %s", err, detail)
+	}
+	return stdout.String(), err
+}
+
+// stderrTail returns the last maxStderrLines non-empty lines of stderr,
+// joined for inclusion in an error message.
+func stderrTail(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxStderrLines {
+		lines = lines[len(lines)-maxStderrLines:]
+	}
+	return strings.Join(lines, "\n")
+}
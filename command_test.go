@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunCommand(t *testing.T) {
+	t.Run("stdout", func(t *testing.T) {
+		out, err := runCommand("", time.Minute, "sh", "-c", "echo hello")
+		if err != nil || out != "hello\n" {
+			t.Fatalf("got %q, %v", out, err)
+		}
+	})
+
+	t.Run("stderr in error", func(t *testing.T) {
+		_, err := runCommand("", time.Minute, "sh", "-c", "echo 'fatal: not a git repository' >&2; exit 3")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, want := range []string{"exit status 3", "fatal: not a git repository"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q should contain %q", err, want)
+			}
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		_, err := runCommand("", 100*time.Millisecond, "sleep", "5")
+		if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("timeout took %s to take effect", elapsed)
+		}
+	})
+
+	t.Run("working directory", func(t *testing.T) {
+		dir, err := filepath.EvalSymlinks(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := runCommand(dir, time.Minute, "pwd", "-P")
+		if err != nil || strings.TrimSpace(out) != dir {
+			t.Fatalf("got %q, %v; want %s", out, err, dir)
+		}
+	})
+}
+
+func TestStderrTail(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 15; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i), "")
+	}
+	got := stderrTail(strings.Join(lines, "\n"))
+	if strings.Contains(got, "line 5\n") || !strings.HasPrefix(got, "line 6\n") || !strings.HasSuffix(got, "line 15") {
+		t.Errorf("expected the last %d non-empty lines, got:\n%s", maxStderrLines, got)
+	}
+}
+
+func TestCommandTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string
+		config string
+		want   time.Duration
+	}{
+		{name: "default", want: defaultCommandTimeout},
+		{name: "config", config: "2m", want: 2 * time.Minute},
+		{name: "env wins over config", env: "90s", config: "2m", want: 90 * time.Second},
+		{name: "bare seconds", env: "45", want: 45 * time.Second},
+		{name: "invalid env ignored", env: "soon", config: "5m", want: 5 * time.Minute},
+		{name: "non-positive ignored", env: "0", want: defaultCommandTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfig(t)
+			t.Setenv("SEED_COMMAND_TIMEOUT", tt.env)
+			if tt.config != "" {
+				if err := saveUserConfig(userConfig{CommandTimeout: tt.config}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := commandTimeout(defaultCommandTimeout); got != tt.want {
+				t.Errorf("commandTimeout() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package main - commits.go
+//
+// PURPOSE:
+// This file describes the commit conventions a project can adopt. It's
+// responsible for:
+// - The catalog: Conventional Commits and gitmoji
+// - Each convention's tooling config (commitlint/commitizen, gitmoji-cli)
+// - The message of seed's own initial commit, written in the convention
+//
+// DESIGN PATTERNS:
+// - Catalog data here, prose in the templates: CONTRIBUTING.md explains the
+//   chosen convention and AGENTS.md points agents at it
+// - No convention ("" or "none") changes nothing, so older answers render
+//   and commit exactly as before
+//
+// USAGE:
+// message := initialCommitMessage(w.CommitConvention, w.ProjectName)
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/huh"
+)
+
+// commitConvention is a commit message convention and the files that set
+// up its tooling.
+type commitConvention struct {
+	ID            string
+	Label         string // Shown in the wizard
+	Files         []RenderedFile
+	InitialCommit string // Format for seed's initial commit; %s is the project name
+}
+
+// defaultInitialCommit is the initial commit message without a convention.
+const defaultInitialCommit = "Initial scaffold for %s (via seed)"
+
+// commitConventions lists the supported conventions in wizard order.
+var commitConventions = []commitConvention{
+	{
+		ID: "conventional", Label: "Conventional Commits",
+		Files: []RenderedFile{
+			{Path: "commitlint.config.mjs", Mode: 0644, Content: []byte("export default { extends: [\"@commitlint/config-conventional\"] };\n")},
+			{Path: ".czrc", Mode: 0644, Content: []byte("{\n  \"path\": \"cz-conventional-changelog\"\n}\n")},
+		},
+		InitialCommit: "chore: initial scaffold for %s (via seed)",
+	},
+	{
+		ID: "gitmoji", Label: "gitmoji",
+		Files: []RenderedFile{
+			{Path: ".gitmojirc.json", Mode: 0644, Content: []byte("{\n  \"emojiFormat\": \"emoji\",\n  \"scopePrompt\": false,\n  \"messagePrompt\": false,\n  \"capitalizeTitle\": true\n}\n")},
+		},
+		InitialCommit: "🎉 Initial scaffold for %s (via seed)",
+	},
+}
+
+// commitConventionFor returns the convention with id, or nil for none.
+func commitConventionFor(id string) *commitConvention {
+	for i := range commitConventions {
+		if commitConventions[i].ID == id {
+			return &commitConventions[i]
+		}
+	}
+	return nil
+}
+
+// Commits returns the project's commit convention, or nil for none.
+func (d TemplateData) Commits() *commitConvention {
+	return commitConventionFor(d.CommitConvention)
+}
+
+// validateCommitConvention rejects unknown conventions.
+func validateCommitConvention(id string) error {
+	if id == "" || id == "none" || commitConventionFor(id) != nil {
+		return nil
+	}
+	return errors.New(T("validate.commitConvention", id))
+}
+
+// commitConventionOptions offers no convention, then the catalog.
+func commitConventionOptions() []huh.Option[string] {
+	options := []huh.Option[string]{huh.NewOption(T("wizard.commits.none"), "")}
+	for _, c := range commitConventions {
+		options = append(options, huh.NewOption(c.Label, c.ID))
+	}
+	return options
+}
+
+// initialCommitMessage is the message of seed's initial commit.
+func initialCommitMessage(convention, projectName string) string {
+	format := defaultInitialCommit
+	if c := commitConventionFor(convention); c != nil {
+		format = c.InitialCommit
+	}
+	return fmt.Sprintf(format, projectName)
+}
+
+// renderCommitConvention returns the convention's tooling config.
+func renderCommitConvention(data TemplateData) []RenderedFile {
+	c := data.Commits()
+	if c == nil {
+		return nil
+	}
+	files := make([]RenderedFile, 0, len(c.Files))
+	for _, f := range c.Files {
+		f.Content = slices.Clone(f.Content)
+		files = append(files, f)
+	}
+	return files
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderCommitConvention(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range commitConventions {
+		t.Run(c.ID, func(t *testing.T) {
+			files, err := s.Render(TemplateData{ProjectName: "commits", Description: "Commit test", License: "MIT", CommitConvention: c.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			byPath := map[string]string{}
+			for _, f := range files {
+				byPath[f.Path] = string(f.Content)
+			}
+			for _, f := range c.Files {
+				if _, ok := byPath[f.Path]; !ok {
+					t.Errorf("missing %s", f.Path)
+				}
+			}
+			contributing := byPath["CONTRIBUTING.md"]
+			if !strings.Contains(contributing, "## Commit messages") || strings.Contains(contributing, "## License") {
+				t.Errorf("CONTRIBUTING.md for an MIT project:\n%s", contributing)
+			}
+			if _, ok := byPath["NOTICE"]; ok {
+				t.Error("NOTICE is only for Apache-2.0")
+			}
+			if !strings.Contains(byPath["AGENTS.md"], "- **Commit messages**: Follow "+c.Label) {
+				t.Errorf("AGENTS.md should point at %s", c.Label)
+			}
+		})
+	}
+}
+
+func TestCommitConventionWithApache(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := s.Render(TemplateData{ProjectName: "both", Description: "Apache and gitmoji", License: "Apache-2.0", CommitConvention: "gitmoji"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if f.Path == "CONTRIBUTING.md" {
+			content := string(f.Content)
+			if !strings.Contains(content, "### License headers") || !strings.Contains(content, "[gitmoji]") {
+				t.Errorf("CONTRIBUTING.md should cover both:\n%s", content)
+			}
+			return
+		}
+	}
+	t.Error("missing CONTRIBUTING.md")
+}
+
+func TestNoCommitConvention(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, convention := range []string{"", "none"} {
+		files, err := s.Render(TemplateData{ProjectName: "plain", Description: "No convention", License: "MIT", CommitConvention: convention})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			if f.Path == "CONTRIBUTING.md" || f.Path == ".czrc" || f.Path == ".gitmojirc.json" {
+				t.Errorf("unexpected %s with convention %q", f.Path, convention)
+			}
+		}
+	}
+}
+
+func TestInitialCommitMessage(t *testing.T) {
+	tests := []struct {
+		convention, want string
+	}{
+		{"", "Initial scaffold for demo (via seed)"},
+		{"none", "Initial scaffold for demo (via seed)"},
+		{"conventional", "chore: initial scaffold for demo (via seed)"},
+		{"gitmoji", "🎉 Initial scaffold for demo (via seed)"},
+	}
+	for _, tt := range tests {
+		if got := initialCommitMessage(tt.convention, "demo"); got != tt.want {
+			t.Errorf("initialCommitMessage(%q) = %q, want %q", tt.convention, got, tt.want)
+		}
+	}
+	if err := validateCommitConvention("angular"); err == nil {
+		t.Error("expected an unknown convention to be refused")
+	}
+}
@@ -0,0 +1,128 @@
+// Package main - config.go
+//
+// PURPOSE:
+// This file reads and writes seed's per-user configuration. It's
+// responsible for:
+// - Locating the config directory (os.UserConfigDir()/seed)
+// - Loading and saving config.json (loadConfig in orgconfig.go adds the
+//   organization's config below it)
+//
+// DESIGN PATTERNS:
+// - Plain JSON (encoding/json), like the manifest and batch specs
+// - A missing file is an empty config, not an error
+//
+// USAGE:
+// cfg, err := loadUserConfig()
+// cfg.Telemetry = telemetryOff
+// err = saveUserConfig(cfg)
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// userConfig is the contents of config.json.
+type userConfig struct {
+	Telemetry      string   `json:"telemetry,omitempty"`      // telemetryOn, telemetryOff, or "" (not asked yet)
+	Locale         string   `json:"locale,omitempty"`         // UI language (e.g. "es"); overrides LANG
+	CommandTimeout string   `json:"commandTimeout,omitempty"` // Limit for git and other external commands (e.g. "2m")
+	CABundle       string   `json:"caBundle,omitempty"`       // PEM file of extra CAs for HTTPS (network.go); machine-specific, not taken from the org config
+	ForwardEnv     []string `json:"forwardEnv,omitempty"`     // Host variables the wizard forwards into dev containers by default
+	Mounts         []string `json:"mounts,omitempty"`         // Extra dev container mounts added to every project
+	AITools        []aiTool `json:"aiTools,omitempty"`        // Relocated state dirs for known AI tools, or extra tools
+
+	// Project defaults, usually set in the org config (orgconfig.go)
+	License    string   `json:"license,omitempty"`    // License preselected in the wizard and used by batch projects without one
+	Require    []string `json:"require,omitempty"`    // Components every project gets: git, devcontainer, vscodeConfig, license, licenseHeaders
+	ReadmeOmit []string `json:"readmeOmit,omitempty"` // README sections every project leaves out (readme.go)
+
+	// Mirror for dev container base images, e.g. "registry.acme.com/devcontainers"
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// Policy, usually set in the org config (policy.go)
+	AllowedSources []string `json:"allowedSources,omitempty"` // Repositories seed may pull into a project (none: any)
+	TrustedKeys    []string `json:"trustedKeys,omitempty"`    // minisign public keys remote content must be signed with (integrity.go)
+
+	// Project rules, checked before a project is written (policy.go)
+	RequiredFiles     []string `json:"requiredFiles,omitempty"`     // Paths (or path.Match globs) every project must contain
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"` // Registries (or registry/path prefixes) dev container images may come from
+
+	// Branding (branding.go)
+	Branding *branding `json:"branding,omitempty"` // Banner name and emoji, and a footer for generated docs
+
+	// Provenance (audit.go); the org's value wins
+	AuditLog string `json:"auditLog,omitempty"` // File or https:// endpoint receiving one entry per scaffold
+
+	// Output limits (sizeguard.go); the lower of the org's and the user's wins
+	MaxFiles int    `json:"maxFiles,omitempty"` // Files a project may have without confirmation (default 500)
+	MaxSize  string `json:"maxSize,omitempty"`  // Total size a project may have without confirmation (e.g. "50MB")
+
+	// Oldest seed allowed (version.go); the newest minimum in effect wins
+	MinSeedVersion   string `json:"minSeedVersion,omitempty"`   // e.g. "1.4.0"
+	SeedVersionCheck string `json:"seedVersionCheck,omitempty"` // "warn" warns about an older seed instead of refusing to run
+
+	// Formatting of generated files (format.go)
+	Format     bool              `json:"format,omitempty"`     // Run the default formatters (gofmt, prettier, shfmt) when installed
+	Formatters map[string]string `json:"formatters,omitempty"` // Command per file type (".go", "Dockerfile"); "" turns a type off
+}
+
+// seedConfigDir returns seed's per-user configuration directory.
+func seedConfigDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "seed"), nil
+}
+
+// userConfigPath returns the path of config.json.
+func userConfigPath() (string, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// loadUserConfig reads config.json, returning an empty config if it doesn't exist.
+func loadUserConfig() (userConfig, error) {
+	var cfg userConfig
+	configPath, err := userConfigPath()
+	if err != nil {
+		return cfg, err
+	}
+	raw, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+	return cfg, nil
+}
+
+// saveUserConfig writes config.json, creating the config directory if needed.
+func saveUserConfig(cfg userConfig) error {
+	configPath, err := userConfigPath()
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, append(raw, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
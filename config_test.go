@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// isolateConfig points the user config directory at a temp dir.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	return dir
+}
+
+func TestUserConfigRoundTrip(t *testing.T) {
+	isolateConfig(t)
+
+	cfg, err := loadUserConfig()
+	if err != nil {
+		t.Fatalf("missing config should load empty, got %v", err)
+	}
+	if !reflect.DeepEqual(cfg, userConfig{}) {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+
+	cfg.Telemetry = telemetryOff
+	cfg.ForwardEnv = []string{"NPM_TOKEN"}
+	if err := saveUserConfig(cfg); err != nil {
+		t.Fatalf("saveUserConfig: %v", err)
+	}
+	got, err := loadUserConfig()
+	if err != nil {
+		t.Fatalf("loadUserConfig: %v", err)
+	}
+	if !reflect.DeepEqual(got, cfg) {
+		t.Errorf("got %+v, want %+v", got, cfg)
+	}
+}
+
+func TestUserConfigInvalid(t *testing.T) {
+	isolateConfig(t)
+	configPath, _ := userConfigPath()
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadUserConfig(); err == nil {
+		t.Error("expected error for invalid config")
+	}
+}
@@ -0,0 +1,194 @@
+// Package main - crash.go
+//
+// PURPOSE:
+// This file turns unexpected failures into a diagnostic bundle the user can
+// attach to a GitHub issue. It's responsible for:
+// - Keeping a small in-memory debug log of what seed did this run
+// - Remembering the wizard answers so they can be reported (sanitized)
+// - Writing a markdown bundle: error, stack trace, environment, checks, log
+//
+// DESIGN PATTERNS:
+// - Nothing is written unless the user agrees at the prompt
+// - Sanitized by construction: free-text answers are reduced to their
+//   length, positional arguments are elided, and the home directory is
+//   replaced with ~ everywhere
+//
+// USAGE:
+// debugf("rendered %d files", n)
+// noteAnswers(wizardData)
+// offerCrashReport(err, stack)
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// errAborted marks a user declining a confirmation; not a crash.
+var errAborted = errors.New("aborted")
+
+// runStarted is when this run began; debug log times are relative to it.
+var runStarted = time.Now()
+
+// debugLog collects debugf lines for crash reports.
+var debugLog []string
+
+// crashAnswers holds the most recent wizard answers, if any.
+var crashAnswers *WizardData
+
+// debugf records a line in the debug log.
+func debugf(format string, args ...any) {
+	elapsed := time.Since(runStarted).Round(time.Millisecond)
+	debugLog = append(debugLog, fmt.Sprintf("+%s %s", elapsed, fmt.Sprintf(format, args...)))
+}
+
+// noteAnswers remembers answers for a potential crash report.
+func noteAnswers(data WizardData) {
+	crashAnswers = &data
+}
+
+// crashWorthy reports whether err is unexpected enough to offer a bundle:
+// usage mistakes, cancellations and declined confirmations are not.
+func crashWorthy(err error) bool {
+	var usageErr usageError
+	return !errors.As(err, &usageErr) && !errors.Is(err, huh.ErrUserAborted) && !errors.Is(err, errAborted) && !errors.Is(err, errInterrupted)
+}
+
+// sanitizeAnswers keeps the structural answers and replaces free text with
+// its length, so bundles can be shared publicly.
+func sanitizeAnswers(data WizardData) WizardData {
+	redact := func(s string) string {
+		if s == "" {
+			return ""
+		}
+		return fmt.Sprintf("<redacted, %d chars>", len(s))
+	}
+	data.ProjectName = redact(data.ProjectName)
+	data.Description = redact(data.Description)
+	data.DotfilesRepo = redact(data.DotfilesRepo)
+	return data
+}
+
+// sanitizeArgs keeps flags and subcommand names but elides everything else
+// (directories, file names, descriptions).
+func sanitizeArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for i, arg := range args {
+		_, isCommand := subcommands[arg]
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			flag, _, _ := strings.Cut(arg, "=")
+			out = append(out, flag)
+		case i == 0 && isCommand:
+			out = append(out, arg)
+		default:
+			out = append(out, "<arg>")
+		}
+	}
+	return out
+}
+
+// sanitizePaths replaces the user's home directory with ~.
+func sanitizePaths(s string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" || home == "/" {
+		return s
+	}
+	return strings.ReplaceAll(s, home, "~")
+}
+
+// renderCrashReport builds the markdown bundle for err. stack may be empty
+// (plain errors carry no stack trace).
+func renderCrashReport(err error, stack string) string {
+	var b strings.Builder
+	b.WriteString("# seed diagnostic bundle\n\n")
+	b.WriteString("Attach this file to an issue at https://github.com/justinphilpott/seed/issues. ")
+	b.WriteString("Answer text and arguments are redacted and your home directory is shown as ~, but error messages may still name files; review it before sharing.\n\n")
+
+	b.WriteString("## Error\n\n```\n" + sanitizePaths(err.Error()) + "\n```\n\n")
+	if stack != "" {
+		b.WriteString("## Stack trace\n\n```\n" + sanitizePaths(stack) + "\n```\n\n")
+	}
+
+	b.WriteString("## Environment\n\n")
+	fmt.Fprintf(&b, "- seed: %s (templates v%d)\n", displayVersion(), templateVersion)
+	fmt.Fprintf(&b, "- go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "- os/arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "- args: %s\n", strings.Join(sanitizeArgs(os.Args[1:]), " "))
+	fmt.Fprintf(&b, "- TERM: %s\n\n", os.Getenv("TERM"))
+
+	// Check names and details are reported in English, like the rest
+	locale := currentLocale
+	currentLocale = defaultLocale
+	results := runChecks(doctorChecks)
+	currentLocale = locale
+	b.WriteString("## Checks\n\n```\n")
+	for _, r := range results {
+		status := [...]string{"pass", "warn", "fail"}[r.Status]
+		fmt.Fprintf(&b, "%-5s %-17s %s\n", status, r.Name, sanitizePaths(r.Detail))
+	}
+	b.WriteString("```\n\n")
+
+	if crashAnswers != nil {
+		a := sanitizeAnswers(*crashAnswers)
+		b.WriteString("## Answers\n\n")
+		fmt.Fprintf(&b, "- projectName: %s\n- description: %s\n- license: %s\n- initGit: %t\n", a.ProjectName, a.Description, a.License, a.InitGit)
+		fmt.Fprintf(&b, "- includeDevContainer: %t\n- devContainerImage: %s\n- aiChatContinuity: %t\n- chatTools: %s\n- agentExtensions: %s\n",
+			a.IncludeDevContainer, a.DevContainerImage, a.AIChatContinuity, strings.Join(a.ChatTools, ", "), strings.Join(a.AgentExtensions, ", "))
+		fmt.Fprintf(&b, "- shell: %s\n- dotfilesRepo: %s\n- dockerAccess: %s\n- workload: %s\n- gpu: %t\n\n", a.Shell, a.DotfilesRepo, a.DockerAccess, a.Workload, a.GPU)
+	}
+
+	b.WriteString("## Debug log\n\n```\n")
+	for _, line := range debugLog {
+		b.WriteString(sanitizePaths(line) + "\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// writeCrashBundle writes the report to dir and returns its path.
+func writeCrashBundle(dir, report string) (string, error) {
+	name := fmt.Sprintf("seed-crash-%s.md", time.Now().Format("20060102-150405"))
+	bundlePath := filepath.Join(dir, name)
+	if err := os.WriteFile(bundlePath, []byte(report), 0644); err != nil {
+		return "", err
+	}
+	return bundlePath, nil
+}
+
+// offerCrashReport asks whether to write a diagnostic bundle for err and
+// writes it to the current directory (or the temp dir if that fails). It
+// only asks on an interactive terminal.
+func offerCrashReport(err error, stack string) {
+	if checkTerminal().Status != checkPass {
+		return
+	}
+	var yes bool
+	formErr := huh.NewConfirm().
+		Title(T("crash.prompt")).
+		Description(T("crash.promptHint")).
+		Value(&yes).
+		Run()
+	if formErr != nil || !yes {
+		return
+	}
+
+	report := renderCrashReport(err, stack)
+	bundlePath, writeErr := writeCrashBundle(".", report)
+	if writeErr != nil {
+		bundlePath, writeErr = writeCrashBundle(os.TempDir(), report)
+	}
+	if writeErr != nil {
+		fmt.Fprintln(os.Stderr, T("crash.writeFailed", writeErr))
+		return
+	}
+	fmt.Fprintln(os.Stderr, T("crash.wrote", bundlePath, "https://github.com/justinphilpott/seed/issues"))
+}
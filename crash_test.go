@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+)
+
+func TestCrashWorthy(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"usage error", usageError{msg: "missing directory argument"}, false},
+		{"wizard cancelled", fmt.Errorf("wizard cancelled: %w", huh.ErrUserAborted), false},
+		{"declined confirmation", fmt.Errorf("%w -> nothing changed", errAborted), false},
+		{"write failure", errors.New("failed to write README.md: permission denied"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crashWorthy(tt.err); got != tt.want {
+				t.Errorf("crashWorthy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	got := sanitizeArgs([]string{"add", "package", "billing", "--description=secret stuff", "--dir", "apps/billing"})
+	want := []string{"add", "<arg>", "<arg>", "--description", "--dir", "<arg>"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRenderCrashReportIsSanitized(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "/" {
+		t.Skip("no usable home directory")
+	}
+	t.Cleanup(func() { crashAnswers, debugLog = nil, nil })
+
+	noteAnswers(WizardData{ProjectName: "acme-secret", Description: "Confidential plan", License: "MIT", Shell: "zsh", DotfilesRepo: "octo-private/dotfiles"})
+	debugf("writing %s/projects/acme-secret", home)
+
+	report := renderCrashReport(fmt.Errorf("failed to write %s/projects/acme-secret/README.md", home), "goroutine 1 [running]:")
+
+	if strings.Contains(report, home) {
+		t.Error("report should not contain the home directory")
+	}
+	if strings.Contains(report, "Confidential") {
+		t.Error("report should not contain the description")
+	}
+	if strings.Contains(report, "octo-private") {
+		t.Error("report should not contain the dotfiles repository")
+	}
+	for _, want := range []string{"## Error", "## Stack trace", "## Environment", "## Checks", "## Debug log", "license: MIT", "shell: zsh", "<redacted, 11 chars>"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
+
+func TestWriteCrashBundle(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath, err := writeCrashBundle(dir, "# report\n")
+	if err != nil {
+		t.Fatalf("writeCrashBundle: %v", err)
+	}
+	got, err := os.ReadFile(bundlePath)
+	if err != nil || string(got) != "# report\n" {
+		t.Errorf("bundle content = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,100 @@
+// Package main - description.go
+//
+// PURPOSE:
+// This file shapes the project description for the generated docs. It's
+// responsible for:
+// - Splitting the description into paragraphs, keeping the user's line
+//   breaks within each one
+// - Picking out the first sentence as the README tagline
+// - Returning the rest for the README's About section
+//
+// DESIGN PATTERNS:
+// - Methods on TemplateData (like Badges and Website), so the templates
+//   decide where each part goes; the recorded answer stays as typed
+// - A one-sentence description renders exactly what seed always has: the
+//   tagline alone, no About section
+// - Sentence detection is deliberately simple: the first ".", "!" or "?"
+//   followed by whitespace ends the tagline, so "v1.2" and "example.com"
+//   don't split it
+//
+// USAGE:
+// {{.Tagline}}
+// {{with .About}}## About{{"\n\n"}}{{.}}{{end}}
+
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// descriptionParagraphs splits a description into paragraphs at blank lines.
+// Line breaks within a paragraph are kept; trailing spaces are dropped.
+func descriptionParagraphs(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	var paragraphs []string
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return paragraphs
+}
+
+// splitSentence splits text after its first sentence, returning the
+// sentence and the rest (both trimmed; rest is "" for a single sentence).
+func splitSentence(text string) (first, rest string) {
+	for i, r := range text {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		next := text[i+1:]
+		if next != "" && unicode.IsSpace(rune(next[0])) {
+			return strings.TrimSpace(text[:i+1]), strings.TrimSpace(next)
+		}
+	}
+	return strings.TrimSpace(text), ""
+}
+
+// Overview returns the whole description as paragraphs separated by blank
+// lines, for docs that show it in full (AGENTS.md).
+func (d TemplateData) Overview() string {
+	return strings.Join(descriptionParagraphs(d.Description), "\n\n")
+}
+
+// Tagline returns the description's first sentence, on one line.
+func (d TemplateData) Tagline() string {
+	paragraphs := descriptionParagraphs(d.Description)
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	first, _ := splitSentence(paragraphs[0])
+	return strings.Join(strings.Fields(first), " ")
+}
+
+// About returns the description after the tagline, as paragraphs separated
+// by blank lines ("" when the description is one sentence).
+func (d TemplateData) About() string {
+	paragraphs := descriptionParagraphs(d.Description)
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	_, rest := splitSentence(paragraphs[0])
+	if rest == "" {
+		paragraphs = paragraphs[1:]
+	} else {
+		paragraphs[0] = rest
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescriptionParts(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		tagline     string
+		about       string
+		overview    string
+	}{
+		{"one sentence", "A tool for pruning branches.", "A tool for pruning branches.", "", "A tool for pruning branches."},
+		{"no punctuation", "Pruning tool", "Pruning tool", "", "Pruning tool"},
+		{"two sentences", "A tool for pruning branches. It runs nightly.", "A tool for pruning branches.", "It runs nightly.", "A tool for pruning branches. It runs nightly."},
+		{"dots inside words", "Serves example.com with v1.2 configs! Fast.", "Serves example.com with v1.2 configs!", "Fast.", "Serves example.com with v1.2 configs! Fast."},
+		{"paragraphs", "Prunes branches.\r\n\r\n\r\nRuns nightly  \nin CI.", "Prunes branches.", "Runs nightly\nin CI.", "Prunes branches.\n\nRuns nightly\nin CI."},
+		{"wrapped first sentence", "A tool for\npruning branches. It runs\nnightly.\n\nMIT licensed.", "A tool for pruning branches.", "It runs\nnightly.\n\nMIT licensed.", "A tool for\npruning branches. It runs\nnightly.\n\nMIT licensed."},
+		{"empty", "  ", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := TemplateData{Description: tt.description}
+			if got := d.Tagline(); got != tt.tagline {
+				t.Errorf("Tagline() = %q, want %q", got, tt.tagline)
+			}
+			if got := d.About(); got != tt.about {
+				t.Errorf("About() = %q, want %q", got, tt.about)
+			}
+			if got := d.Overview(); got != tt.overview {
+				t.Errorf("Overview() = %q, want %q", got, tt.overview)
+			}
+		})
+	}
+}
+
+func TestDescriptionShapesDocs(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	description := "Prunes stale branches. It runs nightly\nfrom CI.\n\nNothing is deleted without a dry run first."
+	files, err := s.Render(TemplateData{ProjectName: "prune", Description: description, License: "MIT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readme := renderedContent(files, "README.md")
+	want := "# prune\n\nPrunes stale branches.\n\n## About\n\nIt runs nightly\nfrom CI.\n\nNothing is deleted without a dry run first.\n\n## Goal"
+	if !strings.Contains(readme, want) {
+		t.Errorf("README.md missing tagline and About section %q:\n%s", want, readme)
+	}
+	agents := renderedContent(files, "AGENTS.md")
+	if !strings.Contains(agents, "Prunes stale branches. It runs nightly\nfrom CI.\n\nNothing is deleted") {
+		t.Errorf("AGENTS.md should carry the whole description with its line breaks:\n%s", agents)
+	}
+
+	// One sentence: no About section
+	files, err = s.Render(TemplateData{ProjectName: "prune", Description: "Prunes stale branches.", License: "MIT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readme := renderedContent(files, "README.md"); strings.Contains(readme, "## About") {
+		t.Errorf("a one-sentence description shouldn't get an About section:\n%s", readme)
+	}
+}
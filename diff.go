@@ -0,0 +1,232 @@
+// Package main - diff.go
+//
+// PURPOSE:
+// This file implements `seed diff`: unified diffs between the files on disk
+// and what the current templates render from the manifest's recorded answers.
+// It's responsible for:
+// - A small line-based unified diff (LCS edit script, 3 lines of context)
+// - Pairing on-disk files with their freshly rendered counterparts
+// - Binary assets are reported as differing, as git does, never diffed
+//
+// DESIGN PATTERNS:
+// - Standard library only; generated docs are small, so an O(n*m) LCS is fine
+// - Output follows `diff -u` conventions so it can be piped to a pager or
+//   applied with `patch -p1`
+//
+// USAGE:
+// diffs, err := projectDiffs(scaffolder, "/path/to/project")
+// for _, d := range diffs { fmt.Print(d.Patch) }
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// fileDiff is the unified diff for one generated file.
+type fileDiff struct {
+	Path  string
+	Patch string
+}
+
+// projectDiffs renders the current templates with the manifest's answers and
+// diffs each file on disk against its rendered version. Files that match are
+// omitted; files missing on disk diff against /dev/null.
+func projectDiffs(s *Scaffolder, dir string) ([]fileDiff, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []fileDiff
+	for _, f := range current {
+		onDisk, err := readGenerated(dir, f.Path)
+		oldName := "a/" + f.Path
+		switch {
+		case os.IsNotExist(err):
+			oldName = "/dev/null"
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		case sameGenerated(onDisk, f.Content):
+			continue // only the version stamp differs
+		}
+		if patch := unifiedDiff(oldName, "b/"+f.Path, onDisk, f.Content); patch != "" {
+			diffs = append(diffs, fileDiff{Path: f.Path, Patch: patch})
+		}
+	}
+	return diffs, nil
+}
+
+// diffOp is one line of an edit script: ' ' (keep), '-' (delete) or '+' (insert).
+type diffOp struct {
+	kind byte
+	line string
+	a, b int // 0-based line positions in a and b before this op
+}
+
+// unifiedDiff returns a `diff -u` style patch turning a into b, or "" if they
+// are identical. Binary content gets git's one-line notice instead of a patch.
+func unifiedDiff(aName, bName string, a, b []byte) string {
+	if bytes.Equal(a, b) {
+		return ""
+	}
+	if isBinary(a) || isBinary(b) {
+		return fmt.Sprintf("Binary files %s and %s differ\n", aName, bName)
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for _, h := range diffHunks(ops) {
+		writeHunk(&out, ops[h[0]:h[1]])
+	}
+	return out.String()
+}
+
+// splitLines splits content into lines, each keeping its trailing newline
+// (the last line may lack one).
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes an edit script from a to b via longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', b[j], i, j})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', a[i], i, j})
+			i++
+		}
+	}
+	return reorderDeletesFirst(ops)
+}
+
+// reorderDeletesFirst moves deletions ahead of insertions within each run of
+// changes, matching how diff(1) prints a replaced block.
+func reorderDeletesFirst(ops []diffOp) []diffOp {
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		end := start
+		for end < len(ops) && ops[end].kind != ' ' {
+			end++
+		}
+		a, b := ops[start].a, ops[start].b
+		var dels, ins []diffOp
+		for _, op := range ops[start:end] {
+			if op.kind == '-' {
+				dels = append(dels, op)
+			} else {
+				ins = append(ins, op)
+			}
+		}
+		k := start
+		for n, op := range dels {
+			ops[k] = diffOp{'-', op.line, a + n, b}
+			k++
+		}
+		for n, op := range ins {
+			ops[k] = diffOp{'+', op.line, a + len(dels), b + n}
+			k++
+		}
+		start = end
+	}
+	return ops
+}
+
+// diffHunks groups changes into [start, end) op ranges with surrounding
+// context, merging changes whose context would overlap.
+func diffHunks(ops []diffOp) [][2]int {
+	var hunks [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		start := max(0, i-diffContext)
+		end := min(len(ops), i+1+diffContext)
+		if n := len(hunks); n > 0 && start <= hunks[n-1][1] {
+			hunks[n-1][1] = end
+		} else {
+			hunks = append(hunks, [2]int{start, end})
+		}
+	}
+	return hunks
+}
+
+// writeHunk writes one @@ hunk for the given ops.
+func writeHunk(out *strings.Builder, ops []diffOp) {
+	aStart, bStart := ops[0].a, ops[0].b
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk's line range: 1-based start, count omitted when 1,
+// and start pointing at the preceding line when the range is empty.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
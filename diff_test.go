@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "identical",
+			a:    "one\ntwo\n",
+			b:    "one\ntwo\n",
+			want: "",
+		},
+		{
+			name: "replaced line",
+			a:    "one\ntwo\nthree\n",
+			b:    "one\nTWO\nthree\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n one\n-two\n+TWO\n three\n",
+		},
+		{
+			name: "appended line",
+			a:    "one\n",
+			b:    "one\ntwo\n",
+			want: "--- a/f\n+++ b/f\n@@ -1 +1,2 @@\n one\n+two\n",
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "new\n",
+			want: "--- a/f\n+++ b/f\n@@ -0,0 +1 @@\n+new\n",
+		},
+		{
+			name: "missing trailing newline",
+			a:    "one",
+			b:    "one\n",
+			want: "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-one\n\\ No newline at end of file\n+one\n",
+		},
+		{
+			name: "distant changes get separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:    "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name: "binary",
+			a:    "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR",
+			b:    "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00",
+			want: "Binary files a/f and b/f differ\n",
+		},
+		{
+			name: "text replaced by binary",
+			a:    "logo\n",
+			b:    "\x89PNG\r\n",
+			want: "Binary files a/f and b/f differ\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff("a/f", "b/f", []byte(tt.a), []byte(tt.b))
+			if got != tt.want {
+				t.Errorf("unifiedDiff mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectDiffs(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+
+	diffs, err := projectDiffs(s, dir)
+	if err != nil {
+		t.Fatalf("projectDiffs: %v", err)
+	}
+	if len(diffs) != 0 {
+		t.Fatalf("fresh project should have no diffs, got %d", len(diffs))
+	}
+
+	readme := filepath.Join(dir, "README.md")
+	original, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, readme, string(original)+"Local notes.\n")
+	if err := os.Remove(filepath.Join(dir, "TODO.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, err = projectDiffs(s, dir)
+	if err != nil {
+		t.Fatalf("projectDiffs: %v", err)
+	}
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 diffs, got %d: %+v", len(diffs), diffs)
+	}
+	byPath := map[string]string{}
+	for _, d := range diffs {
+		byPath[d.Path] = d.Patch
+	}
+	if !strings.Contains(byPath["README.md"], "-Local notes.\n") {
+		t.Errorf("README diff should remove the local line:\n%s", byPath["README.md"])
+	}
+	if !strings.HasPrefix(byPath["TODO.md"], "--- /dev/null\n+++ b/TODO.md\n") {
+		t.Errorf("missing file should diff against /dev/null:\n%s", byPath["TODO.md"])
+	}
+}
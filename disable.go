@@ -0,0 +1,73 @@
+// Package main - disable.go
+//
+// PURPOSE:
+// This file implements the --no-<component> flags (--no-skills,
+// --no-devcontainer, --no-git), which leave one component out of a project
+// without writing an answers file. It's responsible for:
+// - Parsing the flags into component names
+// - Turning the components off in the answers (the wizard doesn't offer them)
+// - Refusing to disable a component config requires
+//
+// DESIGN PATTERNS:
+// - The mirror of requireComponents (orgconfig.go): one func per component
+//   that edits WizardData, so every mode (wizard, --print, --output-archive,
+//   --batch) applies flags the same way
+// - Skills have no answer of their own, so --no-skills is recorded as
+//   noSkills and re-rendering (status, upgrade, sync) keeps them out
+//
+// USAGE:
+// err := disableComponents(&answers, opts.Disabled, cfg.Require)
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// disablableComponents are the components a --no-<name> flag turns off, and
+// how each is turned off in a project's answers.
+var disablableComponents = map[string]func(w *WizardData){
+	"skills": func(w *WizardData) { w.NoSkills = true },
+	"devcontainer": func(w *WizardData) {
+		w.IncludeDevContainer = false
+		w.DevContainerImage = ""
+	},
+	"git": func(w *WizardData) {
+		w.InitGit = false
+		w.BranchProtection = false // Builds on git (extras.go)
+	},
+}
+
+// isDisableFlag reports whether arg is a --no-<component> flag.
+func isDisableFlag(arg string) bool {
+	name, ok := strings.CutPrefix(arg, "--no-")
+	return ok && disablableComponents[name] != nil
+}
+
+// checkDisabled rejects disabling a component config requires, so the
+// conflict is reported before the wizard starts.
+func checkDisabled(disabled, required []string) error {
+	for _, name := range disabled {
+		if slices.Contains(required, name) {
+			return usageError{msg: T("args.disabledRequired", name)}
+		}
+	}
+	return nil
+}
+
+// disableComponents turns off every disabled component in w.
+func disableComponents(w *WizardData, disabled, required []string) error {
+	if err := checkDisabled(disabled, required); err != nil {
+		return err
+	}
+	for _, name := range disabled {
+		apply := disablableComponents[name]
+		if apply == nil {
+			return fmt.Errorf("unknown component %q (use skills, devcontainer or git)", name)
+		}
+		apply(w)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDisableComponents(t *testing.T) {
+	w := WizardData{IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", InitGit: true, BranchProtection: true, VSCodeConfig: true}
+	if err := disableComponents(&w, []string{"devcontainer", "git", "skills"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if w.IncludeDevContainer || w.DevContainerImage != "" || w.InitGit || w.BranchProtection || !w.NoSkills {
+		t.Errorf("components still on: %+v", w)
+	}
+	if !w.VSCodeConfig {
+		t.Error("components that weren't disabled should be left alone")
+	}
+
+	err := disableComponents(&w, []string{"git"}, []string{"license", "git"})
+	if err == nil || !strings.Contains(err.Error(), "--no-git") {
+		t.Errorf("expected disabling a required component to fail, got %v", err)
+	}
+}
+
+func TestParseDisableFlags(t *testing.T) {
+	originalArgs := os.Args
+	t.Cleanup(func() { os.Args = originalArgs })
+
+	os.Args = []string{"seed", "--no-skills", "--no-git", "myproject"}
+	opts, err := parseArgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(opts.Disabled, []string{"skills", "git"}) || opts.TargetDir != "myproject" {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	for _, args := range [][]string{{"seed", "--no-license", "myproject"}, {"seed", "--sync", "--no-git", "myproject"}} {
+		os.Args = args
+		if _, err := parseArgs(); err == nil {
+			t.Errorf("parseArgs(%q) should fail", args[1:])
+		}
+	}
+}
+
+func TestScaffoldWithoutSkills(t *testing.T) {
+	isolateConfig(t)
+	target := tempDir(t)
+	answers := WizardData{ProjectName: "noskills", Description: "No skills", NoSkills: true}
+	if _, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "skills")); !os.IsNotExist(err) {
+		t.Error("skills/ should not be written with --no-skills")
+	}
+	agents, err := os.ReadFile(filepath.Join(target, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(agents), "skills/") || strings.Contains(string(agents), "## Scaffolding Feedback") {
+		t.Errorf("AGENTS.md still points to skills:\n%s", agents)
+	}
+
+	// Re-rendering from the manifest keeps them out
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := projectStatus(s, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Clean() {
+		t.Errorf("status should be clean, got %+v", report)
+	}
+}
@@ -0,0 +1,149 @@
+// Package main - docguard.go
+//
+// PURPOSE:
+// This file generates the optional doc guard: a pre-commit hook that refuses
+// commits deleting or emptying AGENTS.md, DECISIONS.md or LEARNINGS.md. It's
+// responsible for:
+// - The hooks framework catalog: a plain git hook, pre-commit or lefthook,
+//   with the config file each one reads and its install command
+// - Rendering that config (the script itself, scripts/doc-guard.sh, is the
+//   declared template doc-guard.sh.tmpl)
+// - Installing the plain hook in the repository seed initializes
+//
+// DESIGN PATTERNS:
+// - The check lives in the script, so every framework runs the same thing and
+//   it runs by hand too; frameworks only add a few lines of config
+// - A plain hook isn't tracked by git, so the script installs itself into a
+//   clone (--install); seed does the same after its initial commit
+// - An existing pre-commit hook is never overwritten
+//
+// USAGE:
+// files := renderHookFramework(data)
+// action, err := installDocGuardHook(targetDir)
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+const (
+	docGuardScriptPath = "scripts/doc-guard.sh"
+	docGuardHook       = "#!/bin/sh\nexec " + docGuardScriptPath + "\n" // The plain .git/hooks/pre-commit
+)
+
+// hookFramework is a way of running git hooks.
+type hookFramework struct {
+	ID      string // Answer value ("" for a plain git hook)
+	Label   string // Shown in the wizard
+	Config  string // File the framework reads ("" for none)
+	Content string // Config running the doc guard on pre-commit
+	Install string // Command that enables the hooks in a clone
+}
+
+// hookFrameworks lists the supported frameworks in wizard order.
+var hookFrameworks = []hookFramework{
+	{ID: "", Label: "git hook", Install: docGuardScriptPath + " --install"},
+	{
+		ID: "pre-commit", Label: "pre-commit", Config: ".pre-commit-config.yaml", Install: "pre-commit install",
+		Content: `# Hooks run by pre-commit (https://pre-commit.com): enable with ` + "`pre-commit install`" + `
+repos:
+  - repo: local
+    hooks:
+      - id: doc-guard
+        name: Keep AGENTS.md, DECISIONS.md and LEARNINGS.md
+        entry: ` + docGuardScriptPath + `
+        language: script
+        pass_filenames: false
+        always_run: true
+`,
+	},
+	{
+		ID: "lefthook", Label: "lefthook", Config: "lefthook.yml", Install: "lefthook install",
+		Content: `# Hooks run by lefthook (https://lefthook.dev): enable with ` + "`lefthook install`" + `
+pre-commit:
+  commands:
+    doc-guard:
+      run: ` + docGuardScriptPath + `
+`,
+	},
+}
+
+// hookFrameworkFor returns the framework with id, or nil for unknown IDs.
+func hookFrameworkFor(id string) *hookFramework {
+	for i := range hookFrameworks {
+		if hookFrameworks[i].ID == id {
+			return &hookFrameworks[i]
+		}
+	}
+	return nil
+}
+
+// Hooks returns the project's hooks framework (the plain git hook for "").
+func (d TemplateData) Hooks() *hookFramework {
+	if f := hookFrameworkFor(d.HookFramework); f != nil {
+		return f
+	}
+	return &hookFrameworks[0]
+}
+
+// validateHookFramework rejects unknown frameworks.
+func validateHookFramework(id string) error {
+	if hookFrameworkFor(id) == nil {
+		return errors.New(T("validate.hookFramework", id))
+	}
+	return nil
+}
+
+// hookFrameworkOptions offers every framework.
+func hookFrameworkOptions() []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(hookFrameworks))
+	for _, f := range hookFrameworks {
+		label := f.Label
+		if f.ID == "" {
+			label = T("wizard.hooks.git")
+		}
+		options = append(options, huh.NewOption(label, f.ID))
+	}
+	return options
+}
+
+// renderHookFramework returns the framework config running the doc guard,
+// if the framework has one.
+func renderHookFramework(data TemplateData) []RenderedFile {
+	hooks := data.Hooks()
+	if hooks.Config == "" {
+		return nil
+	}
+	return []RenderedFile{{Path: hooks.Config, Mode: 0644, Content: []byte(hooks.Content)}}
+}
+
+// installDocGuardHook makes scripts/doc-guard.sh the pre-commit hook of the
+// repository at dir, returning what it did for the git actions list.
+func installDocGuardHook(dir string) (string, error) {
+	out, err := runCommand(dir, commandTimeout(defaultCommandTimeout), "git", "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("failed to find the hooks directory: %w", err)
+	}
+	hooks := strings.TrimSpace(out)
+	if !filepath.IsAbs(hooks) {
+		hooks = filepath.Join(dir, hooks)
+	}
+	hook := filepath.Join(hooks, "pre-commit")
+	if _, err := os.Lstat(hook); err == nil {
+		return "", fmt.Errorf("%s already exists; run %s from it", hook, docGuardScriptPath)
+	}
+	if err := os.MkdirAll(hooks, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(hook, []byte(docGuardHook), 0755); err != nil {
+		return "", err
+	}
+	return T("flow.docGuardHook"), nil
+}
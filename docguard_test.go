@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocGuardFiles(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		data    WizardData
+		config  string // Framework config rendered ("" for none)
+		install string // In AGENTS.md and the next steps
+	}{
+		{"off", WizardData{}, "", ""},
+		{"git hook", WizardData{DocGuard: true}, "", "scripts/doc-guard.sh --install"},
+		{"pre-commit", WizardData{DocGuard: true, HookFramework: "pre-commit"}, ".pre-commit-config.yaml", "pre-commit install"},
+		{"lefthook", WizardData{DocGuard: true, HookFramework: "lefthook"}, "lefthook.yml", "lefthook install"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.ProjectName, tt.data.Description = "guarded", "Guarded docs"
+			files, err := s.Render(tt.data.ToTemplateData())
+			if err != nil {
+				t.Fatal(err)
+			}
+			script := renderedContent(files, docGuardScriptPath)
+			if (script != "") != tt.data.DocGuard {
+				t.Fatalf("doc guard script rendered = %t, want %t", script != "", tt.data.DocGuard)
+			}
+			for _, f := range hookFrameworks {
+				if f.Config != "" && (renderedContent(files, f.Config) != "") != (f.Config == tt.config) {
+					t.Errorf("%s rendered = %t", f.Config, f.Config != tt.config)
+				}
+			}
+			agents := renderedContent(files, "AGENTS.md")
+			if !tt.data.DocGuard {
+				if strings.Contains(agents, "doc-guard") {
+					t.Errorf("AGENTS.md mentions the hook without it:\n%s", agents)
+				}
+				return
+			}
+			for _, f := range files {
+				if f.Path == docGuardScriptPath && f.Mode != 0755 {
+					t.Errorf("%s mode = %o, want 0755", f.Path, f.Mode)
+				}
+			}
+			if config := renderedContent(files, tt.config); tt.config != "" && !strings.Contains(config, docGuardScriptPath) {
+				t.Errorf("%s doesn't run the script:\n%s", tt.config, config)
+			}
+			if !strings.Contains(agents, "`"+tt.install+"`") || !strings.Contains(script, tt.install) {
+				t.Errorf("AGENTS.md and the script should say how to enable the hook (%s):\n%s", tt.install, agents)
+			}
+
+			// Seed installs the plain hook itself when it creates the repository
+			for _, git := range []bool{false, true} {
+				steps, err := renderNextSteps(s, "guarded", tt.data, git)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := tt.config != "" || !git
+				if got := strings.Contains(steps, tt.install); got != want {
+					t.Errorf("git=%t: next steps include %q = %t, want %t:\n%s", git, tt.install, got, want, steps)
+				}
+			}
+		})
+	}
+
+	if err := (WizardData{ProjectName: "x", Description: "y", HookFramework: "husky"}).Validate(); err == nil || !strings.Contains(err.Error(), "husky") {
+		t.Errorf("expected an unknown framework to be refused, got %v", err)
+	}
+}
+
+func TestDocGuardScript(t *testing.T) {
+	for _, tool := range []string{"bash", "git"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not installed", tool)
+		}
+	}
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "Seed Test")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "seed@example.com")
+	}
+	data := WizardData{ProjectName: "guarded", Description: "Guarded docs", DocGuard: true}
+	project := mustScaffold(t, data.ToTemplateData())
+	writeTestFile(t, filepath.Join(project, "packages", "api", "AGENTS.md"), "# api\n")
+	if _, err := initGitRepo(project, "guarded", "", []string{docGuardScriptPath}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installDocGuardHook(project); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installDocGuardHook(project); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an existing hook to be kept, got %v", err)
+	}
+	git := func(args ...string) (string, error) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = project
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	commit := func() (string, bool) {
+		t.Helper()
+		if out, err := git("add", "-A"); err != nil {
+			t.Fatalf("git add: %v\n%s", err, out)
+		}
+		out, err := git("commit", "-q", "-m", "change")
+		return out, err == nil
+	}
+
+	// Editing the docs is fine
+	writeTestFile(t, filepath.Join(project, "DECISIONS.md"), "# Decisions\n\nRewritten.\n")
+	if out, ok := commit(); !ok {
+		t.Fatalf("an edit should commit:\n%s", out)
+	}
+
+	tests := []struct {
+		name   string
+		change func()
+		want   string
+	}{
+		{"deleted", func() { os.Remove(filepath.Join(project, "LEARNINGS.md")) }, "LEARNINGS.md is deleted"},
+		{"emptied", func() { writeTestFile(t, filepath.Join(project, "AGENTS.md"), " \n\n") }, "AGENTS.md is emptied"},
+		{"package doc", func() { os.Remove(filepath.Join(project, "packages", "api", "AGENTS.md")) }, "packages/api/AGENTS.md is deleted"},
+	}
+	for _, tt := range tests {
+		tt.change()
+		if out, ok := commit(); ok || !strings.Contains(out, tt.want) {
+			t.Errorf("%s: expected the commit to be refused with %q, got ok=%t:\n%s", tt.name, tt.want, ok, out)
+		}
+		if out, err := git("reset", "-q", "--hard"); err != nil {
+			t.Fatalf("git reset: %v\n%s", err, out)
+		}
+	}
+
+	// --install sets the hook up in a clone
+	if err := os.Remove(filepath.Join(project, ".git", "hooks", "pre-commit")); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("bash", docGuardScriptPath, "--install")
+	cmd.Dir = project
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("--install: %v\n%s", err, out)
+	}
+	if hook, err := os.ReadFile(filepath.Join(project, ".git", "hooks", "pre-commit")); err != nil || string(hook) != docGuardHook {
+		t.Errorf("--install wrote %q (%v), want %q", hook, err, docGuardHook)
+	}
+}
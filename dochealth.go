@@ -0,0 +1,41 @@
+// Package main - dochealth.go
+//
+// PURPOSE:
+// This file generates the optional doc health check: a script with the
+// mechanical checks and a GitHub Actions workflow that runs it. It's
+// responsible for:
+// - Rendering scripts/check-docs.sh (missing entry docs, dead relative
+//   links, leftover example entries, a stale AGENTS.md)
+// - Rendering .github/workflows/doc-health.yml, which runs the script on
+//   pull requests that touch docs and weekly, and opens (or comments on) an
+//   issue when the scheduled run finds drift
+//
+// DESIGN PATTERNS:
+// - The checks live in the script, not the workflow, so they run the same
+//   locally and in CI and need neither seed nor an agent
+// - The issue points at the doc-health-check skill for the judgment calls
+//   the script can't make (coverage, contradictions)
+// - One open issue at a time: later runs comment on it
+//
+// USAGE:
+// files, err := s.renderDocHealth(data)
+
+package main
+
+const (
+	docHealthScriptPath   = "scripts/check-docs.sh"
+	docHealthWorkflowPath = ".github/workflows/doc-health.yml"
+)
+
+// renderDocHealth returns the check script and the workflow running it.
+func (s *Scaffolder) renderDocHealth(data TemplateData) ([]RenderedFile, error) {
+	script, err := s.renderFile("check-docs.sh.tmpl", docHealthScriptPath, data)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := s.renderFile("doc-health.yml.tmpl", docHealthWorkflowPath, data)
+	if err != nil {
+		return nil, err
+	}
+	return []RenderedFile{script, workflow}, nil
+}
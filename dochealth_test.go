@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocHealthFiles(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		data    TemplateData
+		want    bool   // Script and workflow are rendered
+		skill   bool   // The issue points at the doc-health-check skill
+		comment string // In AGENTS.md
+	}{
+		{"off", TemplateData{ProjectName: "docs", Description: "Docs"}, false, false, ""},
+		{"on", TemplateData{ProjectName: "docs", Description: "Docs", DocHealth: true}, true, true, "`scripts/check-docs.sh` checks"},
+		{"without skills", TemplateData{ProjectName: "docs", Description: "Docs", DocHealth: true, NoSkills: true}, true, false, "`scripts/check-docs.sh` checks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			workflow := renderedContent(files, docHealthWorkflowPath)
+			if (workflow != "") != tt.want || (renderedContent(files, docHealthScriptPath) != "") != tt.want {
+				t.Fatalf("doc health files rendered = %t, want %t", workflow != "", tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			for _, f := range files {
+				if f.Path == docHealthScriptPath && f.Mode != 0755 {
+					t.Errorf("%s mode = %o, want 0755", f.Path, f.Mode)
+				}
+			}
+			for _, want := range []string{"cron:", "scripts/check-docs.sh", "GH_TOKEN: ${{ github.token }}", "gh issue create"} {
+				if !strings.Contains(workflow, want) {
+					t.Errorf("workflow missing %q:\n%s", want, workflow)
+				}
+			}
+			if got := strings.Contains(workflow, "skills/doc-health-check.md"); got != tt.skill {
+				t.Errorf("workflow mentions the skill = %t, want %t", got, tt.skill)
+			}
+			if agents := renderedContent(files, "AGENTS.md"); !strings.Contains(agents, tt.comment) {
+				t.Errorf("AGENTS.md missing %q:\n%s", tt.comment, agents)
+			}
+		})
+	}
+}
+
+func TestDocHealthScript(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed")
+	}
+	data := WizardData{ProjectName: "docs", Description: "A test project", DocHealth: true}
+	project := mustScaffold(t, data.ToTemplateData())
+	if err := InstallSkills(project); err != nil {
+		t.Fatal(err)
+	}
+	check := func() (string, bool) {
+		t.Helper()
+		cmd := exec.Command("bash", docHealthScriptPath)
+		cmd.Dir = project
+		out, err := cmd.CombinedOutput()
+		if _, failed := err.(*exec.ExitError); err != nil && !failed {
+			t.Fatal(err)
+		}
+		return string(out), err == nil
+	}
+
+	// A fresh project still has its example entries
+	out, ok := check()
+	if ok || !strings.Contains(out, "DECISIONS.md still has its example entry") {
+		t.Errorf("fresh project should report example entries:\n%s", out)
+	}
+
+	for _, doc := range []string{"DECISIONS.md", "LEARNINGS.md"} {
+		path := filepath.Join(project, doc)
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, path, strings.ReplaceAll(string(raw), "### EXAMPLE - DELETE", "### Real"))
+	}
+	if out, ok := check(); !ok {
+		t.Errorf("cleaned-up project should pass:\n%s", out)
+	}
+
+	writeTestFile(t, filepath.Join(project, "docs", "guide.md"), "See [setup](../SETUP.md#install), [the site](https://example.com) and [the top](#top).\n")
+	out, ok = check()
+	if ok || !strings.Contains(out, "docs/guide.md links to ../SETUP.md, which doesn't exist") {
+		t.Errorf("dead link not reported:\n%s", out)
+	}
+	if strings.Contains(out, "example.com") || strings.Contains(out, "#top") {
+		t.Errorf("external links and anchors should be skipped:\n%s", out)
+	}
+}
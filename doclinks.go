@@ -0,0 +1,174 @@
+// Package main - doclinks.go
+//
+// PURPOSE:
+// This file keeps the generated doc set navigable. It's responsible for:
+// - skills/README.md, an index of the installed skills built from the
+//   embedded skill files
+// - The "See also" line that links each working doc (TODO, DECISIONS,
+//   LEARNINGS) to the others
+// - A contents list for longer root docs, built from their ## headings
+//
+// DESIGN PATTERNS:
+// - The skills index is derived from each skill's "# Skill:" title and
+//   opening sentence, so adding a skill never means editing the index
+// - Contents lists are added after rendering, like license headers, so
+//   templates stay readable and the list can't drift from the headings
+// - Anchors follow GitHub's rules: lower case, punctuation dropped,
+//   spaces to hyphens, -1, -2, ... for repeated headings
+//
+// USAGE:
+// files = addContents(files)
+// {{.SeeAlso "TODO.md"}}
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// skillsIndexPath is the generated index of installed skills.
+const skillsIndexPath = "skills/README.md"
+
+// contentsMinSections is how many ## sections a root doc needs before it
+// gets a contents list.
+const contentsMinSections = 6
+
+// docSet lists the docs every project gets, in reading order.
+var docSet = []string{"README.md", "AGENTS.md", "TODO.md", "DECISIONS.md", "LEARNINGS.md"}
+
+// SeeAlso returns a line linking the doc set, minus current, plus the
+// skills index when skills are installed.
+func (d TemplateData) SeeAlso(current string) string {
+	var links []string
+	for _, doc := range docSet {
+		if doc != current {
+			links = append(links, fmt.Sprintf("[%s](%s)", doc, doc))
+		}
+	}
+	if !d.NoSkills {
+		links = append(links, "[skills/]("+skillsIndexPath+")")
+	}
+	return "**See also**: " + strings.Join(links, " · ")
+}
+
+// skillsIndex renders skills/README.md from the skill files.
+func skillsIndex(skills []RenderedFile) RenderedFile {
+	var b strings.Builder
+	b.WriteString("# Skills\n\n")
+	b.WriteString("Procedures agents follow for recurring tasks. Each skill is self-contained: read it in full and follow it step by step. [AGENTS.md](../AGENTS.md) says when to run them.\n\n")
+	for _, f := range skills {
+		title, summary := skillSummary(f.Content)
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(f.Path), ".md")
+		}
+		fmt.Fprintf(&b, "- [%s](%s)", title, path.Base(f.Path))
+		if summary != "" {
+			fmt.Fprintf(&b, " - %s", summary)
+		}
+		b.WriteString("\n")
+	}
+	return RenderedFile{Path: skillsIndexPath, Content: []byte(b.String()), Mode: 0644}
+}
+
+// skillSummary returns a skill's title (from "# Skill: X") and the first
+// sentence of its opening paragraph.
+func skillSummary(content []byte) (title, summary string) {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if t, ok := strings.CutPrefix(line, "# Skill:"); ok {
+			title = strings.TrimSpace(t)
+			for _, next := range lines[i+1:] {
+				if next = strings.TrimSpace(next); next != "" {
+					if !strings.HasPrefix(next, "#") && !strings.HasPrefix(next, ">") {
+						summary, _ = splitSentence(next)
+					}
+					break
+				}
+			}
+			break
+		}
+	}
+	return title, summary
+}
+
+// addContents adds a contents list to each root markdown doc with at least
+// contentsMinSections ## headings, just before the first of them.
+func addContents(files []RenderedFile) []RenderedFile {
+	for i, f := range files {
+		if strings.Contains(f.Path, "/") || path.Ext(f.Path) != ".md" || isSymlink(f) {
+			continue
+		}
+		if content, ok := withContents(f.Content); ok {
+			files[i].Content = content
+		}
+	}
+	return files
+}
+
+// withContents returns content with a contents list inserted, or false when
+// the doc is too short to need one.
+func withContents(content []byte) ([]byte, bool) {
+	lines := strings.SplitAfter(string(content), "\n")
+	var headings []string
+	first := -1
+	fenced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+			continue
+		}
+		if heading, ok := strings.CutPrefix(trimmed, "## "); ok && !fenced {
+			if first < 0 {
+				first = i
+			}
+			headings = append(headings, strings.TrimSpace(heading))
+		}
+	}
+	if len(headings) < contentsMinSections {
+		return content, false
+	}
+
+	var toc bytes.Buffer
+	toc.WriteString("## Contents\n\n")
+	seen := map[string]int{}
+	for _, h := range headings {
+		anchor := headingAnchor(h)
+		if n := seen[anchor]; n > 0 {
+			seen[anchor]++
+			anchor = fmt.Sprintf("%s-%d", anchor, n)
+		} else {
+			seen[anchor] = 1
+		}
+		fmt.Fprintf(&toc, "- [%s](#%s)\n", h, anchor)
+	}
+	toc.WriteString("\n")
+
+	var out bytes.Buffer
+	for _, line := range lines[:first] {
+		out.WriteString(line)
+	}
+	out.Write(toc.Bytes())
+	for _, line := range lines[first:] {
+		out.WriteString(line)
+	}
+	return out.Bytes(), true
+}
+
+// headingAnchor returns the anchor GitHub generates for a heading.
+func headingAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeadingAnchor(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"Quick Links", "quick-links"},
+		{"Goals & Non-Goals", "goals--non-goals"},
+		{"What's `seed`?", "whats-seed"},
+		{"snake_case 2", "snake_case-2"},
+	}
+	for _, tt := range tests {
+		if got := headingAnchor(tt.heading); got != tt.want {
+			t.Errorf("headingAnchor(%q) = %q, want %q", tt.heading, got, tt.want)
+		}
+	}
+}
+
+func TestWithContents(t *testing.T) {
+	short := "# Doc\n\n## One\n\n## Two\n"
+	if _, ok := withContents([]byte(short)); ok {
+		t.Error("a doc with two sections shouldn't get contents")
+	}
+
+	doc := "# Doc\n\nIntro.\n\n## A\n\n```bash\n## not a heading\n```\n\n## B\n\n## C\n\n## D\n\n## E\n\n## A\n"
+	got, ok := withContents([]byte(doc))
+	if !ok {
+		t.Fatal("a doc with six sections should get contents")
+	}
+	want := "# Doc\n\nIntro.\n\n## Contents\n\n- [A](#a)\n- [B](#b)\n- [C](#c)\n- [D](#d)\n- [E](#e)\n- [A](#a-1)\n\n## A\n"
+	if !strings.HasPrefix(string(got), want) {
+		t.Errorf("withContents() =\n%s\nwant prefix\n%s", got, want)
+	}
+}
+
+func TestDocCrossLinks(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, noSkills := range []bool{false, true} {
+		w := WizardData{ProjectName: "links", Description: "Links test", NoSkills: noSkills}
+		files, err := s.Render(w.ToTemplateData())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range []string{"TODO.md", "DECISIONS.md", "LEARNINGS.md"} {
+			content := renderedContent(files, doc)
+			if !strings.Contains(content, "**See also**: [README.md](README.md)") || strings.Contains(content, "("+doc+")") {
+				t.Errorf("%s should link the other docs but not itself:\n%s", doc, content)
+			}
+		}
+		for _, doc := range []string{"README.md", "AGENTS.md", "TODO.md"} {
+			if got := strings.Contains(renderedContent(files, doc), skillsIndexPath); got == noSkills {
+				t.Errorf("NoSkills=%t: %s links the skills index = %t", noSkills, doc, got)
+			}
+		}
+	}
+}
+
+func TestSkillsIndex(t *testing.T) {
+	files, err := skillFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := renderedContent(files, skillsIndexPath)
+	for _, f := range files {
+		if f.Path == skillsIndexPath {
+			continue
+		}
+		title, summary := skillSummary(f.Content)
+		if title == "" || summary == "" {
+			t.Errorf("%s has no title or opening sentence", f.Path)
+		}
+		if !strings.Contains(index, "- ["+title+"]("+strings.TrimPrefix(f.Path, "skills/")+") - "+summary) {
+			t.Errorf("index missing %s:\n%s", f.Path, index)
+		}
+	}
+}
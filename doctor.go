@@ -0,0 +1,303 @@
+// Package main - doctor.go
+//
+// PURPOSE:
+// This file implements `seed doctor`, environment preflight checks. It's
+// responsible for:
+// - Checking the tools seeded projects rely on (git, docker, devcontainer
+//   CLI, gh auth) and the environment seed itself needs (config dir,
+//   interactive terminal, embedded templates)
+// - Reporting pass/warn/fail with a remediation hint for each problem
+// - Running the critical subset before the wizard starts
+//
+// DESIGN PATTERNS:
+// - Each check is a small function returning a checkResult; no check exits
+//   or prints on its own
+// - Optional tools warn, never fail: seed works without them
+//
+// USAGE:
+// results := runChecks(doctorChecks)
+// fmt.Print(formatChecks(results))
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// checkStatus is the outcome of one check.
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn             // Optional capability missing; seed still works
+	checkFail             // Seed can't do its job until this is fixed
+)
+
+// checkResult is what a single check found.
+type checkResult struct {
+	Name   string
+	Status checkStatus
+	Detail string // What was found (version, path, error)
+	Hint   string // How to fix it; empty when passing
+}
+
+// doctorCheck is a named environment check. Critical checks also run as a
+// preflight before the wizard.
+type doctorCheck struct {
+	ID       string // Also the name's key: doctor.check.<ID>
+	Critical bool
+	Run      func() checkResult
+}
+
+// doctorChecks lists every check in the order `seed doctor` prints them.
+var doctorChecks = []doctorCheck{
+	{ID: "templates", Critical: true, Run: checkTemplates},
+	{ID: "terminal", Critical: true, Run: checkTerminal},
+	{ID: "configDir", Run: checkConfigDir},
+	{ID: "network", Run: checkNetwork},
+	{ID: "orgConfig", Run: checkOrgConfig},
+	{ID: "seedVersion", Run: checkSeedVersionPin},
+	{ID: "git", Run: checkGit},
+	{ID: "gitIdentity", Run: checkGitIdentity},
+	{ID: "docker", Run: checkDocker},
+	{ID: "devcontainer", Run: checkDevcontainerCLI},
+	{ID: "ghAuth", Run: checkGHAuth},
+	{ID: "formatters", Run: checkFormatters},
+}
+
+// runChecks runs each check and names its result.
+func runChecks(checks []doctorCheck) []checkResult {
+	results := make([]checkResult, 0, len(checks))
+	for _, c := range checks {
+		r := c.Run()
+		r.Name = T("doctor.check." + c.ID)
+		results = append(results, r)
+	}
+	return results
+}
+
+// preflight runs the critical checks and returns an error describing any
+// failures, so problems surface before the user answers the wizard.
+func preflight() error {
+	var critical []doctorCheck
+	for _, c := range doctorChecks {
+		if c.Critical {
+			critical = append(critical, c)
+		}
+	}
+	var failed []checkResult
+	for _, r := range runChecks(critical) {
+		if r.Status == checkFail {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s\n%s", T("doctor.preflightFailed"), strings.TrimRight(formatChecks(failed), "\n"))
+}
+
+// formatChecks renders results one per line, with hints indented beneath.
+func formatChecks(results []checkResult) string {
+	var b strings.Builder
+	for _, r := range results {
+		var mark string
+		switch r.Status {
+		case checkPass:
+			mark = successStyle.Render("✓")
+		case checkWarn:
+			mark = "!"
+		default:
+			mark = "✗"
+		}
+		fmt.Fprintf(&b, "%s %-17s %s\n", mark, r.Name, r.Detail)
+		if r.Hint != "" {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  %-17s → %s", "", r.Hint)) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// lookupTool finds name on PATH and returns its first line of `name args...`.
+func lookupTool(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", err
+	}
+	out, err := runCommand("", commandTimeout(probeCommandTimeout), name, args...)
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	return line, nil
+}
+
+// checkTemplates renders a sample project to prove the embedded templates and
+// skills parse and execute.
+func checkTemplates() checkResult {
+	s, err := NewScaffolder()
+	if err == nil {
+		sample := WizardData{ProjectName: "doctor", Description: "Preflight", License: "MIT", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", ChatTools: legacyChatTools}
+		_, err = s.Render(sample.ToTemplateData())
+	}
+	if err == nil {
+		_, err = skillFiles()
+	}
+	if err != nil {
+		return checkResult{Status: checkFail, Detail: err.Error(), Hint: T("doctor.templatesBroken")}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.templatesEmbedded", templateVersion)}
+}
+
+// checkTerminal verifies the wizard can run interactively.
+func checkTerminal() checkResult {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 || isDevNull(info) {
+		return checkResult{Status: checkFail, Detail: T("doctor.notTerminal"), Hint: T("doctor.notTerminalHint")}
+	}
+	if term := os.Getenv("TERM"); term == "dumb" {
+		return checkResult{Status: checkWarn, Detail: "TERM=dumb", Hint: T("doctor.dumbTerminalHint")}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.interactive")}
+}
+
+// isDevNull reports whether info describes the null device, which is a
+// character device but not a terminal.
+func isDevNull(info os.FileInfo) bool {
+	null, err := os.Stat(os.DevNull)
+	return err == nil && os.SameFile(info, null)
+}
+
+// checkConfigDir verifies seed can write its per-user configuration.
+func checkConfigDir() checkResult {
+	dir, err := seedConfigDir()
+	if err == nil {
+		err = os.MkdirAll(dir, 0755)
+	}
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(dir, ".doctor-*"); err == nil {
+			f.Close()
+			err = os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		return checkResult{Status: checkWarn, Detail: err.Error(), Hint: T("doctor.configDirHint")}
+	}
+	return checkResult{Status: checkPass, Detail: dir}
+}
+
+// checkNetwork reports the proxy and CA bundle used for HTTPS, and whether
+// the bundle loads.
+func checkNetwork() checkResult {
+	detail, err := describeNetwork("https://github.com")
+	if err != nil {
+		return checkResult{Status: checkFail, Detail: detail, Hint: T("doctor.caBundleHint", err, caBundleEnv)}
+	}
+	return checkResult{Status: checkPass, Detail: detail}
+}
+
+// checkOrgConfig reports where the org config (SEED_ORG_CONFIG) came from
+// and whether it could be loaded.
+func checkOrgConfig() checkResult {
+	org := loadOrgConfig()
+	switch {
+	case org.Source == "":
+		return checkResult{Status: checkPass, Detail: T("doctor.orgConfigUnset")}
+	case org.Stale:
+		return checkResult{Status: checkWarn, Detail: T("doctor.orgConfigCached", org.Source, org.FetchedAt.Local().Format("2006-01-02 15:04")), Hint: org.Err.Error()}
+	case org.Err != nil:
+		return checkResult{Status: checkWarn, Detail: org.Err.Error(), Hint: T("doctor.orgConfigHint", orgConfigEnv)}
+	}
+	if err := validateRequire(org.Config.Require); err != nil {
+		return checkResult{Status: checkWarn, Detail: org.Source, Hint: err.Error()}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.orgConfigFetched", org.Source, org.FetchedAt.Local().Format("2006-01-02 15:04"))}
+}
+
+// checkSeedVersionPin verifies this seed meets config minSeedVersion.
+func checkSeedVersionPin() checkResult {
+	cfg, _ := loadConfig()
+	err := checkSeedVersion(cfg.MinSeedVersion, T("version.orgPin"))
+	switch {
+	case err == nil && cfg.MinSeedVersion != "":
+		return checkResult{Status: checkPass, Detail: T("doctor.seedVersionMinimum", displayVersion(), cfg.MinSeedVersion)}
+	case err == nil:
+		return checkResult{Status: checkPass, Detail: displayVersion()}
+	case cfg.SeedVersionCheck == seedVersionWarn:
+		return checkResult{Status: checkWarn, Detail: err.Error(), Hint: T("doctor.seedVersionHint")}
+	}
+	return checkResult{Status: checkFail, Detail: err.Error(), Hint: T("doctor.seedVersionHint")}
+}
+
+// checkGit verifies git is installed (needed for "Initialize git repository?").
+func checkGit() checkResult {
+	version, err := lookupTool("git", "--version")
+	if err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.notFound"), Hint: T("doctor.gitHint")}
+	}
+	return checkResult{Status: checkPass, Detail: version}
+}
+
+// checkGitIdentity verifies git can commit (seed's initial commit needs a name and email).
+func checkGitIdentity() checkResult {
+	if _, err := exec.LookPath("git"); err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.gitIdentitySkipped")}
+	}
+	email, err := lookupTool("git", "config", "user.email")
+	if err != nil || email == "" {
+		return checkResult{Status: checkWarn, Detail: T("doctor.gitIdentityUnset"), Hint: `git config --global user.name "Your Name" && git config --global user.email you@example.com`}
+	}
+	return checkResult{Status: checkPass, Detail: email}
+}
+
+// checkDocker verifies docker is installed and the daemon responds (needed to
+// build dev containers).
+func checkDocker() checkResult {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.notFound"), Hint: T("doctor.dockerHint")}
+	}
+	version, err := lookupTool("docker", "info", "--format", "{{.ServerVersion}}")
+	if err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.dockerDaemon"), Hint: T("doctor.dockerDaemonHint")}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.dockerServer", version)}
+}
+
+// checkDevcontainerCLI looks for the devcontainer CLI (optional; editors can
+// open dev containers without it).
+func checkDevcontainerCLI() checkResult {
+	version, err := lookupTool("devcontainer", "--version")
+	if err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.notFound"), Hint: T("doctor.devcontainerHint")}
+	}
+	return checkResult{Status: checkPass, Detail: version}
+}
+
+// checkGHAuth verifies gh is logged in (generated dev containers pass the
+// host's gh token through).
+func checkGHAuth() checkResult {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.ghMissing"), Hint: T("doctor.ghMissingHint")}
+	}
+	if _, err := runCommand("", commandTimeout(probeCommandTimeout), "gh", "auth", "status"); err != nil {
+		return checkResult{Status: checkWarn, Detail: T("doctor.ghLoggedOut"), Hint: T("doctor.ghLoggedOutHint")}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.ghLoggedIn")}
+}
+
+// checkFormatters verifies the formatters config turns on are installed;
+// generated files of a type whose formatter is missing are left unformatted.
+func checkFormatters() checkResult {
+	cfg, _ := loadConfig()
+	table := formattersFrom(cfg)
+	if len(table) == 0 {
+		return checkResult{Status: checkPass, Detail: T("doctor.formattersOff")}
+	}
+	if missing := missingFormatters(table); len(missing) > 0 {
+		return checkResult{Status: checkWarn, Detail: T("doctor.formattersMissing", strings.Join(missing, ", ")), Hint: T("doctor.formattersHint")}
+	}
+	return checkResult{Status: checkPass, Detail: T("doctor.formattersOn", len(table))}
+}
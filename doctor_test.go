@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChecksWithoutOptionalTools(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // nothing on PATH
+
+	tests := []struct {
+		name  string
+		check func() checkResult
+	}{
+		{"git", checkGit},
+		{"git identity", checkGitIdentity},
+		{"docker", checkDocker},
+		{"devcontainer CLI", checkDevcontainerCLI},
+		{"gh auth", checkGHAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.check()
+			if r.Status != checkWarn {
+				t.Errorf("missing tool should warn, got status %d (%s)", r.Status, r.Detail)
+			}
+		})
+	}
+}
+
+func TestCheckTemplatesPasses(t *testing.T) {
+	if r := checkTemplates(); r.Status != checkPass {
+		t.Errorf("embedded templates should pass, got %+v", r)
+	}
+}
+
+func TestCheckConfigDir(t *testing.T) {
+	dir := isolateConfig(t)
+
+	r := checkConfigDir()
+	if r.Status != checkPass {
+		t.Fatalf("writable config dir should pass, got %+v", r)
+	}
+	if !strings.HasPrefix(r.Detail, dir) {
+		t.Errorf("detail should name the config dir, got %q", r.Detail)
+	}
+}
+
+func TestFormatChecks(t *testing.T) {
+	out := formatChecks([]checkResult{
+		{Name: "git", Status: checkPass, Detail: "git version 2.43.0"},
+		{Name: "docker", Status: checkWarn, Detail: "not found", Hint: "install Docker"},
+		{Name: "terminal", Status: checkFail, Detail: "stdin is not a terminal", Hint: "use --batch"},
+	})
+
+	for _, want := range []string{"git version 2.43.0", "! docker", "install Docker", "✗ terminal", "use --batch"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "→") != 2 {
+		t.Errorf("expected a hint line per problem:\n%s", out)
+	}
+}
@@ -0,0 +1,145 @@
+// Package main - eject.go
+//
+// PURPOSE:
+// This file implements `seed templates eject`, which writes the embedded
+// templates and skills to a directory as the starting point for a
+// customized template pack. It's responsible for:
+// - Copying templates/*.tmpl and skills/*.md exactly as embedded
+// - Writing pack.json: the pack format, the seed and template versions the
+//   files came from, and the sha256 of every file
+//
+// DESIGN PATTERNS:
+// - Files are copied raw (no version stamps), so they're the editable
+//   source rather than generated output
+// - The layout mirrors the repo (templates/, skills/), so a pack reads like
+//   seed's own tree and can be diffed against a newer seed's eject
+// - pack.json records templateVersion, so a pack made from one template set
+//   can be compared with what a later seed embeds
+// - The target must be empty or missing, like a scaffold target
+//
+// USAGE:
+// seed templates eject ./my-pack
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	packFormat       = 1           // Bumped when the pack layout changes incompatibly
+	packManifestFile = "pack.json" // Describes the pack
+)
+
+const templatesUsage = "seed templates eject <directory>"
+
+// packManifest is pack.json.
+type packManifest struct {
+	Format          int               `json:"format"`
+	Name            string            `json:"name"`
+	SeedVersion     string            `json:"seedVersion"`     // Version the files were ejected from
+	TemplateVersion int               `json:"templateVersion"` // Template set the files were ejected from
+	Files           map[string]string `json:"files"`           // Path → sha256 of every other file
+}
+
+// runTemplates handles `seed templates <command>`.
+func runTemplates(args []string) error {
+	if len(args) == 0 {
+		return usageError{msg: T("args.templatesCommand"), usage: templatesUsage}
+	}
+	if args[0] != "eject" {
+		return usageError{msg: T("args.unknownCommand", "templates", args[0]), usage: templatesUsage}
+	}
+	var dir string
+	for _, arg := range args[1:] {
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: templatesUsage}
+		case dir == "":
+			dir = arg
+		default:
+			return usageError{msg: T("args.tooMany"), usage: templatesUsage}
+		}
+	}
+	if dir == "" {
+		return usageError{msg: T("args.ejectNeedsDirectory"), usage: templatesUsage}
+	}
+
+	m, err := ejectTemplates(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("templates.ejected", len(m.Files), dir))
+	fmt.Println(dimStyle.Render(T("templates.ejectedHint", packManifestFile, m.SeedVersion, m.TemplateVersion)))
+	return nil
+}
+
+// ejectTemplates writes the embedded templates and skills, plus pack.json,
+// to dir, which must be empty or not yet exist.
+func ejectTemplates(dir string) (packManifest, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return packManifest{}, err
+	}
+	m := packManifest{
+		Format:          packFormat,
+		Name:            filepath.Base(abs),
+		SeedVersion:     Version,
+		TemplateVersion: templateVersion,
+		Files:           map[string]string{},
+	}
+	files, err := embeddedPackFiles()
+	if err != nil {
+		return m, err
+	}
+	for _, f := range files {
+		sum := sha256.Sum256(f.Content)
+		m.Files[f.Path] = hex.EncodeToString(sum[:])
+	}
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return m, err
+	}
+	files = append(files, RenderedFile{Path: packManifestFile, Content: append(raw, '\n'), Mode: 0644})
+
+	s, err := NewScaffolder()
+	if err != nil {
+		return m, err
+	}
+	if err := s.prepareDirectory(dir, false); err != nil {
+		return m, err
+	}
+	if err := writeFiles(dir, files); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// embeddedPackFiles returns the embedded templates and skills, unstamped,
+// at their paths in the repo.
+func embeddedPackFiles() ([]RenderedFile, error) {
+	var files []RenderedFile
+	for _, src := range []fs.FS{templatesFS, skillsFS} {
+		err := fs.WalkDir(src, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := fs.ReadFile(src, name)
+			if err != nil {
+				return fmt.Errorf("failed to read embedded %s: %w", name, err)
+			}
+			files = append(files, RenderedFile{Path: name, Content: content, Mode: 0644})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEjectTemplates(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-pack")
+	m, err := ejectTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "my-pack" || m.Format != packFormat || m.TemplateVersion != templateVersion {
+		t.Errorf("manifest = %+v", m)
+	}
+	for _, want := range []string{"templates/README.md.tmpl", "templates/next-steps.txt.tmpl", "skills/doc-health-check.md"} {
+		if _, ok := m.Files[want]; !ok {
+			t.Errorf("pack is missing %s", want)
+		}
+	}
+
+	// Files are the embedded source, unstamped, and match pack.json
+	for name, want := range m.Files {
+		raw, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum := sha256.Sum256(raw); hex.EncodeToString(sum[:]) != want {
+			t.Errorf("%s doesn't match its pack.json hash", name)
+		}
+		if strings.Contains(string(raw), "seed:generated") {
+			t.Errorf("%s carries a version stamp", name)
+		}
+	}
+	var onDisk packManifest
+	raw, err := os.ReadFile(filepath.Join(dir, packManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &onDisk); err != nil || len(onDisk.Files) != len(m.Files) {
+		t.Errorf("pack.json = %s (%v)", raw, err)
+	}
+
+	if _, err := ejectTemplates(dir); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("expected a non-empty directory to be refused, got %v", err)
+	}
+}
+
+func TestRunTemplatesUsage(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{nil, "expects eject"},
+		{[]string{"import"}, `unknown templates command "import"`},
+		{[]string{"eject"}, "expects a directory"},
+		{[]string{"eject", "--force", "dir"}, "--force"},
+		{[]string{"eject", "a", "b"}, T("args.tooMany")},
+	}
+	for _, tt := range tests {
+		var usage usageError
+		err := runTemplates(tt.args)
+		if !errors.As(err, &usage) || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("runTemplates(%q) = %v, want a usage error containing %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
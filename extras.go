@@ -0,0 +1,105 @@
+// Package main - extras.go
+//
+// PURPOSE:
+// This file gathers the optional components into the wizard's single
+// "extras" multi-select instead of a chain of yes/no questions. It's
+// responsible for:
+// - The catalog: dev container, VS Code config, git, branch protection,
+//   the doc health check, the doc guard hook, the agent GitHub Action
+// - The options the wizard offers, adapted to config and installed tools
+// - Applying the selection to the answers
+//
+// DESIGN PATTERNS:
+// - Answers keep one bool per component (includeDevContainer, initGit, ...),
+//   so batch specs and manifests are unchanged; the catalog maps IDs to them
+// - IDs match config "require" components, and required ones aren't offered
+//   (they're on regardless), just named in the hint
+// - An extra that builds on another (branch protection on git) is dropped
+//   when its base isn't selected
+// - Adding an extra is one catalog entry, a WizardData bool and a
+//   wizard.extra.<ID> message
+//
+// USAGE:
+// applyExtras(&data, selected)
+
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// extra is an optional component offered in the wizard's extras group.
+type extra struct {
+	ID    string                  // Also the label's message key: wizard.extra.<ID>
+	Needs string                  // Extra this one builds on ("" for none)
+	Field func(*WizardData) *bool // The answer the extra sets
+}
+
+// extras lists the optional components in wizard order.
+var extras = []extra{
+	{ID: "devcontainer", Field: func(w *WizardData) *bool { return &w.IncludeDevContainer }},
+	{ID: "vscodeConfig", Field: func(w *WizardData) *bool { return &w.VSCodeConfig }},
+	{ID: "git", Field: func(w *WizardData) *bool { return &w.InitGit }},
+	{ID: "branchProtection", Needs: "git", Field: func(w *WizardData) *bool { return &w.BranchProtection }},
+	{ID: "docHealth", Field: func(w *WizardData) *bool { return &w.DocHealth }},
+	{ID: "docGuard", Field: func(w *WizardData) *bool { return &w.DocGuard }},
+	{ID: "agentAction", Field: func(w *WizardData) *bool { return &w.AgentAction }},
+}
+
+// extraOptions offers the extras that aren't required by config, leaving
+// out git (and what needs it) when git isn't installed.
+func extraOptions(required []string, tools toolAvailability) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, e := range extras {
+		if slices.Contains(required, e.ID) || (!tools.Git && (e.ID == "git" || e.Needs == "git")) {
+			continue
+		}
+		options = append(options, huh.NewOption(T("wizard.extra."+e.ID), e.ID))
+	}
+	return options
+}
+
+// extrasHint describes the extras group: what's required by config, and
+// what missing tools mean for the options.
+func extrasHint(required []string, tools toolAvailability) string {
+	lines := []string{T("wizard.extrasHint")}
+	var names []string
+	for _, e := range extras {
+		if slices.Contains(required, e.ID) {
+			names = append(names, T("wizard.extra."+e.ID))
+		}
+	}
+	if len(names) > 0 {
+		lines = append(lines, T("wizard.extras.required", strings.Join(names, ", ")))
+	}
+	if !tools.Git {
+		lines = append(lines, T("wizard.gitMissingHint"))
+	}
+	if hint := devContainerHint(tools); hint != "" {
+		lines = append(lines, hint)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// selectedExtras returns the IDs of the extras turned on in w.
+func selectedExtras(w WizardData) []string {
+	var ids []string
+	for _, e := range extras {
+		if *e.Field(&w) {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids
+}
+
+// applyExtras sets each extra's answer from selected (plus the components
+// config requires), turning off extras whose base isn't on.
+func applyExtras(w *WizardData, selected, required []string) {
+	on := func(id string) bool { return slices.Contains(selected, id) || slices.Contains(required, id) }
+	for _, e := range extras {
+		*e.Field(w) = on(e.ID) && (e.Needs == "" || on(e.Needs))
+	}
+}
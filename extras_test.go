@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestApplyExtras(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected []string
+		required []string
+		want     []string
+	}{
+		{"none", nil, nil, nil},
+		{"all", []string{"devcontainer", "vscodeConfig", "git", "branchProtection"}, nil, []string{"devcontainer", "vscodeConfig", "git", "branchProtection"}},
+		{"branch protection needs git", []string{"vscodeConfig", "branchProtection"}, nil, []string{"vscodeConfig"}},
+		{"required git enables branch protection", []string{"branchProtection"}, []string{"git"}, []string{"git", "branchProtection"}},
+		{"required are on without being selected", nil, []string{"devcontainer", "license"}, []string{"devcontainer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Previous answers are replaced, not merged
+			w := WizardData{InitGit: true, VSCodeConfig: true}
+			applyExtras(&w, tt.selected, tt.required)
+			if got := selectedExtras(w); !slices.Equal(got, tt.want) {
+				t.Errorf("extras = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtraOptionsLeaveOutRequired(t *testing.T) {
+	required := []string{"git", "vscodeConfig"}
+	var offered []string
+	for _, option := range extraOptions(required, toolAvailability{Git: true, Docker: true}) {
+		offered = append(offered, option.Value)
+	}
+	if want := []string{"devcontainer", "branchProtection", "docHealth", "docGuard", "agentAction"}; !slices.Equal(offered, want) {
+		t.Errorf("offered %v, want %v", offered, want)
+	}
+	hint := extrasHint(required, toolAvailability{Git: true, Docker: true})
+	if !strings.Contains(hint, T("wizard.extra.git")) || !strings.Contains(hint, T("wizard.extra.vscodeConfig")) {
+		t.Errorf("hint should name the required extras: %q", hint)
+	}
+	for _, e := range extras {
+		if requirableComponents[e.ID] == nil && e.ID != "branchProtection" {
+			t.Errorf("extra %s isn't a component config can require", e.ID)
+		}
+	}
+}
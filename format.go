@@ -0,0 +1,138 @@
+// Package main - format.go
+//
+// PURPOSE:
+// This file runs formatters over generated files, so a project's own
+// formatter finds nothing to change in what seed wrote. It's responsible for:
+// - The formatters in effect: config "format": true turns on the defaults
+//   (gofmt, prettier for JSON and markdown, shfmt), and "formatters" sets a
+//   command per file type, adding to or overriding them
+// - Piping each matching file through its formatter (content on stdin,
+//   formatted content on stdout)
+// - Reporting configured formatters that aren't installed (seed doctor)
+//
+// DESIGN PATTERNS:
+// - Formatting is part of Render(), after contents lists and before version
+//   stamps, so the manifest, archives, --print and seed status all see the
+//   formatted output and stamps hash what's on disk
+// - Best effort: a formatter that isn't installed is skipped, and one that
+//   fails leaves the file as rendered; scaffolding never fails over style
+// - Off unless configured, since output then depends on which formatter
+//   versions are installed, which a fixed --clock can't pin
+//
+// USAGE:
+// s.Formatters = formattersFrom(cfg)
+// files = formatFiles(files, s.Formatters)
+
+package main
+
+import (
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// formatterPathArg is replaced by the file's project-relative path in a
+// formatter command, for formatters that pick rules by file name.
+const formatterPathArg = "{path}"
+
+// defaultFormatters are the formatters "format": true turns on, by file type.
+var defaultFormatters = map[string]string{
+	".go":   "gofmt",
+	".json": "prettier --stdin-filepath " + formatterPathArg,
+	".md":   "prettier --stdin-filepath " + formatterPathArg,
+	".sh":   "shfmt",
+}
+
+// formattersFrom returns the formatters cfg turns on: the defaults when
+// Format is set, with Formatters' entries on top. An empty command turns a
+// type off.
+func formattersFrom(cfg userConfig) map[string]string {
+	table := map[string]string{}
+	if cfg.Format {
+		for fileType, cmd := range defaultFormatters {
+			table[fileType] = cmd
+		}
+	}
+	for fileType, cmd := range cfg.Formatters {
+		if cmd = strings.TrimSpace(cmd); cmd == "" {
+			delete(table, fileType)
+		} else {
+			table[fileType] = cmd
+		}
+	}
+	return table
+}
+
+// formatterFor returns the command for the file at p: by base name (e.g.
+// "Dockerfile"), else by extension.
+func formatterFor(table map[string]string, p string) (string, bool) {
+	if cmd, ok := table[path.Base(p)]; ok {
+		return cmd, true
+	}
+	cmd, ok := table[path.Ext(p)]
+	return cmd, ok && path.Ext(p) != ""
+}
+
+// formatFiles pipes each file with a formatter in table through it,
+// concurrently. Links and files whose formatter is missing or fails are
+// left as they are.
+func formatFiles(files []RenderedFile, table map[string]string) []RenderedFile {
+	if len(table) == 0 {
+		return files
+	}
+	var g errgroup.Group
+	g.SetLimit(fileWorkers)
+	for i, f := range files {
+		cmd, ok := formatterFor(table, f.Path)
+		if !ok || isSymlink(f) {
+			continue
+		}
+		g.Go(func() error {
+			files[i].Content = formatContent(cmd, f.Path, f.Content)
+			return nil
+		})
+	}
+	g.Wait()
+	return files
+}
+
+// formatContent runs cmd on content, returning content unchanged if the
+// formatter isn't installed, fails or prints nothing.
+func formatContent(cmd, p string, content []byte) []byte {
+	args := strings.Fields(cmd)
+	for i, arg := range args {
+		args[i] = strings.ReplaceAll(arg, formatterPathArg, p)
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		debugf("formatter %s not installed; %s left as rendered", args[0], p)
+		return content
+	}
+	out, err := runCommandInput("", commandTimeout(defaultCommandTimeout), content, args[0], args[1:]...)
+	if err != nil || (out == "" && len(content) > 0) {
+		debugf("formatter %s failed on %s: %v", args[0], p, err)
+		return content
+	}
+	return []byte(out)
+}
+
+// missingFormatters returns the configured formatter commands that aren't
+// on PATH, sorted.
+func missingFormatters(table map[string]string) []string {
+	seen := map[string]bool{}
+	var missing []string
+	for _, cmd := range table {
+		name := strings.Fields(cmd)[0]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, err := exec.LookPath(name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
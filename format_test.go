@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"maps"
+	"testing"
+)
+
+func TestFormattersFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  userConfig
+		want map[string]string
+	}{
+		{name: "off", cfg: userConfig{}, want: map[string]string{}},
+		{name: "defaults", cfg: userConfig{Format: true}, want: defaultFormatters},
+		{
+			name: "override and disable",
+			cfg:  userConfig{Format: true, Formatters: map[string]string{".md": "mdformat -", ".json": " ", ".toml": "taplo fmt -"}},
+			want: map[string]string{".go": "gofmt", ".md": "mdformat -", ".sh": "shfmt", ".toml": "taplo fmt -"},
+		},
+		{
+			name: "formatters alone",
+			cfg:  userConfig{Formatters: map[string]string{"Dockerfile": "dockfmt"}},
+			want: map[string]string{"Dockerfile": "dockfmt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formattersFrom(tt.cfg); !maps.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatterFor(t *testing.T) {
+	table := map[string]string{".md": "md", "Dockerfile": "docker", ".gitignore": "ignore"}
+	tests := []struct {
+		path, want string
+	}{
+		{"README.md", "md"},
+		{"docs/guide.md", "md"},
+		{".devcontainer/Dockerfile", "docker"},
+		{".gitignore", "ignore"},
+		{"setup.sh", ""},
+		{"LICENSE", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := formatterFor(table, tt.path); got != tt.want {
+			t.Errorf("formatterFor(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRenderFormatsFiles(t *testing.T) {
+	data := TemplateData{ProjectName: "fmt-demo", Description: "Formatted output", License: "MIT"}
+	plain := &Scaffolder{templates: templateSetFor("embedded", templatesFS, "templates")}
+	want, err := plain.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Scaffolder{
+		templates: templateSetFor("embedded", templatesFS, "templates"),
+		Formatters: map[string]string{
+			".md":           "tr a-z A-Z",
+			".gitignore":    "seed-no-such-formatter",
+			".editorconfig": "false",
+		},
+	}
+	files, err := s.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readme := renderedContent(files, "README.md")
+	st, body, ok := parseStamp([]byte(readme))
+	if !ok {
+		t.Fatal("README.md should still be stamped")
+	}
+	if !bytes.Contains(body, []byte("FMT-DEMO")) || bytes.Contains(body, []byte("fmt-demo")) {
+		t.Errorf("README.md wasn't formatted:\n%s", body)
+	}
+	if st.SHA256 != hashContent(body)[:stampHashLen] {
+		t.Error("the stamp should hash the formatted content")
+	}
+	if renderedContent(files, "LICENSE") != renderedContent(want, "LICENSE") {
+		t.Error("LICENSE has no formatter and should be left alone")
+	}
+	// A missing formatter and a failing one both leave the file as rendered
+	for _, p := range []string{".gitignore", ".editorconfig"} {
+		if renderedContent(files, p) != renderedContent(want, p) {
+			t.Errorf("%s should be unchanged", p)
+		}
+	}
+}
+
+func TestNewScaffolderReadsFormatters(t *testing.T) {
+	isolateConfig(t)
+	if err := saveUserConfig(userConfig{Format: true, Formatters: map[string]string{".sh": ""}}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{".go": "gofmt", ".json": defaultFormatters[".json"], ".md": defaultFormatters[".md"]}
+	if !maps.Equal(s.Formatters, want) {
+		t.Errorf("got %v, want %v", s.Formatters, want)
+	}
+}
+
+func TestMergeFormatters(t *testing.T) {
+	org := userConfig{Format: true, Formatters: map[string]string{".md": "org-md", ".yml": "org-yml"}}
+	user := userConfig{Formatters: map[string]string{".md": "user-md"}}
+	merged := mergeConfig(org, user)
+	if !merged.Format {
+		t.Error("the org turning formatting on should turn it on")
+	}
+	want := map[string]string{".md": "user-md", ".yml": "org-yml"}
+	if !maps.Equal(merged.Formatters, want) {
+		t.Errorf("got %v, want %v", merged.Formatters, want)
+	}
+	if org.Formatters[".md"] != "org-md" {
+		t.Error("merging shouldn't change the org's map")
+	}
+}
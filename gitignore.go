@@ -0,0 +1,159 @@
+// Package main - gitignore.go
+//
+// PURPOSE:
+// This file composes the generated .gitignore from named pattern sets. It's
+// responsible for:
+// - The catalog of sets: OS, editor, per-language and per-framework
+// - Picking the default sets (OS, editor, the project's language)
+// - Resolving chosen set IDs into ordered, de-duplicated sections
+// - Appending the project's own extra patterns under a labeled section
+//
+// DESIGN PATTERNS:
+// - Data, not template branches: adding a language is a catalog entry
+// - Sets are independent of the dev container; the language only picks defaults
+// - Answers without a gitignore list resolve to the defaults, so projects
+//   scaffolded before sets existed render the same .gitignore
+//
+// USAGE:
+// data.GitignoreSets = gitignoreSections(data)
+
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/huh"
+)
+
+// gitignoreSet is a named group of .gitignore patterns, rendered as a
+// commented section.
+type gitignoreSet struct {
+	ID       string // Also the wizard label's key: wizard.gitignore.<ID>
+	Label    string // Section heading, English like the rest of the generated file
+	Patterns []string
+}
+
+// gitignoreCatalog lists the selectable sets in the order they're rendered.
+var gitignoreCatalog = []gitignoreSet{
+	{"os", "OS", []string{".DS_Store", "Thumbs.db"}},
+	{"editor", "Editor", []string{".idea/", ".vscode/", "*.swp", "*.swo", "*~"}},
+	{"go", "Go", []string{"*.exe", "*.exe~", "*.dll", "*.so", "*.dylib", "*.test", "*.out", "vendor/"}},
+	{"node", "Node", []string{"node_modules/", "dist/", "build/", "*.tsbuildinfo", ".npm"}},
+	{"python", "Python", []string{"__pycache__/", "*.py[cod]", "*$py.class", "*.egg-info/", "dist/", "build/", ".venv/", "venv/"}},
+	{"rust", "Rust", []string{"target/"}},
+	{"java", "Java", []string{"*.class", "*.jar", "*.war", "build/", ".gradle/", "target/"}},
+	{"dotnet", ".NET", []string{"bin/", "obj/", "*.user", "*.suo"}},
+	{"cpp", "C++", []string{"*.o", "*.obj", "*.so", "*.dylib", "*.exe", "build/"}},
+	{"nextjs", "Next.js", []string{".next/", "out/", "next-env.d.ts"}},
+	{"django", "Django", []string{"db.sqlite3", "staticfiles/", "media/"}},
+	{"terraform", "Terraform", []string{".terraform/", "*.tfstate", "*.tfstate.*", "crash.log"}},
+	{"jupyter", "Jupyter", []string{".ipynb_checkpoints/"}},
+	{"ml", "ML", []string{"mlruns/", "wandb/", "lightning_logs/", "checkpoints/", "*.ckpt", "*.pt", "*.pth", "*.safetensors", "*.onnx"}},
+}
+
+// defaultGitignore returns the sets chosen when none are given: OS, editor,
+// and language (a stack ID, whose set shares its ID) if any, plus Jupyter
+// and ML artifacts for the ML workload.
+func defaultGitignore(language, workload string) []string {
+	ids := []string{"os", "editor"}
+	if stackFor(language) != nil {
+		ids = append(ids, language)
+	}
+	if workload == "ml" {
+		ids = append(ids, "jupyter", "ml")
+	}
+	return ids
+}
+
+// validateGitignore rejects unknown set IDs.
+func validateGitignore(ids []string) error {
+	for _, id := range ids {
+		if !slices.ContainsFunc(gitignoreCatalog, func(s gitignoreSet) bool { return s.ID == id }) {
+			return errors.New(T("validate.gitignoreSet", id))
+		}
+	}
+	return nil
+}
+
+// splitPatterns splits the wizard's extra-patterns answer on commas and
+// whitespace, dropping duplicates.
+func splitPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if !slices.Contains(patterns, p) {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// validatePatterns rejects extra patterns that would not be read as one
+// pattern: blanks, comments and anything spanning lines.
+func validatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" || strings.HasPrefix(p, "#") || strings.ContainsAny(p, "\r\n") {
+			return errors.New(T("validate.gitignorePattern", p))
+		}
+	}
+	return nil
+}
+
+// gitignoreOptions offers every set, preselecting ids.
+func gitignoreOptions(ids []string) []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(gitignoreCatalog))
+	for _, set := range gitignoreCatalog {
+		options = append(options, huh.NewOption(T("wizard.gitignore."+set.ID), set.ID).Selected(slices.Contains(ids, set.ID)))
+	}
+	return options
+}
+
+// gitignoreSections resolves data's chosen sets (or the defaults) into the
+// sections of .gitignore. The OS set leads, then the environment files (and
+// the local chat continuity key) that are always ignored, then the rest in
+// catalog order, and last the project's extra patterns. A pattern already listed by an earlier section is dropped,
+// along with sections left empty.
+func gitignoreSections(data TemplateData) []gitignoreSet {
+	ids := data.Gitignore
+	if len(ids) == 0 {
+		ids = defaultGitignore(stackLanguage(data.Language, data.DevContainerImage), data.Workload)
+	}
+
+	var sets []gitignoreSet
+	for _, set := range gitignoreCatalog {
+		if set.ID == "os" && slices.Contains(ids, "os") {
+			sets = append(sets, set)
+		}
+	}
+	sets = append(sets, gitignoreSet{ID: "env", Label: "Environment", Patterns: []string{".env", ".env.local", ".env.*.local"}})
+	if len(data.ChatTools) > 0 && !data.IncludeDevContainer {
+		sets = append(sets, gitignoreSet{ID: "ai", Label: "AI chat continuity (this machine's project key)", Patterns: []string{".ai-project-key"}})
+	}
+	for _, set := range gitignoreCatalog {
+		if set.ID != "os" && slices.Contains(ids, set.ID) {
+			sets = append(sets, set)
+		}
+	}
+	if len(data.GitignoreExtra) > 0 {
+		sets = append(sets, gitignoreSet{ID: "extra", Label: "Project-specific", Patterns: data.GitignoreExtra})
+	}
+
+	seen := map[string]bool{}
+	sections := sets[:0]
+	for _, set := range sets {
+		var patterns []string
+		for _, p := range set.Patterns {
+			if !seen[p] {
+				seen[p] = true
+				patterns = append(patterns, p)
+			}
+		}
+		if len(patterns) > 0 {
+			set.Patterns = patterns
+			sections = append(sections, set)
+		}
+	}
+	return sections
+}
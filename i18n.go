@@ -0,0 +1,158 @@
+// Package main - i18n.go
+//
+// PURPOSE:
+// This file localizes seed's user-facing text. It's responsible for:
+// - Loading the embedded message catalogs (locales/<lang>/messages.json)
+//   and help pages (locales/<lang>/help.txt)
+// - Choosing the locale: config.json "locale", then LC_ALL, LC_MESSAGES, LANG
+// - Looking up messages, falling back to English for anything untranslated
+//
+// DESIGN PATTERNS:
+// - Catalogs are plain files, like templates: translators edit JSON and text,
+//   not Go code
+// - Generated project files are NOT localized; only seed's own UI is
+// - Messages are fmt format strings; a translation must keep the verbs of
+//   its English original (enforced by tests)
+//
+// USAGE:
+// setLocale(detectLocale(cfg))
+// fmt.Println(T("flow.done"))
+// fmt.Print(helpText())
+
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed locales
+var localesFS embed.FS
+
+// defaultLocale is the source language; every key must exist in it.
+const defaultLocale = "en"
+
+// catalogs maps locale -> message key -> format string.
+var catalogs = mustLoadCatalogs(localesFS)
+
+// currentLocale is the locale T and helpText use.
+var currentLocale = defaultLocale
+
+// loadCatalogs reads every locales/<lang>/messages.json in fsys.
+func loadCatalogs(fsys fs.FS) (map[string]map[string]string, error) {
+	dirs, err := fs.ReadDir(fsys, "locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locales: %w", err)
+	}
+	result := make(map[string]map[string]string, len(dirs))
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		raw, err := fs.ReadFile(fsys, path.Join("locales", dir.Name(), "messages.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s catalog: %w", dir.Name(), err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			return nil, fmt.Errorf("invalid %s catalog: %w", dir.Name(), err)
+		}
+		result[dir.Name()] = messages
+	}
+	if _, ok := result[defaultLocale]; !ok {
+		return nil, fmt.Errorf("missing %s catalog", defaultLocale)
+	}
+	return result, nil
+}
+
+// mustLoadCatalogs panics on error: the catalogs are embedded, so a failure
+// is a build defect, not a runtime condition.
+func mustLoadCatalogs(fsys fs.FS) map[string]map[string]string {
+	c, err := loadCatalogs(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// availableLocales returns the embedded locales, sorted.
+func availableLocales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// normalizeLocale reduces a POSIX locale ("es_ES.UTF-8", "pt-BR") to the
+// language code seed ships catalogs by ("es", "pt"). "C" and "POSIX" mean
+// no preference.
+func normalizeLocale(value string) string {
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	lang, _, _ := strings.Cut(strings.ReplaceAll(value, "-", "_"), "_")
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "c" || lang == "posix" {
+		return ""
+	}
+	return lang
+}
+
+// detectLocale picks the locale to use: the config file wins, then the
+// environment in POSIX precedence order. Unknown languages fall back to
+// English.
+func detectLocale(cfg userConfig) string {
+	candidates := []string{cfg.Locale, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, c := range candidates {
+		lang := normalizeLocale(c)
+		if lang == "" {
+			continue
+		}
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		return defaultLocale // first preference set but untranslated
+	}
+	return defaultLocale
+}
+
+// setLocale switches the UI language; unknown locales select English.
+func setLocale(locale string) {
+	if _, ok := catalogs[locale]; !ok {
+		locale = defaultLocale
+	}
+	currentLocale = locale
+}
+
+// T returns the message for key in the current locale, formatted with args.
+// Untranslated keys fall back to English; unknown keys return the key itself
+// so a missing entry is visible rather than blank.
+func T(key string, args ...any) string {
+	format, ok := catalogs[currentLocale][key]
+	if !ok {
+		if format, ok = catalogs[defaultLocale][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// helpText returns the help page for the current locale with the version
+// filled in, falling back to English.
+func helpText() string {
+	raw, err := localesFS.ReadFile(path.Join("locales", currentLocale, "help.txt"))
+	if err != nil {
+		raw, _ = localesFS.ReadFile(path.Join("locales", defaultLocale, "help.txt"))
+	}
+	return fmt.Sprintf(string(raw), Version)
+}
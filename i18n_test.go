@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// fmtVerb matches fmt verbs, ignoring escaped percent signs.
+var fmtVerb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z]`)
+
+// useLocale switches the UI language for one test.
+func useLocale(t *testing.T, locale string) {
+	t.Helper()
+	previous := currentLocale
+	setLocale(locale)
+	t.Cleanup(func() { currentLocale = previous })
+}
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	english := catalogs[defaultLocale]
+	for _, locale := range availableLocales() {
+		t.Run(locale, func(t *testing.T) {
+			for key, format := range catalogs[locale] {
+				source, ok := english[key]
+				if !ok {
+					t.Errorf("%s: key not in the English catalog", key)
+					continue
+				}
+				got, want := fmtVerb.FindAllString(format, -1), fmtVerb.FindAllString(source, -1)
+				if !slices.Equal(got, want) {
+					t.Errorf("%s: verbs %v, English has %v", key, got, want)
+				}
+				if strings.TrimSpace(format) == "" {
+					t.Errorf("%s: empty translation", key)
+				}
+			}
+			for key := range english {
+				if _, ok := catalogs[locale][key]; !ok {
+					t.Errorf("%s: missing translation", key)
+				}
+			}
+		})
+	}
+}
+
+func TestHelpTextPerLocale(t *testing.T) {
+	for _, locale := range availableLocales() {
+		t.Run(locale, func(t *testing.T) {
+			useLocale(t, locale)
+			help := helpText()
+			if strings.Contains(help, "%!") {
+				t.Errorf("help page has a bad format verb:\n%s", help)
+			}
+			for _, command := range []string{"seed add package", "seed list", "seed status", "seed info", "seed diff", "seed regen", "seed upgrade", "seed rename", "seed adopt", "seed profile", "seed doctor", "seed images", "seed telemetry", "seed templates eject", "--batch", "--sync", "--print", "--output-archive"} {
+				if !strings.Contains(help, command) {
+					t.Errorf("help page doesn't mention %s", command)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name                       string
+		config, lcAll, lcMsg, lang string
+		want                       string
+	}{
+		{name: "default", want: "en"},
+		{name: "LANG", lang: "es_ES.UTF-8", want: "es"},
+		{name: "LC_ALL wins over LANG", lcAll: "en_US.UTF-8", lang: "es_ES.UTF-8", want: "en"},
+		{name: "LC_MESSAGES wins over LANG", lcMsg: "es_MX", lang: "en_GB", want: "es"},
+		{name: "config wins over environment", config: "es", lang: "en_US.UTF-8", want: "es"},
+		{name: "C locale is no preference", lcAll: "C", lang: "es_ES", want: "es"},
+		{name: "untranslated language falls back to English", lang: "fr_FR.UTF-8", want: "en"},
+		{name: "BCP 47 tag", config: "es-419", want: "es"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMsg)
+			t.Setenv("LANG", tt.lang)
+			if got := detectLocale(userConfig{Locale: tt.config}); got != tt.want {
+				t.Errorf("detectLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	useLocale(t, "es")
+	if got := T("flow.done"); got != "Listo." {
+		t.Errorf("T(flow.done) = %q", got)
+	}
+	if got := T("args.unknownFlag", "--nope"); got != "opción desconocida --nope" {
+		t.Errorf("T(args.unknownFlag) = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key should return itself, got %q", got)
+	}
+
+	setLocale("xx")
+	if currentLocale != defaultLocale {
+		t.Errorf("unknown locale should select English, got %q", currentLocale)
+	}
+}
+
+func TestUsageErrorsLocalized(t *testing.T) {
+	isolateConfig(t)
+	useLocale(t, "es")
+	tests := []struct {
+		run  func([]string) error
+		args []string
+		want string
+	}{
+		{runBundle, nil, T("args.bundleCommand")},
+		{runTemplates, []string{"fork"}, T("args.unknownCommand", "templates", "fork")},
+		{runProfile, []string{"export"}, T("args.exportNeedsName")},
+		{runAdd, nil, T("args.addComponent")},
+		{runDoctor, []string{"now"}, T("args.noArguments", "doctor")},
+		{runTelemetry, []string{"maybe"}, T("args.onOrOff")},
+	}
+	for _, tt := range tests {
+		var usage usageError
+		if err := tt.run(tt.args); !errors.As(err, &usage) || usage.msg != tt.want {
+			t.Errorf("%q: got %v, want the usage error %q", tt.args, err, tt.want)
+		}
+	}
+	if english := catalogs[defaultLocale]["args.onOrOff"]; T("args.onOrOff") == english {
+		t.Errorf("expected a Spanish usage error, got %q", english)
+	}
+}
+
+func TestLoadCatalogsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "invalid JSON",
+			fsys: fstest.MapFS{
+				"locales/en/messages.json": {Data: []byte(`{"a": "b"}`)},
+				"locales/es/messages.json": {Data: []byte(`{`)},
+			},
+			want: "invalid es catalog",
+		},
+		{
+			name: "no English",
+			fsys: fstest.MapFS{"locales/es/messages.json": {Data: []byte(`{}`)}},
+			want: "missing en catalog",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadCatalogs(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
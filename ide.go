@@ -0,0 +1,115 @@
+// Package main - ide.go
+//
+// PURPOSE:
+// This file describes the IDEs a dev container can be set up for. It's
+// responsible for:
+// - The IDE answer: VS Code (the default), JetBrains Gateway, or none
+//   (the devcontainer CLI, Neovim, Zed, a terminal agent)
+// - The JetBrains backend for each stack (GoLand for Go, PyCharm for
+//   Python, ...) and the agent plugins that match the VS Code extensions
+//   the wizard offers
+// - The wizard's IDE options
+//
+// DESIGN PATTERNS:
+// - "" means VS Code, so answers recorded before the question render as
+//   they did
+// - Agents are chosen by their VS Code extension ID whatever the IDE;
+//   renderDevContainer translates them to JetBrains plugin IDs, and
+//   language extensions are dropped since the backend IDE covers the
+//   language
+// - Only VS Code gets the extensions cache volume, its onCreateCommand
+//   symlink and the .vscode-server directories in the Dockerfile
+//
+// USAGE:
+// if data.UsesVSCode() { ... }
+// backend := jetbrainsBackend(data.Language)
+
+package main
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/huh"
+)
+
+// IDE answers ("" is VS Code).
+const (
+	ideVSCode    = "vscode"
+	ideJetBrains = "jetbrains"
+	ideNone      = "none"
+)
+
+// defaultJetBrainsBackend is the Gateway backend for stacks without their
+// own JetBrains IDE.
+const defaultJetBrainsBackend = "IntelliJ"
+
+// jetbrainsPlugins maps agent VS Code extension IDs to the JetBrains
+// plugin of the same agent, where there is one.
+var jetbrainsPlugins = map[string]string{
+	"anthropics.claude-code": "com.anthropic.code.plugin",
+}
+
+// usesVSCode reports whether ide is VS Code.
+func usesVSCode(ide string) bool {
+	return ide == "" || ide == ideVSCode
+}
+
+// validateIDE rejects unknown IDE answers.
+func validateIDE(ide string) error {
+	switch ide {
+	case "", ideVSCode, ideJetBrains, ideNone:
+		return nil
+	}
+	return errors.New(T("validate.ide", ide))
+}
+
+// UsesVSCode reports whether the dev container is set up for VS Code.
+func (d TemplateData) UsesVSCode() bool {
+	return usesVSCode(d.IDE)
+}
+
+// JetBrainsBackend returns the JetBrains IDE Gateway runs in the dev
+// container.
+func (d TemplateData) JetBrainsBackend() string {
+	return jetbrainsBackend(stackLanguage(d.Language, d.DevContainerImage))
+}
+
+// jetbrainsBackend returns the JetBrains IDE Gateway runs for language.
+func jetbrainsBackend(language string) string {
+	if s := stackFor(language); s != nil && s.JetBrains != "" {
+		return s.JetBrains
+	}
+	return defaultJetBrainsBackend
+}
+
+// agentPlugins returns the JetBrains plugins for the chosen extensions,
+// skipping those without one.
+func agentPlugins(extensions []string) []string {
+	var plugins []string
+	for _, id := range extensions {
+		if plugin, ok := jetbrainsPlugins[id]; ok {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// ideOptions offers VS Code first, so it's preselected.
+func ideOptions() []huh.Option[string] {
+	return []huh.Option[string]{
+		huh.NewOption("VS Code", ""),
+		huh.NewOption(T("wizard.ide.jetbrains"), ideJetBrains),
+		huh.NewOption(T("wizard.ide.none"), ideNone),
+	}
+}
+
+// jetbrainsAgentOptions offers the agents that have a JetBrains plugin.
+func jetbrainsAgentOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, ext := range agentExtensions {
+		if _, ok := jetbrainsPlugins[ext.ID]; ok {
+			options = append(options, huh.NewOption(ext.Label, ext.ID))
+		}
+	}
+	return options
+}
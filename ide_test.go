@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDevContainerIDE(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		ide          string
+		language     string
+		vscodeConfig bool
+		backend      string   // customizations.jetbrains.backend ("" for no jetbrains section)
+		plugins      []string // customizations.jetbrains.plugins
+		vscode       bool     // customizations.vscode, the extensions cache and .vscode-server
+		extensions   bool     // .vscode/extensions.json
+		agents       string   // In AGENTS.md's Dev Container section
+	}{
+		{"vscode", "", "go", false, "", nil, true, true, "select **Reopen in Container**"},
+		{"jetbrains go", ideJetBrains, "go", false, "GoLand", []string{"com.anthropic.code.plugin"}, false, false, "runs GoLand in the container"},
+		{"jetbrains java", ideJetBrains, "java", false, "IntelliJ", []string{"com.anthropic.code.plugin"}, false, false, "runs IntelliJ in the container"},
+		{"jetbrains with vscode configs", ideJetBrains, "python", true, "PyCharm", []string{"com.anthropic.code.plugin"}, false, true, "runs PyCharm"},
+		{"none", ideNone, "rust", false, "", nil, false, false, "`devcontainer up --workspace-folder .`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := WizardData{
+				ProjectName:         "ide",
+				Description:         "An IDE test",
+				Language:            tt.language,
+				IncludeDevContainer: true,
+				DevContainerImage:   stackFor(tt.language).Image,
+				IDE:                 tt.ide,
+				VSCodeConfig:        tt.vscodeConfig,
+				Shell:               "zsh",
+				AgentExtensions:     []string{"anthropics.claude-code", "openai.chatgpt"},
+				ExtensionsVolume:    "ide-0123abcd-vscode-extensions",
+			}
+			if err := w.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			files, err := s.Render(w.ToTemplateData())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var dc DevContainer
+			if err := json.Unmarshal([]byte(renderedContent(files, ".devcontainer/devcontainer.json")), &dc); err != nil {
+				t.Fatal(err)
+			}
+
+			var jb *DevContainerJetBrains
+			var vscode *DevContainerVSCode
+			if dc.Customizations != nil {
+				jb, vscode = dc.Customizations.JetBrains, dc.Customizations.VSCode
+			}
+			if (jb != nil) != (tt.backend != "") {
+				t.Fatalf("jetbrains customizations = %+v, want backend %q", jb, tt.backend)
+			}
+			if jb != nil && (jb.Backend != tt.backend || !slices.Equal(jb.Plugins, tt.plugins)) {
+				t.Errorf("jetbrains = %+v, want backend %s and plugins %v", jb, tt.backend, tt.plugins)
+			}
+			if (vscode != nil) != tt.vscode {
+				t.Errorf("vscode customizations = %+v, want present = %t", vscode, tt.vscode)
+			}
+
+			cache := slices.ContainsFunc(dc.Mounts, func(m string) bool { return strings.Contains(m, "vscode-extensions") })
+			if cache != tt.vscode || (dc.OnCreateCommand != "") != tt.vscode {
+				t.Errorf("extensions cache = %t (onCreateCommand %q), want %t", cache, dc.OnCreateCommand, tt.vscode)
+			}
+			if got := strings.Contains(renderedContent(files, ".devcontainer/Dockerfile"), ".vscode-server"); got != tt.vscode {
+				t.Errorf("Dockerfile creates .vscode-server = %t, want %t", got, tt.vscode)
+			}
+			if _, ok := dc.Features["ghcr.io/devcontainers/features/common-utils:2"]; !ok {
+				t.Error("zsh should be installed whatever the IDE")
+			}
+			if got := renderedContent(files, ".vscode/extensions.json") != ""; got != tt.extensions {
+				t.Errorf(".vscode/extensions.json rendered = %t, want %t", got, tt.extensions)
+			}
+			if agents := renderedContent(files, "AGENTS.md"); !strings.Contains(agents, tt.agents) {
+				t.Errorf("AGENTS.md missing %q:\n%s", tt.agents, agents)
+			}
+		})
+	}
+}
+
+func TestValidateIDE(t *testing.T) {
+	for _, ide := range []string{"", ideVSCode, ideJetBrains, ideNone} {
+		if err := validateIDE(ide); err != nil {
+			t.Errorf("validateIDE(%q) = %v", ide, err)
+		}
+	}
+	if err := validateIDE("fleet"); err == nil {
+		t.Error("unknown IDEs should be rejected")
+	}
+}
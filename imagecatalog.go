@@ -0,0 +1,389 @@
+// Package main - imagecatalog.go
+//
+// PURPOSE:
+// This file describes the dev container images the wizard offers. It's
+// responsible for:
+// - The image catalog: what each image includes, roughly how big it is and
+//   (once refreshed) when its tag was last built
+// - `seed images` (list the catalog) and `seed images refresh` (read sizes
+//   and build dates from the registry, cached in seed's config directory)
+// - The wizard's image picker: options with size and freshness, the
+//   highlighted image's tools, and a custom image reference
+// - Validating custom image references
+//
+// DESIGN PATTERNS:
+// - Embedded first: the catalog ships with seed, so the picker never waits
+//   on the network; a refresh only updates sizes and build dates, never
+//   which images are offered (that's the stacks table)
+// - Refresh asks the registry the images come from (imageRegistry, or MCR)
+//   through the OCI client in registry.go: the linux/amd64 manifest's layer
+//   sizes and its config's "created" date
+// - A custom image is a full reference naming its registry, used as is:
+//   imageRegistry doesn't apply, and allowedRegistries still does
+//
+// USAGE:
+// catalog := loadImageCatalog(cfg)
+// options := imageOptions(data.Language, catalog)
+// err := validateImageRef("ghcr.io/acme/devcontainer:1")
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+const (
+	imageCatalogCacheFile = "image-catalog.json" // Refreshed sizes and build dates, in seed's config directory
+	imageStaleAfter       = 180 * 24 * time.Hour // A tag built longer ago is flagged as stale
+	customImageOption     = "custom"             // Picker value for "enter an image reference"
+)
+
+// imageCatalogSnapshot is when the embedded sizes were measured.
+var imageCatalogSnapshot = time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+// Manifest media types a dev container image may be served as.
+const (
+	ociIndexType       = "application/vnd.oci.image.index.v1+json"
+	dockerListType     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerManifestType = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// imageInfo is a catalog entry.
+type imageInfo struct {
+	Image string    `json:"image"`           // Tag relative to the registry, as DevContainerImage records it
+	Tools []string  `json:"tools,omitempty"` // What the image includes beyond git and Oh My Zsh
+	Size  int64     `json:"size"`            // Compressed linux/amd64 size in bytes
+	Built time.Time `json:"built"`           // When the tag was last built; zero until refreshed
+}
+
+// imageCatalog lists every stack's image plus universal, with the sizes
+// measured at imageCatalogSnapshot.
+var imageCatalog = []imageInfo{
+	{Image: "go:2-1.25-trixie", Tools: []string{"Go 1.25", "gopls", "Delve", "staticcheck", "golangci-lint"}, Size: 720 << 20},
+	{Image: "typescript-node:20-bookworm", Tools: []string{"Node.js 20", "npm", "Yarn", "TypeScript", "ESLint", "nvm"}, Size: 520 << 20},
+	{Image: "python:3-3.12", Tools: []string{"Python 3.12", "pip", "pipx", "pytest", "Black", "mypy", "pylint"}, Size: 480 << 20},
+	{Image: "rust:1-bookworm", Tools: []string{"Rust (stable)", "Cargo", "rustfmt", "Clippy", "LLDB"}, Size: 820 << 20},
+	{Image: "java", Tools: []string{"JDK 21", "SDKMAN!"}, Size: 560 << 20},
+	{Image: "dotnet", Tools: []string{".NET SDK 8"}, Size: 700 << 20},
+	{Image: "cpp", Tools: []string{"GCC", "Clang/LLVM", "CMake", "GDB", "Valgrind", "vcpkg"}, Size: 610 << 20},
+	{Image: "universal", Tools: []string{"Python", "Node.js", "Java", ".NET", "Go", "Rust", "C++", "PHP", "Ruby", "Conda", "Docker"}, Size: 4300 << 20},
+}
+
+// imageCatalogCache is the refreshed part of the catalog.
+type imageCatalogCache struct {
+	Registry  string      `json:"registry"` // Registry the images were read from
+	FetchedAt time.Time   `json:"fetchedAt"`
+	Images    []imageInfo `json:"images"`
+}
+
+// imageRegistryFor returns the registry images come from under cfg.
+func imageRegistryFor(cfg userConfig) string {
+	return strings.TrimSuffix(TemplateData{ImageRegistry: cfg.ImageRegistry}.ImageRef(), "/")
+}
+
+// imageCatalogCachePath returns where the refreshed catalog is kept.
+func imageCatalogCachePath() (string, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, imageCatalogCacheFile), nil
+}
+
+// loadImageCatalog returns the embedded catalog, with sizes and build dates
+// from the last refresh against cfg's registry.
+func loadImageCatalog(cfg userConfig) []imageInfo {
+	catalog := append([]imageInfo(nil), imageCatalog...)
+	path, err := imageCatalogCachePath()
+	if err != nil {
+		return catalog
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return catalog
+	}
+	var cache imageCatalogCache
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		debugf("image catalog cache ignored: %v", err)
+		return catalog
+	}
+	if cache.Registry != imageRegistryFor(cfg) {
+		return catalog // Refreshed against another registry
+	}
+	for _, fresh := range cache.Images {
+		for i := range catalog {
+			if catalog[i].Image == fresh.Image {
+				catalog[i].Size, catalog[i].Built = fresh.Size, fresh.Built
+			}
+		}
+	}
+	return catalog
+}
+
+// refreshImageCatalog reads each catalog image's size and build date from
+// cfg's registry and caches them. Images that can't be read keep their
+// previous values; their errors are returned together.
+func refreshImageCatalog(cfg userConfig) ([]imageInfo, error) {
+	catalog := loadImageCatalog(cfg)
+	var errs []error
+	for i, info := range catalog {
+		size, built, err := fetchImageInfo(TemplateData{ImageRegistry: cfg.ImageRegistry, DevContainerImage: info.Image}.ImageRef())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", info.Image, err))
+			continue
+		}
+		catalog[i].Size, catalog[i].Built = size, built
+	}
+
+	cache := imageCatalogCache{Registry: imageRegistryFor(cfg), FetchedAt: time.Now().UTC()}
+	for _, info := range catalog {
+		cache.Images = append(cache.Images, imageInfo{Image: info.Image, Size: info.Size, Built: info.Built})
+	}
+	path, err := imageCatalogCachePath()
+	if err != nil {
+		return catalog, err
+	}
+	raw, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return catalog, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return catalog, err
+	}
+	if err := writeFileAtomic(path, append(raw, '\n'), 0644); err != nil {
+		return catalog, err
+	}
+	return catalog, errors.Join(errs...)
+}
+
+// fetchImageInfo returns the compressed linux/amd64 size of the image at
+// ref and when it was built.
+func fetchImageInfo(ref string) (int64, time.Time, error) {
+	parsed, err := parseOCIRef(ref)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	c, err := newRegistryClient(parsed)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	var manifest struct {
+		ociManifest
+		Manifests []struct {
+			Digest   string `json:"digest"`
+			Platform struct {
+				OS           string `json:"os"`
+				Architecture string `json:"architecture"`
+			} `json:"platform"`
+		} `json:"manifests"`
+	}
+	reference := parsed.reference()
+	for range 2 {
+		raw, err := c.getManifestAs(reference, ociIndexType, dockerListType, ociManifestType, dockerManifestType)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		manifest.Manifests = nil
+		if err := json.Unmarshal(raw, &manifest); err != nil {
+			return 0, time.Time{}, fmt.Errorf("invalid manifest: %w", err)
+		}
+		if len(manifest.Manifests) == 0 {
+			break
+		}
+		// An index: follow the linux/amd64 image
+		reference = ""
+		for _, m := range manifest.Manifests {
+			if m.Platform.OS == "linux" && m.Platform.Architecture == "amd64" {
+				reference = m.Digest
+			}
+		}
+		if reference == "" {
+			return 0, time.Time{}, errors.New("no linux/amd64 image")
+		}
+	}
+	if len(manifest.Manifests) > 0 || manifest.Config.Digest == "" {
+		return 0, time.Time{}, errors.New("unexpected manifest")
+	}
+
+	var size int64
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	config, err := c.getBlob(manifest.Config)
+	if err != nil {
+		return size, time.Time{}, err
+	}
+	var image struct {
+		Created time.Time `json:"created"`
+	}
+	if err := json.Unmarshal(config, &image); err != nil {
+		return size, time.Time{}, fmt.Errorf("invalid image config: %w", err)
+	}
+	return size, image.Created, nil
+}
+
+// imageFor returns the catalog entry for image.
+func imageFor(catalog []imageInfo, image string) (imageInfo, bool) {
+	for _, info := range catalog {
+		if info.Image == image {
+			return info, true
+		}
+	}
+	return imageInfo{}, false
+}
+
+// Freshness describes when the tag was built, relative to now: "built 12
+// days ago", flagged as stale past imageStaleAfter, or the catalog's date
+// when it hasn't been refreshed.
+func (i imageInfo) Freshness(now time.Time) string {
+	if i.Built.IsZero() {
+		return T("images.snapshot", imageCatalogSnapshot.Format("2006-01"))
+	}
+	age := now.Sub(i.Built)
+	days := int(age.Hours() / 24)
+	var built string
+	switch {
+	case days < 1:
+		built = T("images.builtToday")
+	case days < 60:
+		built = T("images.builtDays", days)
+	default:
+		built = T("images.builtMonths", days/30)
+	}
+	if age > imageStaleAfter {
+		return T("images.stale", built)
+	}
+	return built
+}
+
+// Summary is the one-line form the picker and `seed images` show.
+func (i imageInfo) Summary(now time.Time) string {
+	return fmt.Sprintf("%s · ~%s · %s", i.Image, formatSize(i.Size), i.Freshness(now))
+}
+
+// imageLabel returns the stack label image is offered under.
+func imageLabel(image string) string {
+	for _, s := range stacks {
+		if s.Image == image {
+			return s.Label
+		}
+	}
+	return T("wizard.stack.universal")
+}
+
+// imageOptions offers every catalog image with its size and freshness, the
+// image of language first so it's the one preselected, then a custom image.
+func imageOptions(language string, catalog []imageInfo) []huh.Option[string] {
+	now := time.Now()
+	preferred := ""
+	if s := stackFor(language); s != nil {
+		preferred = s.Image
+	}
+	options := make([]huh.Option[string], 0, len(catalog)+1)
+	for _, info := range catalog {
+		option := huh.NewOption(imageLabel(info.Image)+" — "+info.Summary(now), info.Image)
+		if info.Image == preferred {
+			options = append([]huh.Option[string]{option}, options...)
+		} else {
+			options = append(options, option)
+		}
+	}
+	return append(options, huh.NewOption(T("wizard.stack.custom"), customImageOption))
+}
+
+// imageDescription describes the highlighted image for the picker: its
+// tools, or what a custom image needs.
+func imageDescription(image string, catalog []imageInfo) string {
+	if image == customImageOption {
+		return T("wizard.stack.customHint")
+	}
+	info, ok := imageFor(catalog, image)
+	if !ok || len(info.Tools) == 0 {
+		return T("wizard.stackHint")
+	}
+	return T("wizard.stack.includes", strings.Join(info.Tools, ", "))
+}
+
+// splitImageChoice returns the picker value and custom reference for a
+// recorded image: catalog images are picked, anything else is custom.
+func splitImageChoice(image string, catalog []imageInfo) (choice, custom string) {
+	if _, ok := imageFor(catalog, image); ok || image == "" {
+		return image, ""
+	}
+	return customImageOption, image
+}
+
+// joinImageChoice is splitImageChoice's inverse.
+func joinImageChoice(choice, custom string) string {
+	if choice == customImageOption {
+		return strings.TrimSpace(custom)
+	}
+	return choice
+}
+
+// isImageReference reports whether image is a full reference naming its
+// registry (e.g. ghcr.io/acme/dev:1) rather than an MCR devcontainers tag.
+func isImageReference(image string) bool {
+	host, _, ok := strings.Cut(image, "/")
+	return ok && (strings.ContainsAny(host, ".:") || host == "localhost")
+}
+
+// validateImageRef checks a custom image reference: registry, repository,
+// and an optional tag or digest.
+func validateImageRef(ref string) error {
+	ref = strings.TrimSpace(ref)
+	if strings.Contains(ref, "://") || !isImageReference(ref) {
+		return errors.New(T("validate.imageRef", ref))
+	}
+	if _, err := parseOCIRef(ref); err != nil {
+		return errors.New(T("validate.imageRef", ref))
+	}
+	return nil
+}
+
+// imagesUsage is shown for `seed images` usage errors.
+const imagesUsage = "seed images [refresh]"
+
+// runImages handles `seed images`: it lists the catalog, after reading
+// fresh sizes and build dates from the registry with refresh.
+func runImages(args []string) error {
+	refresh := false
+	switch {
+	case len(args) == 0:
+	case strings.HasPrefix(args[0], "-"):
+		return usageError{msg: T("args.unknownFlag", args[0]), usage: imagesUsage}
+	case args[0] != "refresh":
+		return usageError{msg: T("args.unknownCommand", "images", args[0]), usage: imagesUsage}
+	case len(args) > 1:
+		return usageError{msg: T("args.tooMany"), usage: imagesUsage}
+	default:
+		refresh = true
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	catalog := loadImageCatalog(cfg)
+	if refresh {
+		fmt.Println(T("images.refreshing", imageRegistryFor(cfg)))
+		catalog, err = refreshImageCatalog(cfg)
+		if err != nil {
+			fmt.Println(warnStyle.Render(T("images.refreshFailed", err)))
+		}
+	}
+	now := time.Now()
+	for _, info := range catalog {
+		fmt.Printf("%-18s %s\n", imageLabel(info.Image), info.Summary(now))
+		fmt.Printf("%-18s %s\n", "", dimStyle.Render(strings.Join(info.Tools, ", ")))
+	}
+	return nil
+}
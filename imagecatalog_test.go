@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchImageInfo(t *testing.T) {
+	isolateConfig(t)
+	reg := newTestRegistry(t)
+	loginTo(t, reg.host(), reg.user, reg.password)
+
+	built := time.Date(2026, time.August, 3, 12, 0, 0, 0, time.UTC)
+	config := []byte(`{"created":"` + built.Format(time.RFC3339) + `","architecture":"amd64","os":"linux"}`)
+	reg.blobs[ociDigest(config)] = config
+	image := []byte(`{"schemaVersion":2,"mediaType":"` + dockerManifestType + `","config":{"mediaType":"application/vnd.docker.container.image.v1+json","digest":"` + ociDigest(config) + `","size":` + strconv.Itoa(len(config)) + `},"layers":[{"digest":"sha256:a","size":1000},{"digest":"sha256:b","size":234}]}`)
+	reg.manifests[ociDigest(image)] = image
+	index := []byte(`{"schemaVersion":2,"mediaType":"` + ociIndexType + `","manifests":[` +
+		`{"digest":"sha256:arm","platform":{"os":"linux","architecture":"arm64"}},` +
+		`{"digest":"` + ociDigest(image) + `","platform":{"os":"linux","architecture":"amd64"}}]}`)
+	reg.manifests["multi"] = index
+	reg.manifests["single"] = image
+	reg.manifests["armonly"] = []byte(`{"schemaVersion":2,"manifests":[{"digest":"sha256:arm","platform":{"os":"linux","architecture":"arm64"}}]}`)
+
+	// Through an index, and a single-platform image
+	for _, tag := range []string{"multi", "single"} {
+		size, got, err := fetchImageInfo(reg.host() + "/acme/dev:" + tag)
+		if err != nil {
+			t.Fatalf("%s: %v", tag, err)
+		}
+		if size != 1234 || !got.Equal(built) {
+			t.Errorf("%s: got %d bytes built %v, want 1234 built %v", tag, size, got, built)
+		}
+	}
+	if _, _, err := fetchImageInfo(reg.host() + "/acme/dev:armonly"); err == nil || !strings.Contains(err.Error(), "linux/amd64") {
+		t.Errorf("expected no linux/amd64 image, got %v", err)
+	}
+}
+
+func TestImageCatalogCache(t *testing.T) {
+	isolateConfig(t)
+	reg := newTestRegistry(t)
+	loginTo(t, reg.host(), reg.user, reg.password)
+	cfg := userConfig{ImageRegistry: reg.host() + "/mirror"}
+
+	// Nothing to read there: every image keeps its embedded size, and the
+	// failures are reported together
+	catalog, err := refreshImageCatalog(cfg)
+	if err == nil || !strings.Contains(err.Error(), "go:2-1.25-trixie") || !strings.Contains(err.Error(), "universal") {
+		t.Errorf("expected every image's error, got %v", err)
+	}
+	if len(catalog) != len(imageCatalog) || catalog[0].Size != imageCatalog[0].Size {
+		t.Errorf("expected the embedded catalog, got %+v", catalog)
+	}
+
+	// A refresh against cfg's registry is merged over the embedded catalog
+	path, err := imageCatalogCachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cache imageCatalogCache
+	raw, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(raw, &cache) != nil || cache.Registry != reg.host()+"/mirror" {
+		t.Fatalf("expected a cache for the mirror, got %s (%v)", raw, err)
+	}
+	built := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	cache.Images = []imageInfo{{Image: "python:3-3.12", Size: 99 << 20, Built: built}}
+	raw, _ = json.Marshal(cache)
+	writeTestFile(t, path, string(raw))
+
+	python, _ := imageFor(loadImageCatalog(cfg), "python:3-3.12")
+	if python.Size != 99<<20 || !python.Built.Equal(built) || len(python.Tools) == 0 {
+		t.Errorf("expected the refreshed size and date with the embedded tools, got %+v", python)
+	}
+	if python, _ := imageFor(loadImageCatalog(userConfig{}), "python:3-3.12"); !python.Built.IsZero() {
+		t.Errorf("a refresh against another registry shouldn't apply to MCR, got %+v", python)
+	}
+	if filepath.Base(path) != imageCatalogCacheFile {
+		t.Errorf("unexpected cache path %s", path)
+	}
+}
+
+func TestImageFreshness(t *testing.T) {
+	now := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		built time.Time
+		want  string
+	}{
+		{time.Time{}, T("images.snapshot", "2026-09")},
+		{now.Add(-time.Hour), T("images.builtToday")},
+		{now.AddDate(0, 0, -12), T("images.builtDays", 12)},
+		{now.AddDate(0, 0, -90), T("images.builtMonths", 3)},
+		{now.AddDate(0, 0, -200), T("images.stale", T("images.builtMonths", 6))},
+	}
+	for _, tt := range tests {
+		if got := (imageInfo{Built: tt.built}).Freshness(now); got != tt.want {
+			t.Errorf("Freshness(%v) = %q, want %q", tt.built, got, tt.want)
+		}
+	}
+}
+
+func TestImageOptions(t *testing.T) {
+	options := imageOptions("rust", imageCatalog)
+	if len(options) != len(imageCatalog)+1 {
+		t.Fatalf("expected every image plus custom, got %d options", len(options))
+	}
+	if options[0].Value != "rust:1-bookworm" || !strings.Contains(options[0].Key, "Rust") || !strings.Contains(options[0].Key, "820") {
+		t.Errorf("expected the language's image first with its size, got %q", options[0].Key)
+	}
+	if last := options[len(options)-1]; last.Value != customImageOption {
+		t.Errorf("expected the custom option last, got %q", last.Value)
+	}
+
+	// A fixed clock is for reproducible output; freshness uses the real time
+	fresh := []imageInfo{{Image: "rust:1-bookworm", Built: time.Now().Add(-48 * time.Hour)}}
+	setClock(t, time.Now().AddDate(2, 0, 0))
+	if got := imageOptions("rust", fresh)[0].Key; strings.Contains(got, T("images.stale", "")) {
+		t.Errorf("--clock shouldn't make an image stale, got %q", got)
+	}
+
+	if got := imageDescription("cpp", imageCatalog); !strings.Contains(got, "CMake") {
+		t.Errorf("expected the image's tools, got %q", got)
+	}
+	if got := imageDescription(customImageOption, imageCatalog); got != T("wizard.stack.customHint") {
+		t.Errorf("expected the custom image hint, got %q", got)
+	}
+}
+
+func TestImageChoice(t *testing.T) {
+	tests := []struct {
+		image, choice, custom string
+	}{
+		{"go:2-1.25-trixie", "go:2-1.25-trixie", ""},
+		{"", "", ""},
+		{"ghcr.io/acme/dev:1", customImageOption, "ghcr.io/acme/dev:1"},
+	}
+	for _, tt := range tests {
+		choice, custom := splitImageChoice(tt.image, imageCatalog)
+		if choice != tt.choice || custom != tt.custom {
+			t.Errorf("splitImageChoice(%q) = %q, %q", tt.image, choice, custom)
+		}
+		if got := joinImageChoice(choice, "  "+custom+" "); got != tt.image {
+			t.Errorf("joinImageChoice(%q, %q) = %q, want %q", choice, custom, got, tt.image)
+		}
+	}
+}
+
+func TestValidateImageRef(t *testing.T) {
+	tests := []struct {
+		ref   string
+		valid bool
+	}{
+		{"ghcr.io/acme/devcontainer:1", true},
+		{"localhost:5000/dev", true},
+		{"registry.example.com/team/dev@sha256:" + strings.Repeat("ab", 32), true},
+		{"go:2-1.25-trixie", false},
+		{"acme/dev:1", false},
+		{"oci://ghcr.io/acme/dev:1", false},
+		{"ghcr.io/Acme/dev", false},
+		{"ghcr.io/acme/dev:bad tag", false},
+	}
+	for _, tt := range tests {
+		if err := validateImageRef(tt.ref); (err == nil) != tt.valid {
+			t.Errorf("validateImageRef(%q) = %v, want valid=%t", tt.ref, err, tt.valid)
+		}
+	}
+}
+
+func TestCustomImageRef(t *testing.T) {
+	for image, want := range map[string]string{
+		"go:2-1.25-trixie":   "mcr.microsoft.com/devcontainers/go:2-1.25-trixie",
+		"ghcr.io/acme/dev:1": "ghcr.io/acme/dev:1",
+		"localhost/dev":      "localhost/dev",
+	} {
+		data := TemplateData{ImageRegistry: "mirror.example.com/devcontainers", DevContainerImage: image}
+		if image == "go:2-1.25-trixie" {
+			data.ImageRegistry = ""
+		}
+		if got := data.ImageRef(); got != want {
+			t.Errorf("ImageRef(%q) = %q, want %q", image, got, want)
+		}
+	}
+
+	// Answers files are checked like the picker; telemetry doesn't see the reference
+	w := WizardData{ProjectName: "x", Description: "y", IncludeDevContainer: true, DevContainerImage: "acme/dev:1"}
+	if err := w.Validate(); err == nil || !strings.Contains(err.Error(), "acme/dev:1") {
+		t.Errorf("expected the reference to be refused, got %v", err)
+	}
+	w.DevContainerImage = "ghcr.io/acme/dev:1"
+	if event := newScaffoldEvent("wizard", w); event.Stack != customImageOption {
+		t.Errorf("expected telemetry to record a custom image, got %q", event.Stack)
+	}
+
+	// The registry allowlist covers custom images
+	cfg := userConfig{AllowedRegistries: []string{"mcr.microsoft.com"}}
+	if v := checkProjectPolicy(cfg, w.ToTemplateData(), nil); len(v) != 1 || v[0].Rule != "allowedRegistries" {
+		t.Errorf("expected ghcr.io to be refused, got %+v", v)
+	}
+}
+
+func TestRunImagesUsage(t *testing.T) {
+	for _, args := range [][]string{{"--json"}, {"update"}, {"refresh", "now"}} {
+		var usage usageError
+		if err := runImages(args); !errors.As(err, &usage) {
+			t.Errorf("runImages(%q) = %v, want a usage error", args, err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package main - info.go
+//
+// PURPOSE:
+// This file implements `seed info`, a summary of how a project was
+// scaffolded for whoever inherits it. It's responsible for:
+// - Reading the manifest: seed version, template set, date, license line
+// - Listing the recorded answers that were set
+// - Listing the installed skills with the seed version that wrote them and
+//   whether they've been modified, deleted or have an update available
+//
+// DESIGN PATTERNS:
+// - Read-only, like `seed status`; status covers every file, info covers the
+//   choices behind them
+// - Answers are listed by their JSON names (the batch answers format), so
+//   they can be copied into a spec or an answers file for `seed list`
+// - Skill versions come from the stamp on disk, falling back to the
+//   generated content in the manifest when the stamp was removed
+//
+// USAGE:
+// info, err := projectInfo(dir)
+// fmt.Print(formatInfo(info))
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Skill states reported by seed info.
+const (
+	skillUnmodified = "unmodified"
+	skillModified   = "modified"
+	skillDeleted    = "deleted"
+	skillUpdate     = "updateAvailable" // Unmodified, but the current seed ships a different version
+)
+
+// Each state is shown as info.skill.<state>.
+
+// projectDetails is what `seed info` reports.
+type projectDetails struct {
+	Manifest  Manifest
+	Templates int          // Template set the project was generated with (0 when no file is stamped)
+	Answers   [][2]string  // Set answers as (JSON name, value), in answers-format order
+	Skills    []skillState // Skills recorded in the manifest, by path
+}
+
+// skillState is one installed skill.
+type skillState struct {
+	Path    string
+	Version string // Seed version that generated it ("" when unknown)
+	State   string
+}
+
+// projectInfo reads the project at dir and describes how it was scaffolded.
+func projectInfo(dir string) (projectDetails, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return projectDetails{}, err
+	}
+	info := projectDetails{Manifest: manifest, Answers: setAnswers(manifest.Answers)}
+	for _, f := range manifest.Files {
+		if st, _, ok := parseStamp([]byte(f.Content)); ok && st.Templates > info.Templates {
+			info.Templates = st.Templates
+		}
+	}
+
+	current, err := skillFiles()
+	if err != nil {
+		return info, err
+	}
+	shipped := map[string][]byte{}
+	for _, f := range current {
+		shipped[f.Path] = f.Content
+	}
+	for _, f := range manifest.Files {
+		if !strings.HasPrefix(f.Path, "skills/") || f.Path == skillsIndexPath {
+			continue
+		}
+		skill := skillState{Path: f.Path, State: skillUnmodified}
+		if st, _, ok := parseStamp([]byte(f.Content)); ok {
+			skill.Version = st.Version
+		}
+		content, err := readGenerated(dir, f.Path)
+		switch {
+		case os.IsNotExist(err):
+			skill.State = skillDeleted
+		case err != nil:
+			return info, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		case hashContent(content) != f.SHA256:
+			skill.State = skillModified
+			if st, _, ok := parseStamp(content); ok {
+				skill.Version = st.Version
+			}
+		default:
+			if latest, ok := shipped[f.Path]; ok && f.Outdated(latest) {
+				skill.State = skillUpdate
+			}
+		}
+		info.Skills = append(info.Skills, skill)
+	}
+	sort.Slice(info.Skills, func(i, j int) bool { return info.Skills[i].Path < info.Skills[j].Path })
+	return info, nil
+}
+
+// setAnswers returns the answers in w that aren't empty, by JSON name.
+func setAnswers(w WizardData) [][2]string {
+	var answers [][2]string
+	v := reflect.ValueOf(w)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if field.IsZero() || name == "" || name == "-" {
+			continue
+		}
+		var value string
+		switch x := field.Interface().(type) {
+		case string:
+			value = x
+		case bool:
+			value = T("info.yes")
+		case []string:
+			value = strings.Join(x, ", ")
+		default:
+			raw, _ := json.Marshal(x)
+			value = string(raw)
+		}
+		answers = append(answers, [2]string{name, value})
+	}
+	return answers
+}
+
+// formatInfo renders project details for the terminal.
+func formatInfo(info projectDetails) string {
+	var b strings.Builder
+	m := info.Manifest
+	b.WriteString(T("status.generated", m.SeedVersion, m.GeneratedAt.Format("2006-01-02")))
+	if info.Templates > 0 {
+		b.WriteString(T("info.templateSet", info.Templates))
+	}
+	b.WriteString("\n")
+	if m.MinSeedVersion != "" {
+		b.WriteString(T("info.minSeedVersion", m.MinSeedVersion) + "\n")
+	}
+	if licenseSPDX(m.Answers.License) != "" {
+		license := m.LicenseInfo()
+		b.WriteString(T("info.license", m.Answers.License, license.Years(), license.Holder) + "\n")
+	}
+
+	b.WriteString("\n" + T("info.answers") + "\n")
+	for _, a := range info.Answers {
+		fmt.Fprintf(&b, "  %-20s %s\n", a[0], a[1])
+	}
+
+	b.WriteString("\n" + T("info.skills") + "\n")
+	if len(info.Skills) == 0 {
+		b.WriteString("  " + T("info.noSkills") + "\n")
+	}
+	for _, s := range info.Skills {
+		version := s.Version
+		if version == "" {
+			version = "?"
+		}
+		fmt.Fprintf(&b, "  %-32s %-10s %s\n", s.Path, version, T("info.skill."+s.State))
+	}
+	return b.String()
+}
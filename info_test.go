@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProjectInfo(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	skill := func(name string) string { return filepath.Join(dir, "skills", name) }
+	if err := os.WriteFile(skill("entropy-guard.md"), []byte("my own checks\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(skill("seed-feedback.md")); err != nil {
+		t.Fatal(err)
+	}
+	// An older seed generated a different seed-ux-eval.md, untouched since
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := RenderedFile{Path: "skills/seed-ux-eval.md", Content: []byte("<!-- seed:generated version=0.9.0 templates=1 sha256=000000000000 -->\nold\n")}
+	m.SetFile(old)
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skill("seed-ux-eval.md"), old.Content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := projectInfo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states := map[string]skillState{}
+	for _, s := range info.Skills {
+		states[s.Path] = s
+	}
+	want := map[string]string{
+		"skills/doc-health-check.md": skillUnmodified,
+		"skills/entropy-guard.md":    skillModified,
+		"skills/seed-feedback.md":    skillDeleted,
+		"skills/seed-ux-eval.md":     skillUpdate,
+	}
+	for path, state := range want {
+		if states[path].State != state {
+			t.Errorf("%s: state = %q, want %q", path, states[path].State, state)
+		}
+	}
+	if v := states["skills/seed-ux-eval.md"].Version; v != "0.9.0" {
+		t.Errorf("seed-ux-eval.md version = %q, want the stamped 0.9.0", v)
+	}
+	if v := states["skills/doc-health-check.md"].Version; v != Version {
+		t.Errorf("doc-health-check.md version = %q, want %q", v, Version)
+	}
+	if info.Templates != templateVersion {
+		t.Errorf("template set = %d, want %d", info.Templates, templateVersion)
+	}
+
+	if want := [][2]string{{"projectName", "statustest"}, {"description", "Status test project"}, {"license", "MIT"}}; !slices.Equal(info.Answers, want) {
+		t.Errorf("answers = %v, want %v", info.Answers, want)
+	}
+	out := formatInfo(info)
+	for _, want := range []string{"Generated by seed " + Version, "License MIT, copyright", "  license              MIT\n", "skills/entropy-guard.md", "update available"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSetAnswersFormatsValues(t *testing.T) {
+	got := setAnswers(WizardData{ProjectName: "x", InitGit: true, Topics: []string{"cli", "go"}, CustomChatTools: []aiTool{{ID: "t"}}})
+	want := map[string]string{"projectName": "x", "initGit": "yes", "topics": "cli, go"}
+	for _, a := range got {
+		if w, ok := want[a[0]]; ok && a[1] != w {
+			t.Errorf("%s = %q, want %q", a[0], a[1], w)
+		}
+		if a[0] == "customChatTools" && !strings.HasPrefix(a[1], `[{"`) {
+			t.Errorf("customChatTools = %q, want JSON", a[1])
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("answers = %v, want 4 set", got)
+	}
+}
@@ -24,6 +24,7 @@ esac
 
 ASSET="seed-${OS}-${ARCH}"
 URL="https://github.com/${REPO}/releases/latest/download/${ASSET}"
+CHECKSUMS_URL="https://github.com/${REPO}/releases/latest/download/checksums.txt"
 
 echo ""
 echo "🌱 seed · installer"
@@ -63,17 +64,37 @@ spin "Creating ${INSTALL_DIR}..." mkdir -p "$INSTALL_DIR"
 
 # Download binary
 TMPFILE="$(mktemp)"
-trap 'rm -f "$TMPFILE"' EXIT
+SUMSFILE="$(mktemp)"
+trap 'rm -f "$TMPFILE" "$SUMSFILE"' EXIT
 
 if command -v curl >/dev/null 2>&1; then
     spin "Downloading ${ASSET} from latest release..." curl -fsSL -o "$TMPFILE" "$URL"
+    curl -fsSL -o "$SUMSFILE" "$CHECKSUMS_URL" 2>/dev/null || : > "$SUMSFILE"
 elif command -v wget >/dev/null 2>&1; then
     spin "Downloading ${ASSET} from latest release..." wget -qO "$TMPFILE" "$URL"
+    wget -qO "$SUMSFILE" "$CHECKSUMS_URL" 2>/dev/null || : > "$SUMSFILE"
 else
     echo "Error: curl or wget is required" >&2
     exit 1
 fi
 
+# Check the download against the release's checksums.txt
+EXPECTED="$(awk -v asset="$ASSET" '$2 == asset { print $1 }' "$SUMSFILE")"
+if [ -z "$EXPECTED" ]; then
+    echo "Warning: no checksum published for ${ASSET}; skipping verification" >&2
+else
+    if command -v sha256sum >/dev/null 2>&1; then
+        ACTUAL="$(sha256sum "$TMPFILE" | awk '{ print $1 }')"
+    else
+        ACTUAL="$(shasum -a 256 "$TMPFILE" | awk '{ print $1 }')"
+    fi
+    if [ "$ACTUAL" != "$EXPECTED" ]; then
+        echo "Error: checksum mismatch for ${ASSET} (got ${ACTUAL}, want ${EXPECTED})" >&2
+        exit 1
+    fi
+    echo "Checksum verified ✓"
+fi
+
 # Install
 spin "Installing to ${INSTALL_DIR}/${BINARY_NAME}..." chmod +x "$TMPFILE"
 mv "$TMPFILE" "${INSTALL_DIR}/${BINARY_NAME}"
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// minisignTestKey is a generated key pair with its minisign public key line.
+type minisignTestKey struct {
+	id   [8]byte
+	priv ed25519.PrivateKey
+	pub  string
+}
+
+func newMinisignTestKey(t *testing.T, id byte) minisignTestKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := minisignTestKey{id: [8]byte{id, 1, 2, 3, 4, 5, 6, 7}, priv: priv}
+	k.pub = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), k.id[:]...), pub...))
+	return k
+}
+
+// sign returns a legacy (non-prehashed) minisign signature file for content.
+func (k minisignTestKey) sign(content []byte, comment string) []byte {
+	sig := ed25519.Sign(k.priv, content)
+	global := ed25519.Sign(k.priv, append(append([]byte{}, sig...), comment...))
+	return []byte("untrusted comment: signature from seed tests\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), k.id[:]...), sig...)) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n")
+}
+
+// withAlgorithm returns sigFile with its signature algorithm replaced.
+func withAlgorithm(t *testing.T, sigFile []byte, alg string) []byte {
+	t.Helper()
+	lines := strings.Split(string(sigFile), "\n")
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(raw, alg)
+	lines[1] = base64.StdEncoding.EncodeToString(raw)
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func TestVerifySHA256(t *testing.T) {
+	content := []byte("hello")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"hex", digest, false},
+		{"prefixed", "sha256:" + digest, false},
+		{"uppercase", strings.ToUpper(digest), false},
+		{"mismatch", strings.Repeat("0", 64), true},
+		{"not hex", "sha256:nope", true},
+		{"truncated", digest[:10], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifySHA256(content, tt.want); (err != nil) != tt.wantErr {
+				t.Errorf("verifySHA256() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	trusted := newMinisignTestKey(t, 1)
+	other := newMinisignTestKey(t, 2)
+	content := []byte(`{"license": "MIT"}`)
+	sig := trusted.sign(content, "timestamp:1 file:config.json")
+
+	tamperedComment := strings.Replace(string(sig), "file:config.json", "file:evil.json", 1)
+	prehashed := withAlgorithm(t, sig, "ED")
+
+	tests := []struct {
+		name    string
+		content []byte
+		sig     []byte
+		keys    []string
+		wantErr string
+	}{
+		{"valid", content, sig, []string{trusted.pub}, ""},
+		{"full .pub file", content, sig, []string{"untrusted comment: minisign public key\n" + trusted.pub + "\n"}, ""},
+		{"one of several keys", content, sig, []string{other.pub, trusted.pub}, ""},
+		{"tampered content", []byte(`{"license": "none"}`), sig, []string{trusted.pub}, "doesn't match"},
+		{"tampered trusted comment", content, []byte(tamperedComment), []string{trusted.pub}, "trusted comment"},
+		{"untrusted key", content, sig, []string{other.pub}, "isn't in trustedKeys"},
+		{"prehashed", content, prehashed, []string{trusted.pub}, "minisign -S -l"},
+		{"malformed", content, []byte("not a signature"), []string{trusted.pub}, "malformed"},
+		{"bad trusted key", content, sig, []string{"nope"}, "public key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyMinisign(tt.content, tt.sig, tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verifyMinisign() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifyMinisign() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignatureSource(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"https://config.example.com/seed.json", "https://config.example.com/seed.json.minisig"},
+		{"/etc/seed/org.json", "/etc/seed/org.json.minisig"},
+		{"git+https://git.example.com/platform/seed-config#web.json", "git+https://git.example.com/platform/seed-config#web.json.minisig"},
+		{"git@git.example.com:platform/seed-config.git", "git@git.example.com:platform/seed-config.git#config.json.minisig"},
+	}
+	for _, tt := range tests {
+		if got := signatureSource(tt.source); got != tt.want {
+			t.Errorf("signatureSource(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestOrgConfigVerification(t *testing.T) {
+	dir := isolateConfig(t)
+	source := filepath.Join(dir, "org.json")
+	content := []byte(`{"license": "Apache-2.0"}`)
+	if err := os.WriteFile(source, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	key := newMinisignTestKey(t, 1)
+
+	// A wrong pin rejects the org config
+	t.Setenv(orgConfigSHA256Env, strings.Repeat("0", 64))
+	if state := fetchOrgConfigCached(source, time.Now()); state.Err == nil || state.Config.License != "" {
+		t.Fatalf("expected a pin mismatch, got %+v", state)
+	}
+	t.Setenv(orgConfigSHA256Env, hex.EncodeToString(sum[:]))
+
+	// With trustedKeys in the user's config, a signature is required
+	if err := saveUserConfig(userConfig{TrustedKeys: []string{key.pub}}); err != nil {
+		t.Fatal(err)
+	}
+	if state := fetchOrgConfigCached(source, time.Now()); state.Err == nil || !strings.Contains(state.Err.Error(), "signature") {
+		t.Fatalf("expected a missing signature error, got %+v", state)
+	}
+
+	if err := os.WriteFile(source+minisignSigSuffix, key.sign(content, "org config"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if state := fetchOrgConfigCached(source, time.Now()); state.Err != nil || state.Config.License != "Apache-2.0" {
+		t.Fatalf("expected the signed, pinned org config, got %+v", state)
+	}
+}
@@ -0,0 +1,158 @@
+// Package main - interrupt.go
+//
+// PURPOSE:
+// This file handles Ctrl+C (SIGINT) and SIGTERM while a project is being
+// written. It's responsible for:
+// - Trapping the signals for the scaffold, skills and git phases
+// - Letting the current phase finish (each file is written atomically), then
+//   rolling back what seed created
+// - Reporting the state the directory was left in
+//
+// DESIGN PATTERNS:
+// - Phases check interrupted() at their boundaries rather than being
+//   cancelled midway, so no file is half-written and git isn't left
+//   mid-commit by seed itself
+// - Rollback removes only what seed created: the whole directory when seed
+//   created it, otherwise the new files and the directories that leaves
+//   empty; pre-existing files are never touched
+// - .git is all or nothing: removed whole when seed ran git init, left alone
+//   when the repository already existed (removing the new objects alone
+//   would leave its index and refs pointing at missing ones)
+// - A second signal isn't trapped, so it exits immediately
+//
+// USAGE:
+// guard := trapInterrupts(func() { ... })
+// defer guard.Stop()
+// if guard.Interrupted() { err = rollbackScaffold(dir, existed, gitExisted, before) }
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// errInterrupted marks a run stopped by SIGINT or SIGTERM.
+var errInterrupted = errors.New("interrupted")
+
+// interruptGuard traps SIGINT and SIGTERM until stopped.
+type interruptGuard struct {
+	signals     chan os.Signal
+	interrupted atomic.Bool
+	stop        sync.Once
+	done        chan struct{}
+}
+
+// trapInterrupts starts trapping SIGINT and SIGTERM. The first one is
+// recorded and reported to notify; after that the signals are released, so
+// another one ends the process as usual.
+func trapInterrupts(notify func()) *interruptGuard {
+	g := &interruptGuard{signals: make(chan os.Signal, 1), done: make(chan struct{})}
+	signal.Notify(g.signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-g.signals:
+			g.interrupted.Store(true)
+			signal.Stop(g.signals)
+			debugf("interrupt received")
+			notify()
+		case <-g.done:
+		}
+	}()
+	return g
+}
+
+// Interrupted reports whether a signal has arrived.
+func (g *interruptGuard) Interrupted() bool {
+	return g.interrupted.Load()
+}
+
+// Stop releases the signals.
+func (g *interruptGuard) Stop() {
+	g.stop.Do(func() {
+		signal.Stop(g.signals)
+		close(g.done)
+	})
+}
+
+// interruptedError describes what an interrupted scaffold left behind.
+type interruptedError struct {
+	Dir       string
+	Removed   int      // Files seed created and removed again
+	RemoveDir bool     // Seed created Dir and removed it entirely
+	Remaining []string // Created files that couldn't be removed
+}
+
+func (e *interruptedError) Error() string {
+	switch {
+	case len(e.Remaining) > 0:
+		return T("interrupt.partial", e.Dir, strings.Join(e.Remaining, ", "))
+	case e.RemoveDir:
+		return T("interrupt.removedDir", e.Dir)
+	}
+	return T("interrupt.rolledBack", e.Removed, e.Dir)
+}
+
+func (e *interruptedError) Unwrap() error { return errInterrupted }
+
+// rollbackScaffold removes what scaffolding created in dir: all of it when
+// dir didn't exist before (existed is false), otherwise the files missing
+// from before and the directories left empty. A repository that existed
+// before (gitExisted) is kept as it is; one seed created is removed.
+func rollbackScaffold(dir string, existed, gitExisted bool, before map[string]struct{}) error {
+	result := &interruptedError{Dir: dir}
+	if !existed {
+		if err := os.RemoveAll(dir); err != nil {
+			result.Remaining = []string{fmt.Sprintf("%s (%v)", dir, err)}
+		} else {
+			result.RemoveDir = true
+		}
+		return result
+	}
+
+	after, err := snapshotProjectFiles(dir)
+	if err != nil {
+		result.Remaining = []string{fmt.Sprintf("%s (%v)", dir, err)}
+		return result
+	}
+	parents := map[string]bool{}
+	for _, file := range createdFileList(before, after) {
+		if file == ".git" || strings.HasPrefix(file, ".git/") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(file))); err != nil && !os.IsNotExist(err) {
+			result.Remaining = append(result.Remaining, file)
+			continue
+		}
+		result.Removed++
+		for p := filepath.Dir(filepath.FromSlash(file)); p != "."; p = filepath.Dir(p) {
+			parents[p] = true
+		}
+	}
+
+	// Deepest first, so a parent is empty by the time it's tried; a
+	// directory that still holds anything stays
+	var dirs []string
+	for p := range parents {
+		dirs = append(dirs, p)
+	}
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+	for _, p := range dirs {
+		_ = os.Remove(filepath.Join(dir, p))
+	}
+
+	if !gitExisted {
+		if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+			result.Remaining = append(result.Remaining, ".git")
+		}
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInterruptGuard(t *testing.T) {
+	notified := make(chan struct{})
+	guard := trapInterrupts(func() { close(notified) })
+	defer guard.Stop()
+
+	self, _ := os.FindProcess(os.Getpid())
+	if err := self.Signal(os.Interrupt); err != nil {
+		t.Skipf("can't signal this process: %v", err)
+	}
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the interrupt wasn't trapped")
+	}
+	if !guard.Interrupted() {
+		t.Error("Interrupted should report the signal")
+	}
+	guard.Stop() // Stopping twice is fine
+}
+
+func TestInterruptedScaffoldRollsBack(t *testing.T) {
+	isolateConfig(t)
+	target := tempDir(t)
+	writeTestFile(t, filepath.Join(target, "notes.txt"), "mine\n")
+	writeTestFile(t, filepath.Join(target, "docs", "keep.md"), "mine\n")
+	before, err := snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answers := WizardData{ProjectName: "stopped", Description: "Interrupted", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie"}
+	calls := 0
+	interruptAfterTemplates := func() bool { calls++; return true }
+	_, err = scaffoldSteps(target, answers, true, before, newPlainProgress(io.Discard), interruptAfterTemplates)
+	if !errors.Is(err, errInterrupted) || calls != 1 {
+		t.Fatalf("expected to stop after the templates, got %v after %d checks", err, calls)
+	}
+	if _, err := os.Stat(filepath.Join(target, "skills")); !os.IsNotExist(err) {
+		t.Error("skills shouldn't be installed once interrupted")
+	}
+
+	err = rollbackScaffold(target, true, false, before)
+	var state *interruptedError
+	if !errors.As(err, &state) || state.Removed == 0 || len(state.Remaining) > 0 || !errors.Is(err, errInterrupted) {
+		t.Fatalf("rollback = %v", err)
+	}
+	after, err := snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 2 {
+		t.Errorf("only the pre-existing files should remain, got %v", after)
+	}
+	if _, err := os.Stat(filepath.Join(target, ".devcontainer")); !os.IsNotExist(err) {
+		t.Error("directories seed created should be removed")
+	}
+	if !strings.Contains(state.Error(), target) {
+		t.Errorf("message should name the directory: %v", state)
+	}
+}
+
+func TestRollbackRemovesCreatedDirectory(t *testing.T) {
+	target := filepath.Join(tempDir(t), "new")
+	writeTestFile(t, filepath.Join(target, "README.md"), "x\n")
+	err := rollbackScaffold(target, false, false, nil)
+	var state *interruptedError
+	if !errors.As(err, &state) || !state.RemoveDir {
+		t.Fatalf("rollback = %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Error("a directory seed created should be removed")
+	}
+	if crashWorthy(err) {
+		t.Error("an interrupt isn't a crash")
+	}
+}
+
+func TestRollbackKeepsExistingRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	isolateConfig(t)
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "Seed Test")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "seed@example.com")
+	}
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := runCommand(dir, commandTimeout(defaultCommandTimeout), "git", args...)
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+	answers := WizardData{ProjectName: "brownfield", Description: "Existing repo", InitGit: true}
+
+	// Interrupted once git add and git commit have run in the user's repository
+	target := tempDir(t)
+	writeTestFile(t, filepath.Join(target, "main.c"), "int main(void) { return 0; }\n")
+	git(target, "init", "-q")
+	git(target, "add", ".")
+	git(target, "commit", "-q", "-m", "existing")
+	before, err := snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scaffoldSteps(target, answers, true, before, newPlainProgress(io.Discard), func() bool { return false }); err != nil {
+		t.Fatal(err)
+	}
+	if err := rollbackScaffold(target, true, true, before); !errors.Is(err, errInterrupted) {
+		t.Fatalf("rollback = %v", err)
+	}
+	git(target, "fsck", "--full", "--strict")
+	if _, err := os.Stat(filepath.Join(target, "main.c")); err != nil {
+		t.Errorf("the user's file should remain: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "AGENTS.md")); !os.IsNotExist(err) {
+		t.Error("files seed created should be removed")
+	}
+
+	// A repository seed created goes with the rest
+	target = tempDir(t)
+	writeTestFile(t, filepath.Join(target, "notes.txt"), "mine\n")
+	before, err = snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scaffoldSteps(target, answers, true, before, newPlainProgress(io.Discard), func() bool { return false }); err != nil {
+		t.Fatal(err)
+	}
+	if err := rollbackScaffold(target, true, false, before); !errors.Is(err, errInterrupted) {
+		t.Fatalf("rollback = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, ".git")); !os.IsNotExist(err) {
+		t.Error("the repository seed initialized should be removed")
+	}
+}
@@ -0,0 +1,158 @@
+// Package main - knownprojects.go
+//
+// PURPOSE:
+// This file remembers the projects seed has created on this machine, to
+// catch a new one that would share a slug with an old one. It's
+// responsible for:
+// - The list of known projects (name, slug, path) in seed's state directory
+// - Recording projects when they're scaffolded, adopted or renamed
+// - Warning when a project's slug matches another known project's
+//
+// DESIGN PATTERNS:
+// - The slug is what Docker volume names are built from (the AI chat state
+//   volumes, the extensions cache prefix) and what a GitHub repository
+//   would likely be called, so two projects with one slug clash there even
+//   in different directories
+// - Advisory only: a warning, never a refusal, since sharing a name can be
+//   intended (a rewrite next to the original)
+// - Projects whose directory is gone are forgotten, so moving or deleting
+//   a project doesn't leave a stale warning behind
+//
+// USAGE:
+// for _, w := range duplicateWarnings(name, targetDir) { ... }
+// rememberProject(name, targetDir)
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// knownProjectsFile lists the projects seed has created, in the state dir.
+const knownProjectsFile = "projects.json"
+
+// knownProject is a project seed created or adopted.
+type knownProject struct {
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Path      string    `json:"path"` // Absolute directory
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// seedStateDir returns seed's per-user state directory: $XDG_STATE_HOME/seed,
+// or ~/.local/state/seed; on macOS and Windows, the config directory.
+func seedStateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "seed"), nil
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return seedConfigDir()
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "seed"), nil
+}
+
+// projectSlug is a project name as Docker volume names and repository
+// names use it: lower case, runs of other characters turned into "-".
+func projectSlug(name string) string {
+	return strings.Trim(volumeNameUnsafe.ReplaceAllString(strings.ToLower(name), "-"), "-._")
+}
+
+// loadKnownProjects reads the known projects; without a list there are none.
+func loadKnownProjects() ([]knownProject, error) {
+	dir, err := seedStateDir()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, knownProjectsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var projects []knownProject
+	if err := json.Unmarshal(raw, &projects); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", knownProjectsFile, err)
+	}
+	return projects, nil
+}
+
+// rememberProject records name at dir, replacing what was known about dir
+// and dropping projects whose directory no longer exists.
+func rememberProject(name, dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	projects, err := loadKnownProjects()
+	if err != nil {
+		return err
+	}
+	kept := []knownProject{}
+	for _, p := range projects {
+		if p.Path != abs && dirExists(p.Path) {
+			kept = append(kept, p)
+		}
+	}
+	kept = append(kept, knownProject{Name: name, Slug: projectSlug(name), Path: abs, CreatedAt: seedNow()})
+
+	stateDir, err := seedStateDir()
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(stateDir, knownProjectsFile), append(raw, '\n'), 0600)
+}
+
+// projectCollisions returns the known projects, other than the one at dir,
+// whose slug is name's and whose directory still exists.
+func projectCollisions(name, dir string) []knownProject {
+	slug := projectSlug(name)
+	abs, err := filepath.Abs(dir)
+	if slug == "" || err != nil {
+		return nil
+	}
+	projects, err := loadKnownProjects()
+	if err != nil {
+		debugf("known projects: %v", err)
+		return nil
+	}
+	var clashes []knownProject
+	for _, p := range projects {
+		if p.Slug == slug && p.Path != abs && dirExists(p.Path) {
+			clashes = append(clashes, p)
+		}
+	}
+	return clashes
+}
+
+// duplicateWarnings describes each known project name's slug collides with.
+func duplicateWarnings(name, dir string) []string {
+	var warnings []string
+	for _, p := range projectCollisions(name, dir) {
+		warnings = append(warnings, T("duplicate.warning", p.Name, p.Path, p.Slug))
+	}
+	return warnings
+}
+
+// dirExists reports whether dir is an existing directory.
+func dirExists(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain keeps the known projects every scaffolding test records out of
+// the real state directory.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "seed-state-")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_STATE_HOME", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestProjectSlug(t *testing.T) {
+	for name, want := range map[string]string{
+		"My API":       "my-api",
+		"my-api":       "my-api",
+		"  Data/Pipe ": "data-pipe",
+		"émoji ✨":      "moji",
+		"!!!":          "",
+	} {
+		if got := projectSlug(name); got != want {
+			t.Errorf("projectSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDuplicateProjects(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	root := t.TempDir()
+	first := filepath.Join(root, "work", "api")
+	second := filepath.Join(root, "play", "api")
+	for _, dir := range []string{first, second} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if w := duplicateWarnings("My API", second); len(w) != 0 {
+		t.Fatalf("expected no warnings before anything is known, got %v", w)
+	}
+	if err := rememberProject("My API", first); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same slug elsewhere warns; the project itself and other slugs don't
+	w := duplicateWarnings("my-api", second)
+	if len(w) != 1 || !strings.Contains(w[0], first) || !strings.Contains(w[0], `"my-api"`) {
+		t.Fatalf("expected a warning naming %s, got %v", first, w)
+	}
+	if w := duplicateWarnings("My API", first); len(w) != 0 {
+		t.Errorf("a project shouldn't collide with itself, got %v", w)
+	}
+	if w := duplicateWarnings("web", second); len(w) != 0 {
+		t.Errorf("another slug shouldn't warn, got %v", w)
+	}
+
+	// Renaming replaces the record for that directory
+	if err := rememberProject("Billing", first); err != nil {
+		t.Fatal(err)
+	}
+	if w := duplicateWarnings("my-api", second); len(w) != 0 {
+		t.Errorf("the renamed project shouldn't collide any more, got %v", w)
+	}
+	projects, err := loadKnownProjects()
+	if err != nil || len(projects) != 1 || projects[0].Slug != "billing" {
+		t.Fatalf("expected one known project, got %+v (%v)", projects, err)
+	}
+
+	// A deleted project is forgotten
+	if err := os.RemoveAll(first); err != nil {
+		t.Fatal(err)
+	}
+	if w := duplicateWarnings("Billing", second); len(w) != 0 {
+		t.Errorf("a deleted project shouldn't warn, got %v", w)
+	}
+	if err := rememberProject("api", second); err != nil {
+		t.Fatal(err)
+	}
+	if projects, _ := loadKnownProjects(); len(projects) != 1 || projects[0].Path != second {
+		t.Errorf("expected the deleted project to be dropped, got %+v", projects)
+	}
+}
+
+func TestScaffoldRemembersProject(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	target := filepath.Join(t.TempDir(), "api")
+	answers := WizardData{ProjectName: "api", Description: "An API.", License: "none"}
+	if _, err := scaffoldProject(target, answers, false, nil, newPlainProgress(io.Discard)); err != nil {
+		t.Fatal(err)
+	}
+	if w := duplicateWarnings("API", filepath.Join(t.TempDir(), "api")); len(w) != 1 {
+		t.Errorf("expected the scaffolded project to be known, got %v", w)
+	}
+}
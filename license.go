@@ -0,0 +1,105 @@
+// Package main - license.go
+//
+// PURPOSE:
+// This file adds SPDX license headers to generated source files. It's
+// responsible for:
+// - Mapping a license answer to its SPDX expression
+// - Knowing each language's line-comment syntax
+// - Prepending "SPDX-License-Identifier: <expr>" to the files that take one
+//
+// DESIGN PATTERNS:
+// - Opt-in (licenseHeaders answer) and only with a license chosen
+// - Runs on rendered files before stamping, like the stamp itself, so the
+//   header is part of the hashed content
+// - Docs and data files (markdown, JSON, LICENSE) never get a header
+//
+// USAGE:
+// files = addLicenseHeaders(files, licenseSPDX(data.License))
+
+package main
+
+import (
+	"bytes"
+	"path"
+	"strings"
+)
+
+// dualLicense is the license answer for MIT/Apache-2.0 dual licensing; it
+// is already an SPDX expression.
+const dualLicense = "MIT OR Apache-2.0"
+
+// licenseSPDX returns the SPDX expression for a license answer, or "" for
+// none.
+func licenseSPDX(license string) string {
+	switch license {
+	case "MIT", "Apache-2.0", dualLicense:
+		return license
+	default:
+		return ""
+	}
+}
+
+// lineComments maps file extensions (or whole base names) to their
+// line-comment prefix.
+var lineComments = map[string]string{
+	".go": "// ", ".rs": "// ", ".js": "// ", ".jsx": "// ", ".ts": "// ", ".tsx": "// ",
+	".java": "// ", ".kt": "// ", ".cs": "// ", ".c": "// ", ".h": "// ", ".cpp": "// ",
+	".hpp": "// ", ".cc": "// ", ".swift": "// ",
+	".py": "# ", ".sh": "# ", ".rb": "# ", ".toml": "# ", ".yaml": "# ", ".yml": "# ",
+	"Dockerfile": "# ", "Makefile": "# ",
+	".sql": "-- ", ".lua": "-- ",
+}
+
+// licenseHeaderComment returns the comment prefix for filePath, or ok=false
+// if it isn't a source file that takes a header.
+func licenseHeaderComment(filePath string) (string, bool) {
+	base := path.Base(filePath)
+	if prefix, ok := lineComments[base]; ok {
+		return prefix, true
+	}
+	prefix, ok := lineComments[path.Ext(base)]
+	return prefix, ok
+}
+
+// addLicenseHeaders prepends an SPDX header (after a shebang, if any) to
+// every source file in files that doesn't already have one. An empty spdx
+// leaves files unchanged.
+func addLicenseHeaders(files []RenderedFile, spdx string) []RenderedFile {
+	if spdx == "" {
+		return files
+	}
+	for i, f := range files {
+		prefix, ok := licenseHeaderComment(f.Path)
+		if !ok || isSymlink(f) || hasLicenseHeader(f.Content) {
+			continue
+		}
+		header := prefix + "SPDX-License-Identifier: " + spdx + "\n"
+
+		var head, rest []byte
+		if bytes.HasPrefix(f.Content, []byte("#!")) {
+			if j := bytes.IndexByte(f.Content, '\n'); j >= 0 {
+				head, rest = f.Content[:j+1], f.Content[j+1:]
+			}
+		} else {
+			rest = f.Content
+		}
+		content := make([]byte, 0, len(f.Content)+len(header))
+		content = append(content, head...)
+		content = append(content, header...)
+		content = append(content, rest...)
+		files[i].Content = content
+	}
+	return files
+}
+
+// hasLicenseHeader reports whether content already carries an SPDX header
+// in its first few lines.
+func hasLicenseHeader(content []byte) bool {
+	lines := strings.SplitN(string(content), "\n", 4)
+	for _, line := range lines[:min(len(lines), 3)] {
+		if strings.Contains(line, "SPDX-License-Identifier:") {
+			return true
+		}
+	}
+	return false
+}
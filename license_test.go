@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddLicenseHeaders(t *testing.T) {
+	files := []RenderedFile{
+		{Path: ".devcontainer/setup.sh", Content: []byte("#!/bin/bash\necho hi\n")},
+		{Path: ".devcontainer/Dockerfile", Content: []byte("FROM scratch\n")},
+		{Path: "src/main.go", Content: []byte("package main\n")},
+		{Path: "src/lib.rs", Content: []byte("// SPDX-License-Identifier: MIT\nfn main() {}\n")},
+		{Path: "README.md", Content: []byte("# readme\n")},
+		{Path: ".devcontainer/devcontainer.json", Content: []byte("{}\n")},
+	}
+	want := []string{
+		"#!/bin/bash\n# SPDX-License-Identifier: Apache-2.0\necho hi\n",
+		"# SPDX-License-Identifier: Apache-2.0\nFROM scratch\n",
+		"// SPDX-License-Identifier: Apache-2.0\npackage main\n",
+		"// SPDX-License-Identifier: MIT\nfn main() {}\n",
+		"# readme\n",
+		"{}\n",
+	}
+	for i, f := range addLicenseHeaders(files, "Apache-2.0") {
+		if string(f.Content) != want[i] {
+			t.Errorf("%s:\ngot  %q\nwant %q", f.Path, f.Content, want[i])
+		}
+	}
+}
+
+func TestLicenseHeadersInProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    TemplateData
+		wantHdr bool
+	}{
+		{"opted in", TemplateData{License: "MIT", LicenseHeaders: true}, true},
+		{"not opted in", TemplateData{License: "MIT"}, false},
+		{"no license", TemplateData{License: "none", LicenseHeaders: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.ProjectName = "test-spdx"
+			tt.data.Description = "A test project"
+			tt.data.IncludeDevContainer = true
+			tt.data.DevContainerImage = "go:2-1.25-trixie"
+			s, err := NewScaffolder()
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := s.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range files {
+				if f.Path != ".devcontainer/Dockerfile" {
+					continue
+				}
+				if got := strings.Contains(string(f.Content), "# SPDX-License-Identifier: MIT\n"); got != tt.wantHdr {
+					t.Errorf("Dockerfile header = %t, want %t:\n%s", got, tt.wantHdr, f.Content)
+				}
+			}
+		})
+	}
+}
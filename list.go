@@ -0,0 +1,130 @@
+// Package main - list.go
+//
+// PURPOSE:
+// This file implements `seed list`, which shows what a configuration would
+// generate without scaffolding anything. It's responsible for:
+// - Loading answers from a JSON file (the batch spec's "answers" object) or
+//   from a project's manifest
+// - Listing the enabled components and the exact files, as text or JSON
+//
+// DESIGN PATTERNS:
+// - Read-only: renders in memory like --print, writes nothing
+// - Answers files go through the same config defaults and validation as
+//   batch projects (completeAnswers), so the list matches what --batch
+//   would write
+// - Files are sorted, so listings diff cleanly and can feed generated docs
+//
+// USAGE:
+// listing, err := listProject(answers, answers.ToTemplateData())
+// fmt.Print(formatListing(listing))
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// listedComponent is one enabled component, with its setting if it has one.
+type listedComponent struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// projectListing is what `seed list` reports.
+type projectListing struct {
+	Components []listedComponent `json:"components"`
+	Files      []string          `json:"files"`
+}
+
+// loadAnswersFile reads answers from a JSON file, applies config defaults
+// and validates them. A missing project name defaults to the file's name.
+func loadAnswersFile(path string) (WizardData, error) {
+	var w WizardData
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return w, fmt.Errorf("%s: answers files are JSON (the batch spec's \"answers\" object); YAML isn't supported", path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return w, fmt.Errorf("failed to read answers: %w", err)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&w); err != nil {
+		return w, fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+	if strings.TrimSpace(w.ProjectName) == "" {
+		w.ProjectName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	cfg, _ := loadConfig()
+	if err := completeAnswers(&w, cfg); err != nil {
+		return w, fmt.Errorf("%s: %w", path, err)
+	}
+	return w, nil
+}
+
+// listProject lists the components w enables and the files data renders to,
+// including the seed manifest.
+func listProject(w WizardData, data TemplateData) (projectListing, error) {
+	files, err := renderProjectFiles(data)
+	if err != nil {
+		return projectListing{}, err
+	}
+	listing := projectListing{Components: enabledComponents(w), Files: []string{manifestPath}}
+	for _, f := range files {
+		listing.Files = append(listing.Files, f.Path)
+	}
+	sort.Strings(listing.Files)
+	return listing, nil
+}
+
+// enabledComponents returns the components w turns on, in wizard order.
+func enabledComponents(w WizardData) []listedComponent {
+	var components []listedComponent
+	add := func(on bool, name, value string) {
+		if on {
+			components = append(components, listedComponent{Name: name, Value: value})
+		}
+	}
+	add(w.Language != "", "language", w.Language)
+	for _, id := range selectedExtras(w) {
+		value := ""
+		if id == "devcontainer" {
+			value = w.DevContainerImage
+		}
+		add(true, id, value)
+	}
+	add(len(w.Standards) > 0, "standards", strings.Join(w.Standards, ", "))
+	add(len(w.ChatTools) > 0, "chatTools", strings.Join(w.ChatTools, ", "))
+	add(w.CommitConvention != "" && w.CommitConvention != "none", "commitConvention", w.CommitConvention)
+	add(w.Maturity != "", "maturity", w.Maturity)
+	add(licenseSPDX(w.License) != "", "license", w.License)
+	add(w.LicenseHeaders && licenseSPDX(w.License) != "", "licenseHeaders", "")
+	return components
+}
+
+// formatListing renders a listing for the terminal.
+func formatListing(l projectListing) string {
+	var b strings.Builder
+	b.WriteString(T("list.components") + "\n")
+	if len(l.Components) == 0 {
+		b.WriteString("  " + T("list.noComponents") + "\n")
+	}
+	for _, c := range l.Components {
+		if c.Value == "" {
+			fmt.Fprintf(&b, "  %s\n", c.Name)
+		} else {
+			fmt.Fprintf(&b, "  %-18s %s\n", c.Name, c.Value)
+		}
+	}
+	b.WriteString("\n" + T("list.files", len(l.Files)) + "\n")
+	for _, f := range l.Files {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+	return b.String()
+}
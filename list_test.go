@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestListAnswersFile(t *testing.T) {
+	dir := isolateConfig(t)
+	if err := saveUserConfig(userConfig{License: "MIT", Require: []string{"git"}}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "api.json")
+	writeTestFile(t, path, `{"description": "An API", "language": "go", "includeDevContainer": true, "devContainerImage": "go:2-1.25-trixie", "branchProtection": true}`)
+
+	answers, err := loadAnswersFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answers.ProjectName != "api" || answers.License != "MIT" || !answers.InitGit {
+		t.Errorf("config defaults not applied: %+v", answers)
+	}
+	listing, err := listProject(answers, answers.ToTemplateData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, c := range listing.Components {
+		names = append(names, c.Name)
+	}
+	if want := []string{"language", "devcontainer", "git", "branchProtection", "license"}; !slices.Equal(names, want) {
+		t.Errorf("components = %v, want %v", names, want)
+	}
+	for _, want := range []string{manifestPath, "README.md", "LICENSE", ".devcontainer/devcontainer.json", branchProtectionPath} {
+		if !slices.Contains(listing.Files, want) {
+			t.Errorf("files missing %s: %v", want, listing.Files)
+		}
+	}
+	if !slices.IsSorted(listing.Files) {
+		t.Errorf("files aren't sorted: %v", listing.Files)
+	}
+	if out := formatListing(listing); !strings.Contains(out, "  devcontainer       go:2-1.25-trixie\n") || !strings.Contains(out, "\nFiles (") {
+		t.Errorf("unexpected listing:\n%s", out)
+	}
+}
+
+func TestListMatchesScaffold(t *testing.T) {
+	isolateConfig(t)
+	dir := mustScaffoldProject(t)
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing, err := listProject(m.Answers, m.TemplateData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written []string
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		written = append(written, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(written)
+	if !slices.Equal(listing.Files, written) {
+		t.Errorf("seed list = %v\nscaffolded = %v", listing.Files, written)
+	}
+}
+
+func TestLoadAnswersFileErrors(t *testing.T) {
+	dir := isolateConfig(t)
+	tests := []struct {
+		name, file, content, wantErr string
+	}{
+		{"yaml", "a.yaml", "projectName: x", "YAML isn't supported"},
+		{"unknown field", "a.json", `{"description": "x", "colour": "red"}`, "unknown field"},
+		{"invalid answers", "a.json", `{"description": "x", "language": "cobol"}`, "cobol"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeTestFile(t, path, tt.content)
+			if _, err := loadAnswersFile(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -17,16 +17,41 @@
 // seed <directory>
 // seed myproject     -> Creates ./myproject/
 // seed ~/dev/myapp   -> Creates ~/dev/myapp/
+// seed --output-archive myapp.tar.gz -> Writes the project to an archive
+// seed --print myapp -> Prints the project tree and contents to stdout
+// seed --batch spec.json -> Scaffolds every project listed in the spec
+// seed --sync myapp  -> Adds files newer templates and skills generate, touching nothing else
+// seed add package api -> Adds a package to the enclosing monorepo workspace
+// seed status        -> Reports drift from what seed generated
+// seed info          -> Summarizes how a project was scaffolded
+// seed list --answers a.json -> Lists the components and files answers generate
+// seed diff          -> Shows diffs from project files to current templates
+// seed regen README.md -> Re-renders one generated file from recorded answers
+// seed upgrade       -> Applies current templates, merging with local edits
+// seed rename new-name -> Renames the project wherever seed put its name
+// seed adopt ~/src/legacy -> Adds the agentic docs to an existing repository
+// seed profile export go-service -> Shares a project's answers as a wizard profile
+// seed --profile go-service my-api -> Starts the wizard from a saved profile
+// seed --clock 2026-01-01 --batch spec.json -> Byte-identical output for the same answers
+// seed doctor        -> Checks the environment (git, docker, gh, terminal)
+// seed verify        -> Builds the generated dev container (devcontainer CLI)
+// seed verify --manifest --json -> Checks generated files against the manifest (CI gate)
+// seed images refresh -> Reads dev container image sizes and build dates
+// seed telemetry off -> Opts out of anonymous usage stats
+// seed templates eject my-pack -> Writes the embedded templates and skills to edit
 
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
-	"os/exec"
+	"path"
 	"path/filepath"
+	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
 
@@ -38,163 +63,1443 @@ import (
 var (
 	successStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("2")) // green
 	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))            // gray
+	warnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))            // yellow
 )
 
 // Version is set at build time via ldflags. Falls back to "dev" for local builds.
 var Version = "dev"
 
+// cliOptions holds everything parsed from the command line.
+type cliOptions struct {
+	TargetDir     string   // Project directory (archive root name in archive mode)
+	OutputArchive string   // --output-archive: write a .tar.gz/.zip instead of a directory
+	Print         bool     // --print: write tree + contents to stdout instead of a directory
+	BatchSpec     string   // --batch: scaffold every project listed in a spec file
+	Open          bool     // --open: open the new project in an editor when done
+	ReportOnly    bool     // --report-only: warn about org policy violations instead of failing
+	Sync          bool     // --sync: add missing files to an existing project, touching nothing else
+	Disabled      []string // --no-<component>: components to leave out (disable.go)
+	Profile       string   // --profile: saved profile the wizard starts from (profile.go)
+	Clock         string   // --clock: fixed time for generated output (clock.go)
+	Command       string   // Subcommand name (e.g. "add"); empty for the scaffold flow
+	CommandArgs   []string // Arguments after the subcommand name
+}
+
+// subcommands maps `seed <name> ...` to its handler. Each handler parses its
+// own arguments; the default (no subcommand) is the scaffold wizard.
+var subcommands = map[string]func(args []string) error{
+	"add":       runAdd,
+	"status":    runStatus,
+	"info":      runInfo,
+	"list":      runList,
+	"diff":      runDiff,
+	"regen":     runRegen,
+	"upgrade":   runUpgrade,
+	"rename":    runRename,
+	"adopt":     runAdopt,
+	"profile":   runProfile,
+	"doctor":    runDoctor,
+	"verify":    runVerify,
+	"images":    runImages,
+	"telemetry": runTelemetry,
+	"bundle":    runBundle,
+	"templates": runTemplates,
+}
+
+// versionCheckExempt are the subcommands that run on a seed older than
+// config minSeedVersion, since they help diagnose or fix it.
+var versionCheckExempt = map[string]bool{
+	"doctor":    true,
+	"telemetry": true,
+	"bundle":    true,
+}
+
 type usageError struct {
-	msg string
+	msg   string
+	usage string // Usage line to show; defaults to the top-level usage
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func main() {
+	// Pick the UI language before anything is printed (a bad config is
+	// reported by the commands that use it, not here)
+	cfg, _ := loadUserConfig()
+	setLocale(detectLocale(cfg))
+
+	// A panic is always a bug: report it and offer a diagnostic bundle
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("internal error: %v", r)
+			fmt.Fprintln(os.Stderr, formatErrorOutput(displayVersion(), err))
+			offerCrashReport(err, string(debug.Stack()))
+			os.Exit(2)
+		}
+	}()
+
+	// Run main logic and exit with appropriate code
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, formatErrorOutput(displayVersion(), err))
+		if crashWorthy(err) {
+			offerCrashReport(err, "")
+		}
+		os.Exit(1)
+	}
+}
+
+func displayVersion() string {
+	return strings.TrimPrefix(Version, "v")
+}
+
+func renderStartBanner(version string) string {
+	return T("banner.start", brandName(), version)
+}
+
+func renderErrorBanner(version, message string) string {
+	return T("banner.error", brandName(), version, message)
+}
+
+func renderScaffoldingLine() string {
+	return T("banner.scaffolding")
+}
+
+func formatErrorOutput(version string, err error) string {
+	var b strings.Builder
+	b.WriteString(renderErrorBanner(version, err.Error()))
+
+	var usageErr usageError
+	if errors.As(err, &usageErr) {
+		usage := usageErr.usage
+		if usage == "" {
+			usage = "seed <directory>"
+		}
+		b.WriteString("\n\n" + T("banner.usage", usage))
+	}
+
+	return b.String()
+}
+
+// run contains the main program logic.
+// Separated from main() to enable clean error handling and testing.
+//
+// Flow:
+// 1. Parse CLI arguments -> get target directory
+// 2. Run TUI wizard -> collect user input
+// 3. Review the rendered files -> confirm or cancel (terminal only)
+// 4. Initialize scaffolder -> prepare template engine
+// 5. Scaffold project -> render templates and write files
+// 6. Print success message
+//
+// Returns:
+// - error: If any step fails
+func run() error {
+	// Step 1: Parse command-line arguments
+	opts, err := parseArgs()
+	if err != nil {
+		return err
+	}
+	debugf("args: %s", strings.Join(sanitizeArgs(os.Args[1:]), " "))
+	policyReportOnly = opts.ReportOnly
+	if opts.Clock != "" {
+		fixedClock, _ = parseClock(opts.Clock) // Validated by parseArgs
+	}
+	if !versionCheckExempt[opts.Command] {
+		cfg, _ := loadConfig()
+		if err := enforceSeedVersion(checkSeedVersion(cfg.MinSeedVersion, T("version.orgPin"))); err != nil {
+			return err
+		}
+	}
+	if opts.Command != "" {
+		return subcommands[opts.Command](opts.CommandArgs)
+	}
+	if len(opts.Disabled) > 0 {
+		cfg, _ := loadConfig()
+		if err := checkDisabled(opts.Disabled, cfg.Require); err != nil {
+			return err
+		}
+	}
+	if opts.BatchSpec != "" {
+		return runBatch(opts)
+	}
+	if opts.Sync {
+		return runSync(opts)
+	}
+
+	// Everything below runs the wizard; catch environment problems first
+	if err := preflight(); err != nil {
+		return err
+	}
+	if opts.OutputArchive != "" {
+		return runArchive(opts)
+	}
+	if opts.Print {
+		return runPrint(opts)
+	}
+	targetDir := opts.TargetDir
+	preset, err := profilePreset(opts.Profile)
+	if err != nil {
+		return err
+	}
+
+	// Step 2: Show startup context
+	fmt.Println(renderStartBanner(displayVersion()))
+	fmt.Println()
+
+	targetDirExisted, err := targetDirectoryExists(targetDir)
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Check target directory and confirm if non-empty
+	allowNonEmpty, err := checkTargetDir(targetDir)
+	if err != nil {
+		return err
+	}
+
+	// Capture existing files before scaffolding so we can report created files by phase.
+	beforeFiles, err := snapshotProjectFiles(targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to inspect existing files: %w", err)
+	}
+
+	// Step 4: Run interactive wizard
+	session, err := runWizardSession(targetDir, filepath.Base(targetDir), opts.Disabled, preset)
+	if err != nil {
+		// User cancelled (Ctrl+C) or validation error
+		return fmt.Errorf("%s: %w", T("flow.wizardCancelled"), err)
+	}
+	defer session.Keep()
+	wizardData := session.Answers
+	for _, w := range duplicateWarnings(wizardData.ProjectName, targetDir) {
+		fmt.Println(warnStyle.Render("! " + w))
+	}
+
+	// Step 5: Review the files before anything is written, asking first if
+	// there are more of them than expected
+	if err := guardProjectSize(wizardData, true); err != nil {
+		return err
+	}
+	if isTerminal(os.Stdout) {
+		ok, err := reviewProject(targetDir, wizardData, beforeFiles)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errAborted
+		}
+	}
+
+	progress := newProgress(os.Stdout)
+	if !targetDirExisted {
+		progress.Step(T("flow.createdDir", targetDir))
+	}
+
+	// Steps 6-9: Scaffold templates, install skills, optionally init git
+	report, err := scaffoldProject(targetDir, wizardData, allowNonEmpty, beforeFiles, progress)
+	progress.Done(err)
+	if err != nil {
+		return err
+	}
+	session.Done()
+	recordScaffold("wizard", wizardData, true)
+	if err := recordAudit("wizard", targetDir, report.Answers); err != nil {
+		fmt.Println(warnStyle.Render(T("audit.failed", err)))
+	}
+
+	// In place of a bare "Done.": what to run next, for this project
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return err
+	}
+	nextSteps, err := renderNextSteps(scaffolder, targetDir, wizardData, len(report.GitActions) > 0)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Print(nextSteps)
+
+	// The project is written either way; failing to open it is only a warning
+	if opts.Open {
+		if err := openProject(targetDir, wizardData.IncludeDevContainer); err != nil {
+			fmt.Println(warnStyle.Render(T("open.failed", err)))
+		}
+	}
+
+	return nil
+}
+
+// scaffoldReport summarises what scaffoldProject did, in phase order.
+type scaffoldReport struct {
+	Created          []string // Files created (templates first, then skills)
+	GitActions       []string // Git commands that ran successfully
+	ExtensionsVolume string   // Docker volume caching VS Code extensions ("" when none)
+
+	PolicyViolations []policyViolation // Org rules the project breaks (only written with --report-only)
+	Answers          WizardData        // The answers as recorded in the manifest
+}
+
+// scaffoldProject runs the non-interactive half of seed: render and write
+// templates, install skills, record the manifest, and optionally initialize
+// git. Phases, template files and git actions are reported to progress as
+// they happen; skills and the manifest are reported once their phase ends
+// (pass newPlainProgress(io.Discard) to stay quiet). The caller calls Done.
+//
+// beforeFiles is the snapshot taken before anything was written, so
+// pre-existing files are never reported as created.
+//
+// Ctrl+C or SIGTERM lets the current phase finish, then rolls back what was
+// created (interrupt.go); the error says what state the directory is in.
+func scaffoldProject(targetDir string, wizardData WizardData, allowNonEmpty bool, beforeFiles map[string]struct{}, progress progressReporter) (scaffoldReport, error) {
+	existed, err := targetDirectoryExists(targetDir)
+	if err != nil {
+		return scaffoldReport{}, err
+	}
+	_, err = os.Lstat(filepath.Join(targetDir, ".git"))
+	gitExisted := err == nil
+	guard := trapInterrupts(func() { progress.Step(warnStyle.Render(T("interrupt.finishing"))) })
+	defer guard.Stop()
+
+	report, err := scaffoldSteps(targetDir, wizardData, allowNonEmpty, beforeFiles, progress, guard.Interrupted)
+	if guard.Interrupted() {
+		return report, rollbackScaffold(targetDir, existed, gitExisted, beforeFiles)
+	}
+	return report, err
+}
+
+// scaffoldSteps runs the phases of scaffoldProject, stopping at the next
+// phase boundary once interrupted reports true.
+func scaffoldSteps(targetDir string, wizardData WizardData, allowNonEmpty bool, beforeFiles map[string]struct{}, progress progressReporter, interrupted func() bool) (scaffoldReport, error) {
+	var report scaffoldReport
+	progress.Phase(T("progress.templates"))
+
+	// Initialize scaffolder with embedded templates
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		// This should never happen if templates are valid
+		return report, fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	// Template files are reported as each write finishes; the scaffolder
+	// serializes these calls, so report needs no locking
+	created := make(map[string]struct{}, len(beforeFiles))
+	for file := range beforeFiles {
+		created[file] = struct{}{}
+	}
+	scaffolder.OnProgress = func(e ProgressEvent) {
+		switch e.Kind {
+		case ProgressPhase:
+			debugf("scaffold phase: %s", e.Phase)
+		case ProgressFileFinished:
+			debugf("wrote %s (%d bytes, err=%v)", e.Path, e.Bytes, e.Err)
+			if e.Err != nil {
+				progress.Step(warnStyle.Render("✗ " + e.Err.Error()))
+				return
+			}
+			if _, existed := created[e.Path]; existed {
+				return
+			}
+			created[e.Path] = struct{}{}
+			progress.Step(successStyle.Render("✓") + " " + T("flow.createdSize", e.Path, formatSize(int64(e.Bytes))))
+			report.Created = append(report.Created, e.Path)
+		}
+	}
+
+	// Name the extensions cache volume after this project's path; the name
+	// is recorded with the answers so re-rendering doesn't depend on location
+	if wizardData.IncludeDevContainer && !wizardData.NoExtensionsCache && usesVSCode(wizardData.IDE) {
+		if wizardData.ExtensionsVolume == "" {
+			wizardData.ExtensionsVolume = extensionsVolumeName(wizardData.ProjectName, targetDir)
+		}
+		report.ExtensionsVolume = wizardData.ExtensionsVolume
+	}
+
+	// Convert wizard data to template data and scaffold
+	templateData := wizardData.ToTemplateData()
+	templateData.Year = seedNow().Year()
+	scaffolder.Validate = func(files []RenderedFile) error {
+		skills, err := projectSkillFiles(templateData)
+		if err != nil {
+			return err
+		}
+		cfg, _ := loadConfig()
+		violations := checkProjectPolicy(cfg, templateData, projectPaths(slices.Concat(files, skills), beforeFiles))
+		if err := enforceProjectPolicy(violations); err != nil {
+			return err
+		}
+		for _, v := range violations {
+			progress.Step(warnStyle.Render(T("policy.reportOnly", v.Detail)))
+		}
+		report.PolicyViolations = violations
+		return nil
+	}
+	written, err := scaffolder.ScaffoldFiles(targetDir, templateData, allowNonEmpty)
+	if err != nil {
+		return report, fmt.Errorf("failed to scaffold project: %w", err)
+	}
+	debugf("wrote %d template files", len(written))
+
+	if report.ExtensionsVolume != "" {
+		progress.Step(dimStyle.Render(T("flow.extensionsVolume", report.ExtensionsVolume)))
+	}
+	if interrupted() {
+		return report, errInterrupted
+	}
+
+	// Install agent skills into the project, unless left out
+	if !wizardData.NoSkills {
+		progress.Phase(T("progress.skills"))
+		skillsReport, err := installSkillsWithReport(targetDir)
+		if err != nil {
+			return report, fmt.Errorf("failed to install skills: %w", err)
+		}
+		debugf("installed skills (%d skipped)", len(skillsReport.Skipped))
+		skills, err := skillFiles()
+		if err != nil {
+			return report, err
+		}
+		if skills, err = dropIgnored(targetDir, skills); err != nil {
+			return report, err
+		}
+		for _, skill := range skills {
+			if !slices.Contains(skillsReport.Skipped, path.Base(skill.Path)) {
+				written = append(written, skill)
+			}
+		}
+	}
+
+	// Record what was generated so later commands (e.g. seed status) can detect drift
+	report.Answers = wizardData
+	m := newManifest(wizardData, templateData.Year, written)
+	cfg, _ := loadConfig()
+	m.MinSeedVersion = cfg.MinSeedVersion
+	if err := writeManifest(targetDir, m); err != nil {
+		return report, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	debugf("wrote %s", manifestPath)
+	if err := rememberProject(wizardData.ProjectName, targetDir); err != nil {
+		debugf("failed to record known project: %v", err)
+	}
+
+	afterSkillsFiles, err := snapshotProjectFiles(targetDir)
+	if err != nil {
+		return report, fmt.Errorf("failed to inspect created files: %w", err)
+	}
+	for _, file := range createdFileList(created, afterSkillsFiles) {
+		progress.Step(successStyle.Render("✓") + " " + T("flow.created", file))
+		report.Created = append(report.Created, file)
+	}
+	if interrupted() {
+		return report, errInterrupted
+	}
+
+	// Optionally initialize git repository (skipped, not failed, without git)
+	if wizardData.InitGit && !detectTools().Git {
+		progress.Step(dimStyle.Render(T("flow.gitSkipped")))
+	} else if wizardData.InitGit {
+		progress.Phase(T("progress.git"))
+		gitActions, err := initGitRepo(targetDir, wizardData.ProjectName, wizardData.CommitConvention, executablePaths(written))
+		report.GitActions = gitActions
+		if err != nil {
+			return report, fmt.Errorf("failed to initialize git: %w", err)
+		}
+		for _, action := range gitActions {
+			progress.Step(successStyle.Render("✓") + " " + action)
+		}
+		// A plain hook isn't committed, so seed installs it in the new repository
+		if wizardData.DocGuard && wizardData.HookFramework == "" {
+			action, err := installDocGuardHook(targetDir)
+			if err != nil {
+				progress.Step(warnStyle.Render(T("flow.docGuardHookFailed", err)))
+			} else {
+				report.GitActions = append(report.GitActions, action)
+				progress.Step(successStyle.Render("✓") + " " + action)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// runArchive runs the wizard and writes the rendered project (including skills)
+// to an archive instead of a directory. Nothing is written to the working tree
+// apart from the archive itself, so git initialization is skipped.
+func runArchive(opts cliOptions) error {
+	rootName := opts.TargetDir
+	if rootName == "" {
+		rootName = archiveRootName(opts.OutputArchive)
+	}
+	rootName = filepath.Base(rootName)
+
+	if _, err := detectArchiveFormat(opts.OutputArchive); err != nil {
+		return usageError{msg: err.Error()}
+	}
+	if _, err := os.Stat(opts.OutputArchive); err == nil {
+		return fmt.Errorf("%s already exists", opts.OutputArchive)
+	}
+
+	preset, err := profilePreset(opts.Profile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(renderStartBanner(displayVersion()))
+	fmt.Println()
+
+	session, err := runWizardSession(opts.OutputArchive, rootName, opts.Disabled, preset)
+	if err != nil {
+		return fmt.Errorf("%s: %w", T("flow.wizardCancelled"), err)
+	}
+	defer session.Keep()
+	wizardData := session.Answers
+
+	fmt.Println(renderScaffoldingLine())
+	fmt.Println()
+
+	templateData := wizardData.ToTemplateData()
+	templateData.Year = seedNow().Year()
+	files, err := renderProjectFiles(templateData)
+	if err != nil {
+		return err
+	}
+	cfg, _ := loadConfig()
+	if err := confirmOutputSize(files, cfg); err != nil {
+		return err
+	}
+	m := newManifest(wizardData, templateData.Year, files)
+	m.MinSeedVersion = cfg.MinSeedVersion
+	manifest, err := manifestFile(m)
+	if err != nil {
+		return err
+	}
+	files = append(files, manifest)
+
+	violations := checkProjectPolicy(cfg, templateData, projectPaths(files, nil))
+	if err := enforceProjectPolicy(violations); err != nil {
+		return err
+	}
+	for _, v := range violations {
+		fmt.Println(warnStyle.Render(T("policy.reportOnly", v.Detail)))
+	}
+
+	if err := writeArchive(opts.OutputArchive, rootName, files); err != nil {
+		return err
+	}
+	session.Done()
+
+	fmt.Println(successStyle.Render("✓") + " " + T("flow.archiveWritten", opts.OutputArchive, len(files), rootName))
+	if wizardData.InitGit {
+		fmt.Println(dimStyle.Render(T("flow.archiveNoGit")))
+	}
+	recordScaffold("archive", wizardData, true)
+	if err := recordAudit("archive", opts.OutputArchive, wizardData); err != nil {
+		fmt.Println(warnStyle.Render(T("audit.failed", err)))
+	}
+	fmt.Println(T("flow.done"))
+
+	return nil
+}
+
+// addUsage is shown for `seed add` usage errors.
+const addUsage = "seed add package <name> [--dir <path>] [--description <text>]"
+
+// addLicenseUsage is shown for `seed add license` usage errors.
+const addLicenseUsage = `seed add license <none|MIT|Apache-2.0|"MIT OR Apache-2.0"> [directory] [--yes]`
+
+// runAdd handles `seed add <component>`.
+func runAdd(args []string) error {
+	switch {
+	case len(args) > 0 && args[0] == "package":
+		return runAddPackage(args)
+	case len(args) > 0 && args[0] == "license":
+		return runAddLicense(args[1:])
+	}
+	return usageError{msg: T("args.addComponent"), usage: addUsage + "\n       " + addLicenseUsage}
+}
+
+// runAddPackage handles `seed add package <name>`: it adds a package to the
+// workspace enclosing the current directory.
+func runAddPackage(args []string) error {
+	var opts packageOptions
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--dir" || arg == "--description":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.needsValue", arg), usage: addUsage}
+			}
+			i++
+			if arg == "--dir" {
+				opts.Dir = args[i]
+			} else {
+				opts.Description = args[i]
+			}
+		case strings.HasPrefix(arg, "--dir="):
+			opts.Dir = strings.TrimPrefix(arg, "--dir=")
+		case strings.HasPrefix(arg, "--description="):
+			opts.Description = strings.TrimPrefix(arg, "--description=")
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: addUsage}
+		case opts.Name == "":
+			opts.Name = arg
+		default:
+			return usageError{msg: T("args.tooMany"), usage: addUsage}
+		}
+	}
+	if opts.Name == "" {
+		return usageError{msg: T("args.missingPackageName"), usage: addUsage}
+	}
+	if err := validatePackageName(opts.Name); err != nil {
+		return usageError{msg: err.Error(), usage: addUsage}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	ws, err := detectWorkspace(cwd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(T("package.start", brandName(), displayVersion(), opts.Name, ws.Kind, ws.Root) + "\n")
+
+	if strings.TrimSpace(opts.Description) == "" {
+		err := huh.NewText().
+			Title(T("package.description")).
+			CharLimit(500).
+			Value(&opts.Description).
+			Validate(validateDescription).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+	}
+	opts.Description = strings.TrimSpace(opts.Description)
+
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+
+	report, err := addPackage(scaffolder, ws, opts)
+	for _, file := range report.Created {
+		fmt.Println(successStyle.Render("✓") + " " + T("flow.created", file))
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.Registered:
+		fmt.Println(successStyle.Render("✓") + " " + T("package.registered", report.Dir, filepath.Base(ws.File)))
+	case report.CoveredBy != "":
+		fmt.Println(dimStyle.Render(T("package.covered", filepath.Base(ws.File), report.Dir, report.CoveredBy)))
+	}
+	switch report.RootAgents {
+	case rootAgentsUpdated:
+		fmt.Println(successStyle.Render("✓") + " " + T("package.listed", report.Dir, rootAgentsFile))
+	case rootAgentsConflict:
+		fmt.Println(warnStyle.Render(T("package.listConflict", rootAgentsFile)))
+	case rootAgentsUnmanaged:
+		fmt.Println(dimStyle.Render(T("package.linkHint", report.Dir, rootAgentsFile)))
+	}
+	fmt.Println(T("flow.done"))
+	return nil
+}
+
+// runAddLicense handles `seed add license <license> [dir]`: it adds or
+// switches the license of a scaffolded project, after showing what changes.
+func runAddLicense(args []string) error {
+	var yes bool
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--yes" || arg == "-y":
+			yes = true
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: addLicenseUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return usageError{msg: T("args.missingLicense"), usage: addLicenseUsage}
+	}
+	if len(positional) > 2 {
+		return usageError{msg: T("args.tooMany"), usage: addLicenseUsage}
+	}
+	license, dir := positional[0], "."
+	if len(positional) == 2 {
+		dir = positional[1]
+	}
+	if license != "none" && licenseSPDX(license) == "" {
+		return usageError{msg: T("args.unknownLicense", license), usage: addLicenseUsage}
+	}
+
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	plan, err := planRelicense(scaffolder, dir, license)
+	if err != nil {
+		return err
+	}
+	if plan.From == plan.To {
+		fmt.Println(T("license.unchanged", plan.To))
+		return nil
+	}
+
+	fmt.Println(T("license.switching", plan.From, plan.To) + "\n")
+	printChanges(plan.Upgrade.Changes)
+	for _, p := range plan.Remove {
+		fmt.Printf("  %-11s %s\n", T("change.remove"), p)
+	}
+	for _, f := range plan.Manifests {
+		fmt.Printf("  %-11s %s\n", T("change.update"), T("license.manifestField", f.Path))
+	}
+	fmt.Println()
+	for _, w := range plan.Warnings {
+		fmt.Println(warnStyle.Render("! " + w))
+	}
+	if len(plan.Warnings) > 0 {
+		fmt.Println()
+	}
+
+	if !yes {
+		var confirm bool
+		err := huh.NewConfirm().
+			Title(T("change.confirm")).
+			Value(&confirm).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("%w -> %s", errAborted, T("change.nothingChanged"))
+		}
+	}
+
+	if err := applyRelicense(dir, plan); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("license.done", plan.To))
+	return nil
+}
+
+// printChanges lists an upgrade plan's changes, one file per line.
+func printChanges(changes []upgradeChange) {
+	for _, c := range changes {
+		action := T("change." + string(c.Action))
+		switch c.Action {
+		case upgradeSkip:
+			fmt.Println(dimStyle.Render(fmt.Sprintf("  %-11s %s (%s)", action, c.Path, c.Reason)))
+		case upgradeConflict:
+			fmt.Printf("  %-11s %s\n", action, T("change.conflicts", c.Path, c.Conflicts))
+		default:
+			fmt.Printf("  %-11s %s\n", action, c.Path)
+		}
+	}
+}
+
+// projectDirArg parses the optional [directory] argument shared by commands
+// that inspect an existing project. It defaults to the current directory.
+func projectDirArg(args []string, usage string) (string, error) {
+	switch {
+	case len(args) > 1:
+		return "", usageError{msg: T("args.tooMany"), usage: usage}
+	case len(args) == 1 && strings.HasPrefix(args[0], "-"):
+		return "", usageError{msg: T("args.unknownFlag", args[0]), usage: usage}
+	case len(args) == 1:
+		return args[0], nil
+	}
+	return ".", nil
+}
+
+// statusUsage is shown for `seed status` usage errors.
+const statusUsage = "seed status [directory]"
+
+// runStatus handles `seed status [dir]`: it reports how a scaffolded project
+// has drifted from what seed generated, and which template updates exist.
+func runStatus(args []string) error {
+	dir, err := projectDirArg(args, statusUsage)
+	if err != nil {
+		return err
+	}
+
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	report, err := projectStatus(scaffolder, dir)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatStatus(report))
+	return nil
+}
+
+// infoUsage is shown for `seed info` usage errors.
+const infoUsage = "seed info [directory]"
+
+// runInfo handles `seed info [dir]`: it summarizes how a project was
+// scaffolded (seed version, answers, skills) from its manifest.
+func runInfo(args []string) error {
+	dir, err := projectDirArg(args, infoUsage)
+	if err != nil {
+		return err
+	}
+	info, err := projectInfo(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatInfo(info))
+	return nil
+}
+
+// listUsage is shown for `seed list` usage errors.
+const listUsage = "seed list [directory] [--answers <file.json>] [--json]"
+
+// runList handles `seed list`: it prints the components and files that an
+// answers file (or, without one, a project's recorded answers) generates,
+// without writing anything.
+func runList(args []string) error {
+	var answersPath string
+	var asJSON bool
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
+			asJSON = true
+		case arg == "--answers":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.needsValue", arg), usage: listUsage}
+			}
+			i++
+			answersPath = args[i]
+		case strings.HasPrefix(arg, "--answers="):
+			answersPath = strings.TrimPrefix(arg, "--answers=")
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: listUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) > 1 || (answersPath != "" && len(positional) > 0) {
+		return usageError{msg: T("args.tooMany"), usage: listUsage}
+	}
+
+	var answers WizardData
+	var data TemplateData
+	if answersPath != "" {
+		w, err := loadAnswersFile(answersPath)
+		if err != nil {
+			return err
+		}
+		answers, data = w, w.ToTemplateData()
+	} else {
+		dir := "."
+		if len(positional) == 1 {
+			dir = positional[0]
+		}
+		manifest, err := readManifest(dir)
+		if err != nil {
+			return err
+		}
+		answers, data = manifest.Answers, manifest.TemplateData()
+	}
+
+	listing, err := listProject(answers, data)
+	if err != nil {
+		return err
+	}
+	if !asJSON {
+		fmt.Print(formatListing(listing))
+		return nil
+	}
+	raw, err := json.MarshalIndent(listing, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(raw))
+	return nil
+}
+
+// diffUsage is shown for `seed diff` usage errors.
+const diffUsage = "seed diff [directory]"
+
+// runDiff handles `seed diff [dir]`: it prints unified diffs from the files
+// on disk to what the current templates render from the recorded answers.
+func runDiff(args []string) error {
+	dir, err := projectDirArg(args, diffUsage)
+	if err != nil {
+		return err
+	}
+
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	diffs, err := projectDiffs(scaffolder, dir)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintln(os.Stderr, T("diff.none"))
+		return nil
+	}
+	for _, d := range diffs {
+		fmt.Print(d.Patch)
+	}
+	return nil
 }
 
-func (e usageError) Error() string {
-	return e.msg
-}
+// regenUsage is shown for `seed regen` usage errors.
+const regenUsage = "seed regen <file> [--yes]"
 
-func main() {
-	// Run main logic and exit with appropriate code
-	if err := run(); err != nil {
-		fmt.Fprintln(os.Stderr, formatErrorOutput(displayVersion(), err))
-		os.Exit(1)
+// runRegen handles `seed regen <file>`: it re-renders one generated file in
+// the current project from the recorded answers, after showing the diff.
+func runRegen(args []string) error {
+	var file string
+	var yes bool
+	for _, arg := range args {
+		switch {
+		case arg == "--yes" || arg == "-y":
+			yes = true
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: regenUsage}
+		case file == "":
+			file = arg
+		default:
+			return usageError{msg: T("args.tooMany"), usage: regenUsage}
+		}
+	}
+	if file == "" {
+		return usageError{msg: T("args.missingFile"), usage: regenUsage}
 	}
-}
 
-func displayVersion() string {
-	return strings.TrimPrefix(Version, "v")
-}
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	plan, err := planRegen(scaffolder, ".", file)
+	if err != nil {
+		return err
+	}
+	if plan.Patch == "" {
+		fmt.Println(T("regen.unchanged", plan.File.Path))
+		return nil
+	}
 
-func renderStartBanner(version string) string {
-	return fmt.Sprintf("🌱 Seed %s - Simple project scaffolding. Setup wizard:", version)
-}
+	fmt.Print(plan.Patch)
+	if !yes {
+		var confirm bool
+		err := huh.NewConfirm().
+			Title(T("regen.confirm", plan.File.Path)).
+			Value(&confirm).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("%w -> %s", errAborted, T("regen.leftUnchanged", plan.File.Path))
+		}
+	}
 
-func renderErrorBanner(version, message string) string {
-	return fmt.Sprintf("🌱 Seed %s - Error: %s", version, message)
+	if err := applyRegen(".", plan); err != nil {
+		return err
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("regen.done", plan.File.Path))
+	return nil
 }
 
-func renderScaffoldingLine() string {
-	return "scaffolding..."
-}
+// upgradeUsage is shown for `seed upgrade` usage errors.
+const upgradeUsage = "seed upgrade [directory] [--holder <name>] [--yes]"
 
-func formatErrorOutput(version string, err error) string {
-	var b strings.Builder
-	b.WriteString(renderErrorBanner(version, err.Error()))
+// runUpgrade handles `seed upgrade [dir]`: it applies current template changes
+// to a scaffolded project, merging into files the user has edited. License
+// copyright years are extended to this year, and --holder replaces the holder.
+func runUpgrade(args []string) error {
+	dir := "."
+	var yes bool
+	var opts upgradeOptions
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--yes" || arg == "-y":
+			yes = true
+		case arg == "--holder":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.needsValue", arg), usage: upgradeUsage}
+			}
+			i++
+			opts.Holder = strings.TrimSpace(args[i])
+		case strings.HasPrefix(arg, "--holder="):
+			opts.Holder = strings.TrimSpace(strings.TrimPrefix(arg, "--holder="))
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: upgradeUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) > 1 {
+		return usageError{msg: T("args.tooMany"), usage: upgradeUsage}
+	}
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
 
-	var usageErr usageError
-	if errors.As(err, &usageErr) {
-		b.WriteString("\n\nUsage: seed <directory>")
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	plan, err := planUpgrade(scaffolder, dir, opts)
+	if err != nil {
+		return err
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println(T("upgrade.upToDate", displayVersion()))
+		return nil
 	}
 
-	return b.String()
+	fmt.Println(T("upgrade.upgrading", strings.TrimPrefix(plan.Manifest.SeedVersion, "v"), displayVersion()) + "\n")
+	printChanges(plan.Changes)
+	fmt.Println()
+
+	if !yes {
+		var confirm bool
+		err := huh.NewConfirm().
+			Title(T("change.confirm")).
+			Value(&confirm).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("%w -> %s", errAborted, T("change.nothingChanged"))
+		}
+	}
+
+	if err := applyUpgrade(dir, plan); err != nil {
+		return err
+	}
+	conflicted := 0
+	for _, c := range plan.Changes {
+		if c.Action == upgradeConflict {
+			conflicted++
+		}
+	}
+	if conflicted > 0 {
+		fmt.Println(successStyle.Render("✓") + " " + T("upgrade.doneConflicts", conflicted))
+		return nil
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("upgrade.done", displayVersion()))
+	return nil
 }
 
-// run contains the main program logic.
-// Separated from main() to enable clean error handling and testing.
-//
-// Flow:
-// 1. Parse CLI arguments -> get target directory
-// 2. Run TUI wizard -> collect user input
-// 3. Initialize scaffolder -> prepare template engine
-// 4. Scaffold project -> render templates and write files
-// 5. Print success message
-//
-// Returns:
-// - error: If any step fails
-func run() error {
-	// Step 1: Parse command-line arguments
-	targetDir, err := parseArgs()
+// renameUsage is shown for `seed rename` usage errors.
+const renameUsage = "seed rename <new-name> [directory] [--yes]"
+
+// runRename handles `seed rename <name> [dir]`: it renames a scaffolded
+// project wherever seed rendered its name, after showing what changes.
+func runRename(args []string) error {
+	var yes bool
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--yes" || arg == "-y":
+			yes = true
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: renameUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return usageError{msg: T("args.missingNewName"), usage: renameUsage}
+	}
+	if len(positional) > 2 {
+		return usageError{msg: T("args.tooMany"), usage: renameUsage}
+	}
+	name, dir := positional[0], "."
+	if len(positional) == 2 {
+		dir = positional[1]
+	}
+
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	plan, err := planRename(scaffolder, dir, name)
 	if err != nil {
 		return err
 	}
+	if plan.From == plan.To {
+		fmt.Println(T("rename.unchanged", plan.To))
+		return nil
+	}
 
-	// Step 2: Show startup context
-	fmt.Println(renderStartBanner(displayVersion()))
+	fmt.Println(T("rename.renaming", plan.From, plan.To) + "\n")
+	printChanges(plan.Upgrade.Changes)
+	for _, f := range plan.Manifests {
+		fmt.Printf("  %-11s %s\n", T("change.update"), T("rename.manifestField", f.Path))
+	}
+	if plan.HolderMove {
+		fmt.Println(dimStyle.Render("  " + T("rename.holder", plan.To)))
+	}
 	fmt.Println()
+	for _, w := range plan.Warnings {
+		fmt.Println(warnStyle.Render("! " + w))
+	}
+	if len(plan.Warnings) > 0 {
+		fmt.Println()
+	}
 
-	targetDirExisted, err := targetDirectoryExists(targetDir)
-	if err != nil {
-		return err
+	if !yes {
+		var confirm bool
+		err := huh.NewConfirm().
+			Title(T("change.confirm")).
+			Value(&confirm).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("%w -> %s", errAborted, T("change.nothingChanged"))
+		}
 	}
 
-	// Step 3: Check target directory and confirm if non-empty
-	allowNonEmpty, err := checkTargetDir(targetDir)
-	if err != nil {
+	if err := applyRename(dir, plan); err != nil {
 		return err
 	}
+	if err := rememberProject(plan.To, dir); err != nil {
+		debugf("failed to record known project: %v", err)
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("rename.done", plan.To))
+	return nil
+}
 
-	// Capture existing files before scaffolding so we can report created files by phase.
-	beforeFiles, err := snapshotProjectFiles(targetDir)
-	if err != nil {
-		return fmt.Errorf("failed to inspect existing files: %w", err)
+const adoptUsage = "seed adopt [directory] [--name <name>] [--description <text>] [--no-skills] [--yes]"
+
+// runAdopt handles `seed adopt`: it adds seed's agentic docs and skills to
+// an existing repository, filling in what it can detect.
+func runAdopt(args []string) error {
+	var opts adoptOptions
+	var yes bool
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--name" || arg == "--description":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.needsValue", arg), usage: adoptUsage}
+			}
+			i++
+			if arg == "--name" {
+				opts.Name = args[i]
+			} else {
+				opts.Description = args[i]
+			}
+		case strings.HasPrefix(arg, "--name="):
+			opts.Name = strings.TrimPrefix(arg, "--name=")
+		case strings.HasPrefix(arg, "--description="):
+			opts.Description = strings.TrimPrefix(arg, "--description=")
+		case arg == "--no-skills":
+			opts.NoSkills = true
+		case arg == "--yes" || arg == "-y":
+			yes = true
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: adoptUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) > 1 {
+		return usageError{msg: T("args.tooMany"), usage: adoptUsage}
+	}
+	dir := "."
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
 	}
 
-	// Step 4: Run interactive wizard
-	wizardData, err := RunWizard(filepath.Base(targetDir))
-	if err != nil {
-		// User cancelled (Ctrl+C) or validation error
-		return fmt.Errorf("wizard cancelled: %w", err)
+	// No README paragraph to describe the project: ask, as the wizard would
+	if strings.TrimSpace(opts.Description) == "" && !yes {
+		if d, err := detectRepository(dir); err == nil && d.Description == "" {
+			err := huh.NewText().
+				Title(T("wizard.description")).
+				Description(T("wizard.descriptionHint")).
+				CharLimit(500).
+				Value(&opts.Description).
+				Validate(validateDescription).
+				Run()
+			if err != nil {
+				return fmt.Errorf("cancelled: %w", err)
+			}
+		}
 	}
 
-	// Step 5: Initialize scaffolder with embedded templates
 	scaffolder, err := NewScaffolder()
 	if err != nil {
-		// This should never happen if templates are valid
 		return fmt.Errorf("failed to initialize scaffolder: %w", err)
 	}
+	plan, err := planAdopt(scaffolder, dir, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(formatAdoptPlan(dir, plan))
+	if len(plan.Create) == 0 {
+		fmt.Println(T("adopt.nothing"))
+		return nil
+	}
 
-	fmt.Println(renderScaffoldingLine())
+	if !yes {
+		var confirm bool
+		err := huh.NewConfirm().
+			Title(T("change.confirm")).
+			Value(&confirm).
+			Run()
+		if err != nil {
+			return fmt.Errorf("cancelled: %w", err)
+		}
+		if !confirm {
+			return fmt.Errorf("%w -> %s", errAborted, T("change.nothingChanged"))
+		}
+	}
+
+	if err := applyAdopt(dir, plan); err != nil {
+		return err
+	}
+	if err := rememberProject(plan.Answers.ProjectName, dir); err != nil {
+		debugf("failed to record known project: %v", err)
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("adopt.done", plan.Answers.ProjectName, len(plan.Create)))
+	return nil
+}
+
+// runDoctor handles `seed doctor`: it runs every environment check and
+// returns an error if any check failed outright.
+func runDoctor(args []string) error {
+	if len(args) > 0 {
+		return usageError{msg: T("args.noArguments", "doctor"), usage: "seed doctor"}
+	}
+
+	fmt.Println(T("doctor.start", brandName(), displayVersion()) + "\n")
+	results := runChecks(doctorChecks)
+	fmt.Print(formatChecks(results))
 	fmt.Println()
 
-	if !targetDirExisted {
-		fmt.Printf("Created directory: %s\n", targetDir)
+	failed := 0
+	for _, r := range results {
+		if r.Status == checkFail {
+			failed++
+		}
 	}
+	if failed > 0 {
+		return errors.New(T("doctor.failed", failed))
+	}
+	fmt.Println(T("doctor.ready"))
+	return nil
+}
 
-	// Step 6: Convert wizard data to template data and scaffold
-	templateData := wizardData.ToTemplateData()
-	if err := scaffolder.Scaffold(targetDir, templateData, allowNonEmpty); err != nil {
-		return fmt.Errorf("failed to scaffold project: %w", err)
+// verifyUsage is shown for `seed verify` usage errors.
+const verifyUsage = "seed verify [directory] [--up]\n       seed verify [directory] --manifest [--json] [--allow-modified]"
+
+// runVerify handles `seed verify [dir] [--up]`: it builds the generated dev
+// container with the devcontainers CLI (or starts it with --up). With
+// --manifest it checks the project against its manifest instead.
+func runVerify(args []string) error {
+	dir := "."
+	var up, manifest, asJSON, allowModified bool
+	var positional []string
+	for _, arg := range args {
+		switch {
+		case arg == "--up":
+			up = true
+		case arg == "--manifest":
+			manifest = true
+		case arg == "--json":
+			asJSON = true
+		case arg == "--allow-modified":
+			allowModified = true
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: verifyUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) > 1 {
+		return usageError{msg: T("args.tooMany"), usage: verifyUsage}
+	}
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
+	switch {
+	case manifest && up:
+		return usageError{msg: T("args.verifyUpWithManifest"), usage: verifyUsage}
+	case !manifest && (asJSON || allowModified):
+		return usageError{msg: T("args.verifyNeedsManifest"), usage: verifyUsage}
+	case manifest:
+		return runVerifyManifest(dir, asJSON, allowModified)
 	}
 
-	afterScaffoldFiles, err := snapshotProjectFiles(targetDir)
+	action := "build"
+	if up {
+		action = "up"
+	}
+	fmt.Println(T("verify.start", action, dir))
+	result, err := verifyDevContainer(dir, up)
 	if err != nil {
-		return fmt.Errorf("failed to inspect scaffolded files: %w", err)
+		return err
 	}
-	scaffoldCreatedFiles := createdFileList(beforeFiles, afterScaffoldFiles)
-	for _, file := range scaffoldCreatedFiles {
-		fmt.Printf("%s created %s\n", successStyle.Render("✓"), file)
+	if up {
+		fmt.Printf("%s %s\n", successStyle.Render("✓"), T("verify.up", result.ContainerID))
+		return nil
 	}
+	fmt.Printf("%s %s\n", successStyle.Render("✓"), T("verify.built"))
+	return nil
+}
 
-	// Step 7: Install agent skills into the project
-	_, err = installSkillsWithReport(targetDir)
+// runVerifyManifest handles `seed verify --manifest`: it prints the check
+// (as JSON with --json) and fails when the project doesn't pass.
+func runVerifyManifest(dir string, asJSON, allowModified bool) error {
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to install skills: %w", err)
+		return err
 	}
-
-	afterSkillsFiles, err := snapshotProjectFiles(targetDir)
+	check, err := verifyManifest(dir, cfg, allowModified)
 	if err != nil {
-		return fmt.Errorf("failed to inspect created files: %w", err)
+		return err
+	}
+	if asJSON {
+		raw, err := json.MarshalIndent(check, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(raw))
+	} else {
+		fmt.Print(formatManifestCheck(check))
 	}
-	skillsCreatedFiles := createdFileList(afterScaffoldFiles, afterSkillsFiles)
-	for _, file := range skillsCreatedFiles {
-		fmt.Printf("%s created %s\n", successStyle.Render("✓"), file)
+	if !check.OK {
+		return errors.New(T("verifyManifest.failed", check.Problems()))
+	}
+	return nil
+}
+
+// telemetryUsage is shown for `seed telemetry` usage errors.
+const telemetryUsage = "seed telemetry [on|off]"
+
+// runTelemetry handles `seed telemetry [on|off]`: with no argument it shows
+// the current setting, otherwise it records the user's choice.
+func runTelemetry(args []string) error {
+	cfg, err := loadUserConfig()
+	if err != nil {
+		return err
 	}
 
-	gitActions := []string{}
-	// Step 8: Optionally initialize git repository
-	if wizardData.InitGit {
-		gitActions, err = initGitRepo(targetDir, wizardData.ProjectName)
-		if err != nil {
-			return fmt.Errorf("failed to initialize git: %w", err)
+	switch {
+	case len(args) == 0:
+		state := cfg.Telemetry
+		switch {
+		case TelemetryEndpoint == "":
+			state = T("telemetry.offNoEndpoint")
+		case telemetryEnvOff():
+			state = T("telemetry.offByEnv")
+		case state == "":
+			state = T("telemetry.offNotAsked")
 		}
-		for _, action := range gitActions {
-			fmt.Printf("%s %s\n", successStyle.Render("✓"), action)
+		fmt.Println(T("telemetry.state", state))
+		fmt.Println(dimStyle.Render(T("telemetry.sent")))
+		return nil
+	case len(args) == 1 && (args[0] == telemetryOn || args[0] == telemetryOff):
+		cfg.Telemetry = args[0]
+		if err := saveUserConfig(cfg); err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render("✓") + " " + T("telemetry.set", args[0]))
+		return nil
+	default:
+		return usageError{msg: T("args.onOrOff"), usage: telemetryUsage}
+	}
+}
+
+// runBatch scaffolds every project listed in a batch spec without running
+// the wizard.
+func runBatch(opts cliOptions) error {
+	spec, err := loadBatchSpec(opts.BatchSpec)
+	if err != nil {
+		return err
+	}
+	cfg, _ := loadConfig()
+	for i := range spec.Projects {
+		if err := disableComponents(&spec.Projects[i].Answers, opts.Disabled, cfg.Require); err != nil {
+			return err
 		}
 	}
 
-	fmt.Println("Done.")
+	fmt.Printf("%s %s - Batch scaffolding %d projects from %s\n\n", brandName(), displayVersion(), len(spec.Projects), opts.BatchSpec)
+	if err := runBatchSpec(spec, os.Stdout); err != nil {
+		return err
+	}
+	fmt.Println(T("flow.done"))
+	return nil
+}
+
+// runPrint runs the wizard and prints the rendered project (tree plus each
+// file's contents) to stdout without creating anything. The banner and wizard
+// are drawn on stderr so stdout can be redirected or piped cleanly.
+func runPrint(opts cliOptions) error {
+	rootName := filepath.Base(opts.TargetDir)
+	preset, err := profilePreset(opts.Profile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, renderStartBanner(displayVersion()))
+	fmt.Fprintln(os.Stderr)
+
+	wizardOutput = os.Stderr
+	session, err := runWizardSession(opts.TargetDir, rootName, opts.Disabled, preset)
+	if err != nil {
+		return fmt.Errorf("%s: %w", T("flow.wizardCancelled"), err)
+	}
+	defer session.Keep()
+	wizardData := session.Answers
+
+	files, err := renderProjectFiles(wizardData.ToTemplateData())
+	if err != nil {
+		return err
+	}
 
+	fmt.Print(renderPrintout(rootName, files))
+	session.Done()
+	recordScaffold("print", wizardData, true)
 	return nil
 }
 
+// renderProjectFiles renders templates and skills in memory, in the order
+// they'd be written to disk.
+func renderProjectFiles(data TemplateData) ([]RenderedFile, error) {
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+
+	files, err := scaffolder.Render(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render project: %w", err)
+	}
+	skills, err := projectSkillFiles(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render skills: %w", err)
+	}
+	return append(files, skills...), nil
+}
+
 func targetDirectoryExists(targetDir string) (bool, error) {
 	info, err := os.Stat(targetDir)
 	if os.IsNotExist(err) {
@@ -209,8 +1514,22 @@ func targetDirectoryExists(targetDir string) (bool, error) {
 	return true, nil
 }
 
+// projectPaths returns the paths a project will have once files are written
+// over the existing ones: files, the seed manifest, and existing.
+func projectPaths(files []RenderedFile, existing map[string]struct{}) []string {
+	paths := []string{manifestPath}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	for p := range existing {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
 // snapshotProjectFiles returns all file paths under root as slash-normalized
-// paths relative to root. Missing roots return an empty set.
+// paths relative to root, minus those root's .seedignore excludes (ignored
+// directories aren't walked). Missing roots return an empty set.
 func snapshotProjectFiles(root string) (map[string]struct{}, error) {
 	files := make(map[string]struct{})
 
@@ -225,19 +1544,28 @@ func snapshotProjectFiles(root string) (map[string]struct{}, error) {
 		return nil, fmt.Errorf("%s is not a directory", root)
 	}
 
+	ignore, err := loadSeedIgnore(root)
+	if err != nil {
+		return nil, err
+	}
 	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
-			return nil
-		}
-
 		rel, err := filepath.Rel(root, path)
 		if err != nil {
 			return err
 		}
-		files[filepath.ToSlash(rel)] = struct{}{}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." && ignore.Ignored(rel, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !ignore.Ignored(rel, false) {
+			files[rel] = struct{}{}
+		}
 		return nil
 	})
 	if err != nil {
@@ -303,37 +1631,47 @@ func checkTargetDir(targetDir string) (bool, error) {
 	// Non-empty -> ask user to confirm
 	var confirm bool
 	err = huh.NewConfirm().
-		Title(fmt.Sprintf("Directory %s contains %d items. Continue anyway?", targetDir, len(entries))).
-		Description("Existing files will NOT be overwritten, but new files will be added").
+		Title(T("targetDir.confirm", targetDir, len(entries))).
+		Description(T("targetDir.confirmHint")).
 		Value(&confirm).
 		Run()
 	if err != nil {
 		return false, fmt.Errorf("cancelled: %w", err)
 	}
 	if !confirm {
-		return false, fmt.Errorf("aborted -> directory is not empty")
+		return false, fmt.Errorf("%w -> %s", errAborted, T("targetDir.notEmpty"))
 	}
 	return true, nil
 }
 
 // initGitRepo runs git init, git add, and an initial commit in the target directory.
-func initGitRepo(targetDir, projectName string) ([]string, error) {
-	commands := []struct {
+// The commit message follows the project's commit convention, if it has one.
+// executables are marked executable in the index, so the bit is committed
+// even where the filesystem can't hold it (Windows, or /mnt/c under WSL).
+// Each command is bounded by commandTimeout and its stderr ends up in the error.
+func initGitRepo(targetDir, projectName, convention string, executables []string) ([]string, error) {
+	message := initialCommitMessage(convention, projectName)
+	type gitCommand struct {
 		args  []string
 		label string
-	}{
+	}
+	commands := []gitCommand{
 		{args: []string{"git", "init"}, label: "git init"},
 		{args: []string{"git", "add", "."}, label: "git add ."},
-		{args: []string{"git", "commit", "-m", fmt.Sprintf("Initial scaffold for %s (via seed)", projectName)}, label: "git commit -m \"Initial scaffold for <project> (via seed)\""},
 	}
+	if len(executables) > 0 {
+		commands = append(commands, gitCommand{
+			args:  append([]string{"git", "update-index", "--chmod=+x", "--"}, executables...),
+			label: "git update-index --chmod=+x " + strings.Join(executables, " "),
+		})
+	}
+	commands = append(commands, gitCommand{args: []string{"git", "commit", "-m", message}, label: fmt.Sprintf("git commit -m %q", message)})
 
+	timeout := commandTimeout(defaultCommandTimeout)
 	executed := make([]string, 0, len(commands))
 	for _, c := range commands {
-		cmd := exec.Command(c.args[0], c.args[1:]...)
-		cmd.Dir = targetDir
-		cmd.Stdout = nil // suppress output
-		cmd.Stderr = nil
-		if err := cmd.Run(); err != nil {
+		_, err := runCommand(targetDir, timeout, c.args[0], c.args[1:]...)
+		if err != nil {
 			return executed, fmt.Errorf("%s failed: %w", c.label, err)
 		}
 		executed = append(executed, c.label)
@@ -341,13 +1679,25 @@ func initGitRepo(targetDir, projectName string) ([]string, error) {
 	return executed, nil
 }
 
-// parseArgs parses command-line arguments and returns the target directory.
+// executablePaths returns the paths of files written with an executable bit.
+func executablePaths(files []RenderedFile) []string {
+	var paths []string
+	for _, f := range files {
+		if f.Mode&0111 != 0 {
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths
+}
+
+// parseArgs parses command-line arguments into cliOptions.
 //
 // Expected usage:
-// - seed <directory>
+// - seed [flags] <directory>
+// - seed --output-archive <file> [directory]
 //
 // Returns:
-// - string: Target directory path
+// - cliOptions: Parsed options
 // - error: If arguments are invalid
 //
 // Handles:
@@ -356,8 +1706,14 @@ func initGitRepo(targetDir, projectName string) ([]string, error) {
 // - --help, -h, help -> show usage
 // - --version, -v -> show version
 // - --verbose -> accepted for backward compatibility; ignored
-func parseArgs() (string, error) {
+// - --output-archive <file> / --output-archive=<file> -> archive mode (directory optional)
+// - --print -> print tree and contents to stdout (directory names the project)
+// - --batch <spec> / --batch=<spec> -> scaffold every project in the spec (no directory)
+// - --sync -> add missing files to an existing project (directory required)
+// - --no-skills, --no-devcontainer, --no-git -> leave a component out
+func parseArgs() (cliOptions, error) {
 	args := os.Args[1:] // Skip program name
+	var opts cliOptions
 
 	// Handle no arguments
 	if len(args) == 0 {
@@ -377,62 +1733,126 @@ func parseArgs() (string, error) {
 		os.Exit(0)
 	}
 
-	if args[0] == "--verbose" {
-		args = args[1:]
+	// Subcommands parse their own arguments
+	if _, ok := subcommands[args[0]]; ok {
+		return cliOptions{Command: args[0], CommandArgs: args[1:]}, nil
 	}
 
-	if len(args) == 0 {
-		return "", usageError{msg: "missing directory argument"}
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--verbose":
+			// accepted for backward compatibility; ignored
+		case arg == "--print":
+			opts.Print = true
+		case arg == "--open":
+			opts.Open = true
+		case arg == "--report-only":
+			opts.ReportOnly = true
+		case arg == "--sync":
+			opts.Sync = true
+		case isDisableFlag(arg):
+			opts.Disabled = append(opts.Disabled, strings.TrimPrefix(arg, "--no-"))
+		case arg == "--batch":
+			if i+1 >= len(args) {
+				return cliOptions{}, usageError{msg: T("args.batchNeedsSpec")}
+			}
+			i++
+			opts.BatchSpec = args[i]
+		case strings.HasPrefix(arg, "--batch="):
+			opts.BatchSpec = strings.TrimPrefix(arg, "--batch=")
+			if opts.BatchSpec == "" {
+				return cliOptions{}, usageError{msg: T("args.batchNeedsSpec")}
+			}
+		case arg == "--output-archive":
+			if i+1 >= len(args) {
+				return cliOptions{}, usageError{msg: T("args.archiveNeedsName")}
+			}
+			i++
+			opts.OutputArchive = args[i]
+		case arg == "--profile":
+			if i+1 >= len(args) {
+				return cliOptions{}, usageError{msg: T("args.profileNeedsName")}
+			}
+			i++
+			opts.Profile = args[i]
+		case strings.HasPrefix(arg, "--profile="):
+			opts.Profile = strings.TrimPrefix(arg, "--profile=")
+			if opts.Profile == "" {
+				return cliOptions{}, usageError{msg: T("args.profileNeedsName")}
+			}
+		case arg == "--clock":
+			if i+1 >= len(args) {
+				return cliOptions{}, usageError{msg: T("args.clockNeedsTime")}
+			}
+			i++
+			opts.Clock = args[i]
+		case strings.HasPrefix(arg, "--clock="):
+			opts.Clock = strings.TrimPrefix(arg, "--clock=")
+		case strings.HasPrefix(arg, "--output-archive="):
+			opts.OutputArchive = strings.TrimPrefix(arg, "--output-archive=")
+			if opts.OutputArchive == "" {
+				return cliOptions{}, usageError{msg: T("args.archiveNeedsName")}
+			}
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			return cliOptions{}, usageError{msg: T("args.unknownFlag", arg)}
+		default:
+			positional = append(positional, arg)
+		}
 	}
 
 	// Handle too many arguments
-	if len(args) > 1 {
-		return "", usageError{msg: "too many arguments"}
+	if len(positional) > 1 {
+		return cliOptions{}, usageError{msg: T("args.tooMany")}
+	}
+
+	if len(positional) == 1 {
+		opts.TargetDir = positional[0]
+	}
+
+	if opts.Print && opts.OutputArchive != "" {
+		return cliOptions{}, usageError{msg: T("args.printWithArchive")}
+	}
+
+	if opts.Open && (opts.Print || opts.OutputArchive != "" || opts.BatchSpec != "") {
+		return cliOptions{}, usageError{msg: T("args.openCombined")}
+	}
+
+	if opts.Sync && (opts.Print || opts.OutputArchive != "" || opts.BatchSpec != "" || opts.Open || len(opts.Disabled) > 0) {
+		return cliOptions{}, usageError{msg: T("args.syncCombined")}
+	}
+
+	if opts.Profile != "" && (opts.Sync || opts.BatchSpec != "") {
+		return cliOptions{}, usageError{msg: T("args.profileCombined")}
+	}
+
+	if opts.Clock != "" {
+		if _, err := parseClock(opts.Clock); err != nil {
+			return cliOptions{}, usageError{msg: err.Error()}
+		}
+	}
+
+	if opts.BatchSpec != "" {
+		if opts.Print || opts.OutputArchive != "" {
+			return cliOptions{}, usageError{msg: T("args.batchCombined")}
+		}
+		if len(positional) > 0 {
+			return cliOptions{}, usageError{msg: T("args.batchTakesPaths")}
+		}
+		return opts, nil
+	}
+
+	// The directory is only optional when writing an archive
+	if opts.TargetDir == "" && opts.OutputArchive == "" {
+		return cliOptions{}, usageError{msg: T("args.missingDirectory")}
 	}
 
-	// Return the target directory
-	return args[0], nil
+	return opts, nil
 }
 
 // showUsage prints usage information to stdout.
 // Called when user runs: seed, seed --help, seed -h, or seed help
 func showUsage() {
-	fmt.Printf(`🌱 seed v%s — rapid agentic project scaffolder
-
-USAGE:
-  seed <directory>
-
-WHAT IT DOES:
-  Runs an interactive wizard that asks about your project, then generates
-  a set of structured markdown docs and agent skill files designed for AI
-  agents to work with from day one.
-
-  The wizard collects: project name, description, language/framework,
-  and optional devcontainer setup.
-
-GENERATED FILES:
-  README.md                        Project overview
-  AGENTS.md                        Agent context and constraints
-  DECISIONS.md                     Key architectural decisions
-  TODO.md                          Active work and next steps
-  LEARNINGS.md                     Validated discoveries
-  .gitignore                       Git ignore rules (language-aware)
-  .editorconfig                    Editor formatting defaults
-  LICENSE                          Open-source license (optional)
-  .devcontainer/devcontainer.json  Dev container config (optional)
-  .devcontainer/setup.sh           AI chat continuity (optional)
-  skills/                          Reusable agent skill files
-
-EXAMPLES:
-  seed myproject                Create ./myproject/
-  seed ~/dev/myapp              Create ~/dev/myapp/
-  seed .                        Use current directory (if empty)
-
-FLAGS:
-  -h, --help      Show this help message
-  -v, --version   Show version number
-
-LEARN MORE:
-  https://github.com/justinphilpott/seed
-`, Version)
+	fmt.Print(helpText())
 }
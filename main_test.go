@@ -11,6 +11,9 @@ func TestParseArgs(t *testing.T) {
 		name         string
 		args         []string
 		wantDir      string
+		wantArchive  string
+		wantPrint    bool
+		wantCommand  string
 		wantErr      bool
 		wantUsageErr bool
 	}{
@@ -35,6 +38,160 @@ func TestParseArgs(t *testing.T) {
 			wantErr:      true,
 			wantUsageErr: true,
 		},
+		{
+			name:         "output archive without directory",
+			args:         []string{"seed", "--output-archive", "myproject.tar.gz"},
+			wantDir:      "",
+			wantArchive:  "myproject.tar.gz",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "output archive with equals and directory",
+			args:         []string{"seed", "myproject", "--output-archive=out.zip"},
+			wantDir:      "myproject",
+			wantArchive:  "out.zip",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "output archive missing value",
+			args:         []string{"seed", "--output-archive"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "print flag",
+			args:         []string{"seed", "--print", "myproject"},
+			wantDir:      "myproject",
+			wantPrint:    true,
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "print requires directory",
+			args:         []string{"seed", "--print"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "print and archive conflict",
+			args:         []string{"seed", "--print", "--output-archive", "out.zip", "myproject"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "open flag",
+			args:         []string{"seed", "--open", "myproject"},
+			wantDir:      "myproject",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "report-only flag",
+			args:         []string{"seed", "--report-only", "myproject"},
+			wantDir:      "myproject",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "open and print conflict",
+			args:         []string{"seed", "--open", "--print", "myproject"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "batch spec without directory",
+			args:         []string{"seed", "--batch", "spec.json"},
+			wantDir:      "",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "batch rejects directory argument",
+			args:         []string{"seed", "--batch=spec.json", "myproject"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "subcommand passes remaining args through",
+			args:         []string{"seed", "add", "package", "api"},
+			wantDir:      "",
+			wantCommand:  "add",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "status subcommand",
+			args:         []string{"seed", "status"},
+			wantDir:      "",
+			wantCommand:  "status",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "sync",
+			args:         []string{"seed", "--sync", "myproject"},
+			wantDir:      "myproject",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "sync needs a directory",
+			args:         []string{"seed", "--sync"},
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "sync with print",
+			args:         []string{"seed", "--sync", "--print", "myproject"},
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "profile",
+			args:         []string{"seed", "--profile", "go-service", "myproject"},
+			wantDir:      "myproject",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "profile needs a name",
+			args:         []string{"seed", "myproject", "--profile"},
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "profile with sync",
+			args:         []string{"seed", "--profile=go-service", "--sync", "myproject"},
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "clock",
+			args:         []string{"seed", "--clock", "2026-01-01T00:00:00Z", "myproject"},
+			wantDir:      "myproject",
+			wantErr:      false,
+			wantUsageErr: false,
+		},
+		{
+			name:         "invalid clock",
+			args:         []string{"seed", "--clock=yesterday", "myproject"},
+			wantErr:      true,
+			wantUsageErr: true,
+		},
+		{
+			name:         "unknown flag",
+			args:         []string{"seed", "--bogus", "myproject"},
+			wantDir:      "",
+			wantErr:      true,
+			wantUsageErr: true,
+		},
 		{
 			name:         "too many args",
 			args:         []string{"seed", "one", "two"},
@@ -53,7 +210,7 @@ func TestParseArgs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			os.Args = tt.args
 
-			gotDir, err := parseArgs()
+			gotOpts, err := parseArgs()
 			if tt.wantErr {
 				if err == nil {
 					t.Fatalf("expected error, got nil")
@@ -71,8 +228,17 @@ func TestParseArgs(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
-			if gotDir != tt.wantDir {
-				t.Fatalf("directory mismatch: got %q, want %q", gotDir, tt.wantDir)
+			if gotOpts.TargetDir != tt.wantDir {
+				t.Fatalf("directory mismatch: got %q, want %q", gotOpts.TargetDir, tt.wantDir)
+			}
+			if gotOpts.OutputArchive != tt.wantArchive {
+				t.Fatalf("archive mismatch: got %q, want %q", gotOpts.OutputArchive, tt.wantArchive)
+			}
+			if gotOpts.Command != tt.wantCommand {
+				t.Fatalf("command mismatch: got %q, want %q", gotOpts.Command, tt.wantCommand)
+			}
+			if gotOpts.Print != tt.wantPrint {
+				t.Fatalf("print mismatch: got %v, want %v", gotOpts.Print, tt.wantPrint)
 			}
 		})
 	}
@@ -98,6 +264,11 @@ func TestFormatErrorOutput(t *testing.T) {
 		t.Fatalf("usage error output mismatch:\n got: %q\nwant: %q", usage, want)
 	}
 
+	custom := formatErrorOutput("0.1.0", usageError{msg: "missing package name", usage: "seed add package <name>"})
+	if want := "🌱 Seed 0.1.0 - Error: missing package name\n\nUsage: seed add package <name>"; custom != want {
+		t.Fatalf("custom usage output mismatch:\n got: %q\nwant: %q", custom, want)
+	}
+
 	nonUsage := formatErrorOutput("0.1.0", errors.New("failed to scaffold project"))
 	if want := "🌱 Seed 0.1.0 - Error: failed to scaffold project"; nonUsage != want {
 		t.Fatalf("non-usage error output mismatch:\n got: %q\nwant: %q", nonUsage, want)
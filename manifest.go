@@ -0,0 +1,204 @@
+// Package main - manifest.go
+//
+// PURPOSE:
+// This file records what seed generated for a project in .seed/manifest.json.
+// It's responsible for:
+// - Capturing the seed version, wizard answers and copyright years/holder used
+// - Hashing every generated file so later commands can detect drift
+// - Keeping the generated content as the base for three-way merges
+// - Reading and writing the manifest
+//
+// DESIGN PATTERNS:
+// - Plain JSON (encoding/json), committed alongside the project
+// - Answers reuse WizardData's JSON form, so a manifest can re-render the project
+//
+// USAGE:
+// m := newManifest(answers, year, files)
+// err := writeManifest(targetDir, m)
+// m, err := readManifest(targetDir)
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// manifestPath is where the manifest lives, relative to the project root.
+const manifestPath = ".seed/manifest.json"
+
+// Manifest records how a project was generated.
+type Manifest struct {
+	SeedVersion string         `json:"seedVersion"` // Seed that last generated or upgraded the project
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Year        int            `json:"year"`              // Year used for LICENSE rendering
+	License     *LicenseInfo   `json:"license,omitempty"` // Copyright line; nil in older manifests (Year, project name)
+	Answers     WizardData     `json:"answers"`           // Answers the project was rendered from
+	Files       []ManifestFile `json:"files"`
+
+	// Oldest seed that may upgrade or regenerate the project: config
+	// minSeedVersion when it was generated (version.go)
+	MinSeedVersion string `json:"minSeedVersion,omitempty"`
+}
+
+// ManifestFile is one generated file, the hash of its generated content, and
+// the content itself (the merge base when upgrading a locally modified file).
+// Binary files are recorded by hash alone: JSON strings can't hold them, and
+// they can't be merged anyway.
+type ManifestFile struct {
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+	Content string `json:"content,omitempty"`
+}
+
+// LicenseInfo is the copyright line the license files carry. seed upgrade
+// extends LastYear to the current year, turning "2025" into "2025-2026".
+type LicenseInfo struct {
+	Holder    string `json:"holder"`
+	FirstYear int    `json:"firstYear"`
+	LastYear  int    `json:"lastYear"`
+}
+
+// Years returns the copyright year or range.
+func (l LicenseInfo) Years() string {
+	if l.LastYear <= l.FirstYear {
+		return strconv.Itoa(l.FirstYear)
+	}
+	return fmt.Sprintf("%d-%d", l.FirstYear, l.LastYear)
+}
+
+// errNoManifest is returned when a directory has no .seed/manifest.json.
+var errNoManifest = errors.New("no seed manifest found (was this project scaffolded by seed?)")
+
+// newManifest builds a manifest for the given generated files.
+func newManifest(answers WizardData, year int, files []RenderedFile) Manifest {
+	m := Manifest{
+		SeedVersion: Version,
+		GeneratedAt: seedNow().Truncate(time.Second),
+		Year:        year,
+		License:     &LicenseInfo{Holder: answers.ProjectName, FirstYear: year, LastYear: year},
+		Answers:     answers,
+	}
+	m.Files = make([]ManifestFile, 0, len(files))
+	for _, f := range files {
+		m.Files = append(m.Files, newManifestFile(f))
+	}
+	return m
+}
+
+// newManifestFile records a generated file's path, hash and, unless it's
+// binary, content.
+func newManifestFile(f RenderedFile) ManifestFile {
+	entry := ManifestFile{Path: f.Path, SHA256: hashContent(f.Content)}
+	if !isBinary(f.Content) {
+		entry.Content = string(f.Content)
+	}
+	return entry
+}
+
+// File returns the manifest entry for path, if recorded.
+func (m Manifest) File(path string) (ManifestFile, bool) {
+	for _, f := range m.Files {
+		if f.Path == path {
+			return f, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+// Outdated reports whether current (a fresh render of this file) differs from
+// what was recorded. Differences confined to the version stamp don't count.
+func (f ManifestFile) Outdated(current []byte) bool {
+	if hashContent(current) == f.SHA256 {
+		return false
+	}
+	return f.Content == "" || !sameGenerated([]byte(f.Content), current)
+}
+
+// SetFile records f as freshly generated, replacing any existing entry.
+func (m *Manifest) SetFile(f RenderedFile) {
+	entry := newManifestFile(f)
+	for i := range m.Files {
+		if m.Files[i].Path == entry.Path {
+			m.Files[i] = entry
+			return
+		}
+	}
+	m.Files = append(m.Files, entry)
+}
+
+// RemoveFile stops tracking path.
+func (m *Manifest) RemoveFile(path string) {
+	for i := range m.Files {
+		if m.Files[i].Path == path {
+			m.Files = append(m.Files[:i], m.Files[i+1:]...)
+			return
+		}
+	}
+}
+
+// TemplateData returns the data the project was originally rendered with.
+func (m Manifest) TemplateData() TemplateData {
+	data := m.Answers.ToTemplateData()
+	data.Year = m.Year
+	license := m.LicenseInfo()
+	data.CopyrightYears = license.Years()
+	data.CopyrightHolder = license.Holder
+	return data
+}
+
+// LicenseInfo returns the recorded copyright line, deriving it from Year
+// and the project name for manifests that predate it.
+func (m Manifest) LicenseInfo() LicenseInfo {
+	if m.License != nil {
+		return *m.License
+	}
+	return LicenseInfo{Holder: m.Answers.ProjectName, FirstYear: m.Year, LastYear: m.Year}
+}
+
+// hashContent returns the hex-encoded SHA-256 of content.
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// manifestFile renders m as the RenderedFile stored at manifestPath.
+func manifestFile(m Manifest) (RenderedFile, error) {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return RenderedFile{}, fmt.Errorf("failed to generate manifest: %w", err)
+	}
+	return RenderedFile{Path: manifestPath, Content: append(raw, '\n'), Mode: 0644}, nil
+}
+
+// writeManifest writes m to targetDir/.seed/manifest.json.
+func writeManifest(targetDir string, m Manifest) error {
+	file, err := manifestFile(m)
+	if err != nil {
+		return err
+	}
+	return writeFiles(targetDir, []RenderedFile{file})
+}
+
+// readManifest loads targetDir/.seed/manifest.json.
+func readManifest(targetDir string) (Manifest, error) {
+	var m Manifest
+	raw, err := os.ReadFile(filepath.Join(targetDir, filepath.FromSlash(manifestPath)))
+	if os.IsNotExist(err) {
+		return m, errNoManifest
+	}
+	if err != nil {
+		return m, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return m, fmt.Errorf("invalid manifest %s: %w", manifestPath, err)
+	}
+	return m, nil
+}
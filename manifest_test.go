@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// BenchmarkNewManifest hashes 1,000 README-sized files into a manifest.
+func BenchmarkNewManifest(b *testing.B) {
+	files := make([]RenderedFile, 1000)
+	for i := range files {
+		files[i] = RenderedFile{Path: fmt.Sprintf("docs/doc%04d.md", i), Content: []byte(strings.Repeat("Some generated text.\n", 100))}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newManifest(WizardData{ProjectName: "bench"}, 2026, files)
+	}
+}
@@ -0,0 +1,111 @@
+// Package main - maturity.go
+//
+// PURPOSE:
+// This file describes who a project is for and how far along it means to
+// be: a prototype, an internal tool, an open-source library or a production
+// service. It's responsible for:
+// - The catalog and the wizard's options
+// - Each maturity's README badge and TODO.md starter tasks
+//
+// DESIGN PATTERNS:
+// - Catalog data here, README prose in the template (like commits.go):
+//   README.md.tmpl branches on the ID for its status note and sections
+// - No maturity ("") renders exactly what seed always has
+//
+// USAGE:
+// {{with .Stage}}{{range .Tasks}}- [ ] {{.}}{{end}}{{end}}
+
+package main
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/huh"
+)
+
+// projectMaturity is an intended audience and maturity.
+type projectMaturity struct {
+	ID    string   // Also the wizard label's key: wizard.maturity.<ID>
+	Badge string   // README badge ("" for none)
+	Tasks []string // TODO.md Next Up starter tasks
+}
+
+// maturities lists the supported maturities in wizard order.
+var maturities = []projectMaturity{
+	{
+		ID:    "prototype",
+		Badge: "![Status: experimental](https://img.shields.io/badge/status-experimental-orange.svg)",
+		Tasks: []string{
+			"Write down the riskiest assumption and how you'll test it",
+			"Decide what result would make you stop or change course",
+		},
+	},
+	{
+		ID:    "internal",
+		Badge: "![Audience: internal](https://img.shields.io/badge/audience-internal-lightgrey.svg)",
+		Tasks: []string{
+			"Name the owning team and support channel in README.md",
+			"Document how colleagues get access and run it",
+		},
+	},
+	{
+		ID: "library",
+		Tasks: []string{
+			"Write a minimal usage example in README.md",
+			"Choose a versioning and release process (e.g. semver tags)",
+			"Decide what is public API and document it",
+		},
+	},
+	{
+		ID: "service",
+		Tasks: []string{
+			"Add a health check endpoint",
+			"Document deployment and rollback in README.md",
+			"Set up logging, metrics and alerting",
+		},
+	},
+}
+
+// maturityFor returns the maturity with id, or nil for none.
+func maturityFor(id string) *projectMaturity {
+	for i := range maturities {
+		if maturities[i].ID == id {
+			return &maturities[i]
+		}
+	}
+	return nil
+}
+
+// Stage returns the project's audience and maturity, or nil when unset.
+func (d TemplateData) Stage() *projectMaturity {
+	return maturityFor(d.Maturity)
+}
+
+// Badges returns the README badges: the license, then the maturity's.
+func (d TemplateData) Badges() []string {
+	var badges []string
+	if b := d.LicenseBadge(); b != "" {
+		badges = append(badges, b)
+	}
+	if m := d.Stage(); m != nil && m.Badge != "" {
+		badges = append(badges, m.Badge)
+	}
+	return badges
+}
+
+// validateMaturity rejects unknown maturities.
+func validateMaturity(id string) error {
+	if id == "" || maturityFor(id) != nil {
+		return nil
+	}
+	return errors.New(T("validate.maturity", id))
+}
+
+// maturityOptions offers no maturity, then the catalog.
+func maturityOptions() []huh.Option[string] {
+	options := []huh.Option[string]{huh.NewOption(T("wizard.maturity.none"), "")}
+	for _, m := range maturities {
+		options = append(options, huh.NewOption(T("wizard.maturity."+m.ID), m.ID))
+	}
+	return options
+}
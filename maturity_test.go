@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaturityShapesReadme(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		maturity string
+		want     []string // In README.md
+		wantNot  []string
+	}{
+		{"", []string{"## Quick Start", "[Add installation and usage instructions as they emerge]"}, []string{"img.shields.io", "## Installation", "## Experiment notes"}},
+		{"prototype", []string{"status-experimental", "> **Prototype**", "## Quick Start", "## Experiment notes"}, []string{"## Installation", "## Operations"}},
+		{"internal", []string{"audience-internal", "> **Internal tool**", "## Support"}, []string{"## Experiment notes"}},
+		{"library", []string{"## Installation", "## Usage", "## Development\n\n```bash\ngo build ./...", "[How to build and test mod locally]"}, []string{"## Quick Start", "img.shields.io"}},
+		{"service", []string{"## Quick Start", "## Operations"}, []string{"img.shields.io", "## Support"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.maturity, func(t *testing.T) {
+			files, err := s.Render(TemplateData{ProjectName: "mod", Description: "Maturity test", Language: "go", Maturity: tt.maturity})
+			if err != nil {
+				t.Fatal(err)
+			}
+			readme := renderedContent(files, "README.md")
+			for _, want := range tt.want {
+				if !strings.Contains(readme, want) {
+					t.Errorf("README.md missing %q:\n%s", want, readme)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(readme, unwanted) {
+					t.Errorf("README.md shouldn't contain %q:\n%s", unwanted, readme)
+				}
+			}
+			todo := renderedContent(files, "TODO.md")
+			if m := maturityFor(tt.maturity); m != nil {
+				for _, task := range m.Tasks {
+					if !strings.Contains(todo, "- [ ] "+task+"\n") {
+						t.Errorf("TODO.md missing %q:\n%s", task, todo)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMaturityBadges(t *testing.T) {
+	d := TemplateData{License: "MIT", Visibility: "public", Maturity: "prototype"}
+	badges := d.Badges()
+	if len(badges) != 2 || !strings.Contains(badges[0], "License-MIT") || !strings.Contains(badges[1], "experimental") {
+		t.Errorf("badges = %v", badges)
+	}
+	if err := validateMaturity("beta"); err == nil {
+		t.Error("expected an unknown maturity to be refused")
+	}
+}
+
+// renderedContent returns the content of the rendered file at path.
+func renderedContent(files []RenderedFile, path string) string {
+	for _, f := range files {
+		if f.Path == path {
+			return string(f.Content)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+// Package main - merge.go
+//
+// PURPOSE:
+// This file implements a line-based three-way merge for `seed upgrade`.
+// It's responsible for:
+// - Combining local edits and template changes made against the same base
+// - Writing git-style conflict markers where both sides changed the same lines
+//
+// DESIGN PATTERNS:
+// - diff3 approach: lines the base shares with both sides are sync points;
+//   each region between sync points is resolved independently
+// - Reuses diffLines from diff.go for the base-to-side matchings
+//
+// USAGE:
+// merged, conflicts := merge3(base, local, upstream, "local", "seed v1.2.0")
+
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// merge3 merges the changes from base to local and from base to upstream.
+// Regions changed on only one side take that side; regions changed
+// identically on both take either; regions changed differently on both are
+// written between conflict markers labelled localLabel and upstreamLabel.
+// Returns the merged content and the number of conflicting regions.
+func merge3(base, local, upstream []byte, localLabel, upstreamLabel string) ([]byte, int) {
+	baseLines := splitLines(base)
+	localLines := splitLines(local)
+	upLines := splitLines(upstream)
+	toLocal := matchLines(baseLines, localLines)
+	toUp := matchLines(baseLines, upLines)
+
+	var out strings.Builder
+	conflicts := 0
+	i, l, u := 0, 0, 0
+	for {
+		// Find the next base line kept by both sides
+		next := i
+		for next < len(baseLines) && (toLocal[next] < 0 || toUp[next] < 0) {
+			next++
+		}
+		lEnd, uEnd := len(localLines), len(upLines)
+		if next < len(baseLines) {
+			lEnd, uEnd = toLocal[next], toUp[next]
+		}
+
+		b, lo, up := baseLines[i:next], localLines[l:lEnd], upLines[u:uEnd]
+		switch {
+		case slices.Equal(lo, b):
+			writeLines(&out, up, false)
+		case slices.Equal(up, b), slices.Equal(lo, up):
+			writeLines(&out, lo, false)
+		default:
+			conflicts++
+			out.WriteString("<<<<<<< " + localLabel + "\n")
+			writeLines(&out, lo, true)
+			out.WriteString("=======\n")
+			writeLines(&out, up, true)
+			out.WriteString(">>>>>>> " + upstreamLabel + "\n")
+		}
+
+		if next == len(baseLines) {
+			break
+		}
+		out.WriteString(baseLines[next])
+		i, l, u = next+1, lEnd+1, uEnd+1
+	}
+	return []byte(out.String()), conflicts
+}
+
+// matchLines maps each line of a to the line of b it's kept as in the LCS
+// edit script, or -1 if it's deleted.
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, op := range diffLines(a, b) {
+		if op.kind == ' ' {
+			match[op.a] = op.b
+		}
+	}
+	return match
+}
+
+// writeLines writes lines as-is. With terminate set, an unterminated last
+// line gets a newline so a following conflict marker starts its own line.
+func writeLines(out *strings.Builder, lines []string, terminate bool) {
+	for _, line := range lines {
+		out.WriteString(line)
+		if terminate && !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n")
+		}
+	}
+}
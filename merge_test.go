@@ -0,0 +1,71 @@
+package main
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		local         string
+		upstream      string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:     "upstream only",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n",
+			upstream: "a\nB\nc\n",
+			want:     "a\nB\nc\n",
+		},
+		{
+			name:     "local only",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\nmine\n",
+			upstream: "a\nb\nc\n",
+			want:     "a\nb\nc\nmine\n",
+		},
+		{
+			name:     "non-overlapping changes",
+			base:     "title\n\none\ntwo\nthree\n",
+			local:    "title\n\none\ntwo\nthree\nmy notes\n",
+			upstream: "Title\n\none\ntwo\nthree\n",
+			want:     "Title\n\none\ntwo\nthree\nmy notes\n",
+		},
+		{
+			name:     "same change on both sides",
+			base:     "a\nb\n",
+			local:    "a\nX\n",
+			upstream: "a\nX\n",
+			want:     "a\nX\n",
+		},
+		{
+			name:          "conflicting change",
+			base:          "a\nb\nc\n",
+			local:         "a\nmine\nc\n",
+			upstream:      "a\ntheirs\nc\n",
+			want:          "a\n<<<<<<< local\nmine\n=======\ntheirs\n>>>>>>> seed 2\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name:          "no base",
+			base:          "",
+			local:         "mine\n",
+			upstream:      "theirs\n",
+			want:          "<<<<<<< local\nmine\n=======\ntheirs\n>>>>>>> seed 2\n",
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := merge3([]byte(tt.base), []byte(tt.local), []byte(tt.upstream), "local", "seed 2")
+			if string(got) != tt.want {
+				t.Errorf("merge3 mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if conflicts != tt.wantConflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
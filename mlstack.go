@@ -0,0 +1,123 @@
+// Package main - mlstack.go
+//
+// PURPOSE:
+// This file adds what ML projects need to the dev container. It's
+// responsible for:
+// - The GPU answer: none, optional (used when the host has one) or CUDA
+//   (an NVIDIA GPU is required and the CUDA toolkit is installed)
+// - CUDA: the nvidia-cuda feature on the stack's image, `--gpus all` and a
+//   required GPU in hostRequirements
+// - Model caches for the ML workload: Hugging Face and PyTorch downloads in
+//   volumes shared by every seed project, so a model is fetched once per
+//   machine rather than once per project or rebuild
+//
+// DESIGN PATTERNS:
+// - CUDA comes from a dev container feature, not a CUDA base image: MCR has
+//   no CUDA images, and nvidia/cuda images lack the vscode user and tooling
+//   every other part of the container relies on, so the stack's image (and
+//   the imageRegistry mirror) still applies
+// - `--gpus all` is only added with CUDA: it stops the container starting on
+//   a host without the NVIDIA runtime, which "optional" must not do
+// - A user mount on a cache's path replaces seed's volume for it (e.g. to
+//   bind the host's own Hugging Face cache)
+//
+// USAGE:
+// applyMLStack(&dc, data)
+// data.GPU, data.CUDA = gpuFlags(choice)
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// cudaFeature installs the CUDA toolkit (and cuDNN) on any Debian/Ubuntu image.
+const cudaFeature = "ghcr.io/devcontainers/features/nvidia-cuda:1"
+
+// GPU answers, as the wizard offers them.
+const (
+	gpuNone     = "none"
+	gpuOptional = "optional"
+	gpuCUDA     = "cuda"
+)
+
+// modelCache is a download cache ML libraries keep under the home directory.
+type modelCache struct {
+	Volume string // Named volume, shared by every project
+	Dir    string // Relative to /home/vscode
+}
+
+// modelCaches are mounted for the ML workload.
+var modelCaches = []modelCache{
+	{"seed-huggingface-cache", ".cache/huggingface"},
+	{"seed-torch-cache", ".cache/torch"},
+}
+
+// gpuOptions returns the wizard's GPU choices.
+func gpuOptions() []huh.Option[string] {
+	return []huh.Option[string]{
+		huh.NewOption(T("wizard.gpu.none"), gpuNone),
+		huh.NewOption(T("wizard.gpu.optional"), gpuOptional),
+		huh.NewOption(T("wizard.gpu.cuda"), gpuCUDA),
+	}
+}
+
+// gpuChoice returns the GPU answer w's flags make.
+func gpuChoice(w WizardData) string {
+	switch {
+	case w.CUDA:
+		return gpuCUDA
+	case w.GPU:
+		return gpuOptional
+	}
+	return gpuNone
+}
+
+// gpuFlags turns a GPU answer back into WizardData's GPU and CUDA flags.
+func gpuFlags(choice string) (gpu, cuda bool) {
+	return choice != gpuNone, choice == gpuCUDA
+}
+
+// ModelCacheDirs returns the cache directories mounted into the container,
+// for the Dockerfile to create as vscode before Docker mounts them.
+func (d TemplateData) ModelCacheDirs() []string {
+	var dirs []string
+	for _, c := range d.modelCaches() {
+		dirs = append(dirs, "/home/vscode/"+c.Dir)
+	}
+	return dirs
+}
+
+// modelCaches returns the caches d's dev container mounts: none unless the
+// workload is ML, and none whose path a user mount already takes.
+func (d TemplateData) modelCaches() []modelCache {
+	if d.Workload != "ml" {
+		return nil
+	}
+	var caches []modelCache
+	for _, c := range modelCaches {
+		target := "/home/vscode/" + c.Dir
+		if !slices.ContainsFunc(d.Mounts, func(m string) bool {
+			_, t, err := mountSpec(m)
+			return err == nil && strings.TrimSuffix(t, "/") == target
+		}) {
+			caches = append(caches, c)
+		}
+	}
+	return caches
+}
+
+// applyMLStack adds CUDA and the model caches to dc.
+func applyMLStack(dc *DevContainer, data TemplateData) {
+	if data.CUDA {
+		dc.Features[cudaFeature] = map[string]interface{}{"installCudnn": true}
+		dc.RunArgs = append(dc.RunArgs, "--gpus", "all")
+	}
+	for _, c := range data.modelCaches() {
+		dc.Mounts = append(dc.Mounts, fmt.Sprintf("source=%s,target=/home/vscode/%s,type=volume", c.Volume, c.Dir))
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMLStackDevContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       TemplateData
+		cuda       bool
+		caches     []string
+		dockerfile []string
+	}{
+		{
+			name: "general",
+			data: TemplateData{Workload: "general", GPU: true},
+		},
+		{
+			name:       "ml",
+			data:       TemplateData{Workload: "ml"},
+			caches:     []string{"seed-huggingface-cache", "seed-torch-cache"},
+			dockerfile: []string{"RUN mkdir -p /home/vscode/.cache/huggingface /home/vscode/.cache/torch"},
+		},
+		{
+			name:   "ml with cuda",
+			data:   TemplateData{Workload: "ml", GPU: true, CUDA: true},
+			cuda:   true,
+			caches: []string{"seed-huggingface-cache", "seed-torch-cache"},
+		},
+		{
+			// The host's own cache replaces seed's volume for that path
+			name:       "user mount on a cache",
+			data:       TemplateData{Workload: "ml", Mounts: mountSpecs([]string{"${localEnv:HOME}/.cache/huggingface:/home/vscode/.cache/huggingface"})},
+			caches:     []string{"seed-torch-cache"},
+			dockerfile: []string{"RUN mkdir -p /home/vscode/.cache/torch\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			data.ProjectName, data.Description = "ml-demo", "An ML project"
+			data.IncludeDevContainer, data.DevContainerImage = true, "python:3-3.12"
+			target := mustScaffold(t, data)
+			dc := readDevContainer(t, target)
+
+			_, hasCUDA := dc.Features[cudaFeature]
+			if hasCUDA != tt.cuda || slices.Equal(dc.RunArgs, []string{"--gpus", "all"}) != tt.cuda {
+				t.Errorf("cuda=%t: got feature %t, runArgs %v", tt.cuda, hasCUDA, dc.RunArgs)
+			}
+			var caches []string
+			for _, m := range dc.Mounts {
+				if volume, ok := strings.CutPrefix(m, "source=seed-"); ok {
+					caches = append(caches, "seed-"+strings.SplitN(volume, ",", 2)[0])
+				}
+			}
+			if !slices.Equal(caches, tt.caches) {
+				t.Errorf("cache volumes = %v, want %v", caches, tt.caches)
+			}
+
+			dockerfile, err := os.ReadFile(filepath.Join(target, ".devcontainer", "Dockerfile"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.dockerfile {
+				if !strings.Contains(string(dockerfile), want) {
+					t.Errorf("expected %q in the Dockerfile:\n%s", want, dockerfile)
+				}
+			}
+			if len(tt.caches) == 0 && strings.Contains(string(dockerfile), ".cache") {
+				t.Errorf("no cache directories expected:\n%s", dockerfile)
+			}
+		})
+	}
+}
+
+func TestGPUChoice(t *testing.T) {
+	for _, choice := range []string{gpuNone, gpuOptional, gpuCUDA} {
+		var w WizardData
+		w.GPU, w.CUDA = gpuFlags(choice)
+		if got := gpuChoice(w); got != choice {
+			t.Errorf("gpuChoice(gpuFlags(%q)) = %q", choice, got)
+		}
+	}
+	// cuda alone in an answers file still asks for a GPU
+	if td := (WizardData{CUDA: true}).ToTemplateData(); !td.GPU {
+		t.Error("CUDA should imply a GPU")
+	}
+}
+
+func TestMLGitignoreDefaults(t *testing.T) {
+	if got := defaultGitignore("python", "ml"); !slices.Equal(got, []string{"os", "editor", "python", "jupyter", "ml"}) {
+		t.Errorf("ml defaults = %v", got)
+	}
+	files, err := (&Scaffolder{templates: templateSetFor("embedded", templatesFS, "templates")}).Render(TemplateData{ProjectName: "ml-demo", Description: "An ML project", Language: "python", Workload: "ml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitignore := renderedContent(files, ".gitignore")
+	for _, want := range []string{"*.safetensors", "wandb/", ".ipynb_checkpoints/"} {
+		if !strings.Contains(gitignore, want) {
+			t.Errorf("expected %q in .gitignore:\n%s", want, gitignore)
+		}
+	}
+}
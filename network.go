@@ -0,0 +1,131 @@
+// Package main - network.go
+//
+// PURPOSE:
+// This file makes seed's network access work behind corporate proxies.
+// It's responsible for:
+// - The HTTP client for seed's own requests: proxy from HTTP(S)_PROXY /
+//   NO_PROXY, plus the extra CA bundle if one is set
+// - Being the one list of them. Seed only reaches the network for:
+//   - the org config and its signature (orgconfig.go), over https or git
+//   - `seed profile import` from a URL (profile.go), fetched the same way
+//   - an https auditLog endpoint (audit.go), when config sets one
+//   - telemetry (telemetry.go), only after opting in
+//   - `seed images refresh` (imagecatalog.go, registry.go), to the
+//     registry the images come from
+// - The environment for external commands (git, gh), so they see the same
+//   proxy and CA bundle
+//
+// DESIGN PATTERNS:
+// - The CA bundle comes from SEED_CA_BUNDLE or config.json "caBundle". It's
+//   machine-specific, so like locale it's never taken from the org config
+//   (which may itself need the bundle to be fetched)
+// - For seed's own requests the bundle adds to the system roots. git and gh
+//   use it instead of their defaults, so it should be a complete bundle
+// - Proxy variables are passed to commands in upper and lower case, since
+//   curl (under git) ignores uppercase HTTP_PROXY
+//
+// USAGE:
+// client, err := httpClient(orgConfigTimeout)
+// cmd.Env = commandEnv()
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// caBundleEnv names a PEM file of extra CA certificates to trust.
+const caBundleEnv = "SEED_CA_BUNDLE"
+
+// proxyVars are the standard proxy variables.
+var proxyVars = []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"}
+
+// caBundlePath returns SEED_CA_BUNDLE, else config.json's caBundle.
+func caBundlePath() string {
+	if p := strings.TrimSpace(os.Getenv(caBundleEnv)); p != "" {
+		return p
+	}
+	cfg, _ := loadUserConfig()
+	return strings.TrimSpace(cfg.CABundle)
+}
+
+// certPool returns the system roots plus the certificates in bundle.
+func certPool(bundle string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s: no PEM certificates found", bundle)
+	}
+	return pool, nil
+}
+
+// httpClient returns a client for seed's own requests, honouring the proxy
+// variables and the CA bundle.
+func httpClient(timeout time.Duration) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if bundle := caBundlePath(); bundle != "" {
+		pool, err := certPool(bundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// commandEnv returns the environment for external commands: seed's own,
+// with each proxy variable also set in the other case and the CA bundle
+// passed to git (GIT_SSL_CAINFO) and gh (SSL_CERT_FILE).
+func commandEnv() []string {
+	env := os.Environ()
+	for _, name := range proxyVars {
+		upper, lower := os.Getenv(name), os.Getenv(strings.ToLower(name))
+		switch {
+		case upper != "" && lower == "":
+			env = append(env, strings.ToLower(name)+"="+upper)
+		case lower != "" && upper == "":
+			env = append(env, name+"="+lower)
+		}
+	}
+	if bundle := caBundlePath(); bundle != "" {
+		env = append(env, "GIT_SSL_CAINFO="+bundle, "SSL_CERT_FILE="+bundle)
+	}
+	return append(env, gitDateEnv()...)
+}
+
+// describeNetwork summarizes the proxy and CA bundle seed would use to reach
+// target, for `seed doctor`.
+func describeNetwork(target string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	detail := "direct"
+	proxy, err := http.ProxyFromEnvironment(req)
+	if err != nil {
+		return "", fmt.Errorf("proxy: %w", err)
+	}
+	if proxy != nil {
+		detail = "proxy " + proxy.Redacted()
+	}
+	if bundle := caBundlePath(); bundle != "" {
+		if _, err := certPool(bundle); err != nil {
+			return detail, err
+		}
+		detail += ", CA bundle " + bundle
+	}
+	return detail, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeServerCA writes srv's certificate as a PEM bundle and returns its path.
+func writeServerCA(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	block := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(bundle, block, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestHTTPClientCABundle(t *testing.T) {
+	isolateConfig(t)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	get := func() error {
+		client, err := httpClient(5 * time.Second)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Setenv(caBundleEnv, "")
+	if err := get(); err == nil {
+		t.Fatal("expected the test server's certificate to be untrusted without a bundle")
+	}
+
+	// From config.json
+	bundle := writeServerCA(t, srv)
+	if err := saveUserConfig(userConfig{CABundle: bundle}); err != nil {
+		t.Fatal(err)
+	}
+	if err := get(); err != nil {
+		t.Fatalf("caBundle in config.json: %v", err)
+	}
+
+	// SEED_CA_BUNDLE wins over config.json
+	notPEM := filepath.Join(t.TempDir(), "bundle.txt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(caBundleEnv, notPEM)
+	if err := get(); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Fatalf("expected an invalid bundle error, got %v", err)
+	}
+	if _, err := describeNetwork("https://github.com"); err == nil {
+		t.Error("describeNetwork should report the invalid bundle")
+	}
+}
+
+func TestCommandEnv(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("https_proxy", "")
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("http_proxy", "")
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "localhost,.internal")
+	t.Setenv(caBundleEnv, "/etc/ssl/corp.pem")
+
+	env := commandEnv()
+	for _, want := range []string{
+		"https_proxy=http://proxy.example.com:3128",
+		"NO_PROXY=localhost,.internal",
+		"GIT_SSL_CAINFO=/etc/ssl/corp.pem",
+		"SSL_CERT_FILE=/etc/ssl/corp.pem",
+	} {
+		if !slices.Contains(env, want) {
+			t.Errorf("commandEnv() is missing %s", want)
+		}
+	}
+	if slices.ContainsFunc(env, func(kv string) bool { return strings.HasPrefix(kv, "http_proxy=h") }) {
+		t.Error("commandEnv() shouldn't invent an http_proxy")
+	}
+}
@@ -0,0 +1,64 @@
+// Package main - nextsteps.go
+//
+// PURPOSE:
+// This file renders the "next steps" printed after the wizard scaffolds a
+// project. It's responsible for:
+// - Collecting what the steps depend on: where the project is, whether it
+//   has a dev container, its stack, the chosen agent and whether git ran
+// - Rendering templates/next-steps.txt.tmpl with it
+//
+// DESIGN PATTERNS:
+// - A template, not Printf calls, so the epilogue can be customized the same
+//   way as generated files
+// - Every step is a command the user can paste; commentary goes after "#"
+//
+// USAGE:
+// text, err := renderNextSteps(scaffolder, targetDir, wizardData, gitInitialized)
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+// nextStepsData is what templates/next-steps.txt.tmpl renders from: the
+// project's template data plus facts about this run.
+type nextStepsData struct {
+	TemplateData
+	Dir   string  // Project directory as the user would cd to it (shell-quoted if needed)
+	Agent *aiTool // First chat tool chosen, started as the agent (nil for none)
+	Git   bool    // Whether a git repository was initialized
+	Repo  string  // GitHub repository name derived from the project name
+
+	HomepageArg string // Website for gh repo create --homepage, shell-quoted ("" for none)
+}
+
+// shellSafe matches words that need no quoting in a POSIX shell.
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./~+-]+$`)
+
+// repoNameUnsafe matches runs of characters GitHub replaces in repo names.
+var repoNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// renderNextSteps renders the next steps for a project scaffolded into dir.
+func renderNextSteps(s *Scaffolder, dir string, w WizardData, git bool) (string, error) {
+	data := nextStepsData{TemplateData: w.ToTemplateData(), Dir: dir, Git: git}
+	data.Stack = stackFor(data.Language)
+	if !shellSafe.MatchString(dir) {
+		data.Dir = shellQuote(dir)
+	}
+	if len(data.ChatTools) > 0 {
+		data.Agent = &data.ChatTools[0]
+	}
+	data.Repo = repoNameUnsafe.ReplaceAllString(data.ProjectName, "-")
+	if website := data.Website(); website != "" {
+		data.HomepageArg = shellQuote(website)
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "next-steps.txt.tmpl", data); err != nil {
+		return "", fmt.Errorf("failed to render next steps: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderNextSteps(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		data    WizardData
+		git     bool
+		want    []string
+		wantNot []string
+	}{
+		{
+			name:    "minimal",
+			dir:     "myapp",
+			data:    WizardData{ProjectName: "myapp"},
+			want:    []string{"Next steps:\n  cd myapp\n", "# Point your agent at AGENTS.md"},
+			wantNot: []string{"code .", "gh repo create"},
+		},
+		{
+			name:    "jetbrains dev container",
+			dir:     "myapp",
+			data:    WizardData{ProjectName: "myapp", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", IDE: ideJetBrains},
+			want:    []string{"# JetBrains Gateway: Dev Containers > New Dev Container"},
+			wantNot: []string{"code ."},
+		},
+		{
+			name:    "dev container without an IDE",
+			dir:     "myapp",
+			data:    WizardData{ProjectName: "myapp", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", IDE: ideNone},
+			want:    []string{"  devcontainer up --workspace-folder .\n"},
+			wantNot: []string{"code ."},
+		},
+		{
+			name: "dev container, go, agent and git",
+			dir:  "~/dev/my app",
+			data: WizardData{ProjectName: "My App", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", Language: "go", ChatTools: []string{"claude"}},
+			git:  true,
+			want: []string{
+				"cd '~/dev/my app'",
+				`code .  # then "Dev Containers: Reopen in Container"`,
+				"  go mod tidy\n",
+				"claude  # Claude Code reads AGENTS.md first",
+				"gh repo create My-App --private --source=. --push",
+			},
+			wantNot: []string{"gh repo edit"},
+		},
+		{
+			name: "public with topics",
+			dir:  "tool",
+			data: WizardData{ProjectName: "tool", Visibility: "public", Topics: []string{"cli", "go"}},
+			git:  true,
+			want: []string{"gh repo create tool --public --source=. --push\n  gh repo edit --add-topic cli,go"},
+		},
+		{
+			name: "agent action",
+			dir:  "tool",
+			data: WizardData{ProjectName: "tool", AgentAction: true},
+			git:  true,
+			want: []string{"--source=. --push\n  gh secret set ANTHROPIC_API_KEY"},
+		},
+		{
+			name: "documentation as the website",
+			dir:  "tool",
+			data: WizardData{ProjectName: "tool", Documentation: "https://docs.example.com/?v=1&lang=en"},
+			git:  true,
+			want: []string{"gh repo create tool --private --homepage 'https://docs.example.com/?v=1&lang=en' --source=. --push"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderNextSteps(s, tt.dir, tt.data, tt.git)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("missing %q in:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("unexpected %q in:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
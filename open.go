@@ -0,0 +1,70 @@
+// Package main - open.go
+//
+// PURPOSE:
+// This file opens a freshly scaffolded project in the user's editor (--open).
+// It's responsible for:
+// - Picking the command: VS Code straight into the dev container, VS Code on
+//   the folder, or $EDITOR
+// - Building the dev-container folder URI VS Code understands
+//
+// DESIGN PATTERNS:
+// - Choosing (openCommand) is separate from running, so the choice is
+//   testable without an editor installed
+// - VS Code returns as soon as its window is up, so it goes through
+//   runCommand; $EDITOR may be a terminal editor and gets seed's terminal
+// - Failing to open is reported, not fatal: the project is already written
+//
+// USAGE:
+// if err := openProject(dir, data.IncludeDevContainer); err != nil { ... }
+
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// devContainerURI returns the --folder-uri that opens absDir inside its dev
+// container, at the default /workspaces/<name> mount.
+func devContainerURI(absDir string) string {
+	return "vscode-remote://dev-container+" + hex.EncodeToString([]byte(absDir)) + "/workspaces/" + filepath.Base(absDir)
+}
+
+// openCommand returns the command that opens absDir: VS Code (in the dev
+// container when there is one) if lookPath finds `code`, else editor (the
+// value of $EDITOR, which may carry its own arguments).
+func openCommand(absDir string, devContainer bool, lookPath func(string) (string, error), editor string) ([]string, error) {
+	if _, err := lookPath("code"); err == nil {
+		if devContainer {
+			return []string{"code", "--folder-uri", devContainerURI(absDir)}, nil
+		}
+		return []string{"code", absDir}, nil
+	}
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		return append(fields, absDir), nil
+	}
+	return nil, errors.New(T("open.noEditor"))
+}
+
+// openProject opens dir in the user's editor.
+func openProject(dir string, devContainer bool) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	argv, err := openCommand(absDir, devContainer, exec.LookPath, os.Getenv("EDITOR"))
+	if err != nil {
+		return err
+	}
+	if argv[0] == "code" {
+		_, err = runCommand("", commandTimeout(defaultCommandTimeout), argv[0], argv[1:]...)
+		return err
+	}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
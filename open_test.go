@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestOpenCommand(t *testing.T) {
+	found := func(string) (string, error) { return "/usr/bin/code", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+
+	tests := []struct {
+		name         string
+		lookPath     func(string) (string, error)
+		devContainer bool
+		editor       string
+		want         []string
+	}{
+		{"code", found, false, "vim", []string{"code", "/home/me/myapp"}},
+		{"code into dev container", found, true, "", []string{"code", "--folder-uri", "vscode-remote://dev-container+2f686f6d652f6d652f6d79617070/workspaces/myapp"}},
+		{"editor with arguments", missing, true, "emacs -nw", []string{"emacs", "-nw", "/home/me/myapp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := openCommand("/home/me/myapp", tt.devContainer, tt.lookPath, tt.editor)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := openCommand("/home/me/myapp", false, missing, " "); err == nil {
+		t.Fatal("expected an error with no code and no $EDITOR")
+	}
+}
@@ -0,0 +1,438 @@
+// Package main - orgconfig.go
+//
+// PURPOSE:
+// This file loads the organization-wide config named by SEED_ORG_CONFIG and
+// merges it below the user's config.json. It's responsible for:
+// - Fetching the org config over HTTPS, from a git repository, or from a
+//   local path
+// - Verifying a fresh copy against a sha256 pin or minisign signature when
+//   the user has set one up (integrity.go)
+// - Caching it in seed's config directory, refetching after orgConfigTTL and
+//   falling back to the cached copy when the source can't be reached
+// - Merging: the user's settings win, lists are combined, and the org's
+//   required components are applied to every project's answers
+//
+// DESIGN PATTERNS:
+// - Same JSON shape as config.json (userConfig), so anything a user can set
+//   an organization can default
+// - Locale and telemetry stay personal: they're read from config.json only
+// - Fetched at most once per process; a broken org config never stops seed,
+//   it's reported by `seed doctor`
+//
+// USAGE:
+// cfg, err := loadConfig() // org config merged below config.json
+// err = requireComponents(&answers, cfg.Require)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	orgConfigEnv       = "SEED_ORG_CONFIG" // Where the org config lives
+	orgConfigTTL       = time.Hour         // How long a fetched copy is used before refetching
+	orgConfigFile      = "config.json"     // File read from a git source without a #path
+	orgConfigCacheFile = "org-config.json" // Cache in seed's config directory
+	orgConfigMaxSize   = 1 << 20           // Larger responses are rejected
+	orgConfigTimeout   = 15 * time.Second  // HTTPS fetch limit
+)
+
+// orgConfigSHA256Env pins the sha256 of the org config.
+const orgConfigSHA256Env = "SEED_ORG_CONFIG_SHA256"
+
+// orgConfigCache is the cached copy of the org config and where it came from.
+type orgConfigCache struct {
+	Source    string          `json:"source"`
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Config    json.RawMessage `json:"config"`
+}
+
+// orgConfigState is the outcome of loading the org config, kept for the
+// rest of the process.
+type orgConfigState struct {
+	Source    string     // SEED_ORG_CONFIG or the imported bundle's config ("" when neither)
+	Config    userConfig // The org config (zero when unset or unavailable)
+	FetchedAt time.Time  // When the copy in use was fetched
+	Stale     bool       // The source failed and an older cached copy is in use
+	Err       error      // Why no org config is in use, or why refreshing failed
+}
+
+var (
+	orgConfigMu   sync.Mutex
+	orgConfigMemo *orgConfigState
+)
+
+// loadConfig returns the effective config: the org config (if any) merged
+// below config.json. An unavailable org config is reported in the error but
+// the user's config is still returned, so callers that ignore the error
+// carry on with it.
+func loadConfig() (userConfig, error) {
+	user, err := loadUserConfig()
+	if err != nil {
+		return user, err
+	}
+	org := loadOrgConfig()
+	if org.Source == "" {
+		return user, nil
+	}
+	if org.Config.isZero() && org.Err != nil {
+		return user, org.Err
+	}
+	return mergeConfig(org.Config, user), nil
+}
+
+// loadOrgConfig loads the org config named by SEED_ORG_CONFIG, else the one
+// from an imported bundle, once per process (per source).
+func loadOrgConfig() orgConfigState {
+	source := strings.TrimSpace(os.Getenv(orgConfigEnv))
+	if source == "" {
+		source = importedOrgConfig()
+	}
+	orgConfigMu.Lock()
+	defer orgConfigMu.Unlock()
+	if orgConfigMemo != nil && orgConfigMemo.Source == source {
+		return *orgConfigMemo
+	}
+	state := orgConfigState{Source: source}
+	if source != "" {
+		state = fetchOrgConfigCached(source, time.Now())
+	}
+	orgConfigMemo = &state
+	return state
+}
+
+// fetchOrgConfigCached returns the cached org config while it's fresh, and
+// otherwise fetches it, falling back to a stale cached copy if that fails.
+func fetchOrgConfigCached(source string, now time.Time) orgConfigState {
+	state := orgConfigState{Source: source}
+	cache, cacheErr := readOrgConfigCache(source)
+	if cacheErr == nil && now.Sub(cache.FetchedAt) < orgConfigTTL {
+		state.FetchedAt = cache.FetchedAt
+		state.Err = json.Unmarshal(cache.Config, &state.Config)
+		return state
+	}
+
+	raw, err := fetchOrgConfig(source)
+	if err == nil {
+		err = verifyOrgConfig(source, raw)
+	}
+	if err == nil {
+		err = json.Unmarshal(raw, &state.Config)
+	}
+	if err != nil {
+		state.Err = fmt.Errorf("org config %s: %w", source, err)
+		debugf("org config: %v", state.Err)
+		if cacheErr == nil && json.Unmarshal(cache.Config, &state.Config) == nil {
+			state.FetchedAt, state.Stale = cache.FetchedAt, true
+		}
+		return state
+	}
+
+	state.FetchedAt = now
+	if err := writeOrgConfigCache(orgConfigCache{Source: source, FetchedAt: now, Config: raw}); err != nil {
+		debugf("org config cache: %v", err)
+	}
+	return state
+}
+
+// orgConfigCachePath returns where the fetched org config is cached.
+func orgConfigCachePath() (string, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, orgConfigCacheFile), nil
+}
+
+// readOrgConfigCache returns the cached org config, if it came from source.
+func readOrgConfigCache(source string) (orgConfigCache, error) {
+	var cache orgConfigCache
+	cachePath, err := orgConfigCachePath()
+	if err != nil {
+		return cache, err
+	}
+	raw, err := os.ReadFile(cachePath)
+	if err != nil {
+		return cache, err
+	}
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		return cache, err
+	}
+	if cache.Source != source {
+		return cache, errors.New("cached org config is from another source")
+	}
+	return cache, nil
+}
+
+// writeOrgConfigCache replaces the cached org config.
+func writeOrgConfigCache(cache orgConfigCache) error {
+	cachePath, err := orgConfigCachePath()
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(cachePath, append(raw, '\n'), 0644)
+}
+
+// fetchOrgConfig reads the org config from source: an https:// URL, a git
+// repository (git+https://..., ssh://..., git@host:..., or any URL ending in
+// .git; "#path/in/repo.json" picks the file, config.json by default), or a
+// local path.
+func fetchOrgConfig(source string) ([]byte, error) {
+	if repo, file, ok := gitConfigSource(source); ok {
+		return fetchGitConfig(repo, file)
+	}
+	switch {
+	case strings.HasPrefix(source, "https://"):
+		return fetchHTTPSConfig(source)
+	case strings.Contains(source, "://"):
+		return nil, errors.New("use an https:// or git URL, or a local path")
+	}
+	return os.ReadFile(source)
+}
+
+// verifyOrgConfig checks a freshly fetched org config against the pin in
+// SEED_ORG_CONFIG_SHA256 and, when the user's config lists trustedKeys,
+// against the minisign signature published next to it. The trust roots come
+// from outside the org config, which can't vouch for itself.
+func verifyOrgConfig(source string, raw []byte) error {
+	// A git source is cloned a second time for its signature; it's only
+	// fetched once an hour
+	return verifyOrgConfigWith(raw, func() ([]byte, error) { return fetchOrgConfig(signatureSource(source)) })
+}
+
+// verifyOrgConfigWith is verifyOrgConfig with the signature read by sig, which is
+// only called when the user's config lists trustedKeys.
+func verifyOrgConfigWith(raw []byte, sig func() ([]byte, error)) error {
+	if pin := os.Getenv(orgConfigSHA256Env); pin != "" {
+		if err := verifySHA256(raw, pin); err != nil {
+			return err
+		}
+	}
+	user, err := loadUserConfig()
+	if err != nil || len(user.TrustedKeys) == 0 {
+		return nil
+	}
+	signature, err := sig()
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+	return verifyMinisign(raw, signature, user.TrustedKeys)
+}
+
+// signatureSource returns where the signature of the org config at source
+// is published: the same path with .minisig appended.
+func signatureSource(source string) string {
+	if _, _, ok := gitConfigSource(source); ok {
+		repo, file, _ := strings.Cut(source, "#")
+		if file == "" {
+			file = orgConfigFile
+		}
+		return repo + "#" + file + minisignSigSuffix
+	}
+	return source + minisignSigSuffix
+}
+
+// gitConfigSource reports whether source names a git repository, splitting
+// off the file to read from it.
+func gitConfigSource(source string) (repo, file string, ok bool) {
+	repo, file, _ = strings.Cut(source, "#")
+	if file == "" {
+		file = orgConfigFile
+	}
+	switch {
+	case strings.HasPrefix(repo, "git+"):
+		return strings.TrimPrefix(repo, "git+"), file, true
+	case strings.HasPrefix(repo, "ssh://"), strings.HasPrefix(repo, "git@"), strings.HasSuffix(repo, ".git"):
+		return repo, file, true
+	}
+	return "", "", false
+}
+
+// fetchGitConfig shallow-clones repo into a temporary directory and reads file.
+func fetchGitConfig(repo, file string) ([]byte, error) {
+	tmp, err := os.MkdirTemp("", "seed-org-config-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// Not commandTimeout(): that reads the effective config, which is what's
+	// being loaded
+	timeout := defaultCommandTimeout
+	if user, err := loadUserConfig(); err == nil {
+		timeout = commandTimeoutFrom(user, timeout)
+	}
+	if _, err := runCommand("", timeout, "git", "clone", "--depth", "1", "--quiet", repo, tmp); err != nil {
+		return nil, fmt.Errorf("git clone failed: %w", err)
+	}
+	path := filepath.Join(tmp, filepath.FromSlash(file))
+	if rel, err := filepath.Rel(tmp, path); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("%s is outside the repository", file)
+	}
+	return os.ReadFile(path)
+}
+
+// fetchHTTPSConfig downloads url.
+func fetchHTTPSConfig(url string) ([]byte, error) {
+	client, err := httpClient(orgConfigTimeout)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, orgConfigMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > orgConfigMaxSize {
+		return nil, fmt.Errorf("larger than %d bytes", orgConfigMaxSize)
+	}
+	return raw, nil
+}
+
+// isZero reports whether cfg sets nothing.
+func (cfg userConfig) isZero() bool {
+	raw, _ := json.Marshal(cfg)
+	return string(raw) == "{}"
+}
+
+// mergeConfig layers user over org: the user's values win, list settings
+// are combined (org entries first), and locale and telemetry come from the
+// user alone. An org allowlist, set of trusted keys or registry list replaces
+// the user's, so it can't be widened, the org's audit log can't be
+// redirected, and output limits can only be lowered.
+func mergeConfig(org, user userConfig) userConfig {
+	merged := user
+	if merged.CommandTimeout == "" {
+		merged.CommandTimeout = org.CommandTimeout
+	}
+	if merged.License == "" {
+		merged.License = org.License
+	}
+	if merged.ImageRegistry == "" {
+		merged.ImageRegistry = org.ImageRegistry
+	}
+	merged.ForwardEnv = unionStrings(org.ForwardEnv, user.ForwardEnv)
+	merged.Mounts = unionStrings(org.Mounts, user.Mounts)
+	merged.Require = unionStrings(org.Require, user.Require)
+	merged.RequiredFiles = unionStrings(org.RequiredFiles, user.RequiredFiles)
+	merged.ReadmeOmit = unionStrings(org.ReadmeOmit, user.ReadmeOmit)
+	if len(org.AllowedSources) > 0 {
+		merged.AllowedSources = org.AllowedSources
+	}
+	if len(org.TrustedKeys) > 0 {
+		merged.TrustedKeys = org.TrustedKeys
+	}
+	if len(org.AllowedRegistries) > 0 {
+		merged.AllowedRegistries = org.AllowedRegistries
+	}
+	merged.Branding = mergeBranding(org.Branding, user.Branding)
+	if org.AuditLog != "" {
+		merged.AuditLog = org.AuditLog
+	}
+	if org.MaxFiles > 0 && (user.MaxFiles <= 0 || org.MaxFiles < user.MaxFiles) {
+		merged.MaxFiles = org.MaxFiles
+	}
+	merged.MaxSize = lowerMaxSize(org.MaxSize, user.MaxSize)
+	merged.MinSeedVersion = newerVersion(org.MinSeedVersion, user.MinSeedVersion)
+	if org.SeedVersionCheck != "" {
+		merged.SeedVersionCheck = org.SeedVersionCheck
+	}
+	merged.Format = org.Format || user.Format
+	if len(org.Formatters) > 0 {
+		merged.Formatters = maps.Clone(org.Formatters)
+		maps.Copy(merged.Formatters, user.Formatters)
+	}
+
+	merged.AITools = nil
+	for _, tool := range org.AITools {
+		if !slices.ContainsFunc(user.AITools, func(t aiTool) bool { return t.ID == tool.ID }) {
+			merged.AITools = append(merged.AITools, tool)
+		}
+	}
+	merged.AITools = append(merged.AITools, user.AITools...)
+	return merged
+}
+
+// unionStrings returns a followed by the entries of b not already in a.
+func unionStrings(a, b []string) []string {
+	var out []string
+	for _, s := range slices.Concat(a, b) {
+		if !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// requirableComponents are the components config can require, and how each
+// is turned on in a project's answers.
+var requirableComponents = map[string]func(w *WizardData){
+	"git":            func(w *WizardData) { w.InitGit = true },
+	"vscodeConfig":   func(w *WizardData) { w.VSCodeConfig = true },
+	"licenseHeaders": func(w *WizardData) { w.LicenseHeaders = true },
+	"docHealth":      func(w *WizardData) { w.DocHealth = true },
+	"docGuard":       func(w *WizardData) { w.DocGuard = true },
+	"agentAction":    func(w *WizardData) { w.AgentAction = true },
+	"devcontainer": func(w *WizardData) {
+		w.IncludeDevContainer = true
+		if w.DevContainerImage == "" {
+			w.DevContainerImage = "universal"
+			if s := stackFor(w.Language); s != nil {
+				w.DevContainerImage = s.Image
+			}
+		}
+	},
+}
+
+// validateRequire rejects components config can't require.
+func validateRequire(required []string) error {
+	for _, name := range required {
+		if requirableComponents[name] == nil && name != "license" {
+			return fmt.Errorf("unknown required component %q (use git, devcontainer, vscodeConfig, docHealth, docGuard, agentAction, license or licenseHeaders)", name)
+		}
+	}
+	return nil
+}
+
+// requireComponents turns on every required component in w. "license" can't
+// be turned on without choosing one, so a project without a license is an
+// error.
+func requireComponents(w *WizardData, required []string) error {
+	if err := validateRequire(required); err != nil {
+		return err
+	}
+	for _, name := range required {
+		if name != "license" {
+			requirableComponents[name](w)
+		} else if licenseSPDX(w.License) == "" {
+			return errors.New(T("org.licenseRequired"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useOrgConfig points SEED_ORG_CONFIG at source for the test, forgetting any
+// org config loaded earlier in the process.
+func useOrgConfig(t *testing.T, source string) {
+	t.Helper()
+	t.Setenv(orgConfigEnv, source)
+	orgConfigMu.Lock()
+	orgConfigMemo = nil
+	orgConfigMu.Unlock()
+	t.Cleanup(func() {
+		orgConfigMu.Lock()
+		orgConfigMemo = nil
+		orgConfigMu.Unlock()
+	})
+}
+
+func TestMergeConfig(t *testing.T) {
+	org := userConfig{
+		Telemetry:      telemetryOff,
+		Locale:         "es",
+		CommandTimeout: "2m",
+		License:        "Apache-2.0",
+		ForwardEnv:     []string{"NPM_TOKEN", "CORP_TOKEN"},
+		Require:        []string{"git"},
+		AITools:        []aiTool{{ID: "claude", StateDir: ".corp/claude"}, {ID: "corp", StateDir: ".corp-ai"}},
+		AuditLog:       "https://audit.example.com/seed",
+		MinSeedVersion: "1.4.0",
+	}
+	user := userConfig{
+		Telemetry:      telemetryOn,
+		License:        "MIT",
+		ForwardEnv:     []string{"NPM_TOKEN", "MY_TOKEN"},
+		AITools:        []aiTool{{ID: "claude", StateDir: ".config/claude"}},
+		AuditLog:       "/dev/null",
+		MinSeedVersion: "1.10.0",
+	}
+	want := userConfig{
+		Telemetry:      telemetryOn,
+		CommandTimeout: "2m",
+		License:        "MIT",
+		ForwardEnv:     []string{"NPM_TOKEN", "CORP_TOKEN", "MY_TOKEN"},
+		Require:        []string{"git"},
+		AITools:        []aiTool{{ID: "corp", StateDir: ".corp-ai"}, {ID: "claude", StateDir: ".config/claude"}},
+		AuditLog:       "https://audit.example.com/seed",
+		MinSeedVersion: "1.10.0",
+	}
+	if got := mergeConfig(org, user); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestGitConfigSource(t *testing.T) {
+	tests := []struct {
+		source, repo, file string
+		ok                 bool
+	}{
+		{"git+https://example.com/platform/seed-config", "https://example.com/platform/seed-config", "config.json", true},
+		{"https://example.com/platform/seed-config.git#teams/web.json", "https://example.com/platform/seed-config.git", "teams/web.json", true},
+		{"git@example.com:platform/seed-config.git", "git@example.com:platform/seed-config.git", "config.json", true},
+		{"ssh://git@example.com/platform/seed-config", "ssh://git@example.com/platform/seed-config", "config.json", true},
+		{"https://example.com/seed/config.json", "", "", false},
+		{"/etc/seed/org.json", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			repo, file, ok := gitConfigSource(tt.source)
+			if repo != tt.repo || file != tt.file || ok != tt.ok {
+				t.Errorf("got (%q, %q, %v), want (%q, %q, %v)", repo, file, ok, tt.repo, tt.file, tt.ok)
+			}
+		})
+	}
+}
+
+func TestOrgConfigCache(t *testing.T) {
+	dir := isolateConfig(t)
+	source := filepath.Join(dir, "org.json")
+	if err := os.WriteFile(source, []byte(`{"license": "Apache-2.0", "require": ["git"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	state := fetchOrgConfigCached(source, now)
+	if state.Err != nil || state.Config.License != "Apache-2.0" {
+		t.Fatalf("first fetch: %+v", state)
+	}
+
+	// Within the TTL the cached copy is used without reading the source
+	if err := os.WriteFile(source, []byte(`{"license": "MIT"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if state := fetchOrgConfigCached(source, now.Add(time.Minute)); state.Config.License != "Apache-2.0" {
+		t.Errorf("expected the cached copy within the TTL, got %+v", state.Config)
+	}
+
+	// After it, the source is read again
+	if state := fetchOrgConfigCached(source, now.Add(2*orgConfigTTL)); state.Config.License != "MIT" || state.Stale {
+		t.Errorf("expected a refetch after the TTL, got %+v", state)
+	}
+
+	// An unreachable source falls back to the stale copy
+	if err := os.Remove(source); err != nil {
+		t.Fatal(err)
+	}
+	state = fetchOrgConfigCached(source, now.Add(4*orgConfigTTL))
+	if !state.Stale || state.Err == nil || state.Config.License != "MIT" {
+		t.Errorf("expected the stale cached copy and an error, got %+v", state)
+	}
+
+	// Without any copy, loadConfig reports the error and returns the user's config
+	useOrgConfig(t, filepath.Join(dir, "missing.json"))
+	if _, err := loadConfig(); err == nil {
+		t.Error("expected an error for a missing org config")
+	}
+}
+
+func TestOrgConfigFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := isolateConfig(t)
+	repo := filepath.Join(dir, "seed-config")
+	if err := os.MkdirAll(filepath.Join(repo, "teams"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "teams", "web.json"), []byte(`{"mounts": ["corp-cache:/cache"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "-c", "commit.gpgsign=false", "commit", "-q", "-m", "config"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	useOrgConfig(t, "git+file://"+repo+"#teams/web.json")
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.Mounts, []string{"corp-cache:/cache"}) {
+		t.Errorf("mounts = %v", cfg.Mounts)
+	}
+
+	useOrgConfig(t, "git+file://"+repo+"#../outside.json")
+	if _, err := loadConfig(); err == nil || !strings.Contains(err.Error(), "outside the repository") {
+		t.Errorf("expected a path escape error, got %v", err)
+	}
+}
+
+func TestRequireComponents(t *testing.T) {
+	w := WizardData{ProjectName: "p", Description: "d", Language: "go", License: "MIT"}
+	if err := requireComponents(&w, []string{"git", "devcontainer", "vscodeConfig", "license", "licenseHeaders"}); err != nil {
+		t.Fatal(err)
+	}
+	if !w.InitGit || !w.IncludeDevContainer || !w.VSCodeConfig || !w.LicenseHeaders {
+		t.Errorf("components not turned on: %+v", w)
+	}
+	if w.DevContainerImage != stackFor("go").Image {
+		t.Errorf("image = %q, want the language's", w.DevContainerImage)
+	}
+
+	if err := requireComponents(&WizardData{License: "none"}, []string{"license"}); err == nil {
+		t.Error("expected an error for a required license")
+	}
+	if err := requireComponents(&WizardData{}, []string{"skills"}); err == nil {
+		t.Error("expected an error for an unknown component")
+	}
+}
+
+func TestLoadBatchSpecOrgConfig(t *testing.T) {
+	dir := isolateConfig(t)
+	org := filepath.Join(dir, "org.json")
+	if err := os.WriteFile(org, []byte(`{"license": "Apache-2.0", "require": ["git", "license"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useOrgConfig(t, org)
+
+	spec, err := loadBatchSpec(writeSpec(t, dir, `{"projects": [{"path": "a", "answers": {"description": "d"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := spec.Projects[0].Answers; got.License != "Apache-2.0" || !got.InitGit {
+		t.Errorf("org defaults not applied: %+v", got)
+	}
+
+	_, err = loadBatchSpec(writeSpec(t, dir, `{"projects": [{"path": "a", "answers": {"description": "d", "license": "none"}}]}`))
+	if err == nil || !strings.Contains(err.Error(), "requires a license") {
+		t.Errorf("expected a required license error, got %v", err)
+	}
+}
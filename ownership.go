@@ -0,0 +1,107 @@
+// Package main - ownership.go
+//
+// PURPOSE:
+// This file handles who owns a project: a team and a primary maintainer.
+// It's responsible for:
+// - Validating the team (a name or a GitHub @org/team) and the maintainer
+//   (a GitHub @handle or an email address)
+// - Deriving the CODEOWNERS owners and the links used in README.md and
+//   SECURITY.md
+//
+// DESIGN PATTERNS:
+// - Only GitHub handles and emails go in CODEOWNERS (GitHub ignores anything
+//   else), so a team given as a plain name shows in the README only
+// - SECURITY.md needs someone to report to, so it's rendered only with a
+//   maintainer
+//
+// USAGE:
+// owners := data.CodeOwners()
+
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// codeOwnersPath is where the CODEOWNERS file is written.
+const codeOwnersPath = ".github/CODEOWNERS"
+
+var (
+	// githubUser matches a GitHub @handle.
+	githubUser = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`)
+	// githubTeam matches a GitHub @org/team.
+	githubTeam = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?/[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	// ownerEmail matches a plain email address.
+	ownerEmail = regexp.MustCompile(`^[^@\s<>()]+@[^@\s<>()]+\.[^@\s<>()]+$`)
+)
+
+// validateTeam accepts "", a GitHub @org/team, or a plain team name.
+func validateTeam(team string) error {
+	team = strings.TrimSpace(team)
+	switch {
+	case team == "":
+		return nil
+	case strings.HasPrefix(team, "@"):
+		if !githubTeam.MatchString(team) {
+			return errors.New(T("validate.team", team))
+		}
+	case len(team) > 100 || strings.ContainsAny(team, "\n`[]"):
+		return errors.New(T("validate.team", team))
+	}
+	return nil
+}
+
+// validateMaintainer accepts "", a GitHub @handle or an email address.
+func validateMaintainer(maintainer string) error {
+	maintainer = strings.TrimSpace(maintainer)
+	if maintainer == "" || githubUser.MatchString(maintainer) || ownerEmail.MatchString(maintainer) {
+		return nil
+	}
+	return errors.New(T("validate.maintainer", maintainer))
+}
+
+// CodeOwners returns the owners for CODEOWNERS: a GitHub team, then the
+// maintainer. Empty when neither can go there.
+func (d TemplateData) CodeOwners() []string {
+	var owners []string
+	if githubTeam.MatchString(d.Team) {
+		owners = append(owners, d.Team)
+	}
+	if d.Maintainer != "" {
+		owners = append(owners, d.Maintainer)
+	}
+	return owners
+}
+
+// renderCodeOwners returns .github/CODEOWNERS making the owners own
+// everything, or nil when there are none.
+func renderCodeOwners(data TemplateData) []RenderedFile {
+	owners := data.CodeOwners()
+	if len(owners) == 0 {
+		return nil
+	}
+	content := "# Reviewers GitHub requests for changes; add narrower patterns below\n* " + strings.Join(owners, " ") + "\n"
+	return []RenderedFile{{Path: codeOwnersPath, Content: []byte(content), Mode: 0644}}
+}
+
+// MaintainerEmail reports whether the maintainer is an email address.
+func (d TemplateData) MaintainerEmail() bool {
+	return ownerEmail.MatchString(d.Maintainer)
+}
+
+// OwnerLink returns owner as a Markdown link: GitHub handles and teams to
+// their pages, emails to mailto:, anything else unchanged.
+func (d TemplateData) OwnerLink(owner string) string {
+	switch {
+	case githubTeam.MatchString(owner):
+		org, team, _ := strings.Cut(owner[1:], "/")
+		return "[" + owner + "](https://github.com/orgs/" + org + "/teams/" + team + ")"
+	case githubUser.MatchString(owner):
+		return "[" + owner + "](https://github.com/" + owner[1:] + ")"
+	case ownerEmail.MatchString(owner):
+		return "[" + owner + "](mailto:" + owner + ")"
+	}
+	return owner
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderOwnership(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		team       string
+		maintainer string
+		codeOwners string // "" for no CODEOWNERS
+		readme     []string
+		security   string // "" for no SECURITY.md
+	}{
+		{"none", "", "", "", nil, ""},
+		{
+			name: "github team and handle", team: "@acme/platform", maintainer: "@alice",
+			codeOwners: "* @acme/platform @alice\n",
+			readme:     []string{"## Ownership\n\n- **Team**: [@acme/platform](https://github.com/orgs/acme/teams/platform)\n- **Maintainer**: [@alice](https://github.com/alice)\n\nReport security issues"},
+			security:   "**Report a vulnerability**), or contact [@alice](https://github.com/alice) directly.",
+		},
+		{
+			name: "team name and email", team: "Platform team", maintainer: "alice@example.com",
+			codeOwners: "* alice@example.com\n",
+			readme:     []string{"- **Team**: Platform team\n- **Maintainer**: [alice@example.com](mailto:alice@example.com)"},
+			security:   "Email [alice@example.com](mailto:alice@example.com) instead.",
+		},
+		{
+			name: "team name only", team: "Platform team",
+			readme: []string{"## Ownership\n\n- **Team**: Platform team\n\n---"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.Render(TemplateData{ProjectName: "owned", Description: "Ownership test", Team: tt.team, Maintainer: tt.maintainer})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := renderedContent(files, codeOwnersPath); !strings.HasSuffix(got, tt.codeOwners) || (got == "") != (tt.codeOwners == "") {
+				t.Errorf("CODEOWNERS = %q, want it to end %q", got, tt.codeOwners)
+			}
+			readme := renderedContent(files, "README.md")
+			for _, want := range tt.readme {
+				if !strings.Contains(readme, want) {
+					t.Errorf("README.md missing %q:\n%s", want, readme)
+				}
+			}
+			if tt.readme == nil && strings.Contains(readme, "## Ownership") {
+				t.Error("unexpected Ownership section")
+			}
+			security := renderedContent(files, "SECURITY.md")
+			if (security == "") != (tt.security == "") || !strings.Contains(security, tt.security) {
+				t.Errorf("SECURITY.md missing %q:\n%s", tt.security, security)
+			}
+		})
+	}
+}
+
+func TestValidateOwnership(t *testing.T) {
+	for _, ok := range []string{"", "Platform", "@acme/platform-eng"} {
+		if err := validateTeam(ok); err != nil {
+			t.Errorf("validateTeam(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"@acme", "@acme/", "Team [x]"} {
+		if err := validateTeam(bad); err == nil {
+			t.Errorf("validateTeam(%q) should fail", bad)
+		}
+	}
+	for _, ok := range []string{"", "@alice", "alice@example.com"} {
+		if err := validateMaintainer(ok); err != nil {
+			t.Errorf("validateMaintainer(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"alice", "@-alice", "Alice <alice@example.com>"} {
+		if err := validateMaintainer(bad); err == nil {
+			t.Errorf("validateMaintainer(%q) should fail", bad)
+		}
+	}
+}
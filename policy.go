@@ -0,0 +1,146 @@
+// Package main - policy.go
+//
+// PURPOSE:
+// This file enforces organization policy on what seed pulls into a project
+// and what the project must contain. It's responsible for:
+// - The source allowlist (allowedSources in config): remote repositories
+//   seed may use, e.g. a dotfiles repo installed in the dev container
+// - Project rules checked before anything is written: files every project
+//   must have (requiredFiles) and registries dev container images may come
+//   from (allowedRegistries)
+// - Reporting violations clearly, or only reporting them (--report-only)
+//
+// DESIGN PATTERNS:
+// - No allowlist means no restriction; any entry turns enforcement on
+// - Sources and patterns are compared scheme-free ("github.com/acme/x"), so
+//   one entry covers https, ssh and git@ forms of the same repository
+// - The org's allowlists can't be widened by the user's config (mergeConfig);
+//   the user can add required files but not drop the org's
+// - Project rules see every path the project will have: rendered files,
+//   skills and files already in the directory
+//
+// USAGE:
+// err := checkSourceAllowed(cfg, sourceDotfiles, dotfilesURL(repo))
+// violations := checkProjectPolicy(cfg, templateData, paths)
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Kinds of source the allowlist covers.
+const (
+	sourceDotfiles = "dotfiles"
+)
+
+// policyError is a policy violation.
+type policyError struct {
+	Kind   string // What the source is for, e.g. sourceDotfiles
+	Source string // The source as given
+}
+
+func (e policyError) Error() string {
+	return T("policy.sourceNotAllowed", e.Kind, e.Source)
+}
+
+// scpLikeSource matches git's user@host:path form.
+var scpLikeSource = regexp.MustCompile(`^[\w.-]+@([^:/]+):(.+)$`)
+
+// normalizeSource reduces a repository URL to host/path, without scheme,
+// user, ".git" or trailing slash. Local paths are only cleaned.
+func normalizeSource(source string) string {
+	s := strings.TrimPrefix(strings.TrimSpace(source), "git+")
+	if m := scpLikeSource.FindStringSubmatch(s); m != nil {
+		s = m[1] + "/" + m[2]
+	} else if u, err := url.Parse(s); err == nil && u.Host != "" {
+		s = u.Hostname() + u.Path
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+}
+
+// sourceAllowed reports whether source matches an allowlist entry: the same
+// repository, anything under it, or a path.Match glob such as
+// "github.com/acme/*".
+func sourceAllowed(allowed []string, source string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	src := normalizeSource(source)
+	for _, entry := range allowed {
+		pattern := normalizeSource(entry)
+		if ok, _ := path.Match(pattern, src); ok || src == pattern || strings.HasPrefix(src, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSourceAllowed returns a policyError unless cfg's allowlist permits
+// source.
+func checkSourceAllowed(cfg userConfig, kind, source string) error {
+	if source == "" || sourceAllowed(cfg.AllowedSources, source) {
+		return nil
+	}
+	return policyError{Kind: kind, Source: source}
+}
+
+// policyReportOnly is set by --report-only: project rule violations are
+// reported as warnings instead of stopping the scaffold.
+var policyReportOnly bool
+
+// policyViolation is a project rule the project would break.
+type policyViolation struct {
+	Rule   string // The config setting, e.g. "requiredFiles"
+	Detail string // Localized explanation
+}
+
+// policyViolationsError stops a scaffold that breaks project rules.
+type policyViolationsError []policyViolation
+
+func (e policyViolationsError) Error() string {
+	var b strings.Builder
+	b.WriteString(T("policy.violations", len(e)))
+	for _, v := range e {
+		fmt.Fprintf(&b, "\n  - %s (%s)", v.Detail, v.Rule)
+	}
+	return b.String()
+}
+
+// checkProjectPolicy returns the project rules in cfg broken by a project
+// rendered from data whose files will be paths (slash-separated).
+func checkProjectPolicy(cfg userConfig, data TemplateData, paths []string) []policyViolation {
+	var violations []policyViolation
+	for _, required := range cfg.RequiredFiles {
+		if !slices.ContainsFunc(paths, func(p string) bool {
+			ok, _ := path.Match(required, p)
+			return ok
+		}) {
+			violations = append(violations, policyViolation{Rule: "requiredFiles", Detail: T("policy.missingFile", required)})
+		}
+	}
+	if data.IncludeDevContainer && len(cfg.AllowedRegistries) > 0 {
+		image := data.ImageRef()
+		if !slices.ContainsFunc(cfg.AllowedRegistries, func(r string) bool {
+			r = strings.TrimSuffix(r, "/")
+			return image == r || strings.HasPrefix(image, r+"/")
+		}) {
+			violations = append(violations, policyViolation{Rule: "allowedRegistries", Detail: T("policy.registry", image)})
+		}
+	}
+	return violations
+}
+
+// enforceProjectPolicy turns violations into an error, unless
+// policyReportOnly is set.
+func enforceProjectPolicy(violations []policyViolation) error {
+	if len(violations) == 0 || policyReportOnly {
+		return nil
+	}
+	return policyViolationsError(violations)
+}
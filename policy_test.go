@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSourceAllowed(t *testing.T) {
+	allowed := []string{"https://github.com/acme/*", "git@gitlab.example.com:platform/dotfiles.git", "/srv/seed"}
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{"https://github.com/acme/dotfiles", true},
+		{"git@github.com:acme/dotfiles.git", true},
+		{"ssh://git@github.com/acme/dotfiles", true},
+		{"git+https://github.com/acme/dotfiles.git/", true},
+		{"https://github.com/acme-evil/dotfiles", false},
+		{"https://github.com/someone/dotfiles", false},
+		{"https://gitlab.example.com/platform/dotfiles", true},
+		{"https://gitlab.example.com/platform/dotfiles/sub", true},
+		{"https://gitlab.example.com/platform/dotfiles-fork", false},
+		{"/srv/seed/packs/web", true},
+		{"/srv/seed-other", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := sourceAllowed(allowed, tt.source); got != tt.want {
+				t.Errorf("sourceAllowed(%q) = %v, want %v", tt.source, got, tt.want)
+			}
+		})
+	}
+
+	if !sourceAllowed(nil, "https://anywhere.example.com/x") {
+		t.Error("an empty allowlist should allow everything")
+	}
+}
+
+func TestAllowlistPolicy(t *testing.T) {
+	dir := isolateConfig(t)
+	org := filepath.Join(dir, "org.json")
+	if err := os.WriteFile(org, []byte(`{"allowedSources": ["github.com/acme/*"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useOrgConfig(t, org)
+
+	// The user's config can't widen the org's allowlist
+	if err := saveUserConfig(userConfig{AllowedSources: []string{"github.com/*"}}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSourceAllowed(cfg, sourceDotfiles, dotfilesURL("someone/dotfiles")); err == nil {
+		t.Error("expected the org allowlist to reject someone/dotfiles")
+	}
+
+	_, err = loadBatchSpec(writeSpec(t, dir, `{"projects": [{"path": "a", "answers": {"description": "d", "includeDevContainer": true, "devContainerImage": "universal", "dotfilesRepo": "someone/dotfiles"}}]}`))
+	var policyErr policyError
+	if !errors.As(err, &policyErr) || policyErr.Kind != sourceDotfiles {
+		t.Fatalf("expected a dotfiles policy error, got %v", err)
+	}
+
+	if _, err := loadBatchSpec(writeSpec(t, dir, `{"projects": [{"path": "a", "answers": {"description": "d", "includeDevContainer": true, "devContainerImage": "universal", "dotfilesRepo": "acme/dotfiles"}}]}`)); err != nil {
+		t.Fatalf("acme/dotfiles should be allowed: %v", err)
+	}
+}
+
+func TestCheckProjectPolicy(t *testing.T) {
+	paths := []string{"README.md", "LICENSE", ".devcontainer/devcontainer.json"}
+	goImage := TemplateData{IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie"}
+
+	tests := []struct {
+		name  string
+		cfg   userConfig
+		data  TemplateData
+		rules []string
+	}{
+		{"no rules", userConfig{}, goImage, nil},
+		{"required files present", userConfig{RequiredFiles: []string{"LICENSE", ".devcontainer/*.json"}}, goImage, nil},
+		{"required file missing", userConfig{RequiredFiles: []string{"LICENSE", "SECURITY.md"}}, goImage, []string{"requiredFiles"}},
+		{"approved registry", userConfig{AllowedRegistries: []string{"mcr.microsoft.com"}}, goImage, nil},
+		{"approved registry path", userConfig{AllowedRegistries: []string{"mcr.microsoft.com/devcontainers/"}}, goImage, nil},
+		{"unapproved registry", userConfig{AllowedRegistries: []string{"registry.example.com"}}, goImage, []string{"allowedRegistries"}},
+		{"similar registry name", userConfig{AllowedRegistries: []string{"mcr.microsoft.co"}}, goImage, []string{"allowedRegistries"}},
+		{"mirror registry", userConfig{AllowedRegistries: []string{"registry.example.com"}}, TemplateData{IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", ImageRegistry: "registry.example.com/devcontainers"}, nil},
+		{"no dev container", userConfig{AllowedRegistries: []string{"registry.example.com"}}, TemplateData{}, nil},
+		{"both", userConfig{RequiredFiles: []string{"SECURITY.md"}, AllowedRegistries: []string{"registry.example.com"}}, goImage, []string{"requiredFiles", "allowedRegistries"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []string
+			for _, v := range checkProjectPolicy(tt.cfg, tt.data, paths) {
+				rules = append(rules, v.Rule)
+			}
+			if !slices.Equal(rules, tt.rules) {
+				t.Errorf("violated rules = %v, want %v", rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestScaffoldProjectPolicy(t *testing.T) {
+	dir := isolateConfig(t)
+	if err := saveUserConfig(userConfig{RequiredFiles: []string{"SECURITY.md"}}); err != nil {
+		t.Fatal(err)
+	}
+	answers := WizardData{ProjectName: "policytest", Description: "Policy test project"}
+
+	// Enforced: nothing is written
+	target := filepath.Join(dir, "enforced")
+	_, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard))
+	var violations policyViolationsError
+	if !errors.As(err, &violations) || len(violations) != 1 {
+		t.Fatalf("expected one policy violation, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "README.md")); !os.IsNotExist(err) {
+		t.Error("a project breaking policy must not be written")
+	}
+
+	// A SECURITY.md already in the directory satisfies the rule
+	existing := map[string]struct{}{"SECURITY.md": {}}
+	if _, err := scaffoldProject(filepath.Join(dir, "existing"), answers, true, existing, newPlainProgress(io.Discard)); err != nil {
+		t.Fatalf("existing SECURITY.md: %v", err)
+	}
+
+	// Report only: written, with the violation reported
+	policyReportOnly = true
+	t.Cleanup(func() { policyReportOnly = false })
+	report, err := scaffoldProject(filepath.Join(dir, "reported"), answers, false, map[string]struct{}{}, newPlainProgress(io.Discard))
+	if err != nil {
+		t.Fatalf("report only: %v", err)
+	}
+	if len(report.PolicyViolations) != 1 || report.PolicyViolations[0].Rule != "requiredFiles" {
+		t.Errorf("report.PolicyViolations = %+v", report.PolicyViolations)
+	}
+}
@@ -0,0 +1,323 @@
+// Package main - preview.go
+//
+// PURPOSE:
+// This file implements the review step between the wizard and scaffolding:
+// a collapsible tree of the files seed is about to write, beside a pane
+// showing the selected file's rendered content. It's responsible for:
+// - Rendering the project in memory, exactly as scaffolding will
+// - Building the tree (directories first, like --print) and flagging files
+//   that would replace existing ones
+// - Letting the user browse, then create the project or cancel
+//
+// DESIGN PATTERNS:
+// - A Bubble Tea model over renderProjectFiles output; nothing touches the
+//   filesystem until the user confirms
+// - Only shown on a terminal; --print, --output-archive and --batch have
+//   their own output and skip it
+//
+// USAGE:
+// ok, err := reviewProject(targetDir, wizardData, beforeFiles)
+// if !ok { return errAborted }
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	previewCursorStyle = lipgloss.NewStyle().Reverse(true)
+	previewPaneStyle   = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).PaddingLeft(1)
+)
+
+// previewNode is a directory or file in the review tree.
+type previewNode struct {
+	name     string
+	parent   *previewNode
+	children []*previewNode // Directories first, then by name
+	expanded bool
+	file     *RenderedFile // nil for directories
+	replaces bool          // The file exists and will be overwritten
+}
+
+// previewRow is a visible line of the tree.
+type previewRow struct {
+	node  *previewNode
+	depth int
+}
+
+// previewModel is the Bubble Tea model behind the review step.
+type previewModel struct {
+	title     string
+	root      *previewNode
+	rows      []previewRow
+	cursor    int
+	scroll    int // First content line shown for the selected file
+	width     int
+	height    int
+	confirmed bool
+}
+
+// newPreviewModel builds the tree for files; existing (paths relative to
+// the project, slash-separated) marks files that will be overwritten.
+func newPreviewModel(title string, files []RenderedFile, existing map[string]struct{}) previewModel {
+	root := &previewNode{expanded: true}
+	for i := range files {
+		node := root
+		parts := strings.Split(files[i].Path, "/")
+		for _, part := range parts[:len(parts)-1] {
+			node = node.child(part)
+		}
+		leaf := node.child(parts[len(parts)-1])
+		leaf.file = &files[i]
+		_, leaf.replaces = existing[files[i].Path]
+	}
+	root.sort()
+
+	m := previewModel{title: title, root: root, width: 80, height: 24}
+	m.refresh()
+	return m
+}
+
+// child returns n's child named name, adding it if needed.
+func (n *previewNode) child(name string) *previewNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &previewNode{name: name, parent: n}
+	n.children = append(n.children, c)
+	return c
+}
+
+func (n *previewNode) sort() {
+	sort.Slice(n.children, func(i, j int) bool {
+		di, dj := n.children[i].file == nil, n.children[j].file == nil
+		if di != dj {
+			return di // directories first
+		}
+		return n.children[i].name < n.children[j].name
+	})
+	for _, c := range n.children {
+		c.sort()
+	}
+}
+
+// refresh recomputes the visible rows, keeping the cursor on its node.
+func (m *previewModel) refresh() {
+	var selected *previewNode
+	if m.cursor < len(m.rows) {
+		selected = m.rows[m.cursor].node
+	}
+	m.rows = nil
+	var walk func(n *previewNode, depth int)
+	walk = func(n *previewNode, depth int) {
+		for _, c := range n.children {
+			m.rows = append(m.rows, previewRow{node: c, depth: depth})
+			if c.expanded {
+				walk(c, depth+1)
+			}
+		}
+	}
+	walk(m.root, 0)
+	m.cursor = 0
+	for i, row := range m.rows {
+		if row.node == selected {
+			m.cursor = i
+		}
+	}
+}
+
+func (m previewModel) selected() *previewNode {
+	if m.cursor < len(m.rows) {
+		return m.rows[m.cursor].node
+	}
+	return nil
+}
+
+func (m previewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m previewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		node := m.selected()
+		switch msg.String() {
+		case "y", "Y":
+			m.confirmed = true
+			return m, tea.Quit
+		case "n", "N", "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.scroll = 0
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+				m.scroll = 0
+			}
+		case "right", "l":
+			if node != nil && node.file == nil && !node.expanded {
+				node.expanded = true
+				m.refresh()
+			}
+		case "enter", " ":
+			if node != nil && node.file == nil {
+				node.expanded = !node.expanded
+				m.refresh()
+			}
+		case "left", "h":
+			switch {
+			case node == nil:
+			case node.file == nil && node.expanded:
+				node.expanded = false
+				m.refresh()
+			case node.parent != m.root:
+				node.parent.expanded = false
+				m.cursor = m.indexOf(node.parent)
+				m.refresh()
+			}
+		case "pgdown", "ctrl+d":
+			m.scroll = min(m.scroll+m.bodyHeight()/2, max(0, m.lineCount()-m.bodyHeight()))
+		case "pgup", "ctrl+u":
+			m.scroll = max(0, m.scroll-m.bodyHeight()/2)
+		}
+	}
+	return m, nil
+}
+
+func (m previewModel) indexOf(n *previewNode) int {
+	for i, row := range m.rows {
+		if row.node == n {
+			return i
+		}
+	}
+	return 0
+}
+
+// bodyHeight is the number of lines the tree and content panes get.
+func (m previewModel) bodyHeight() int {
+	return max(3, m.height-4) // Title, blank line, blank line, help
+}
+
+// lineCount is the number of lines in the selected file.
+func (m previewModel) lineCount() int {
+	if n := m.selected(); n != nil && n.file != nil {
+		return len(previewLines(n.file.Content))
+	}
+	return 0
+}
+
+func (m previewModel) View() string {
+	height := m.bodyHeight()
+
+	// Tree pane: the rows around the cursor
+	first := max(0, min(m.cursor-height/2, len(m.rows)-height))
+	last := min(len(m.rows), first+height)
+	labels := make([]string, 0, last-first)
+	treeWidth := 0
+	for _, row := range m.rows[first:last] {
+		label := row.node.name
+		switch {
+		case row.node.file == nil && row.node.expanded:
+			label = "▾ " + label + "/"
+		case row.node.file == nil:
+			label = "▸ " + label + "/"
+		default:
+			label = "  " + label
+		}
+		if row.node.replaces {
+			label += " " + T("preview.replaces")
+		}
+		label = strings.Repeat("  ", row.depth) + label
+		labels = append(labels, label)
+		treeWidth = max(treeWidth, lipgloss.Width(label))
+	}
+	treeWidth = min(treeWidth+1, max(24, m.width*2/5))
+	var tree []string
+	for i, label := range labels {
+		label = truncateRunes(label, treeWidth)
+		label += strings.Repeat(" ", treeWidth-lipgloss.Width(label))
+		node := m.rows[first+i].node
+		switch {
+		case first+i == m.cursor:
+			label = previewCursorStyle.Render(label)
+		case node.replaces:
+			label = warnStyle.Render(label)
+		}
+		tree = append(tree, label)
+	}
+	treePane := strings.Join(tree, "\n")
+
+	// Content pane: the selected file from the scroll offset
+	contentWidth := max(10, m.width-treeWidth-3)
+	var content []string
+	if n := m.selected(); n != nil && n.file != nil {
+		lines := previewLines(n.file.Content)
+		for i := m.scroll; i < len(lines) && i < m.scroll+height; i++ {
+			content = append(content, truncateRunes(lines[i], contentWidth))
+		}
+	} else if n != nil {
+		content = append(content, dimStyle.Render(T("preview.directory", len(n.children))))
+	}
+	contentPane := previewPaneStyle.Height(height).Render(strings.Join(content, "\n"))
+
+	var b strings.Builder
+	b.WriteString(successStyle.Render(m.title) + "\n\n")
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, treePane, contentPane) + "\n\n")
+	b.WriteString(dimStyle.Render(T("preview.help")) + "\n")
+	return b.String()
+}
+
+// previewLines splits content into display lines, expanding tabs.
+func previewLines(content []byte) []string {
+	text := strings.ReplaceAll(string(content), "\t", "    ")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// truncateRunes shortens s to width runes, marking the cut with "…".
+func truncateRunes(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// reviewProject renders the project for targetDir in memory and shows it
+// for review. It reports whether the user chose to create it.
+func reviewProject(targetDir string, wizardData WizardData, beforeFiles map[string]struct{}) (bool, error) {
+	// As scaffoldSteps does, so the preview matches what's written
+	if wizardData.IncludeDevContainer && !wizardData.NoExtensionsCache && usesVSCode(wizardData.IDE) && wizardData.ExtensionsVolume == "" {
+		wizardData.ExtensionsVolume = extensionsVolumeName(wizardData.ProjectName, targetDir)
+	}
+	data := wizardData.ToTemplateData()
+	data.Year = seedNow().Year()
+	files, err := renderProjectFiles(data)
+	if err != nil {
+		return false, err
+	}
+	if files, err = dropIgnored(targetDir, files); err != nil {
+		return false, err
+	}
+
+	title := T("preview.title", len(files), filepath.Clean(targetDir))
+	final, err := tea.NewProgram(newPreviewModel(title, files, beforeFiles), tea.WithOutput(os.Stdout), tea.WithAltScreen()).Run()
+	if err != nil {
+		return false, fmt.Errorf("failed to show the review: %w", err)
+	}
+	return final.(previewModel).confirmed, nil
+}
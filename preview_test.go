@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func previewKeys(m previewModel, keys ...string) previewModel {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "pgdown":
+			msg = tea.KeyMsg{Type: tea.KeyPgDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(previewModel)
+	}
+	return m
+}
+
+func previewNames(m previewModel) []string {
+	var names []string
+	for _, row := range m.rows {
+		names = append(names, strings.Repeat(" ", row.depth)+row.node.name)
+	}
+	return names
+}
+
+func TestPreviewTree(t *testing.T) {
+	files := []RenderedFile{
+		{Path: "README.md", Content: []byte("# demo\n")},
+		{Path: ".devcontainer/devcontainer.json", Content: []byte("{}\n")},
+		{Path: "skills/dev/triage.md", Content: []byte("triage\n")},
+		{Path: "AGENTS.md", Content: []byte("agents\n")},
+	}
+	m := newPreviewModel("Review", files, map[string]struct{}{"README.md": {}})
+
+	// Directories first, collapsed
+	if got, want := strings.Join(previewNames(m), ","), ".devcontainer,skills,AGENTS.md,README.md"; got != want {
+		t.Fatalf("rows = %s, want %s", got, want)
+	}
+
+	// Expand skills, then skills/dev
+	m = previewKeys(m, "down", "right", "down", "enter")
+	if got, want := strings.Join(previewNames(m), ","), ".devcontainer,skills, dev,  triage.md,AGENTS.md,README.md"; got != want {
+		t.Fatalf("rows = %s, want %s", got, want)
+	}
+
+	// Peek at the file, then collapse back up to its parent
+	m = previewKeys(m, "down")
+	if n := m.selected(); n.file == nil || n.file.Path != "skills/dev/triage.md" {
+		t.Fatalf("selected %v, want skills/dev/triage.md", n)
+	}
+	if view := m.View(); !strings.Contains(view, "triage") {
+		t.Errorf("view should show the selected file's content:\n%s", view)
+	}
+	m = previewKeys(m, "left")
+	if n := m.selected(); n.name != "dev" || n.expanded {
+		t.Errorf("left on a file should collapse its directory and select it, got %s (expanded %t)", n.name, n.expanded)
+	}
+
+	// Files that already exist are flagged
+	m = previewKeys(m, "down", "down", "down")
+	if n := m.selected(); n.name != "README.md" || !n.replaces {
+		t.Errorf("README.md should be flagged as replacing an existing file")
+	}
+	if view := m.View(); !strings.Contains(view, T("preview.replaces")) {
+		t.Errorf("view should flag replaced files:\n%s", view)
+	}
+}
+
+func TestPreviewScroll(t *testing.T) {
+	var lines []string
+	for i := range 100 {
+		lines = append(lines, strings.Repeat("x", i))
+	}
+	m := newPreviewModel("Review", []RenderedFile{{Path: "long.txt", Content: []byte(strings.Join(lines, "\n"))}}, nil)
+	m = previewKeys(m, "pgdown")
+	if m.scroll != m.bodyHeight()/2 {
+		t.Errorf("scroll = %d, want %d", m.scroll, m.bodyHeight()/2)
+	}
+	m = previewKeys(m, "pgdown", "pgdown", "pgdown", "pgdown", "pgdown", "pgdown", "pgdown", "pgdown", "pgdown")
+	if want := 100 - m.bodyHeight(); m.scroll != want {
+		t.Errorf("scroll should stop at the last page: %d, want %d", m.scroll, want)
+	}
+}
+
+func TestPreviewConfirm(t *testing.T) {
+	files := []RenderedFile{{Path: "README.md", Content: []byte("# demo\n")}}
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"y", true},
+		{"n", false},
+		{"q", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			next, cmd := newPreviewModel("Review", files, nil).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			if cmd == nil {
+				t.Fatal("expected the review to quit")
+			}
+			if got := next.(previewModel).confirmed; got != tt.want {
+				t.Errorf("confirmed = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Package main - print.go
+//
+// PURPOSE:
+// This file formats a rendered project as plain text for `seed --print`.
+// It's responsible for:
+// - Drawing a directory tree of the generated files
+// - Emitting each file's contents inside a markdown code fence
+//
+// DESIGN PATTERNS:
+// - Pure functions over Scaffolder.Render output; nothing touches the filesystem
+// - Output is valid markdown so it can be pasted into a review or agent chat
+//
+// USAGE:
+// files, _ := scaffolder.Render(data)
+// fmt.Print(renderPrintout("myproject", files))
+
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory or file in the printed tree.
+type treeNode struct {
+	name     string
+	children map[string]*treeNode
+}
+
+// renderFileTree draws files as a tree rooted at rootName, directories first,
+// each level sorted by name. Directory names carry a trailing slash.
+//
+// Example:
+// myproject/
+// ├── .devcontainer/
+// │   └── devcontainer.json
+// └── README.md
+func renderFileTree(rootName string, files []RenderedFile) string {
+	root := &treeNode{name: rootName, children: map[string]*treeNode{}}
+	for _, f := range files {
+		node := root
+		for _, part := range strings.Split(f.Path, "/") {
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{name: part, children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(rootName + "/\n")
+	writeTreeChildren(&b, root, "")
+	return b.String()
+}
+
+func writeTreeChildren(b *strings.Builder, node *treeNode, prefix string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		di := len(node.children[names[i]].children) > 0
+		dj := len(node.children[names[j]].children) > 0
+		if di != dj {
+			return di // directories first
+		}
+		return names[i] < names[j]
+	})
+
+	for i, name := range names {
+		child := node.children[name]
+		last := i == len(names)-1
+		branch, indent := "├── ", "│   "
+		if last {
+			branch, indent = "└── ", "    "
+		}
+		label := name
+		if len(child.children) > 0 {
+			label += "/"
+		}
+		b.WriteString(prefix + branch + label + "\n")
+		writeTreeChildren(b, child, prefix+indent)
+	}
+}
+
+// renderPrintout returns the tree followed by every file's contents, each
+// under a heading and wrapped in a code fence long enough not to collide with
+// fences inside the content (generated markdown often contains ``` blocks).
+func renderPrintout(rootName string, files []RenderedFile) string {
+	var b strings.Builder
+	b.WriteString("```\n")
+	b.WriteString(renderFileTree(rootName, files))
+	b.WriteString("```\n")
+
+	for _, f := range files {
+		switch {
+		case isSymlink(f):
+			b.WriteString(fmt.Sprintf("\n### %s\n\nLink to `%s`.\n", f.Path, f.Content))
+			continue
+		case isBinary(f.Content):
+			b.WriteString(fmt.Sprintf("\n### %s\n\nBinary file, %d bytes.\n", f.Path, len(f.Content)))
+			continue
+		}
+		content := string(f.Content)
+		fence := strings.Repeat("`", max(3, longestBacktickRun(content)+1))
+
+		b.WriteString(fmt.Sprintf("\n### %s\n\n", f.Path))
+		b.WriteString(fence + fenceLanguage(f.Path) + "\n")
+		b.WriteString(content)
+		if !strings.HasSuffix(content, "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString(fence + "\n")
+	}
+	return b.String()
+}
+
+// longestBacktickRun returns the length of the longest run of backticks in s.
+func longestBacktickRun(s string) int {
+	longest, current := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
+
+// fenceLanguage picks a code fence info string from the file name so
+// renderers can syntax-highlight the output.
+func fenceLanguage(filePath string) string {
+	base := path.Base(filePath)
+	switch {
+	case base == "Dockerfile":
+		return "dockerfile"
+	case strings.HasSuffix(base, ".md"):
+		return "markdown"
+	case strings.HasSuffix(base, ".json"):
+		return "json"
+	case strings.HasSuffix(base, ".sh"):
+		return "bash"
+	case base == ".editorconfig":
+		return "ini"
+	default:
+		return ""
+	}
+}
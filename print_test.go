@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderFileTree(t *testing.T) {
+	files := []RenderedFile{
+		{Path: "README.md"},
+		{Path: ".devcontainer/devcontainer.json"},
+		{Path: ".devcontainer/Dockerfile"},
+		{Path: "AGENTS.md"},
+	}
+
+	got := renderFileTree("myproject", files)
+	want := `myproject/
+├── .devcontainer/
+│   ├── Dockerfile
+│   └── devcontainer.json
+├── AGENTS.md
+└── README.md
+`
+	if got != want {
+		t.Fatalf("tree mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderPrintoutFencesContent(t *testing.T) {
+	files := []RenderedFile{
+		{Path: "AGENTS.md", Content: []byte("# Agents\n\n```bash\nmake test\n```\n")},
+		{Path: ".devcontainer/devcontainer.json", Content: []byte("{}")},
+	}
+
+	out := renderPrintout("myproject", files)
+
+	if !strings.HasPrefix(out, "```\nmyproject/\n") {
+		t.Errorf("printout should start with the fenced tree, got %q", out[:20])
+	}
+	if !strings.Contains(out, "### AGENTS.md\n\n````markdown\n# Agents") {
+		t.Error("markdown containing ``` should be wrapped in a longer fence")
+	}
+	if !strings.Contains(out, "### .devcontainer/devcontainer.json\n\n```json\n{}\n```\n") {
+		t.Error("content without trailing newline should still close its fence on a new line")
+	}
+}
+
+func TestRenderPrintoutIncludesEveryFile(t *testing.T) {
+	files := renderTestProject(t)
+	out := renderPrintout("project", files)
+	for _, f := range files {
+		if !strings.Contains(out, "### "+f.Path+"\n") {
+			t.Errorf("printout should include a section for %s", f.Path)
+		}
+	}
+}
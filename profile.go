@@ -0,0 +1,404 @@
+// Package main - profile.go
+//
+// PURPOSE:
+// This file implements `seed profile`, which shares wizard answers between
+// people. It's responsible for:
+// - Exporting a project's recorded answers, less what's specific to the
+//   project (name, description, brief, links), as a profile file
+// - Importing a profile from a file, an https:// URL or a git repository
+//   into seed's config directory
+// - Loading a saved profile, which --profile turns into the wizard's
+//   starting answers
+//
+// DESIGN PATTERNS:
+// - A profile is an answers object (WizardData's JSON form, as in batch
+//   specs) with a small header, so the extras selection, stack, license and
+//   dev container setup all travel with it
+// - Fetched like the org config (https, git or a path), and remote sources
+//   are checked against allowedSources
+// - The org config still applies on top: required components and defaults
+//   aren't something a profile can turn off
+// - Template packs can't be loaded yet, so a profile records the template
+//   set version it was exported with instead of a pack reference
+//
+// USAGE:
+// seed profile export go-service ~/src/billing
+// seed profile import https://example.com/go-service.json
+// seed --profile go-service ./new-service
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	profileFormat = 1          // Bumped when the profile format changes incompatibly
+	profilesDir   = "profiles" // Saved profiles, in seed's config directory
+)
+
+const profileUsage = "seed profile export <name> [directory] [--output <file>] | seed profile import <file-or-url> [--name <name>] | seed profile list"
+
+// sourceProfile is the allowlist kind of a remote profile.
+const sourceProfile = "profile"
+
+// profileName matches a profile name, which is also its file name.
+var profileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// profile is a profile file.
+type profile struct {
+	Format          int        `json:"format"`
+	Name            string     `json:"name"`
+	SeedVersion     string     `json:"seedVersion,omitempty"`     // Version it was exported with
+	TemplateVersion int        `json:"templateVersion,omitempty"` // Template set the answers were made for
+	Answers         WizardData `json:"answers"`
+}
+
+// validateProfileName rejects names that can't be a file name.
+func validateProfileName(name string) error {
+	if !profileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// profileAnswers returns the answers a profile shares: everything but what
+// describes one project, and what config fills in when the profile is used.
+func profileAnswers(w WizardData) WizardData {
+	w.ProjectName = ""
+	w.Description = ""
+	w.Topics = nil
+	w.Homepage = ""
+	w.Documentation = ""
+	w.IssueTracker = ""
+	w.LinkTasks = false
+	w.Goals = nil
+	w.NonGoals = nil
+	w.Constraints = nil
+	w.Commands = nil
+	w.Packages = nil
+	w.Adopted = false
+	w.ExtensionsVolume = ""
+	w.CustomChatTools = nil
+	w.Footer = ""
+	w.ImageRegistry = ""
+	return w
+}
+
+// exportProfile makes a profile called name from the answers recorded in
+// the project at dir.
+func exportProfile(dir, name string) (profile, error) {
+	if err := validateProfileName(name); err != nil {
+		return profile{}, err
+	}
+	m, err := readManifest(dir)
+	if err != nil {
+		return profile{}, err
+	}
+	if m.Answers.Adopted {
+		return profile{}, fmt.Errorf("%s was set up by seed adopt, so it has no wizard answers to share", dir)
+	}
+	return profile{
+		Format:          profileFormat,
+		Name:            name,
+		SeedVersion:     Version,
+		TemplateVersion: templateVersion,
+		Answers:         profileAnswers(m.Answers),
+	}, nil
+}
+
+// parseProfile decodes and checks a profile file.
+func parseProfile(raw []byte) (profile, error) {
+	var p profile
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return p, fmt.Errorf("invalid profile: %w", err)
+	}
+	if p.Format < 1 || p.Format > profileFormat {
+		return p, fmt.Errorf("profile format %d isn't supported by seed %s; upgrade seed", p.Format, Version)
+	}
+	if err := validateProfileName(p.Name); err != nil {
+		return p, err
+	}
+	// Validate as a project would be: the answers a profile leaves out are
+	// filled with placeholders
+	check := p.Answers
+	check.ProjectName = "profile"
+	check.Description = "Profile check."
+	if err := check.Validate(); err != nil {
+		return p, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	return p, nil
+}
+
+// profilePath returns where the profile called name is saved.
+func profilePath(name string) (string, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, profilesDir, name+".json"), nil
+}
+
+// encodeProfile returns p as indented JSON.
+func encodeProfile(p profile) ([]byte, error) {
+	raw, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profile: %w", err)
+	}
+	return append(raw, '\n'), nil
+}
+
+// importProfile fetches the profile at source and saves it, under name when
+// one is given. It returns the profile and whether it replaced a saved one.
+func importProfile(source, name string) (profile, bool, error) {
+	if strings.Contains(source, "://") || strings.HasPrefix(source, "git@") {
+		cfg, _ := loadConfig()
+		if err := checkSourceAllowed(cfg, sourceProfile, source); err != nil {
+			return profile{}, false, err
+		}
+	}
+	raw, err := fetchOrgConfig(source)
+	if err != nil {
+		return profile{}, false, fmt.Errorf("failed to fetch profile %s: %w", source, err)
+	}
+	p, err := parseProfile(raw)
+	if err != nil {
+		return p, false, err
+	}
+	if name != "" {
+		if err := validateProfileName(name); err != nil {
+			return p, false, err
+		}
+		p.Name = name
+	}
+
+	path, err := profilePath(p.Name)
+	if err != nil {
+		return p, false, err
+	}
+	replaced := fileExists(path)
+	out, err := encodeProfile(p)
+	if err != nil {
+		return p, false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return p, false, fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return p, false, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return p, replaced, nil
+}
+
+// loadProfile reads the saved profile called name.
+func loadProfile(name string) (profile, error) {
+	if err := validateProfileName(name); err != nil {
+		return profile{}, err
+	}
+	path, err := profilePath(name)
+	if err != nil {
+		return profile{}, err
+	}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return profile{}, fmt.Errorf("no profile called %s; import one with `seed profile import`", name)
+	}
+	if err != nil {
+		return profile{}, fmt.Errorf("failed to read profile: %w", err)
+	}
+	p, err := parseProfile(raw)
+	if err != nil {
+		return p, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// listProfiles returns the saved profiles, by name.
+func listProfiles() ([]profile, error) {
+	dir, err := seedConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, profilesDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profiles []profile
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		p, err := loadProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// profileSummary describes what a profile turns on, e.g. "go, MIT, git,
+// devcontainer".
+func profileSummary(p profile) string {
+	var parts []string
+	if p.Answers.Language != "" {
+		parts = append(parts, p.Answers.Language)
+	}
+	if p.Answers.License != "" && p.Answers.License != "none" {
+		parts = append(parts, p.Answers.License)
+	}
+	parts = append(parts, selectedExtras(p.Answers)...)
+	if len(parts) == 0 {
+		return T("profile.defaults")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// profilePreset returns the answers the wizard starts from for --profile
+// (none without one).
+func profilePreset(name string) (WizardData, error) {
+	if name == "" {
+		return WizardData{}, nil
+	}
+	p, err := loadProfile(name)
+	if err != nil {
+		return WizardData{}, err
+	}
+	return p.Answers, nil
+}
+
+// runProfile handles `seed profile export|import|list`.
+func runProfile(args []string) error {
+	if len(args) == 0 {
+		return usageError{msg: T("args.profileCommand"), usage: profileUsage}
+	}
+	switch args[0] {
+	case "export":
+		return runProfileExport(args[1:])
+	case "import":
+		return runProfileImport(args[1:])
+	case "list":
+		if len(args) > 1 {
+			return usageError{msg: T("args.tooMany"), usage: profileUsage}
+		}
+		profiles, err := listProfiles()
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			fmt.Println(dimStyle.Render(T("profile.none")))
+			return nil
+		}
+		for _, p := range profiles {
+			fmt.Printf("%-20s %s\n", p.Name, dimStyle.Render(profileSummary(p)))
+		}
+		return nil
+	}
+	return usageError{msg: T("args.unknownCommand", "profile", args[0]), usage: profileUsage}
+}
+
+// runProfileExport handles `seed profile export <name> [directory] [--output <file>]`.
+func runProfileExport(args []string) error {
+	var positional []string
+	output := ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--output" || arg == "-o":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.outputNeedsFile"), usage: profileUsage}
+			}
+			i++
+			output = args[i]
+		case strings.HasPrefix(arg, "--output="):
+			output = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			return usageError{msg: T("args.unknownFlag", arg), usage: profileUsage}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return usageError{msg: T("args.exportNeedsName"), usage: profileUsage}
+	}
+	dir, err := projectDirArg(positional[1:], profileUsage)
+	if err != nil {
+		return err
+	}
+	p, err := exportProfile(dir, positional[0])
+	if err != nil {
+		return err
+	}
+	raw, err := encodeProfile(p)
+	if err != nil {
+		return err
+	}
+	if output == "-" {
+		_, err := os.Stdout.Write(raw)
+		return err
+	}
+	if output == "" {
+		output = p.Name + ".json"
+		if fileExists(output) {
+			return fmt.Errorf("%s already exists; pass --output to write elsewhere", output)
+		}
+	}
+	if err := os.WriteFile(output, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T("profile.exported", p.Name, output, profileSummary(p)))
+	fmt.Println(dimStyle.Render(T("profile.exportedHint", p.Name)))
+	return nil
+}
+
+// runProfileImport handles `seed profile import <file-or-url> [--name <name>]`.
+func runProfileImport(args []string) error {
+	var source, name string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--name":
+			if i+1 >= len(args) {
+				return usageError{msg: T("args.needsValue", "--name"), usage: profileUsage}
+			}
+			i++
+			name = args[i]
+		case strings.HasPrefix(arg, "--name="):
+			name = strings.TrimPrefix(arg, "--name=")
+		case strings.HasPrefix(arg, "-"):
+			return usageError{msg: T("args.unknownFlag", arg), usage: profileUsage}
+		case source == "":
+			source = arg
+		default:
+			return usageError{msg: T("args.tooMany"), usage: profileUsage}
+		}
+	}
+	if source == "" {
+		return usageError{msg: T("args.importNeedsSource"), usage: profileUsage}
+	}
+	p, replaced, err := importProfile(source, name)
+	if err != nil {
+		return err
+	}
+	done := "profile.imported"
+	if replaced {
+		done = "profile.replaced"
+	}
+	fmt.Println(successStyle.Render("✓") + " " + T(done, p.Name, profileSummary(p)))
+	if p.TemplateVersion > templateVersion {
+		fmt.Println(warnStyle.Render(T("profile.newerTemplates", p.TemplateVersion, templateVersion)))
+	}
+	fmt.Println(dimStyle.Render(T("profile.useHint", p.Name)))
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	isolateConfig(t)
+	project := tempDir(t)
+	answers := WizardData{
+		ProjectName:         "billing",
+		Description:         "Bills customers.",
+		Language:            "go",
+		License:             "MIT",
+		InitGit:             true,
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		DocHealth:           true,
+		Topics:              []string{"billing"},
+		Goals:               []string{"Invoice on time"},
+		Secrets:             []string{"STRIPE_KEY"},
+	}
+	if err := writeManifest(project, newManifest(answers, 2026, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := exportProfile(project, "go-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := p.Answers
+	if a.ProjectName != "" || a.Description != "" || a.Topics != nil || a.Goals != nil || a.ExtensionsVolume != "" {
+		t.Errorf("project-specific answers should be left out: %+v", a)
+	}
+	if a.Language != "go" || a.License != "MIT" || !a.InitGit || !a.IncludeDevContainer || !a.DocHealth || !slices.Equal(a.Secrets, []string{"STRIPE_KEY"}) {
+		t.Errorf("shared answers should be kept: %+v", a)
+	}
+	if got := profileSummary(p); got != "go, MIT, devcontainer, git, docHealth" {
+		t.Errorf("profileSummary() = %q", got)
+	}
+
+	raw, err := encodeProfile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "shared.json")
+	writeTestFile(t, file, string(raw))
+	if _, replaced, err := importProfile(file, ""); err != nil || replaced {
+		t.Fatalf("importProfile() replaced = %t, err = %v", replaced, err)
+	}
+	if _, replaced, err := importProfile(file, "go-svc"); err != nil || replaced {
+		t.Fatalf("import under another name: replaced = %t, err = %v", replaced, err)
+	}
+	if _, replaced, _ := importProfile(file, ""); !replaced {
+		t.Error("importing the same profile again should replace it")
+	}
+
+	profiles, err := listProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range profiles {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"go-service", "go-svc"}) {
+		t.Errorf("listProfiles() = %v", names)
+	}
+
+	preset, err := profilePreset("go-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(selectedExtras(preset), selectedExtras(a)) {
+		t.Errorf("preset extras = %v, want %v", selectedExtras(preset), selectedExtras(a))
+	}
+	if _, err := profilePreset("missing"); err == nil || !strings.Contains(err.Error(), "seed profile import") {
+		t.Errorf("a missing profile should point at import, got %v", err)
+	}
+}
+
+func TestParseProfileRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"not json", "answers: {}", "invalid profile"},
+		{"unknown field", `{"format": 1, "name": "x", "answers": {}, "pack": "acme"}`, "unknown field"},
+		{"newer format", `{"format": 2, "name": "x", "answers": {}}`, "upgrade seed"},
+		{"bad name", `{"format": 1, "name": "../x", "answers": {}}`, "invalid profile name"},
+		{"bad answers", `{"format": 1, "name": "x", "answers": {"license": "GPL-9"}}`, "license"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseProfile([]byte(tt.raw)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseProfile() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWizardDefaults(t *testing.T) {
+	cfg := userConfig{License: "Apache-2.0", ForwardEnv: []string{"NPM_TOKEN"}}
+	data := wizardDefaults("api", WizardData{}, cfg)
+	if data.ProjectName != "api" || data.Shell != "bash" || data.Visibility != "private" || data.License != "Apache-2.0" {
+		t.Errorf("defaults not applied: %+v", data)
+	}
+
+	preset := WizardData{License: "MIT", Shell: "zsh", ForwardEnv: []string{"AWS_PROFILE"}, Footer: "stale"}
+	data = wizardDefaults("api", preset, cfg)
+	if data.License != "MIT" || data.Shell != "zsh" {
+		t.Errorf("profile answers should win over defaults: %+v", data)
+	}
+	if !slices.Contains(data.ForwardEnv, "NPM_TOKEN") || !slices.Contains(data.ForwardEnv, "AWS_PROFILE") {
+		t.Errorf("forwardEnv = %v, want config's and the profile's", data.ForwardEnv)
+	}
+	if data.Footer != brandFooter(cfg) {
+		t.Errorf("footer should come from config, got %q", data.Footer)
+	}
+}
+
+func TestExportProfileNeedsManifest(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := exportProfile(dir, "x"); err == nil {
+		t.Error("exporting from a directory without a manifest should fail")
+	}
+	if _, err := exportProfile(dir, "bad/name"); err == nil || !strings.Contains(err.Error(), "invalid profile name") {
+		t.Errorf("expected an invalid name error, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+// Package main - progress.go
+//
+// PURPOSE:
+// This file reports scaffolding progress. It's responsible for:
+// - Showing each phase (templates, skills, git) with a spinner while it runs
+//   and its duration once done, on an interactive terminal (Bubble Tea)
+// - Printing each created file as it happens, above the phase list
+// - Falling back to plain lines when stdout isn't a terminal
+//
+// DESIGN PATTERNS:
+// - scaffoldProject only talks to the progressReporter interface, so batch
+//   mode and tests pass a plain reporter (often on io.Discard)
+// - The Bubble Tea program runs in its own goroutine; scaffolding stays on
+//   the caller's goroutine and feeds it messages in order
+//
+// USAGE:
+// progress := newProgress(os.Stdout)
+// progress.Phase(T("progress.templates"))
+// progress.Step("✓ created README.md")
+// progress.Done(err)
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// progressReporter receives scaffolding progress.
+type progressReporter interface {
+	Phase(name string) // Starts a phase, ending the previous one
+	Step(line string)  // Reports one line of output (e.g. a created file)
+	Done(err error)    // Ends the last phase; err marks it failed
+}
+
+// newProgress returns the live view when out is an interactive terminal and
+// plain lines otherwise.
+func newProgress(out *os.File) progressReporter {
+	if isTerminal(out) && os.Getenv("TERM") != "dumb" {
+		return newTeaProgress(out)
+	}
+	fmt.Fprintln(out, renderScaffoldingLine())
+	fmt.Fprintln(out)
+	return newPlainProgress(out)
+}
+
+// isTerminal reports whether f is a terminal (the null device is a character
+// device too, so it's excluded).
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && !isDevNull(info)
+}
+
+// formatPhaseDuration rounds d for display.
+func formatPhaseDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// plainProgress writes steps as lines and a timing line after each phase.
+type plainProgress struct {
+	w       io.Writer
+	current string
+	started time.Time
+}
+
+func newPlainProgress(w io.Writer) *plainProgress {
+	return &plainProgress{w: w}
+}
+
+func (p *plainProgress) Phase(name string) {
+	p.end()
+	p.current, p.started = name, time.Now()
+}
+
+func (p *plainProgress) Step(line string) {
+	fmt.Fprintln(p.w, line)
+}
+
+func (p *plainProgress) Done(err error) {
+	p.end()
+}
+
+// end prints the current phase's duration, if a phase is running.
+func (p *plainProgress) end() {
+	if p.current == "" {
+		return
+	}
+	fmt.Fprintln(p.w, dimStyle.Render(T("progress.phaseDone", p.current, formatPhaseDuration(time.Since(p.started)))))
+	p.current = ""
+}
+
+// Messages fed to progressModel by teaProgress.
+type (
+	phaseStartMsg struct {
+		name string
+		at   time.Time
+	}
+	progressDoneMsg struct {
+		failed bool
+		at     time.Time
+	}
+)
+
+// progressPhase is one row of the live view.
+type progressPhase struct {
+	name     string
+	started  time.Time
+	duration time.Duration
+	done     bool
+	failed   bool
+}
+
+// progressModel is the Bubble Tea model behind the live view.
+type progressModel struct {
+	spinner spinner.Model
+	phases  []progressPhase
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case phaseStartMsg:
+		m.finishPhase(msg.at, false)
+		m.phases = append(m.phases, progressPhase{name: msg.name, started: msg.at})
+		return m, nil
+	case progressDoneMsg:
+		m.finishPhase(msg.at, msg.failed)
+		return m, tea.Quit
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// finishPhase marks the running phase, if any, as done at t.
+func (m *progressModel) finishPhase(t time.Time, failed bool) {
+	if n := len(m.phases); n > 0 && !m.phases[n-1].done {
+		m.phases[n-1].done = true
+		m.phases[n-1].failed = failed
+		m.phases[n-1].duration = t.Sub(m.phases[n-1].started)
+	}
+}
+
+func (m progressModel) View() string {
+	var b strings.Builder
+	for _, p := range m.phases {
+		switch {
+		case p.failed:
+			fmt.Fprintf(&b, "✗ %-10s %s\n", p.name, dimStyle.Render(formatPhaseDuration(p.duration)))
+		case p.done:
+			fmt.Fprintf(&b, "%s %-10s %s\n", successStyle.Render("✓"), p.name, dimStyle.Render(formatPhaseDuration(p.duration)))
+		default:
+			fmt.Fprintf(&b, "%s %-10s %s\n", m.spinner.View(), p.name, dimStyle.Render(formatPhaseDuration(time.Since(p.started))))
+		}
+	}
+	return b.String()
+}
+
+// teaProgress drives a progressModel running in the background. Steps go
+// through Program.Println, which shares Send's queue, so files and phase
+// changes are shown in the order they happened.
+type teaProgress struct {
+	program  *tea.Program
+	finished chan struct{} // Closed by Done
+	exited   chan struct{} // Closed when the program has restored the terminal
+}
+
+func newTeaProgress(out io.Writer) *teaProgress {
+	model := progressModel{spinner: spinner.New(spinner.WithSpinner(spinner.MiniDot))}
+	t := &teaProgress{
+		// No input: the view isn't interactive, and leaving stdin alone
+		// keeps the terminal out of raw mode. Signals are left to
+		// scaffoldProject, which rolls back on Ctrl+C (interrupt.go)
+		program:  tea.NewProgram(model, tea.WithOutput(out), tea.WithInput(nil), tea.WithoutSignalHandler()),
+		finished: make(chan struct{}),
+		exited:   make(chan struct{}),
+	}
+	go func() {
+		_, _ = t.program.Run()
+		close(t.exited)
+		select {
+		case <-t.finished:
+			return
+		default:
+		}
+		// Bubble Tea only stops on its own when it can't start; later
+		// steps would block on the stopped program
+		fmt.Fprintln(os.Stderr, T("progress.interrupted"))
+		os.Exit(130)
+	}()
+	return t
+}
+
+func (t *teaProgress) Phase(name string) {
+	t.program.Send(phaseStartMsg{name: name, at: time.Now()})
+}
+
+func (t *teaProgress) Step(line string) {
+	t.program.Println(line)
+}
+
+func (t *teaProgress) Done(err error) {
+	close(t.finished)
+	t.program.Send(progressDoneMsg{failed: err != nil, at: time.Now()})
+	<-t.exited
+}
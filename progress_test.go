@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlainProgress(t *testing.T) {
+	var out bytes.Buffer
+	p := newPlainProgress(&out)
+	p.Phase("templates")
+	p.Step("✓ created README.md")
+	p.Phase("skills")
+	p.Step("✓ created skills/entropy-guard.md")
+	p.Done(nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), out.String())
+	}
+	for i, want := range []string{"✓ created README.md", "templates done in", "✓ created skills/entropy-guard.md", "skills done in"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %q, want it to contain %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestPlainProgressDoneWithoutPhase(t *testing.T) {
+	var out bytes.Buffer
+	newPlainProgress(&out).Done(errors.New("boom"))
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+}
+
+func TestProgressModelPhases(t *testing.T) {
+	start := time.Now()
+	var m progressModel
+	step := func(msg any) {
+		t.Helper()
+		next, _ := m.Update(msg)
+		m = next.(progressModel)
+	}
+
+	step(phaseStartMsg{name: "templates", at: start})
+	step(phaseStartMsg{name: "skills", at: start.Add(20 * time.Millisecond)})
+	if !m.phases[0].done || m.phases[0].duration != 20*time.Millisecond {
+		t.Errorf("starting a phase should end the previous one: %+v", m.phases[0])
+	}
+	if m.phases[1].done {
+		t.Error("new phase should be running")
+	}
+
+	next, cmd := m.Update(progressDoneMsg{failed: true, at: start.Add(50 * time.Millisecond)})
+	m = next.(progressModel)
+	if cmd == nil {
+		t.Error("done should quit the program")
+	}
+	if !m.phases[1].failed || m.phases[1].duration != 30*time.Millisecond {
+		t.Errorf("last phase should be failed after 30ms: %+v", m.phases[1])
+	}
+
+	view := m.View()
+	for _, want := range []string{"templates", "20ms", "✗ skills", "30ms"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestFormatPhaseDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{1234567 * time.Nanosecond, "1ms"},
+		{345 * time.Millisecond, "345ms"},
+		{2345 * time.Millisecond, "2.3s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatPhaseDuration(tt.in); got != tt.want {
+				t.Errorf("formatPhaseDuration(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScaffoldReportsWrittenFiles(t *testing.T) {
+	isolateConfig(t)
+	target := tempDir(t)
+	writeTestFile(t, filepath.Join(target, "README.md"), "# mine\n")
+	before, err := snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	data := WizardData{ProjectName: "reported", Description: "Reported files", NoSkills: true}
+	report, err := scaffoldSteps(target, data, true, before, newPlainProgress(&out), func() bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	agents, err := os.ReadFile(filepath.Join(target, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := T("flow.createdSize", "AGENTS.md", formatSize(int64(len(agents)))); !strings.Contains(out.String(), want) {
+		t.Errorf("expected %q as it was written, got:\n%s", want, out.String())
+	}
+	if slices.Contains(report.Created, "README.md") || strings.Contains(out.String(), " README.md (") {
+		t.Errorf("a file that existed shouldn't be reported as created:\n%s", out.String())
+	}
+	if !slices.Contains(report.Created, "AGENTS.md") || !slices.Contains(report.Created, manifestPath) {
+		t.Errorf("expected the templates and the manifest in the report, got %v", report.Created)
+	}
+}
@@ -0,0 +1,85 @@
+// Package main - protection.go
+//
+// PURPOSE:
+// This file sets up default branch protection. It's responsible for:
+// - The policy: changes land through reviewed pull requests, CI must pass,
+//   and the branch can't be force-pushed or deleted
+// - Writing it as .github/branch-protection.json, the body of GitHub's
+//   branch protection API
+// - The gh command that applies it, shown in the next steps and in
+//   CONTRIBUTING.md
+//
+// DESIGN PATTERNS:
+// - seed doesn't create the GitHub repository, so it doesn't call the API
+//   either: the next steps apply the file right after `gh repo create`, and
+//   CONTRIBUTING.md documents the policy whether or not it's applied
+// - The file is the single source of the policy, so re-applying after an
+//   edit is the same command
+//
+// USAGE:
+// files := renderBranchProtection(data)
+
+package main
+
+import "encoding/json"
+
+// branchProtectionPath is where the branch protection settings are written.
+const branchProtectionPath = ".github/branch-protection.json"
+
+// protectCommand applies branchProtectionPath to the current branch of the
+// repository gh is run in.
+const protectCommand = `gh api -X PUT "repos/{owner}/{repo}/branches/$(git branch --show-current)/protection" --input ` + branchProtectionPath
+
+// branchProtection is the request body of GitHub's "update branch
+// protection" API. Every field is required; null turns a rule off.
+type branchProtection struct {
+	RequiredStatusChecks       *statusChecks   `json:"required_status_checks"`
+	EnforceAdmins              bool            `json:"enforce_admins"`
+	RequiredPullRequestReviews *reviewSettings `json:"required_pull_request_reviews"`
+	Restrictions               *struct{}       `json:"restrictions"`
+	AllowForcePushes           bool            `json:"allow_force_pushes"`
+	AllowDeletions             bool            `json:"allow_deletions"`
+}
+
+type statusChecks struct {
+	Strict   bool     `json:"strict"` // The branch must be up to date before merging
+	Contexts []string `json:"contexts"`
+}
+
+type reviewSettings struct {
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+}
+
+// RequiredChecks returns the CI checks branch protection requires: the jobs
+// of the workflows seed generates.
+func (d TemplateData) RequiredChecks() []string {
+	if len(d.CodingStandards()) > 0 {
+		return []string{"lint"} // The job in templates/lint.yml.tmpl
+	}
+	return nil
+}
+
+// ProtectCommand returns the command that applies the branch protection.
+func (d TemplateData) ProtectCommand() string {
+	return protectCommand
+}
+
+// renderBranchProtection returns .github/branch-protection.json, or nil
+// when the project doesn't want branch protection.
+func renderBranchProtection(data TemplateData) ([]RenderedFile, error) {
+	if !data.BranchProtection {
+		return nil, nil
+	}
+	settings := branchProtection{
+		RequiredPullRequestReviews: &reviewSettings{DismissStaleReviews: true, RequiredApprovingReviewCount: 1},
+	}
+	if checks := data.RequiredChecks(); len(checks) > 0 {
+		settings.RequiredStatusChecks = &statusChecks{Strict: true, Contexts: checks}
+	}
+	raw, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []RenderedFile{{Path: branchProtectionPath, Content: append(raw, '\n'), Mode: 0644}}, nil
+}
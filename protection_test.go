@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderBranchProtection(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		data   TemplateData
+		checks []string
+	}{
+		{"reviews only", TemplateData{ProjectName: "guarded", Description: "Protection test", BranchProtection: true}, nil},
+		{"with CI", TemplateData{ProjectName: "guarded", Description: "Protection test", BranchProtection: true, Language: "go", Standards: []string{"golangci-lint"}}, []string{"lint"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var settings branchProtection
+			if err := json.Unmarshal([]byte(renderedContent(files, branchProtectionPath)), &settings); err != nil {
+				t.Fatalf("%s: %v", branchProtectionPath, err)
+			}
+			if r := settings.RequiredPullRequestReviews; r == nil || r.RequiredApprovingReviewCount != 1 {
+				t.Errorf("pull request reviews = %+v", r)
+			}
+			if settings.AllowForcePushes || settings.AllowDeletions {
+				t.Error("force pushes and deletions should be blocked")
+			}
+			if tt.checks == nil && settings.RequiredStatusChecks != nil {
+				t.Errorf("unexpected status checks %+v", settings.RequiredStatusChecks)
+			}
+			if tt.checks != nil && (settings.RequiredStatusChecks == nil || strings.Join(settings.RequiredStatusChecks.Contexts, ",") != strings.Join(tt.checks, ",")) {
+				t.Errorf("status checks = %+v, want %v", settings.RequiredStatusChecks, tt.checks)
+			}
+
+			contributing := renderedContent(files, "CONTRIBUTING.md")
+			for _, want := range []string{"## Branch protection", "```bash\n" + protectCommand + "\n```"} {
+				if !strings.Contains(contributing, want) {
+					t.Errorf("CONTRIBUTING.md missing %q:\n%s", want, contributing)
+				}
+			}
+			if got := strings.Contains(contributing, "The `lint` check must pass"); got != (tt.checks != nil) {
+				t.Errorf("CONTRIBUTING.md mentions the lint check: %v\n%s", got, contributing)
+			}
+		})
+	}
+}
+
+func TestNextStepsApplyBranchProtection(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := renderNextSteps(s, "guarded", WizardData{ProjectName: "guarded", BranchProtection: true}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "--source=. --push\n  " + protectCommand + "\n"; !strings.Contains(got, want) {
+		t.Errorf("next steps missing %q:\n%s", want, got)
+	}
+
+	// Without a git repository there's nothing to protect yet
+	got, err = renderNextSteps(s, "guarded", WizardData{ProjectName: "guarded", BranchProtection: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(got, "gh api") {
+		t.Errorf("unexpected gh api without git:\n%s", got)
+	}
+}
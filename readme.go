@@ -0,0 +1,105 @@
+// Package main - readme.go
+//
+// PURPOSE:
+// This file defines the sections the generated README.md is composed of.
+// It's responsible for:
+// - The catalog of sections a project can leave out (badges, about, goals,
+//   quick start, development, license, agent workflow)
+// - Deciding, for the template, whether a section is rendered
+// - The wizard's section picker, and validating readmeOmit answers
+//
+// DESIGN PATTERNS:
+// - Each section is a {{define}} in README.md.tmpl, rendered when
+//   TemplateData.Section says so; the template still decides what a section
+//   holds for the project (a library's Installation and Usage are its quick
+//   start)
+// - Answers list the sections left out, not the ones kept, so a section
+//   added later appears in existing projects when they upgrade
+// - Config's readmeOmit (often the org's) is added to every project's
+//   answers, as defaults the wizard can still change
+//
+// USAGE:
+// {{if .Section "badges"}}{{template "badges" .}}{{end}}
+// data.ReadmeOmit = omittedSections(keptSections(data.ReadmeOmit))
+
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// readmeSection is a part of README.md a project can leave out.
+type readmeSection struct {
+	ID string // Also the wizard label's key: wizard.readme.<ID>
+}
+
+// readmeSections lists the optional sections in the order they're rendered.
+var readmeSections = []readmeSection{
+	{"badges"},
+	{"about"},
+	{"goals"},
+	{"quickstart"},
+	{"development"},
+	{"license"},
+	{"agentWorkflow"},
+}
+
+// Section reports whether the README section id is rendered.
+func (d TemplateData) Section(id string) bool {
+	return !slices.Contains(d.ReadmeOmit, id)
+}
+
+// validateReadmeOmit rejects unknown section IDs.
+func validateReadmeOmit(ids []string) error {
+	for _, id := range ids {
+		if !slices.ContainsFunc(readmeSections, func(s readmeSection) bool { return s.ID == id }) {
+			return errors.New(T("validate.readmeSection", id, readmeSectionIDs()))
+		}
+	}
+	return nil
+}
+
+// readmeSectionIDs lists the section IDs, for error messages.
+func readmeSectionIDs() string {
+	ids := make([]string, len(readmeSections))
+	for i, s := range readmeSections {
+		ids[i] = s.ID
+	}
+	return strings.Join(ids, ", ")
+}
+
+// readmeSectionOptions returns the wizard's options, with every section not
+// in omit selected.
+func readmeSectionOptions(omit []string) []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(readmeSections))
+	for _, s := range readmeSections {
+		options = append(options, huh.NewOption(T("wizard.readme."+s.ID), s.ID).Selected(!slices.Contains(omit, s.ID)))
+	}
+	return options
+}
+
+// keptSections returns the section IDs not in omit, in catalog order.
+func keptSections(omit []string) []string {
+	var kept []string
+	for _, s := range readmeSections {
+		if !slices.Contains(omit, s.ID) {
+			kept = append(kept, s.ID)
+		}
+	}
+	return kept
+}
+
+// omittedSections returns the sections not in kept, in catalog order.
+func omittedSections(kept []string) []string {
+	var omit []string
+	for _, s := range readmeSections {
+		if !slices.Contains(kept, s.ID) {
+			omit = append(omit, s.ID)
+		}
+	}
+	return omit
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadmeSections(t *testing.T) {
+	base := TemplateData{
+		ProjectName:   "readme-demo",
+		Description:   "A demo. It has more to say.",
+		License:       dualLicense,
+		Visibility:    "public",
+		Language:      "go",
+		Topics:        []string{"cli"},
+		Goals:         []string{"Ship it"},
+		Documentation: "https://docs.example.com",
+	}
+	tests := []struct {
+		name     string
+		omit     []string
+		maturity string
+		absent   []string
+		present  []string
+	}{
+		{name: "all", present: []string{"![License]", "## About", "**Topics**", "## Goals", "## Quick Start", "go test ./...", "## License", "**Project Files**"}},
+		{name: "badges", omit: []string{"badges"}, absent: []string{"![License]"}, present: []string{"## About"}},
+		{name: "about", omit: []string{"about"}, absent: []string{"## About", "**Topics**", "[Documentation]"}, present: []string{"A demo."}},
+		{name: "goals", omit: []string{"goals"}, absent: []string{"## Goals", "Ship it"}},
+		{name: "license", omit: []string{"license"}, absent: []string{"## License", "LICENSE-APACHE"}},
+		{name: "agent workflow", omit: []string{"agentWorkflow"}, absent: []string{"**Project Files**", "LEARNINGS.md"}},
+		// An application's commands move to Development without a quick start
+		{name: "quickstart", omit: []string{"quickstart"}, absent: []string{"## Quick Start"}, present: []string{"## Development", "go test ./..."}},
+		{name: "quickstart and development", omit: []string{"quickstart", "development"}, absent: []string{"## Quick Start", "## Development", "go test ./..."}},
+		{name: "library development", maturity: "library", omit: []string{"development"}, absent: []string{"## Development", "go test ./..."}, present: []string{"## Installation", "## Usage"}},
+		{name: "library quickstart", maturity: "library", omit: []string{"quickstart"}, absent: []string{"## Installation", "## Usage"}, present: []string{"## Development", "go test ./..."}},
+	}
+	s := &Scaffolder{templates: templateSetFor("embedded", templatesFS, "templates")}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base
+			data.ReadmeOmit = tt.omit
+			data.Maturity = tt.maturity
+			files, err := s.Render(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			readme := renderedContent(files, "README.md")
+			for _, want := range tt.present {
+				if !strings.Contains(readme, want) {
+					t.Errorf("expected %q in README.md:\n%s", want, readme)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(readme, unwanted) {
+					t.Errorf("expected no %q in README.md:\n%s", unwanted, readme)
+				}
+			}
+			if strings.Contains(readme, "\n\n\n") {
+				t.Errorf("leaving sections out shouldn't leave gaps:\n%s", readme)
+			}
+		})
+	}
+}
+
+func TestReadmeOmitAnswers(t *testing.T) {
+	if err := validateReadmeOmit([]string{"badges", "agentWorkflow"}); err != nil {
+		t.Errorf("known sections rejected: %v", err)
+	}
+	if err := validateReadmeOmit([]string{"changelog"}); err == nil || !strings.Contains(err.Error(), "changelog") {
+		t.Errorf("expected an unknown section to be rejected, got %v", err)
+	}
+
+	kept := keptSections([]string{"goals", "badges"})
+	if slices.Contains(kept, "goals") || !slices.Contains(kept, "about") {
+		t.Errorf("unexpected kept sections %v", kept)
+	}
+	if omit := omittedSections(kept); !slices.Equal(omit, []string{"badges", "goals"}) {
+		t.Errorf("expected omitted sections in catalog order, got %v", omit)
+	}
+
+	// Config's sections are left out of every project, with the answers' own
+	cfg := userConfig{ReadmeOmit: []string{"badges"}}
+	if got := wizardDefaults("api", WizardData{ReadmeOmit: []string{"goals"}}, cfg).ReadmeOmit; !slices.Equal(got, []string{"badges", "goals"}) {
+		t.Errorf("wizard defaults: got %v", got)
+	}
+	w := WizardData{ProjectName: "api", Description: "An API"}
+	if err := completeAnswers(&w, cfg); err != nil || !slices.Equal(w.ReadmeOmit, []string{"badges"}) {
+		t.Errorf("batch answers: got %v (%v)", w.ReadmeOmit, err)
+	}
+
+	// The picker's labels come from the catalog
+	useLocale(t, "es")
+	for i, o := range readmeSectionOptions(nil) {
+		key := "wizard.readme." + readmeSections[i].ID
+		if o.Key != catalogs["es"][key] || o.Key == catalogs[defaultLocale][key] {
+			t.Errorf("expected the Spanish label for %s, got %q", readmeSections[i].ID, o.Key)
+		}
+	}
+}
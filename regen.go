@@ -0,0 +1,88 @@
+// Package main - regen.go
+//
+// PURPOSE:
+// This file implements `seed regen <file>`: re-rendering a single generated
+// file from the manifest's recorded answers. It's responsible for:
+// - Resolving a user-supplied path to one file the templates generate
+// - Producing the diff shown before overwriting
+// - Writing the file and refreshing its manifest hash
+//
+// DESIGN PATTERNS:
+// - Renders through renderCurrent, the same source as status and diff
+// - Confirmation lives in main.go; this file only prepares and applies
+//
+// USAGE:
+// plan, err := planRegen(scaffolder, ".", "README.md")
+// fmt.Print(plan.Patch)
+// err = applyRegen(".", plan)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// regenPlan is a single file ready to be regenerated.
+type regenPlan struct {
+	Manifest Manifest
+	File     RenderedFile
+	Patch    string // Diff from the file on disk to File; "" if identical
+}
+
+// planRegen renders the generated file at relPath (slash or OS separators,
+// relative to dir) and diffs it against what's on disk.
+func planRegen(s *Scaffolder, dir, relPath string) (regenPlan, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return regenPlan{}, err
+	}
+	if err := enforceSeedVersion(checkProjectSeedVersion(manifest)); err != nil {
+		return regenPlan{}, err
+	}
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return regenPlan{}, err
+	}
+
+	want := path.Clean(filepath.ToSlash(relPath))
+	for _, f := range current {
+		if f.Path != want {
+			continue
+		}
+		onDisk, err := readGenerated(dir, f.Path)
+		oldName := "a/" + f.Path
+		switch {
+		case os.IsNotExist(err):
+			oldName = "/dev/null"
+		case err != nil:
+			return regenPlan{}, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		return regenPlan{
+			Manifest: manifest,
+			File:     f,
+			Patch:    unifiedDiff(oldName, "b/"+f.Path, onDisk, f.Content),
+		}, nil
+	}
+
+	var generated []string
+	for _, f := range current {
+		generated = append(generated, f.Path)
+	}
+	return regenPlan{}, fmt.Errorf("%s is not a file seed generates for this project (generated: %s)", want, strings.Join(generated, ", "))
+}
+
+// applyRegen writes the regenerated file and records its new hash, so status
+// treats it as freshly generated.
+func applyRegen(dir string, plan regenPlan) error {
+	if err := writeFiles(dir, []RenderedFile{plan.File}); err != nil {
+		return err
+	}
+
+	m := plan.Manifest
+	m.SetFile(plan.File)
+	return writeManifest(dir, m)
+}
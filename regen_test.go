@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegenRestoresFile(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+
+	readme := filepath.Join(dir, "README.md")
+	original, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, readme, "mangled\n")
+
+	plan, err := planRegen(s, dir, "./README.md")
+	if err != nil {
+		t.Fatalf("planRegen: %v", err)
+	}
+	if !strings.Contains(plan.Patch, "-mangled\n") {
+		t.Errorf("patch should remove the mangled content:\n%s", plan.Patch)
+	}
+	if err := applyRegen(dir, plan); err != nil {
+		t.Fatalf("applyRegen: %v", err)
+	}
+
+	got, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(original) {
+		t.Errorf("README.md not restored:\n%s", got)
+	}
+	report, err := projectStatus(s, dir)
+	if err != nil {
+		t.Fatalf("projectStatus: %v", err)
+	}
+	if !report.Clean() {
+		t.Errorf("project should be clean after regen, got %+v", report)
+	}
+}
+
+func TestRegenLeavesOtherFilesAlone(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+	writeTestFile(t, filepath.Join(dir, "TODO.md"), "my todo\n")
+	writeTestFile(t, filepath.Join(dir, "README.md"), "mangled\n")
+
+	plan, err := planRegen(s, dir, "README.md")
+	if err != nil {
+		t.Fatalf("planRegen: %v", err)
+	}
+	if err := applyRegen(dir, plan); err != nil {
+		t.Fatalf("applyRegen: %v", err)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(dir, "TODO.md"))
+	if string(got) != "my todo\n" {
+		t.Errorf("TODO.md was touched: %q", got)
+	}
+}
+
+func TestRegenRejectsUnknownFile(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+
+	for _, name := range []string{"main.go", ".devcontainer/Dockerfile", "../README.md"} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := planRegen(s, dir, name); err == nil {
+				t.Errorf("expected error for %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+// Package main - registry.go
+//
+// PURPOSE:
+// This file reads from container registries for `seed images refresh`
+// (imagecatalog.go). It's responsible for:
+// - Parsing references that name their registry (ghcr.io/acme/dev:1)
+// - Fetching manifests and blobs over the OCI distribution API, checked
+//   against their digests
+// - Registry auth from the credentials docker, podman or oras already
+//   stored: credential helpers, then config.json "auths" entries
+//
+// DESIGN PATTERNS:
+// - Standard library only: the few distribution API calls seed needs
+//   (manifest and blob GETs) and the token handshake are short
+// - Every blob is checked against its digest
+// - Pull access only; seed never writes to a registry
+// - localhost registries are spoken to over plain HTTP, as docker does;
+//   everything else over HTTPS with seed's proxy and CA bundle (network.go)
+//
+// USAGE:
+// c, err := newRegistryClient(ref)
+// raw, err := c.getManifestAs(ref.reference(), ociManifestType)
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ociManifestType is the media type of an OCI image manifest.
+const ociManifestType = "application/vnd.oci.image.manifest.v1+json"
+
+const (
+	ociMaxSize = 50 << 20         // Larger manifests and blobs are refused
+	ociTimeout = 60 * time.Second // Limit for each registry request
+)
+
+// ociDescriptor points at a blob.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest.
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// ociRef is a parsed artifact reference.
+type ociRef struct {
+	Registry   string // Host, with any port
+	Repository string
+	Tag        string // "latest" unless given; ignored when Digest is set
+	Digest     string // "sha256:..." when pinned
+}
+
+// ociRefPattern matches registry/repository[:tag][@digest].
+var ociRefPattern = regexp.MustCompile(`^([a-zA-Z0-9.-]+(?::[0-9]+)?)/([a-z0-9]+(?:[._/-][a-z0-9]+)*)(?::([\w][\w.-]{0,127}))?(?:@(sha256:[a-f0-9]{64}))?$`)
+
+// parseOCIRef parses a reference such as ghcr.io/acme/dev:1, with or
+// without an oci:// prefix. The registry must be named: there's no default.
+func parseOCIRef(s string) (ociRef, error) {
+	m := ociRefPattern.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(s), "oci://"))
+	if m == nil {
+		return ociRef{}, fmt.Errorf("invalid reference %q: expected registry/repository[:tag][@sha256:digest]", s)
+	}
+	host := m[1]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return ociRef{}, fmt.Errorf("invalid reference %q: name the registry, e.g. ghcr.io/%s", s, strings.TrimPrefix(s, "oci://"))
+	}
+	ref := ociRef{Registry: host, Repository: m[2], Tag: m[3], Digest: m[4]}
+	if ref.Tag == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// String returns the reference in its canonical form.
+func (r ociRef) String() string {
+	if r.Digest != "" {
+		return r.Registry + "/" + r.Repository + "@" + r.Digest
+	}
+	return r.Registry + "/" + r.Repository + ":" + r.Tag
+}
+
+// reference returns the tag or digest the manifest is addressed by.
+func (r ociRef) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// ociDigest returns content's sha256 digest.
+func ociDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// verifyOCIBlob checks content against the descriptor that pointed at it.
+func verifyOCIBlob(content []byte, desc ociDescriptor) error {
+	if int64(len(content)) != desc.Size {
+		return fmt.Errorf("%s: size %d, expected %d", desc.Digest, len(content), desc.Size)
+	}
+	if got := ociDigest(content); got != desc.Digest {
+		return fmt.Errorf("digest mismatch: got %s, want %s", got, desc.Digest)
+	}
+	return nil
+}
+
+// registryClient speaks the OCI distribution API to one repository.
+type registryClient struct {
+	ref    ociRef
+	base   string // scheme://host/v2/repository
+	client *http.Client
+	auth   string // Authorization header once a challenge has been answered
+}
+
+// newRegistryClient returns a client for ref's repository.
+func newRegistryClient(ref ociRef) (*registryClient, error) {
+	client, err := httpClient(ociTimeout)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "https"
+	if host, _, err := net.SplitHostPort(ref.Registry); (err == nil && isLoopback(host)) || isLoopback(ref.Registry) {
+		scheme = "http"
+	}
+	return &registryClient{ref: ref, base: scheme + "://" + ref.Registry + "/v2/" + ref.Repository, client: client}, nil
+}
+
+// isLoopback reports whether host is localhost or a loopback address.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// do sends the request newReq builds, answering one auth challenge. newReq
+// is called again for the retry.
+func (c *registryClient) do(newReq func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		if c.auth != "" {
+			req.Header.Set("Authorization", c.auth)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if c.auth, err = c.answer(challenge); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.ref.Registry, err)
+		}
+	}
+}
+
+// challengeParam matches one key="value" pair of a WWW-Authenticate header.
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// answer returns the Authorization header for a registry's challenge: the
+// stored credentials for Basic, or a token fetched with them for Bearer.
+func (c *registryClient) answer(challenge string) (string, error) {
+	user, secret, err := registryCredentials(c.ref.Registry)
+	if err != nil {
+		return "", err
+	}
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if user == "" {
+			return "", fmt.Errorf("authentication required; log in with docker login %s", c.ref.Registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+secret)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication %q", scheme)
+	}
+
+	values := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q", values["realm"])
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + c.ref.Repository + ":pull"
+	}
+	q := realm.Query()
+	q.Set("scope", scope)
+	if values["service"] != "" {
+		q.Set("service", values["service"])
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if user == identityTokenUser {
+		// An identity token is an OAuth2 refresh token, exchanged by POST
+		form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {secret}, "service": {values["service"]}, "scope": {scope}, "client_id": {"seed"}}
+		realm.RawQuery = ""
+		req, err = http.NewRequest(http.MethodPost, realm.String(), strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else if err == nil && user != "" {
+		req.SetBasicAuth(user, secret)
+	}
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if user == "" {
+			return "", fmt.Errorf("token request failed (%s); log in with docker login %s", resp.Status, c.ref.Registry)
+		}
+		return "", fmt.Errorf("token request failed: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", errors.New("token response has no token")
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// registryError describes a failed registry response, with the first of
+// the distribution API's error messages when there is one.
+func registryError(action string, resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(raw, &body) == nil && len(body.Errors) > 0 {
+		return fmt.Errorf("%s: %s: %s", action, resp.Status, body.Errors[0].Message)
+	}
+	return fmt.Errorf("%s: %s", action, resp.Status)
+}
+
+// getManifestAs downloads the manifest at reference in one of the accepted
+// media types.
+func (c *registryClient) getManifestAs(reference string, accept ...string) ([]byte, error) {
+	resp, err := c.do(func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, c.base+"/manifests/"+reference, nil)
+		if err == nil {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, registryError(c.ref.String(), resp)
+	}
+	return readLimited(resp.Body, ociMaxSize)
+}
+
+// getBlob downloads the blob desc points at and checks it.
+func (c *registryClient) getBlob(desc ociDescriptor) ([]byte, error) {
+	if desc.Size > ociMaxSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", desc.Digest, ociMaxSize)
+	}
+	resp, err := c.do(func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, c.base+"/blobs/"+desc.Digest, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, registryError("download "+desc.Digest, resp)
+	}
+	content, err := readLimited(resp.Body, ociMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	return content, verifyOCIBlob(content, desc)
+}
+
+// readLimited reads r, refusing more than limit bytes.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, fmt.Errorf("larger than %d bytes", limit)
+	}
+	return raw, nil
+}
+
+// dockerConfig is the part of docker's config.json (and podman's
+// auth.json) that holds registry credentials.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// dockerConfigPaths returns where stored registry credentials are looked
+// for: REGISTRY_AUTH_FILE (podman, skopeo), then docker's config.json.
+func dockerConfigPaths() []string {
+	var paths []string
+	if p := os.Getenv("REGISTRY_AUTH_FILE"); p != "" {
+		paths = append(paths, p)
+	}
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return append(paths, filepath.Join(dir, "config.json"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".docker", "config.json"))
+	}
+	return paths
+}
+
+// identityTokenUser is the username stored with an identity token rather
+// than a password, by docker login and credential helpers alike.
+const identityTokenUser = "<token>"
+
+// registryCredentials returns the stored username and secret for registry,
+// or empty strings when none are stored (anonymous access).
+func registryCredentials(registry string) (user, secret string, err error) {
+	for _, p := range dockerConfigPaths() {
+		raw, err := os.ReadFile(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		var cfg dockerConfig
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return "", "", fmt.Errorf("%s: %w", p, err)
+		}
+		helper := cfg.CredHelpers[registry]
+		if helper == "" {
+			helper = cfg.CredsStore
+		}
+		if helper != "" {
+			out, err := runCommandInput("", commandTimeout(defaultCommandTimeout), []byte(registry), "docker-credential-"+helper, "get")
+			if err != nil {
+				// Helpers fail for registries they hold nothing for
+				debugf("credential helper %s: %v", helper, err)
+			} else {
+				var creds struct{ Username, Secret string }
+				if err := json.Unmarshal([]byte(out), &creds); err == nil && creds.Secret != "" {
+					return creds.Username, creds.Secret, nil
+				}
+			}
+		}
+		entry, ok := cfg.Auths[registry]
+		if !ok {
+			entry, ok = cfg.Auths["https://"+registry]
+		}
+		if !ok {
+			continue
+		}
+		if entry.IdentityToken != "" {
+			return identityTokenUser, entry.IdentityToken, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: invalid auth for %s", p, registry)
+		}
+		user, secret, _ = strings.Cut(string(decoded), ":")
+		return user, secret, nil
+	}
+	return "", "", nil
+}
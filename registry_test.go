@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testRegistry is an in-memory registry that wants a bearer token from its
+// own token endpoint, issued for the user and password it was given.
+type testRegistry struct {
+	*httptest.Server
+	user, password string
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte // Tag or digest → manifest
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+	r := &testRegistry{user: "ci", password: "s3cret", blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// host is the registry's host:port, as a reference names it.
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if user, password, ok := req.BasicAuth(); !ok || user != r.user || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "t0ken"})
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer t0ken" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.URL+`/token",service="test",scope="repository:acme/dev:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rest := strings.TrimPrefix(req.URL.Path, "/v2/acme/dev/")
+	switch {
+	case strings.HasPrefix(rest, "blobs/"):
+		blob, ok := r.blobs[strings.TrimPrefix(rest, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == http.MethodGet {
+			w.Write(blob)
+		}
+	case req.Method == http.MethodGet && strings.HasPrefix(rest, "manifests/"):
+		manifest, ok := r.manifests[strings.TrimPrefix(rest, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"code": "MANIFEST_UNKNOWN", "message": "manifest unknown"}}})
+			return
+		}
+		w.Header().Set("Content-Type", ociManifestType)
+		w.Write(manifest)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// loginTo stores credentials for registry the way docker login does.
+func loginTo(t *testing.T, registry, user, password string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("REGISTRY_AUTH_FILE", "")
+	auth := base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+	writeTestFile(t, filepath.Join(dir, "config.json"), `{"auths": {"`+registry+`": {"auth": "`+auth+`"}}}`)
+}
+
+func TestRegistryClient(t *testing.T) {
+	isolateConfig(t)
+	reg := newTestRegistry(t)
+	loginTo(t, reg.host(), reg.user, reg.password)
+	blob := []byte("layer")
+	reg.blobs[ociDigest(blob)] = blob
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"` + ociManifestType + `","layers":[]}`)
+	reg.manifests["1"] = manifest
+
+	ref, err := parseOCIRef(reg.host() + "/acme/dev:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newRegistryClient(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.getManifestAs(ref.reference(), ociManifestType); err != nil || string(got) != string(manifest) {
+		t.Fatalf("getManifestAs = %s, %v", got, err)
+	}
+	desc := ociDescriptor{Digest: ociDigest(blob), Size: int64(len(blob))}
+	if got, err := c.getBlob(desc); err != nil || string(got) != "layer" {
+		t.Fatalf("getBlob = %q, %v", got, err)
+	}
+
+	// A tampered blob fails its digest
+	reg.blobs[desc.Digest] = []byte("LAYER")
+	if _, err := c.getBlob(desc); err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("expected a digest mismatch, got %v", err)
+	}
+
+	// Registry errors come through
+	if _, err := c.getManifestAs("missing", ociManifestType); err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("expected the registry's message, got %v", err)
+	}
+
+	// Without credentials the token endpoint refuses
+	loginTo(t, "elsewhere.example.com", "x", "y")
+	anonymous, err := newRegistryClient(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := anonymous.getManifestAs("1", ociManifestType); err == nil || !strings.Contains(err.Error(), "docker login") {
+		t.Errorf("expected a login hint, got %v", err)
+	}
+}
+
+func TestParseOCIRef(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	tests := []struct {
+		in      string
+		want    ociRef
+		wantErr string
+	}{
+		{in: "ghcr.io/acme/dev:1", want: ociRef{Registry: "ghcr.io", Repository: "acme/dev", Tag: "1"}},
+		{in: "oci://ghcr.io/acme/dev", want: ociRef{Registry: "ghcr.io", Repository: "acme/dev", Tag: "latest"}},
+		{in: "localhost:5000/pack@" + digest, want: ociRef{Registry: "localhost:5000", Repository: "pack", Tag: "latest", Digest: digest}},
+		{in: "acme/dev:1", wantErr: "name the registry"},
+		{in: "ghcr.io/Acme/pack", wantErr: "invalid reference"},
+		{in: "ghcr.io", wantErr: "invalid reference"},
+	}
+	for _, tt := range tests {
+		got, err := parseOCIRef(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseOCIRef(%q) = %v, want an error containing %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseOCIRef(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
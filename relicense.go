@@ -0,0 +1,220 @@
+// Package main - relicense.go
+//
+// PURPOSE:
+// This file implements `seed add license <license>`: adding or switching the
+// license of an existing scaffolded project. It's responsible for:
+// - Re-rendering the license-dependent files (LICENSE*, NOTICE, README
+//   section, SPDX headers) from the recorded answers with the new license
+// - Removing license files the new license no longer generates
+// - Updating the license field in package.json, Cargo.toml and pyproject.toml
+//   and shields.io license badges in README.md
+// - Explaining what switching a license does (and doesn't) do
+//
+// DESIGN PATTERNS:
+// - Plan/apply split like upgrade: planRelicense is read-only
+// - Only files whose output depends on the license are touched, so a pending
+//   template upgrade isn't applied as a side effect
+// - Locally edited files are merged (see merge.go) or, if dropped, kept
+//
+// USAGE:
+// plan, err := planRelicense(scaffolder, ".", "Apache-2.0")
+// err = applyRelicense(".", plan)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// relicensePlan is everything switching a project's license would change.
+type relicensePlan struct {
+	From, To  string
+	Upgrade   upgradePlan    // License-dependent files, planned like an upgrade
+	Remove    []string       // Generated files the new license drops (untouched locally)
+	Keep      []string       // Dropped files that were edited locally; left in place
+	Manifests []RenderedFile // Package manifests and README with license fields updated
+	Warnings  []string
+}
+
+// planRelicense plans switching the project at dir to license.
+func planRelicense(s *Scaffolder, dir, license string) (relicensePlan, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return relicensePlan{}, err
+	}
+	plan := relicensePlan{From: manifest.Answers.License, To: license}
+	if plan.From == "" {
+		plan.From = "none"
+	}
+	if plan.From == plan.To {
+		return plan, nil
+	}
+
+	before, err := renderCurrent(s, manifest)
+	if err != nil {
+		return plan, err
+	}
+	manifest.Answers.License = license
+	after, err := renderCurrent(s, manifest)
+	if err != nil {
+		return plan, err
+	}
+
+	// Only files whose output the license changes
+	old := make(map[string][]byte, len(before))
+	for _, f := range before {
+		old[f.Path] = f.Content
+	}
+	var changed []string
+	for _, f := range after {
+		if content, ok := old[f.Path]; !ok || string(content) != string(f.Content) {
+			changed = append(changed, f.Path)
+		}
+		delete(old, f.Path)
+	}
+	upgrade, err := planUpgradeFrom(dir, manifest, after)
+	if err != nil {
+		return plan, err
+	}
+	upgrade.Changes = filterChanges(upgrade.Changes, changed)
+	plan.Upgrade = upgrade
+
+	// Files only the old license generated
+	for _, f := range before {
+		if _, dropped := old[f.Path]; !dropped {
+			continue
+		}
+		local, err := readGenerated(dir, f.Path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return plan, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		if recorded, tracked := manifest.File(f.Path); tracked && hashContent(local) == recorded.SHA256 {
+			plan.Remove = append(plan.Remove, f.Path)
+		} else {
+			plan.Keep = append(plan.Keep, f.Path)
+		}
+	}
+
+	if plan.Manifests, err = relicenseManifests(dir, plan.Upgrade, licenseSPDX(license)); err != nil {
+		return plan, err
+	}
+	plan.Warnings = relicenseWarnings(plan)
+	return plan, nil
+}
+
+// filterChanges keeps the changes for paths.
+func filterChanges(changes []upgradeChange, paths []string) []upgradeChange {
+	keep := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		keep[p] = true
+	}
+	var out []upgradeChange
+	for _, c := range changes {
+		if keep[c.Path] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+var (
+	cargoLicense     = regexp.MustCompile(`(?m)^license\s*=\s*"[^"]*"\n`)
+	packageLicense   = regexp.MustCompile(`("license"\s*:\s*)"[^"]*"`)
+	shieldsBadge     = regexp.MustCompile(`(img\.shields\.io/badge/[Ll]icense-)((?:[^-/)\s]|--)+)(-)`)
+	licenseManifests = []string{"package.json", "Cargo.toml", "pyproject.toml"}
+)
+
+// relicenseManifests updates an existing license field in the project's
+// package manifests, and license badges in README.md (on top of any README
+// change already planned). spdx is "" when the license is removed: npm gets
+// "UNLICENSED", Cargo and pyproject lose the field, badges stay for the user.
+func relicenseManifests(dir string, upgrade upgradePlan, spdx string) ([]RenderedFile, error) {
+	var files []RenderedFile
+	for _, name := range licenseManifests {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		var updated string
+		switch {
+		case name == "package.json" && spdx == "":
+			updated = packageLicense.ReplaceAllString(string(raw), `${1}"UNLICENSED"`)
+		case name == "package.json":
+			updated = packageLicense.ReplaceAllString(string(raw), fmt.Sprintf("${1}%q", spdx))
+		case spdx == "":
+			updated = cargoLicense.ReplaceAllString(string(raw), "")
+		default:
+			updated = cargoLicense.ReplaceAllString(string(raw), fmt.Sprintf("license = %q\n", spdx))
+		}
+		if updated != string(raw) {
+			files = append(files, RenderedFile{Path: name, Content: []byte(updated), Mode: 0644})
+		}
+	}
+
+	if spdx == "" {
+		return files, nil
+	}
+	badge := strings.NewReplacer("-", "--", " ", "%20").Replace(spdx)
+	for i, c := range upgrade.Changes {
+		if c.Path == "README.md" && c.Content != nil {
+			upgrade.Changes[i].Content = shieldsBadge.ReplaceAll(c.Content, []byte("${1}"+badge+"${3}"))
+			return files, nil
+		}
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		return files, nil // no README to update
+	}
+	if updated := shieldsBadge.ReplaceAll(raw, []byte("${1}"+badge+"${3}")); string(updated) != string(raw) {
+		files = append(files, RenderedFile{Path: "README.md", Content: updated, Mode: 0644})
+	}
+	return files, nil
+}
+
+// relicenseWarnings explains the implications of the switch.
+func relicenseWarnings(plan relicensePlan) []string {
+	var warnings []string
+	if plan.From != "none" {
+		warnings = append(warnings,
+			T("license.warnReleased", plan.From),
+			T("license.warnContributors"))
+	}
+	if plan.To == "none" {
+		warnings = append(warnings, T("license.warnNone"))
+	}
+	for _, p := range plan.Keep {
+		warnings = append(warnings, T("license.warnKept", p))
+	}
+	return warnings
+}
+
+// applyRelicense writes the plan and records the new license in the manifest.
+func applyRelicense(dir string, plan relicensePlan) error {
+	m := plan.Upgrade.Manifest
+	if err := applyChanges(dir, &m, plan.Upgrade.Changes); err != nil {
+		return err
+	}
+	for _, p := range plan.Remove {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(p))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+		m.RemoveFile(p)
+	}
+	for _, p := range plan.Keep {
+		m.RemoveFile(p)
+	}
+	if err := writeFiles(dir, plan.Manifests); err != nil {
+		return err
+	}
+	return writeManifest(dir, m)
+}
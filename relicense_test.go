@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRelicense(t *testing.T) {
+	dir := mustScaffoldProject(t) // MIT
+	s, _ := NewScaffolder()
+	writeTestFile(t, filepath.Join(dir, "package.json"), "{\n  \"name\": \"statustest\",\n  \"license\": \"MIT\"\n}\n")
+	writeTestFile(t, filepath.Join(dir, "Cargo.toml"), "[package]\nname = \"statustest\"\nlicense = \"MIT\"\n")
+	readme, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	writeTestFile(t, filepath.Join(dir, "README.md"), "![License](https://img.shields.io/badge/license-MIT-blue)\n"+string(readme))
+
+	plan, err := planRelicense(s, dir, dualLicense)
+	if err != nil {
+		t.Fatalf("planRelicense: %v", err)
+	}
+	if !slices.Equal(plan.Remove, []string{"LICENSE"}) {
+		t.Errorf("Remove = %v, want [LICENSE]", plan.Remove)
+	}
+	if len(plan.Warnings) == 0 || !strings.Contains(plan.Warnings[0], "released under MIT") {
+		t.Errorf("switching away from MIT should warn, got %v", plan.Warnings)
+	}
+	if err := applyRelicense(dir, plan); err != nil {
+		t.Fatalf("applyRelicense: %v", err)
+	}
+
+	for _, want := range []struct{ file, text string }{
+		{"LICENSE-MIT", "MIT License"},
+		{"LICENSE-APACHE", "Apache License"},
+		{"README.md", "license-MIT%20OR%20Apache--2.0-blue"},
+		{"README.md", "Licensed under either of"},
+		{"package.json", `"license": "MIT OR Apache-2.0"`},
+		{"Cargo.toml", "license = \"MIT OR Apache-2.0\"\n"},
+	} {
+		raw, err := os.ReadFile(filepath.Join(dir, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "LICENSE")); !os.IsNotExist(err) {
+		t.Error("LICENSE should be removed")
+	}
+	m, _ := readManifest(dir)
+	if m.Answers.License != dualLicense {
+		t.Errorf("manifest license = %q, want %q", m.Answers.License, dualLicense)
+	}
+	if _, tracked := m.File("LICENSE"); tracked {
+		t.Error("manifest should stop tracking LICENSE")
+	}
+
+	// Removing the license keeps a locally edited license file
+	writeTestFile(t, filepath.Join(dir, "LICENSE-MIT"), "edited\n")
+	plan, err = planRelicense(s, dir, "none")
+	if err != nil {
+		t.Fatalf("planRelicense: %v", err)
+	}
+	if !slices.Equal(plan.Remove, []string{"LICENSE-APACHE"}) || !slices.Equal(plan.Keep, []string{"LICENSE-MIT"}) {
+		t.Errorf("Remove = %v, Keep = %v", plan.Remove, plan.Keep)
+	}
+	if err := applyRelicense(dir, plan); err != nil {
+		t.Fatalf("applyRelicense: %v", err)
+	}
+	pkg, _ := os.ReadFile(filepath.Join(dir, "package.json"))
+	cargo, _ := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if !strings.Contains(string(pkg), `"UNLICENSED"`) || strings.Contains(string(cargo), "license") {
+		t.Errorf("manifests should drop the license:\n%s\n%s", pkg, cargo)
+	}
+}
+
+func TestRelicenseSameLicense(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+	plan, err := planRelicense(s, dir, "MIT")
+	if err != nil {
+		t.Fatalf("planRelicense: %v", err)
+	}
+	if len(plan.Upgrade.Changes) != 0 || len(plan.Remove) != 0 {
+		t.Errorf("same license should plan nothing, got %+v", plan)
+	}
+}
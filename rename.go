@@ -0,0 +1,167 @@
+// Package main - rename.go
+//
+// PURPOSE:
+// This file implements `seed rename <name>`: renaming a scaffolded project
+// everywhere seed put its name. It's responsible for:
+// - Re-rendering the files whose output depends on the name (doc titles,
+//   NOTICE, the dev container name and extensions volume) from the recorded
+//   answers with the new name
+// - Updating the name field in package.json, Cargo.toml and pyproject.toml
+//   when it still holds the old name
+// - Carrying the copyright holder along when it was derived from the name
+//
+// DESIGN PATTERNS:
+// - Guided by the manifest, not find-and-replace: only text the templates
+//   render from the name changes, so prose that mentions the old name and
+//   unrelated matches are left alone
+// - Plan/apply split like relicense.go, whose render-before/render-after
+//   approach this follows; locally edited files are merged (merge.go)
+// - The project directory, go.mod module path and git remote aren't
+//   renamed; seed says so rather than guessing
+//
+// USAGE:
+// plan, err := planRename(scaffolder, ".", "new-name")
+// err = applyRename(".", plan)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// renamePlan is everything renaming a project would change.
+type renamePlan struct {
+	From, To   string
+	Upgrade    upgradePlan    // Name-dependent files, planned like an upgrade
+	Manifests  []RenderedFile // Package manifests with the name field updated
+	OldVolume  string         // Extensions cache volume named after the old name ("" if unchanged)
+	HolderMove bool           // The copyright holder was the old name and follows it
+	Warnings   []string
+}
+
+var (
+	packageName  = regexp.MustCompile(`("name"\s*:\s*)"([^"]*)"`)
+	tomlName     = regexp.MustCompile(`(?m)^(name\s*=\s*)"([^"]*)"`)
+	nameManifest = []string{"package.json", "Cargo.toml", "pyproject.toml"}
+)
+
+// planRename plans renaming the project at dir to name.
+func planRename(s *Scaffolder, dir, name string) (renamePlan, error) {
+	name = strings.TrimSpace(name)
+	if err := validateProjectName(name); err != nil {
+		return renamePlan{}, err
+	}
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return renamePlan{}, err
+	}
+	if err := enforceSeedVersion(checkProjectSeedVersion(manifest)); err != nil {
+		return renamePlan{}, err
+	}
+	plan := renamePlan{From: manifest.Answers.ProjectName, To: name}
+	if plan.From == plan.To {
+		return plan, nil
+	}
+
+	before, err := renderCurrent(s, manifest)
+	if err != nil {
+		return plan, err
+	}
+	manifest.Answers.ProjectName = name
+	if license := manifest.LicenseInfo(); license.Holder == plan.From {
+		license.Holder = name
+		manifest.License = &license
+		plan.HolderMove = true
+	}
+	// A volume named after the old name follows it; a custom one stays
+	if old := manifest.Answers.ExtensionsVolume; old != "" && old == extensionsVolumeName(plan.From, dir) {
+		manifest.Answers.ExtensionsVolume = extensionsVolumeName(name, dir)
+		plan.OldVolume = old
+	}
+	after, err := renderCurrent(s, manifest)
+	if err != nil {
+		return plan, err
+	}
+
+	// Only files whose output the name changes
+	old := make(map[string][]byte, len(before))
+	for _, f := range before {
+		old[f.Path] = f.Content
+	}
+	var changed []string
+	for _, f := range after {
+		if content, ok := old[f.Path]; !ok || string(content) != string(f.Content) {
+			changed = append(changed, f.Path)
+		}
+	}
+	upgrade, err := planUpgradeFrom(dir, manifest, after)
+	if err != nil {
+		return plan, err
+	}
+	upgrade.Changes = filterChanges(upgrade.Changes, changed)
+	plan.Upgrade = upgrade
+
+	if plan.Manifests, err = renameManifests(dir, plan.From, name); err != nil {
+		return plan, err
+	}
+	plan.Warnings = renameWarnings(dir, plan)
+	return plan, nil
+}
+
+// renameManifests updates the first name field of each package manifest
+// whose value is the old name (the package's own name; dependencies come
+// later in the file).
+func renameManifests(dir, from, to string) ([]RenderedFile, error) {
+	var files []RenderedFile
+	for _, file := range nameManifest {
+		raw, err := os.ReadFile(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		pattern := tomlName
+		if file == "package.json" {
+			pattern = packageName
+		}
+		m := pattern.FindSubmatchIndex(raw)
+		if m == nil || string(raw[m[4]:m[5]]) != from {
+			continue
+		}
+		updated := append(append(append([]byte{}, raw[:m[4]]...), to...), raw[m[5]:]...)
+		files = append(files, RenderedFile{Path: file, Content: updated, Mode: 0644})
+	}
+	return files, nil
+}
+
+// renameWarnings lists what renaming leaves to the user.
+func renameWarnings(dir string, plan renamePlan) []string {
+	var warnings []string
+	if abs, err := filepath.Abs(dir); err == nil && filepath.Base(abs) == plan.From {
+		warnings = append(warnings, T("rename.warnDirectory", plan.From))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		warnings = append(warnings, T("rename.warnGoModule"))
+	}
+	if plan.OldVolume != "" {
+		warnings = append(warnings, T("rename.warnVolume", plan.OldVolume))
+	}
+	return warnings
+}
+
+// applyRename writes the plan and records the new name in the manifest.
+func applyRename(dir string, plan renamePlan) error {
+	m := plan.Upgrade.Manifest
+	if err := applyChanges(dir, &m, plan.Upgrade.Changes); err != nil {
+		return err
+	}
+	if err := writeFiles(dir, plan.Manifests); err != nil {
+		return err
+	}
+	return writeManifest(dir, m)
+}
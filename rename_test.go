@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	dir := mustScaffoldProject(t) // "statustest", MIT
+	s, _ := NewScaffolder()
+	writeTestFile(t, filepath.Join(dir, "package.json"), "{\n  \"name\": \"statustest\",\n  \"dependencies\": {\"name\": \"x\"}\n}\n")
+	writeTestFile(t, filepath.Join(dir, "Cargo.toml"), "[package]\nname = \"other\"\n")
+	// A local edit that mentions the name in prose is merged, not rewritten
+	readme, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	writeTestFile(t, filepath.Join(dir, "README.md"), string(readme)+"\nWhy statustest exists.\n")
+
+	plan, err := planRename(s, dir, "billing-api")
+	if err != nil {
+		t.Fatalf("planRename: %v", err)
+	}
+	if !plan.HolderMove {
+		t.Error("the copyright holder was the project name and should follow it")
+	}
+	if len(plan.Manifests) != 1 || plan.Manifests[0].Path != "package.json" {
+		t.Errorf("manifests = %v, want only package.json (Cargo.toml's name isn't the project's)", plan.Manifests)
+	}
+	if err := applyRename(dir, plan); err != nil {
+		t.Fatalf("applyRename: %v", err)
+	}
+
+	for _, want := range []struct{ file, text string }{
+		{"README.md", "# billing-api\n"},
+		{"README.md", "Why statustest exists."},
+		{"AGENTS.md", "# Agent Context for billing-api"},
+		{"LICENSE", "billing-api"},
+		{"package.json", `"name": "billing-api"`},
+		{"package.json", `{"name": "x"}`},
+	} {
+		raw, err := os.ReadFile(filepath.Join(dir, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+	m, _ := readManifest(dir)
+	if m.Answers.ProjectName != "billing-api" || m.LicenseInfo().Holder != "billing-api" {
+		t.Errorf("manifest = %+v", m.Answers)
+	}
+	report, err := projectStatus(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Updated) > 0 || len(report.Modified) != 1 {
+		t.Errorf("after renaming only the edited README should differ: %+v", report)
+	}
+
+	if plan, err := planRename(s, dir, "billing-api"); err != nil || plan.From != plan.To {
+		t.Errorf("renaming to the same name should be a no-op, got %+v, %v", plan, err)
+	}
+	if _, err := planRename(s, dir, "  "); err == nil {
+		t.Error("expected an empty name to be rejected")
+	}
+}
+
+func TestRenameExtensionsVolume(t *testing.T) {
+	target := tempDir(t)
+	answers := WizardData{ProjectName: "voltest", Description: "Volume test", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie"}
+	if _, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := NewScaffolder()
+	plan, err := planRename(s, target, "newvol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.OldVolume != extensionsVolumeName("voltest", target) {
+		t.Errorf("old volume = %q", plan.OldVolume)
+	}
+	if err := applyRename(target, plan); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(target, ".devcontainer", "devcontainer.json"))
+	for _, want := range []string{`"name": "newvol (Dev Container)"`, extensionsVolumeName("newvol", target)} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("devcontainer.json should contain %q:\n%s", want, raw)
+		}
+	}
+}
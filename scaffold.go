@@ -21,14 +21,24 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
-	"text/template"
-	"time"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // templatesFS embeds all .tmpl files at compile time.
@@ -43,26 +53,138 @@ var templatesFS embed.FS
 // Fields match the template variables documented in CONTRIBUTING.md:
 // - Required (from wizard): ProjectName, Description
 type TemplateData struct {
-	ProjectName         string // User's project name
-	Description         string // User's project description (1-2 sentences)
-	IncludeDevContainer bool   // Whether to scaffold .devcontainer/
-	DevContainerImage   string // MCR image tag, e.g. "go:2-1.25-trixie"
-	AIChatContinuity    bool     // Whether to enable AI chat continuity
-	VSCodeExtensions    []string // VS Code extension IDs to install in dev container
-	License             string   // "none", "MIT", or "Apache-2.0"
+	ProjectName         string   // User's project name
+	Description         string   // User's project description, as typed (see description.go)
+	IncludeDevContainer bool     // Whether to scaffold .devcontainer/
+	DevContainerImage   string   // MCR image tag, e.g. "go:2-1.25-trixie", or a full custom reference
+	Language            string   // Stack ID from stack.go, e.g. "go" ("" for language-neutral output)
+	ChatTools           []aiTool // Tools whose host state is mounted for chat continuity (none disables it)
+	ChatState           string   // How the container gets tool state: "bind"/"" (host dir, live) or "copy" (volume seeded once)
+	VSCodeExtensions    []string // VS Code extension IDs for the dev container and .vscode/extensions.json
+	IDE                 string   // Dev container IDE from ide.go: ""/"vscode", "jetbrains" or "none"
+	VSCodeConfig        bool     // Generate .vscode/settings.json, tasks.json and launch.json
+	Shell               string   // Dev container shell: "bash", "zsh" (oh-my-zsh), or "" (image default)
+	DotfilesRepo        string   // Dotfiles clone URL installed on container creation ("" for none)
+	DockerAccess        string   // "docker-in-docker", "docker-outside-of-docker", or "none"/"" (no Docker)
+	Workload            string   // "general", "ml", or "" (no hostRequirements)
+	GPU                 bool     // Request a GPU in hostRequirements (as "optional")
+	CUDA                bool     // Install CUDA and require an NVIDIA GPU (mlstack.go)
+	Secrets             []string // Environment variable names the project needs (never values)
+	ForwardEnv          []string // Extra host variables forwarded into the dev container
+	Mounts              []string // Extra devcontainer.json mount strings, appended after seed's own
+	NoExtensionsCache   bool     // Skip the VS Code extensions cache volume and its onCreateCommand symlink
+	ExtensionsVolume    string   // Extensions cache volume name ("" for the older "<name>-vscode-extensions")
+	License             string   // "none", "MIT", "Apache-2.0", or "MIT OR Apache-2.0"
+	LicenseHeaders      bool     // Prepend SPDX license headers to generated source files
 	Year                int      // Current year for LICENSE copyright
+	CopyrightYears      string   // Copyright year or range, e.g. "2025-2026" ("" for Year)
+	CopyrightHolder     string   // Copyright holder ("" for ProjectName)
+	WorkspaceRoot       string   // Workspace packages only: relative path back to the workspace root, e.g. "../.."
+	Footer              string   // Branding footer appended to generated docs ("" for none)
+	ImageRegistry       string   // Registry path dev container images come from ("" for defaultImageRegistry)
+	NoSkills            bool     // Leave out skills/ and the docs that point to them
+	ReadmeOmit          []string // README sections left out, by ID from readme.go (see Section)
+	Commands            []string // Project commands listed in AGENTS.md (empty keeps the placeholder)
+	Visibility          string   // GitHub repository visibility: "private", "public", or "" (private)
+	Topics              []string // GitHub topics, also README and package keywords
+	Homepage            string   // Homepage URL ("" for none): README link, package manifests, GitHub website
+	Documentation       string   // Documentation URL ("" for none): README link, Cargo documentation
+	IssueTracker        string   // Issue tracker URL ("" for none): TODO.md and CONTRIBUTING.md
+	LinkTasks           bool     // TODO.md tasks start with their tracker ID (see TaskRef)
+	Team                string   // Owning team ("" for none): README Ownership, CODEOWNERS when a GitHub @org/team
+	Maintainer          string   // Primary maintainer, @handle or email ("" for none): README, CODEOWNERS, SECURITY.md
+	Maturity            string   // Audience and maturity ID from maturity.go ("" for none): README badge and sections, TODO tasks
+	BranchProtection    bool     // Write .github/branch-protection.json and document the policy in CONTRIBUTING.md
+	DocHealth           bool     // Write scripts/check-docs.sh and the doc-health workflow running it (dochealth.go)
+	DocGuard            bool     // Write scripts/doc-guard.sh, the pre-commit hook keeping the agent docs (docguard.go)
+	HookFramework       string   // Framework running the hook: "pre-commit", "lefthook", or "" for a plain git hook
+	AgentAction         bool     // Write the Claude Code workflow that answers @claude on issues and pull requests
+	CommitConvention    string   // Commit convention ID from commits.go ("" or "none" for none)
+	Goals               []string // Project brief: README Goals and AGENTS.md (empty keeps the placeholder)
+	NonGoals            []string // Project brief: what the project won't do
+	Constraints         []string // Project brief: AGENTS.md Project Constraints
+	Standards           []string // Linter/formatter IDs from standards.go (config files, lint workflow, AGENTS.md commands)
+
+	// .gitignore composition (see gitignore.go)
+	Gitignore      []string       // Pattern set IDs (empty for the defaults)
+	GitignoreExtra []string       // Extra patterns, in a "Project-specific" section
+	GitignoreSets  []gitignoreSet // Resolved sections, filled by Render
+
+	Stack *stack // Language details for Language (nil if none), filled by Render
+
+	// Workspace root only: packages with their own AGENTS.md (workspaceagents.go)
+	Packages []workspacePackage
+}
+
+// Public reports whether the repository will be published publicly.
+func (d TemplateData) Public() bool {
+	return d.Visibility == "public"
+}
+
+// LicenseBadge returns a shields.io badge for the license of a public
+// project, or "" (private, or no license). relicense.go keeps it current.
+func (d TemplateData) LicenseBadge() string {
+	spdx := licenseSPDX(d.License)
+	if !d.Public() || spdx == "" {
+		return ""
+	}
+	label := strings.NewReplacer("-", "--", " ", "%20").Replace(spdx)
+	return "![License](https://img.shields.io/badge/License-" + label + "-blue.svg)"
+}
+
+// TopicList returns the topics comma-separated, as gh expects them.
+func (d TemplateData) TopicList() string {
+	return strings.Join(d.Topics, ",")
+}
+
+// Website returns the URL for the GitHub repository's website field: the
+// homepage, else the documentation.
+func (d TemplateData) Website() string {
+	if d.Homepage != "" {
+		return d.Homepage
+	}
+	return d.Documentation
+}
+
+// defaultImageRegistry is where dev container base images come from unless
+// config names a mirror (imageRegistry).
+const defaultImageRegistry = "mcr.microsoft.com/devcontainers"
+
+// ImageRef returns the dev container's base image, e.g.
+// "mcr.microsoft.com/devcontainers/go:2-1.25-trixie". A custom image that
+// names its own registry is used as is.
+func (d TemplateData) ImageRef() string {
+	if isImageReference(d.DevContainerImage) {
+		return d.DevContainerImage
+	}
+	registry := strings.TrimSuffix(d.ImageRegistry, "/")
+	if registry == "" {
+		registry = defaultImageRegistry
+	}
+	return registry + "/" + d.DevContainerImage
+}
+
+// aiTool is an AI coding tool whose state chat continuity can persist.
+// Config and answers use the same JSON form to relocate or add tools.
+type aiTool struct {
+	ID       string `json:"id"`              // Answer value (e.g. "claude")
+	Label    string `json:"label,omitempty"` // Human-readable name
+	StateDir string `json:"stateDir"`        // Directory under $HOME (e.g. ".claude")
 }
 
 // knownAITools lists AI coding tools and their state directories.
-// setup.sh auto-detects which are present on the host at container start time.
-var knownAITools = []struct {
-	Label    string // Human-readable name
-	StateDir string // Directory under $HOME (e.g. ".claude")
-}{
-	{"Claude Code", ".claude"},
-	{"Codex", ".codex"},
+// Only the tools chosen in the wizard are mounted; setup.sh still checks
+// each one is present on the host at container start time.
+var knownAITools = []aiTool{
+	{"claude", "Claude Code", ".claude"},
+	{"codex", "Codex", ".codex"},
+	{"gemini", "Gemini CLI", ".gemini"},
+	{"aider", "Aider", ".aider"},
 }
 
+// legacyChatTools are the tools the former yes/no chat continuity answer covered.
+var legacyChatTools = []string{"claude", "codex"}
+
 // DevContainer represents a devcontainer.json configuration.
 // Marshaled to JSON programmatically (not via text/template) to guarantee
 // valid JSON output and handle conditional fields cleanly.
@@ -73,12 +195,20 @@ type DevContainerBuild struct {
 
 // DevContainerVSCode holds VS Code-specific customizations.
 type DevContainerVSCode struct {
-	Extensions []string `json:"extensions,omitempty"`
+	Extensions []string               `json:"extensions,omitempty"`
+	Settings   map[string]interface{} `json:"settings,omitempty"`
+}
+
+// DevContainerJetBrains holds JetBrains Gateway customizations.
+type DevContainerJetBrains struct {
+	Backend string   `json:"backend,omitempty"`
+	Plugins []string `json:"plugins,omitempty"`
 }
 
 // DevContainerCustomizations holds IDE customizations for the dev container.
 type DevContainerCustomizations struct {
-	VSCode DevContainerVSCode `json:"vscode,omitempty"`
+	VSCode    *DevContainerVSCode    `json:"vscode,omitempty"`
+	JetBrains *DevContainerJetBrains `json:"jetbrains,omitempty"`
 }
 
 // VSCodeWorkspaceExtensions represents the content of .vscode/extensions.json.
@@ -88,38 +218,124 @@ type VSCodeWorkspaceExtensions struct {
 	Recommendations []string `json:"recommendations"`
 }
 
+// DevContainerHostRequirements is the "hostRequirements" field: the minimum
+// machine Codespaces and similar tools should provision.
+type DevContainerHostRequirements struct {
+	CPUs    int         `json:"cpus,omitempty"`
+	Memory  string      `json:"memory,omitempty"`
+	Storage string      `json:"storage,omitempty"`
+	GPU     interface{} `json:"gpu,omitempty"` // true, false or "optional"
+}
+
 type DevContainer struct {
-	Name              string                      `json:"name"`
-	Build             DevContainerBuild           `json:"build"`
-	Features          map[string]interface{}      `json:"features,omitempty"`
-	Customizations    *DevContainerCustomizations `json:"customizations,omitempty"`
-	Mounts            []string                    `json:"mounts,omitempty"`
-	ContainerEnv      map[string]string           `json:"containerEnv,omitempty"`
-	PostCreateCommand string                      `json:"postCreateCommand,omitempty"`
-	InitializeCommand string                      `json:"initializeCommand,omitempty"`
+	Name              string                        `json:"name"`
+	Build             DevContainerBuild             `json:"build"`
+	Features          map[string]interface{}        `json:"features,omitempty"`
+	Customizations    *DevContainerCustomizations   `json:"customizations,omitempty"`
+	Mounts            []string                      `json:"mounts,omitempty"`
+	Privileged        bool                          `json:"privileged,omitempty"`
+	RunArgs           []string                      `json:"runArgs,omitempty"`
+	HostRequirements  *DevContainerHostRequirements `json:"hostRequirements,omitempty"`
+	ContainerEnv      map[string]string             `json:"containerEnv,omitempty"`
+	OnCreateCommand   string                        `json:"onCreateCommand,omitempty"`
+	PostCreateCommand string                        `json:"postCreateCommand,omitempty"`
+	InitializeCommand string                        `json:"initializeCommand,omitempty"`
 }
 
 // Scaffolder handles template rendering and file generation.
 // It encapsulates the embedded filesystem and template parsing logic.
 type Scaffolder struct {
-	templates *template.Template
+	templates *templateSet
+
+	// OnProgress, when set, receives a ProgressEvent for each phase and
+	// file. Calls are serialized, so it needn't be safe for concurrent use;
+	// it should return quickly, as it holds up the worker that sent it.
+	OnProgress func(ProgressEvent)
+	progressMu sync.Mutex
+
+	// Validate, when set, sees the rendered files before any is written;
+	// an error stops ScaffoldFiles with nothing written.
+	Validate func([]RenderedFile) error
+
+	// Formatters maps a file type (".go", or a base name like "Dockerfile")
+	// to the command rendered files of that type are piped through
+	// (format.go). NewScaffolder fills it from config.
+	Formatters map[string]string
+}
+
+// ProgressKind says what a ProgressEvent reports.
+type ProgressKind string
+
+const (
+	ProgressPhase        ProgressKind = "phase"         // A phase started: Phase is set
+	ProgressFileStarted  ProgressKind = "file-started"  // Writing Path began
+	ProgressFileFinished ProgressKind = "file-finished" // Writing Path ended: Bytes written, or Err
+)
+
+// Scaffolder phases, in order.
+const (
+	PhaseRender = "render" // Rendering every file in memory
+	PhaseWrite  = "write"  // Writing rendered files to the target directory
+)
+
+// ProgressEvent is one progress report from a Scaffolder. The scaffolder
+// never prints; callers (the CLI, or a program embedding seed) decide what
+// to show.
+type ProgressEvent struct {
+	Kind  ProgressKind
+	Phase string // PhaseRender or PhaseWrite
+	Path  string // Slash-separated path relative to the project root (file events)
+	Bytes int    // Bytes written (ProgressFileFinished)
+	Err   error  // Why the file failed (ProgressFileFinished)
+}
+
+// progress delivers e to OnProgress, if set, one call at a time.
+func (s *Scaffolder) progress(e ProgressEvent) {
+	if s.OnProgress == nil {
+		return
+	}
+	s.progressMu.Lock()
+	defer s.progressMu.Unlock()
+	s.OnProgress(e)
 }
 
-// NewScaffolder creates a new Scaffolder with parsed templates.
-// It loads all .tmpl files from the embedded filesystem.
+// NewScaffolder creates a new Scaffolder over the embedded templates.
+// Templates are parsed when first rendered and cached for the process (see
+// templateset.go), so this is cheap to call. Formatters come from config, so
+// every command re-rendering a project formats it the same way.
 //
 // Returns:
 // - *Scaffolder: Ready-to-use scaffolder
-// - error: If template parsing fails (shouldn't happen with valid templates)
+// - error: Always nil; kept so callers stay unchanged if loading a pack can fail
 func NewScaffolder() (*Scaffolder, error) {
-	// Parse all templates from embedded filesystem
-	// Pattern "templates/*.tmpl" matches all .tmpl files
-	tmpl, err := template.ParseFS(templatesFS, "templates/*.tmpl")
+	cfg, err := loadConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse templates: %w", err)
+		debugf("formatters: %v", err)
 	}
+	return &Scaffolder{
+		templates:  templateSetFor("embedded", templatesFS, "templates"),
+		Formatters: formattersFrom(cfg),
+	}, nil
+}
+
+// RenderedFile is a single generated file held in memory.
+// Rendering produces a list of these; writing them to disk (or to an archive)
+// is a separate step, so callers can inspect output without touching the filesystem.
+type RenderedFile struct {
+	Path    string      // Slash-separated path relative to the project root
+	Content []byte      // Rendered file content
+	Mode    os.FileMode // Permission bits used when writing the file
+}
 
-	return &Scaffolder{templates: tmpl}, nil
+// coreTemplates lists the templates rendered for every project.
+var coreTemplates = []string{
+	"README.md.tmpl",
+	"AGENTS.md.tmpl",
+	"DECISIONS.md.tmpl",
+	"TODO.md.tmpl",
+	"LEARNINGS.md.tmpl",
+	".gitignore.tmpl",
+	".editorconfig.tmpl",
 }
 
 // Scaffold generates project files in the target directory.
@@ -138,56 +354,175 @@ func NewScaffolder() (*Scaffolder, error) {
 // - If targetDir exists and is non-empty, returns error (prevents overwrites)
 // - Renders core templates: README.md, AGENTS.md, DECISIONS.md, TODO.md, LEARNINGS.md
 func (s *Scaffolder) Scaffold(targetDir string, data TemplateData, allowNonEmpty ...bool) error {
-	// Step 1: Ensure target directory exists and is safe to use
 	nonEmpty := len(allowNonEmpty) > 0 && allowNonEmpty[0]
-	if err := s.prepareDirectory(targetDir, nonEmpty); err != nil {
-		return err
+	_, err := s.ScaffoldFiles(targetDir, data, nonEmpty)
+	return err
+}
+
+// ScaffoldFiles behaves like Scaffold but also returns the files it wrote,
+// so callers can record them (e.g. in the project manifest).
+func (s *Scaffolder) ScaffoldFiles(targetDir string, data TemplateData, allowNonEmpty bool) ([]RenderedFile, error) {
+	// Step 1: Ensure target directory exists and is safe to use
+	if err := s.prepareDirectory(targetDir, allowNonEmpty); err != nil {
+		return nil, err
 	}
 
+	// Step 2: Render everything in memory before touching the directory,
+	// leaving out what the directory's .seedignore excludes
+	files, err := s.Render(data)
+	if err != nil {
+		return nil, err
+	}
+	if files, err = dropIgnored(targetDir, files); err != nil {
+		return nil, err
+	}
+
+	if s.Validate != nil {
+		if err := s.Validate(files); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 3: Write rendered files
+	s.progress(ProgressEvent{Kind: ProgressPhase, Phase: PhaseWrite})
+	if err := writeFilesNotify(targetDir, files, s.progress); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Render renders every file the project would contain, without writing anything.
+// Files are returned in a stable order: core templates, templates declaring
+// a when: condition that holds (in name order), linter and formatter
+// configs with the lint workflow, the local chat continuity script, devcontainer files, then
+// .vscode/extensions.json and the other .vscode/ configs, then the template
+// pack's assets (assets.go).
+//
+// Returns:
+// - []RenderedFile: Generated files with slash-separated relative paths
+// - error: If any template fails to render
+func (s *Scaffolder) Render(data TemplateData) ([]RenderedFile, error) {
 	// Auto-populate year for license templates
 	if data.Year == 0 {
-		data.Year = time.Now().Year()
+		data.Year = seedNow().Year()
 	}
+	if data.CopyrightYears == "" {
+		data.CopyrightYears = strconv.Itoa(data.Year)
+	}
+	if data.CopyrightHolder == "" {
+		data.CopyrightHolder = data.ProjectName
+	}
+	data.GitignoreSets = gitignoreSections(data)
+	data.Stack = stackFor(data.Language)
+	s.progress(ProgressEvent{Kind: ProgressPhase, Phase: PhaseRender})
 
-	// Step 2: Define which templates to render
-	// Core templates are always created
-	coreTemplates := []string{
-		"README.md.tmpl",
-		"AGENTS.md.tmpl",
-		"DECISIONS.md.tmpl",
-		"TODO.md.tmpl",
-		"LEARNINGS.md.tmpl",
-		".gitignore.tmpl",
-		".editorconfig.tmpl",
+	// Each job renders independent files; they run concurrently and their
+	// results are concatenated in job order, so output order stays stable
+	var jobs []renderJob
+	one := func(tmplName, path string) renderJob {
+		return func() ([]RenderedFile, error) {
+			file, err := s.renderFile(tmplName, path, data)
+			return []RenderedFile{file}, err
+		}
 	}
 
-	// Render all core templates
+	// Core templates are always rendered
 	for _, tmplName := range coreTemplates {
-		if err := s.renderTemplate(targetDir, tmplName, data); err != nil {
-			return err
-		}
+		jobs = append(jobs, one(tmplName, strings.TrimSuffix(tmplName, ".tmpl")))
 	}
 
-	// Step 3: Conditionally scaffold LICENSE
-	if err := s.scaffoldLicense(targetDir, data); err != nil {
-		return err
+	// Templates declaring a when: condition in their header (LICENSE texts,
+	// NOTICE, CONTRIBUTING.md, SECURITY.md, .env.example, the agent
+	// workflow, the dev container's Dockerfile, and whatever a pack adds),
+	// each rendered where its path: says when the condition holds
+	declared, err := s.templates.Declared()
+	if err != nil {
+		return nil, err
+	}
+	for _, tmplName := range declared {
+		jobs = append(jobs, func() ([]RenderedFile, error) {
+			path, ok, err := s.templates.Output(tmplName, data)
+			if err != nil || !ok {
+				return nil, err
+			}
+			file, err := s.renderFile(tmplName, path, data)
+			return []RenderedFile{file}, err
+		})
+	}
+
+	// Branch protection settings, applied with the gh command in the next steps
+	if data.BranchProtection {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return renderBranchProtection(data) })
 	}
 
-	// Step 4: Conditionally scaffold .devcontainer/
+	// Scheduled doc health check: the script and the workflow running it
+	if data.DocHealth {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return s.renderDocHealth(data) })
+	}
+
+	// Doc guard hook config for its framework (the script is a declared template)
+	if data.DocGuard {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return renderHookFramework(data), nil })
+	}
+
+	// Ownership: CODEOWNERS (SECURITY.md is a declared template)
+	jobs = append(jobs, func() ([]RenderedFile, error) { return renderCodeOwners(data), nil })
+
+	// Commit convention tooling (commitlint/commitizen, gitmoji-cli)
+	if data.Commits() != nil {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return renderCommitConvention(data), nil })
+	}
+
+	// Linter and formatter configs, and the CI workflow running them
+	if len(data.Standards) > 0 {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return s.renderCodingStandards(data) })
+	}
+
+	// Local chat continuity; with a dev container, setup.sh does this instead
+	if len(data.ChatTools) > 0 && !data.IncludeDevContainer {
+		jobs = append(jobs, func() ([]RenderedFile, error) {
+			return []RenderedFile{{
+				Path:    localContinuityScript,
+				Content: []byte(generateContinuityScript(data.ChatTools)),
+				Mode:    0755,
+			}}, nil
+		})
+	}
+
+	// Conditionally render .devcontainer/
 	if data.IncludeDevContainer {
-		if err := s.scaffoldDevContainer(targetDir, data); err != nil {
-			return err
-		}
+		jobs = append(jobs, func() ([]RenderedFile, error) { return s.renderDevContainer(data) })
 	}
 
-	// Step 5: Conditionally generate .vscode/extensions.json
-	if data.IncludeDevContainer && len(data.VSCodeExtensions) > 0 {
-		if err := s.writeVSCodeExtensions(targetDir, data.VSCodeExtensions); err != nil {
-			return err
-		}
+	// Conditionally render .vscode/extensions.json
+	if (data.IncludeDevContainer && data.UsesVSCode() || data.VSCodeConfig) && len(data.VSCodeExtensions) > 0 {
+		jobs = append(jobs, func() ([]RenderedFile, error) {
+			ext, err := renderVSCodeExtensions(data.VSCodeExtensions)
+			return []RenderedFile{ext}, err
+		})
 	}
 
-	return nil
+	// Optional .vscode/ settings, tasks and launch configs
+	if data.VSCodeConfig {
+		jobs = append(jobs, func() ([]RenderedFile, error) { return renderVSCodeConfig(data) })
+	}
+
+	files, err := runRenderJobs(jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.LicenseHeaders {
+		files = addLicenseHeaders(files, licenseSPDX(data.License))
+	}
+	// Formatting comes before stamping, so stamps hash what's written
+	files = formatFiles(addContents(files), s.Formatters)
+	// Pack assets are copied as they are, after anything that edits text
+	assets, err := s.templates.Assets()
+	if err != nil {
+		return nil, err
+	}
+	return append(stampFiles(files), assets...), nil
 }
 
 // prepareDirectory ensures the target directory is ready for scaffolding.
@@ -239,109 +574,175 @@ func (s *Scaffolder) prepareDirectory(targetDir string, allowNonEmpty bool) erro
 	return nil
 }
 
-// renderTemplate renders a single template file and writes it to targetDir.
-// It automatically converts "TEMPLATE.md.tmpl" → "TEMPLATE.md".
+// writeFiles writes rendered files under targetDir, creating parent
+// directories as needed. Files are written concurrently (at most
+// fileWorkers at a time); the first error is returned once all have finished.
+func writeFiles(targetDir string, files []RenderedFile) error {
+	return writeFilesNotify(targetDir, files, nil)
+}
+
+// writeFilesNotify is writeFiles, also sending each file's start and finish
+// to notify (which may be nil and must be safe for concurrent use).
+func writeFilesNotify(targetDir string, files []RenderedFile, notify func(ProgressEvent)) error {
+	if notify == nil {
+		notify = func(ProgressEvent) {}
+	}
+
+	// Create each directory once, up front, rather than per file
+	dirs := map[string]bool{}
+	for _, f := range files {
+		dir := filepath.Join(targetDir, filepath.FromSlash(path.Dir(f.Path)))
+		if !dirs[dir] {
+			dirs[dir] = true
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+			}
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(fileWorkers)
+	for _, f := range files {
+		g.Go(func() error {
+			notify(ProgressEvent{Kind: ProgressFileStarted, Phase: PhaseWrite, Path: f.Path})
+			err := writeFile(targetDir, f)
+			finished := ProgressEvent{Kind: ProgressFileFinished, Phase: PhaseWrite, Path: f.Path, Err: err}
+			if err == nil {
+				finished.Bytes = len(f.Content)
+			}
+			notify(finished)
+			return err
+		})
+	}
+	return g.Wait()
+}
+
+// writeFile writes one rendered file under targetDir, whose directory
+// already exists.
+func writeFile(targetDir string, f RenderedFile) error {
+	outputPath := filepath.Join(targetDir, filepath.FromSlash(f.Path))
+	if isSymlink(f) {
+		if err := writeSymlinkAtomic(outputPath, string(f.Content)); err != nil {
+			return fmt.Errorf("failed to create link %s: %w", outputPath, err)
+		}
+		return nil
+	}
+	if err := writeFileAtomic(outputPath, f.Content, f.Mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes content to a temporary file next to name and
+// renames it into place, so name is either left as it was or fully
+// written — never truncated by an interrupted run. Like os.WriteFile, mode
+// is subject to the umask.
+func writeFileAtomic(name string, content []byte, mode os.FileMode) error {
+	tmp := filepath.Join(filepath.Dir(name), "."+filepath.Base(name)+".tmp-"+strconv.FormatUint(rand.Uint64(), 36))
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, name)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// fileWorkers bounds how many files are rendered or written at once.
+var fileWorkers = runtime.GOMAXPROCS(0)
+
+// renderJob renders one or more files that don't depend on any other job.
+type renderJob func() ([]RenderedFile, error)
+
+// runRenderJobs runs jobs concurrently (at most fileWorkers at a time) and
+// returns their files in job order. The first error wins.
+func runRenderJobs(jobs []renderJob) ([]RenderedFile, error) {
+	results := make([][]RenderedFile, len(jobs))
+	var g errgroup.Group
+	g.SetLimit(fileWorkers)
+	for i, job := range jobs {
+		g.Go(func() error {
+			files, err := job()
+			results[i] = files
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
+}
+
+// renderFile executes a single template and returns it as a RenderedFile.
 //
 // Parameters:
-// - targetDir: Directory to write rendered file
 // - templateName: Name of template file (e.g., "README.md.tmpl")
+// - outputPath: Slash-separated output path (e.g., "README.md")
 // - data: Template data to render with
 //
 // Example:
-// renderTemplate("/my/project", "README.md.tmpl", data)
-// → Creates /my/project/README.md
-func (s *Scaffolder) renderTemplate(targetDir, templateName string, data TemplateData) error {
-	// Calculate output filename by stripping .tmpl extension
-	// "README.md.tmpl" → "README.md"
-	outputName := strings.TrimSuffix(templateName, ".tmpl")
-	outputPath := filepath.Join(targetDir, outputName)
-
-	// Create output file
-	// 0644 = rw-r--r-- (owner: rw, group: r, others: r)
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create %s: %w", outputPath, err)
-	}
-	defer file.Close() // Ensure file is closed even if template execution fails
+// renderFile("README.md.tmpl", "README.md", data)
+// → RenderedFile{Path: "README.md", ...}
+func (s *Scaffolder) renderFile(templateName, outputPath string, data TemplateData) (RenderedFile, error) {
+	// Render into a pooled buffer (templates write in many small pieces, so a
+	// fresh buffer would regrow several times) and keep an exact-size copy
+	buf := renderBuffers.Get().(*bytes.Buffer)
+	defer renderBuffers.Put(buf)
+	buf.Reset()
 
-	// Execute template and write to file
 	// ExecuteTemplate finds the template by name and renders it
-	if err := s.templates.ExecuteTemplate(file, templateName, data); err != nil {
-		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	if err := s.templates.ExecuteTemplate(buf, templateName, data); err != nil {
+		return RenderedFile{}, fmt.Errorf("failed to render %s: %w", templateName, err)
 	}
 
-	return nil
-}
-
-// scaffoldLicense renders the chosen license template as LICENSE in the target directory.
-// Does nothing if License is "none" or empty.
-func (s *Scaffolder) scaffoldLicense(targetDir string, data TemplateData) error {
-	var tmplName string
-	switch data.License {
-	case "MIT":
-		tmplName = "LICENSE-MIT.tmpl"
-	case "Apache-2.0":
-		tmplName = "LICENSE-Apache.tmpl"
-	default:
-		return nil // "none" or empty — skip
-	}
-
-	outputPath := filepath.Join(targetDir, "LICENSE")
-	file, err := os.Create(outputPath)
+	// 0644 = rw-r--r-- (owner: rw, group: r, others: r), unless the
+	// template's header declares another mode or makes it a link
+	meta, err := s.templates.Meta(templateName)
 	if err != nil {
-		return fmt.Errorf("failed to create LICENSE: %w", err)
+		return RenderedFile{}, err
 	}
-	defer file.Close()
-
-	if err := s.templates.ExecuteTemplate(file, tmplName, data); err != nil {
-		return fmt.Errorf("failed to render LICENSE: %w", err)
+	if meta.Link != "" {
+		if strings.TrimSpace(buf.String()) != "" {
+			return RenderedFile{}, fmt.Errorf("failed to render %s: a link template can't have content", templateName)
+		}
+		return newSymlink(outputPath, meta.Link)
 	}
-	return nil
+	mode := meta.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	return RenderedFile{Path: outputPath, Content: bytes.Clone(buf.Bytes()), Mode: mode}, nil
 }
 
-// writeVSCodeExtensions generates .vscode/extensions.json with workspace
+// renderBuffers recycles renderFile's buffers across files.
+var renderBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// renderVSCodeExtensions generates .vscode/extensions.json with workspace
 // extension recommendations. VS Code shows an "Install recommended extensions?"
 // prompt when the workspace is opened, both locally and in devcontainers.
-func (s *Scaffolder) writeVSCodeExtensions(targetDir string, extensions []string) error {
-	vscodDir := filepath.Join(targetDir, ".vscode")
-	if err := os.MkdirAll(vscodDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .vscode directory: %w", err)
-	}
-
+func renderVSCodeExtensions(extensions []string) (RenderedFile, error) {
 	content := VSCodeWorkspaceExtensions{Recommendations: extensions}
 	jsonBytes, err := json.MarshalIndent(content, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to generate .vscode/extensions.json: %w", err)
-	}
-
-	outputPath := filepath.Join(vscodDir, "extensions.json")
-	if err := os.WriteFile(outputPath, append(jsonBytes, '\n'), 0644); err != nil {
-		return fmt.Errorf("failed to write .vscode/extensions.json: %w", err)
+		return RenderedFile{}, fmt.Errorf("failed to generate .vscode/extensions.json: %w", err)
 	}
-	return nil
+	return RenderedFile{Path: ".vscode/extensions.json", Content: append(jsonBytes, '\n'), Mode: 0644}, nil
 }
 
-// scaffoldDevContainer generates .devcontainer/devcontainer.json and optionally
+// renderDevContainer generates .devcontainer/devcontainer.json and optionally
 // .devcontainer/setup.sh for AI chat continuity. Uses encoding/json to guarantee
 // valid JSON output rather than text/template (which is fragile for JSON).
-func (s *Scaffolder) scaffoldDevContainer(targetDir string, data TemplateData) error {
-	dcDir := filepath.Join(targetDir, ".devcontainer")
-	if err := os.MkdirAll(dcDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .devcontainer directory: %w", err)
-	}
-
-	// Render Dockerfile template (pre-creates dirs for volume mount ownership fix)
-	if err := s.renderTemplate(dcDir, "Dockerfile.tmpl", data); err != nil {
-		return err
-	}
-
-	// Use a named volume to cache VS Code extensions across container rebuilds.
-	// Mount to a staging path (not inside .vscode-server) to avoid Docker creating
-	// .vscode-server as root, which blocks VS Code from writing extensions.json and
-	// its bin/ and data/ siblings. A symlink connects the staging path at startup.
-	extensionsVolume := strings.ToLower(strings.ReplaceAll(data.ProjectName, " ", "-")) + "-vscode-extensions"
-	extensionsSymlink := "ln -sfn /home/vscode/.vscode-extensions-cache /home/vscode/.vscode-server/extensions" +
-		"; [ -f /home/vscode/.vscode-extensions-cache/extensions.json ] || echo '[]' > /home/vscode/.vscode-extensions-cache/extensions.json"
+func (s *Scaffolder) renderDevContainer(data TemplateData) ([]RenderedFile, error) {
+	// .devcontainer/Dockerfile is a declared template (Dockerfile.tmpl)
+	var files []RenderedFile
 
 	dc := DevContainer{
 		Name:  fmt.Sprintf("%s (Dev Container)", data.ProjectName),
@@ -349,85 +750,297 @@ func (s *Scaffolder) scaffoldDevContainer(targetDir string, data TemplateData) e
 		Features: map[string]interface{}{
 			"ghcr.io/devcontainers/features/github-cli:1": map[string]interface{}{},
 		},
-		Mounts: []string{
-			fmt.Sprintf("source=%s,target=/home/vscode/.vscode-extensions-cache,type=volume", extensionsVolume),
-		},
 		ContainerEnv: map[string]string{
 			"GH_TOKEN":     "${localEnv:GH_TOKEN}",
 			"GITHUB_TOKEN": "${localEnv:GITHUB_TOKEN}",
 		},
-		PostCreateCommand: extensionsSymlink,
 	}
 
-	// If user selected agent extensions, add them to customizations
-	if len(data.VSCodeExtensions) > 0 {
+	// Use a named volume to cache VS Code extensions across container rebuilds.
+	// Mount to a staging path (not inside .vscode-server) to avoid Docker creating
+	// .vscode-server as root, which blocks VS Code from writing extensions.json and
+	// its bin/ and data/ siblings. A symlink made once, in onCreateCommand,
+	// connects the staging path, leaving postCreateCommand for project setup.
+	// Other IDEs keep their own backends, so they get none of this.
+	if !data.NoExtensionsCache && data.UsesVSCode() {
+		extensionsVolume := data.ExtensionsVolume
+		if extensionsVolume == "" {
+			extensionsVolume = strings.ToLower(strings.ReplaceAll(data.ProjectName, " ", "-")) + "-vscode-extensions"
+		}
+		dc.Mounts = append(dc.Mounts, fmt.Sprintf("source=%s,target=/home/vscode/.vscode-extensions-cache,type=volume", extensionsVolume))
+		dc.OnCreateCommand = "ln -sfn /home/vscode/.vscode-extensions-cache /home/vscode/.vscode-server/extensions" +
+			"; [ -f /home/vscode/.vscode-extensions-cache/extensions.json ] || echo '[]' > /home/vscode/.vscode-extensions-cache/extensions.json"
+	}
+
+	// Forward chosen host variables and the project's secrets by name
+	for _, name := range slices.Concat(data.ForwardEnv, data.Secrets) {
+		dc.ContainerEnv[name] = "${localEnv:" + name + "}"
+	}
+
+	// If user selected agent extensions, add them to customizations; for
+	// JetBrains Gateway, the stack's IDE and the agents' plugins instead
+	switch {
+	case data.IDE == ideJetBrains:
+		dc.Customizations = &DevContainerCustomizations{
+			JetBrains: &DevContainerJetBrains{
+				Backend: data.JetBrainsBackend(),
+				Plugins: agentPlugins(data.VSCodeExtensions),
+			},
+		}
+	case data.UsesVSCode() && len(data.VSCodeExtensions) > 0:
 		dc.Customizations = &DevContainerCustomizations{
-			VSCode: DevContainerVSCode{
+			VSCode: &DevContainerVSCode{
 				Extensions: data.VSCodeExtensions,
 			},
 		}
 	}
 
-	// If chat continuity enabled, mount all known AI tool dirs and generate setup script
-	if data.AIChatContinuity {
-		dirs := make([]string, 0, len(knownAITools))
-		for _, tool := range knownAITools {
-			dc.Mounts = append(dc.Mounts, fmt.Sprintf(
-				"source=${localEnv:HOME}/%s,target=/home/vscode/%s,type=bind,consistency=cached",
-				tool.StateDir, tool.StateDir))
-			dirs = append(dirs, "~/"+tool.StateDir)
+	// Docker for projects whose tests build or run containers: a nested daemon
+	// (needs a privileged container), or the host's daemon via its socket
+	switch data.DockerAccess {
+	case "docker-in-docker":
+		dc.Features["ghcr.io/devcontainers/features/docker-in-docker:2"] = map[string]interface{}{}
+		dc.Privileged = true
+	case "docker-outside-of-docker":
+		dc.Features["ghcr.io/devcontainers/features/docker-outside-of-docker:1"] = map[string]interface{}{}
+		dc.Mounts = append(dc.Mounts, "source=/var/run/docker.sock,target=/var/run/docker-host.sock,type=bind")
+	}
+
+	dc.HostRequirements = hostRequirements(data)
+	applyMLStack(&dc, data)
+
+	// Shell: zsh comes from the common-utils feature with oh-my-zsh; either
+	// choice becomes VS Code's default terminal profile
+	if data.Shell == "zsh" {
+		dc.Features["ghcr.io/devcontainers/features/common-utils:2"] = map[string]interface{}{
+			"installZsh":                 true,
+			"installOhMyZsh":             true,
+			"configureZshAsDefaultShell": true,
+		}
+	}
+	if data.Shell != "" && data.UsesVSCode() {
+		if dc.Customizations == nil {
+			dc.Customizations = &DevContainerCustomizations{VSCode: &DevContainerVSCode{}}
+		}
+		dc.Customizations.VSCode.Settings = map[string]interface{}{
+			"terminal.integrated.defaultProfile.linux": data.Shell,
+		}
+	}
+
+	// If chat continuity enabled, mount the chosen AI tool dirs and generate setup script
+	var setupScript *RenderedFile
+	if len(data.ChatTools) > 0 {
+		dirs := make([]string, 0, len(data.ChatTools))
+		for _, tool := range data.ChatTools {
+			if data.ChatState == "copy" {
+				// A volume holds the container's copy; the host dir is only read,
+				// once, from a read-only staging mount
+				dc.Mounts = append(dc.Mounts,
+					fmt.Sprintf("source=%s-%s-state,target=/home/vscode/%s,type=volume", projectVolumePrefix(data), tool.ID, tool.StateDir),
+					fmt.Sprintf("source=${localEnv:HOME}/%s,target=%s/%s,type=bind,readonly", tool.StateDir, hostStateStaging, tool.StateDir))
+			} else {
+				dc.Mounts = append(dc.Mounts, fmt.Sprintf(
+					"source=${localEnv:HOME}/%s,target=/home/vscode/%s,type=bind,consistency=cached",
+					tool.StateDir, tool.StateDir))
+			}
+			dirs = append(dirs, `"${localEnv:HOME}/`+tool.StateDir+`"`)
 		}
 
-		// Pre-create AI tool state dirs on the host before Docker bind-mounts them.
-		// Without this, Docker creates missing dirs as root, causing permission failures.
-		dc.InitializeCommand = "mkdir -p " + strings.Join(dirs, " ")
+		// Create missing AI tool state dirs on the host, as the host user, before
+		// Docker bind-mounts them: a missing source fails container start or is
+		// created as root. Paths match the mount sources exactly, and existing
+		// dirs are left alone.
+		dc.InitializeCommand = "for d in " + strings.Join(dirs, " ") + `; do [ -d "$d" ] || mkdir -p "$d"; done`
 
 		dc.ContainerEnv["HOST_WORKSPACE"] = "${localWorkspaceFolder}"
 		dc.PostCreateCommand = "bash .devcontainer/setup.sh"
 
-		script := generateSetupScript(extensionsSymlink)
-		scriptPath := filepath.Join(dcDir, "setup.sh")
-		if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
-			return fmt.Errorf("failed to write setup.sh: %w", err)
+		setupScript = &RenderedFile{
+			Path:    ".devcontainer/setup.sh",
+			Content: []byte(generateSetupScript(data.ChatTools, data.ChatState == "copy")),
+			Mode:    0755,
 		}
 	}
 
-	// Marshal and write devcontainer.json
+	// User mounts go after seed's own (validation keeps their targets apart)
+	dc.Mounts = append(dc.Mounts, data.Mounts...)
+
+	// Dotfiles run last so they can customize everything above
+	if data.DotfilesRepo != "" {
+		if dc.PostCreateCommand != "" {
+			dc.PostCreateCommand += "; "
+		}
+		dc.PostCreateCommand += dotfilesCommand(data.DotfilesRepo)
+	}
+
+	// Marshal devcontainer.json
 	jsonBytes, err := json.MarshalIndent(dc, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to generate devcontainer.json: %w", err)
+		return nil, fmt.Errorf("failed to generate devcontainer.json: %w", err)
 	}
+	files = append(files, RenderedFile{Path: ".devcontainer/devcontainer.json", Content: append(jsonBytes, '\n'), Mode: 0644})
 
-	outputPath := filepath.Join(dcDir, "devcontainer.json")
-	if err := os.WriteFile(outputPath, append(jsonBytes, '\n'), 0644); err != nil {
-		return fmt.Errorf("failed to write devcontainer.json: %w", err)
+	if setupScript != nil {
+		files = append(files, *setupScript)
 	}
 
-	return nil
+	return files, nil
+}
+
+// compiledStacks are images whose toolchains need more memory to build
+// comfortably (linkers, JVM, MSBuild).
+var compiledStacks = map[string]bool{"rust:1-bookworm": true, "java": true, "dotnet": true, "cpp": true}
+
+// hostRequirements sizes the machine for the workload and stack. An empty
+// Workload (projects generated before the option existed) emits nothing.
+func hostRequirements(data TemplateData) *DevContainerHostRequirements {
+	var req DevContainerHostRequirements
+	switch {
+	case data.Workload == "":
+		return nil
+	case data.Workload == "ml":
+		req = DevContainerHostRequirements{CPUs: 4, Memory: "16gb", Storage: "64gb"}
+	case compiledStacks[data.DevContainerImage]:
+		req = DevContainerHostRequirements{CPUs: 4, Memory: "8gb", Storage: "32gb"}
+	default:
+		req = DevContainerHostRequirements{CPUs: 2, Memory: "4gb", Storage: "32gb"}
+	}
+	switch {
+	case data.CUDA:
+		req.GPU = true
+	case data.GPU:
+		// "optional" still starts on machines without a GPU
+		req.GPU = "optional"
+	}
+	return &req
+}
+
+// dotfilesCommand clones repo into ~/dotfiles on first creation and runs the
+// first install script it finds, using the same script names as the
+// devcontainer CLI's --dotfiles-repository.
+func dotfilesCommand(repo string) string {
+	return "[ -d ~/dotfiles ] || { git clone --depth 1 " + shellQuote(repo) + " ~/dotfiles" +
+		" && for f in install.sh install bootstrap.sh bootstrap setup.sh setup; do" +
+		" if [ -x ~/dotfiles/$f ]; then (cd ~/dotfiles && ./$f); break; fi; done; }"
+}
+
+// shellQuote single-quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
-// generateSetupScript builds a bash script that auto-detects installed AI tools
+// hostStateStaging is where the copy strategy mounts host AI tool state
+// (read-only) for setup.sh to copy from.
+const hostStateStaging = "/home/vscode/.host-state"
+
+// projectVolumePrefix is the prefix for a project's named volumes: the
+// extensions volume's name without its suffix, or else the project slug.
+func projectVolumePrefix(data TemplateData) string {
+	if prefix, ok := strings.CutSuffix(data.ExtensionsVolume, "-vscode-extensions"); ok && prefix != "" {
+		return prefix
+	}
+	return projectSlug(data.ProjectName)
+}
+
+// localContinuityScript is the chat continuity script for projects without
+// a dev container.
+const localContinuityScript = "scripts/link-ai-history.sh"
+
+// agentWorkflowPath is the workflow running Claude Code on @claude mentions
+// (the path in agent.yml.tmpl's header).
+const agentWorkflowPath = ".github/workflows/claude.yml"
+
+// generateContinuityScript builds a bash script that keeps a project's AI chat
+// history when the project moves. Tools key history by absolute path, so the
+// first run records the project's key and later runs from another path link
+// the new key to it in each tool's state directory.
+func generateContinuityScript(tools []aiTool) string {
+	var b strings.Builder
+
+	b.WriteString("#!/bin/bash\n")
+	b.WriteString("# AI chat continuity — created by seed\n")
+	b.WriteString("# AI coding tools key chat history by the project's absolute path\n")
+	b.WriteString("# (/home/me/code/app -> -home-me-code-app), so moving or re-cloning the\n")
+	b.WriteString("# project loses it. The first run records this project's key in\n")
+	b.WriteString("# .ai-project-key (git-ignored); after a move, run it again to link the new\n")
+	b.WriteString("# key to the recorded one. Tools that aren't installed are skipped.\n")
+	b.WriteString("#\n")
+	b.WriteString("# Usage: bash " + localContinuityScript + "\n")
+	b.WriteString("set -euo pipefail\n\n")
+
+	b.WriteString("cd \"$(dirname \"$0\")/..\"\n")
+	b.WriteString("CURRENT_KEY=$(pwd | tr '/' '-')\n")
+	b.WriteString("[ -f .ai-project-key ] || echo \"$CURRENT_KEY\" > .ai-project-key\n")
+	b.WriteString("PROJECT_KEY=$(cat .ai-project-key)\n\n")
+
+	b.WriteString("# link <state dir> <label>: keep history under PROJECT_KEY, reachable from\n")
+	b.WriteString("# CURRENT_KEY (an existing history at the new path is left alone)\n")
+	b.WriteString("link() {\n")
+	b.WriteString("  [ -d \"$HOME/$1\" ] || return 0\n")
+	b.WriteString("  local projects=\"$HOME/$1/projects\"\n")
+	b.WriteString("  mkdir -p \"$projects/$PROJECT_KEY\"\n")
+	b.WriteString("  if [ \"$CURRENT_KEY\" != \"$PROJECT_KEY\" ] && [ ! -e \"$projects/$CURRENT_KEY\" ]; then\n")
+	b.WriteString("    ln -s \"$projects/$PROJECT_KEY\" \"$projects/$CURRENT_KEY\"\n")
+	b.WriteString("  fi\n")
+	b.WriteString("  echo \"$2: $projects/$PROJECT_KEY\"\n")
+	b.WriteString("}\n\n")
+
+	for _, tool := range tools {
+		b.WriteString(fmt.Sprintf("link %s %s\n", tool.StateDir, shellQuote(tool.Label)))
+	}
+
+	return b.String()
+}
+
+// extensionsVolumeName names a project's extensions cache volume after its
+// name and a short hash of its absolute path, so two projects with the same
+// name in different folders don't share one.
+func extensionsVolumeName(projectName, dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	slug := projectSlug(projectName)
+	if slug == "" {
+		slug = "project"
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return fmt.Sprintf("%s-%s-vscode-extensions", slug, hex.EncodeToString(sum[:])[:8])
+}
+
+// volumeNameUnsafe matches runs of characters Docker doesn't allow in volume names.
+var volumeNameUnsafe = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// generateSetupScript builds a bash script that auto-detects the given AI tools
 // and creates symlinks for chat continuity. It converts host and container
 // workspace paths to the dash-separated key format used for project state.
 // e.g. /home/user/projects/myapp -> home-user-projects-myapp
-func generateSetupScript(extensionsSymlink string) string {
+func generateSetupScript(tools []aiTool, copyState bool) string {
 	var b strings.Builder
 
 	b.WriteString("#!/bin/bash\n")
 	b.WriteString("# Dev container setup — created by seed\n")
-	b.WriteString("# Symlinks VS Code extensions cache and auto-detects AI coding tools,\n")
-	b.WriteString("# symlinking host project state into the container so conversations persist.\n")
+	b.WriteString("# Auto-detects AI coding tools, symlinking host project state into the\n")
+	b.WriteString("# container so conversations persist.\n")
 	b.WriteString("#\n")
 	b.WriteString("# HOST_WORKSPACE is set via containerEnv in devcontainer.json\n")
 	b.WriteString("# and resolved from ${localWorkspaceFolder} at container creation time.\n\n")
 
-	b.WriteString("# Symlink cached extensions into the path VS Code expects\n")
-	b.WriteString(extensionsSymlink + "\n\n")
-
 	b.WriteString("HOST_KEY=$(echo \"$HOST_WORKSPACE\" | tr '/' '-')\n")
 	b.WriteString("CONTAINER_KEY=$(pwd | tr '/' '-')\n\n")
 
-	for _, tool := range knownAITools {
+	// Copy strategy: seed each tool's volume from the read-only host mount
+	// once; later changes stay in the volume
+	if copyState {
+		for _, tool := range tools {
+			b.WriteString(fmt.Sprintf("# %s: copy host state into the volume on first start\n", tool.Label))
+			b.WriteString(fmt.Sprintf("if [ ! -e \"$HOME/%s/.seed-copied\" ]; then\n", tool.StateDir))
+			b.WriteString(fmt.Sprintf("  cp -a \"%s/%s/.\" \"$HOME/%s/\" 2>/dev/null || true\n", hostStateStaging, tool.StateDir, tool.StateDir))
+			b.WriteString(fmt.Sprintf("  touch \"$HOME/%s/.seed-copied\"\n", tool.StateDir))
+			b.WriteString("fi\n\n")
+		}
+	}
+
+	for _, tool := range tools {
 		b.WriteString(fmt.Sprintf("# %s (auto-detected)\n", tool.Label))
 		b.WriteString(fmt.Sprintf("if [ -d \"$HOME/%s\" ]; then\n", tool.StateDir))
 		b.WriteString(fmt.Sprintf("  mkdir -p \"$HOME/%s/projects/$HOST_KEY\"\n", tool.StateDir))
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func tempDir(t *testing.T) string {
@@ -70,7 +75,6 @@ func TestDevcontainerMinimal(t *testing.T) {
 		Description:         "A test project",
 		IncludeDevContainer: true,
 		DevContainerImage:   "python:3-3.12",
-		AIChatContinuity:    false,
 	})
 
 	dcPath := filepath.Join(target, ".devcontainer", "devcontainer.json")
@@ -93,8 +97,11 @@ func TestDevcontainerMinimal(t *testing.T) {
 	if len(dc.Mounts) != 1 {
 		t.Errorf("expected 1 mount (extensions volume), got %d", len(dc.Mounts))
 	}
-	if !strings.Contains(dc.PostCreateCommand, "ln -sfn") || !strings.Contains(dc.PostCreateCommand, ".vscode-extensions-cache") {
-		t.Errorf("expected postCreateCommand with extensions symlink, got %q", dc.PostCreateCommand)
+	if !strings.Contains(dc.OnCreateCommand, "ln -sfn") || !strings.Contains(dc.OnCreateCommand, ".vscode-extensions-cache") {
+		t.Errorf("expected onCreateCommand with extensions symlink, got %q", dc.OnCreateCommand)
+	}
+	if dc.PostCreateCommand != "" {
+		t.Errorf("postCreateCommand should be left free for project setup, got %q", dc.PostCreateCommand)
 	}
 	if dc.ContainerEnv["GH_TOKEN"] != "${localEnv:GH_TOKEN}" {
 		t.Errorf("expected GH_TOKEN env forwarding, got %v", dc.ContainerEnv)
@@ -127,7 +134,7 @@ func TestDevcontainerWithChatContinuity(t *testing.T) {
 		Description:         "A test project",
 		IncludeDevContainer: true,
 		DevContainerImage:   "go:2-1.25-trixie",
-		AIChatContinuity:    true,
+		ChatTools:           knownAITools,
 	})
 
 	// devcontainer.json
@@ -196,14 +203,14 @@ func TestDevcontainerWithChatContinuity(t *testing.T) {
 }
 
 func TestSetupScriptContent(t *testing.T) {
-	script := generateSetupScript("ln -sfn /home/vscode/.vscode-extensions-cache /home/vscode/.vscode-server/extensions")
+	script := generateSetupScript(knownAITools, false)
 
 	if !strings.HasPrefix(script, "#!/bin/bash\n") {
 		t.Error("setup script should start with shebang")
 	}
 
-	if !strings.Contains(script, ".vscode-extensions-cache") {
-		t.Error("script should symlink extensions cache")
+	if strings.Contains(script, ".vscode-extensions-cache") {
+		t.Error("the extensions cache symlink belongs in onCreateCommand, not setup.sh")
 	}
 
 	if !strings.Contains(script, "HOST_KEY=") {
@@ -226,7 +233,7 @@ func TestSetupScriptContent(t *testing.T) {
 
 func TestSetupScriptAutoDetects(t *testing.T) {
 	// Verify the script checks if the tool dir exists before acting
-	script := generateSetupScript("ln -sfn /home/vscode/.vscode-extensions-cache /home/vscode/.vscode-server/extensions")
+	script := generateSetupScript(knownAITools, false)
 
 	// Each tool block should be wrapped in an existence check
 	for _, tool := range knownAITools {
@@ -347,6 +354,94 @@ func TestAllImageOptions(t *testing.T) {
 	}
 }
 
+func TestImageRegistryMirror(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := TemplateData{ProjectName: "mirrored", Description: "Mirror test", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", ImageRegistry: "registry.acme.com/devcontainers/"}
+	files, err := s.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if strings.Contains(string(f.Content), "mcr.microsoft.com") {
+			t.Errorf("%s still references MCR", f.Path)
+		}
+		if f.Path == ".devcontainer/Dockerfile" && !strings.Contains(string(f.Content), "FROM registry.acme.com/devcontainers/go:2-1.25-trixie\n") {
+			t.Errorf("Dockerfile should use the mirror:\n%s", f.Content)
+		}
+	}
+}
+
+func TestReadmeRepositoryMetadata(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	readme := func(data TemplateData) string {
+		t.Helper()
+		files, err := s.Render(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			if f.Path == "README.md" {
+				return string(f.Content)
+			}
+		}
+		t.Fatal("no README.md rendered")
+		return ""
+	}
+
+	public := readme(TemplateData{ProjectName: "meta", Description: "Metadata test", License: dualLicense, Visibility: "public", Topics: []string{"cli", "go"}})
+	for _, want := range []string{
+		"# meta\n\n![License](https://img.shields.io/badge/License-MIT%20OR%20Apache--2.0-blue.svg)\n\nMetadata test\n",
+		"**Topics**: `cli` `go`\n",
+	} {
+		if !strings.Contains(public, want) {
+			t.Errorf("README.md missing %q:\n%s", want, public)
+		}
+	}
+
+	links := readme(TemplateData{ProjectName: "meta", Description: "Metadata test", Homepage: "https://meta.dev", Documentation: "https://docs.meta.dev"})
+	if want := "Metadata test\n\n[Homepage](https://meta.dev) · [Documentation](https://docs.meta.dev)\n"; !strings.Contains(links, want) {
+		t.Errorf("README.md missing %q:\n%s", want, links)
+	}
+
+	// Private projects get no badge, and no topics line without topics
+	private := readme(TemplateData{ProjectName: "meta", Description: "Metadata test", License: "MIT"})
+	if !strings.Contains(private, "# meta\n\nMetadata test\n\n## Goal") {
+		t.Errorf("unexpected README.md start:\n%s", private)
+	}
+}
+
+func TestProjectBrief(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := s.Render(TemplateData{ProjectName: "brief", Description: "Brief test", Goals: []string{"Ship a CLI", "Start in under 10ms"}, NonGoals: []string{"A GUI"}, Constraints: []string{"No cgo"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"README.md": {"## Goals\n\n- Ship a CLI\n- Start in under 10ms\n\n**Non-goals**:\n\n- A GUI\n\n## Quick Start"},
+		"AGENTS.md": {"## Goals\n\n- Ship a CLI\n- Start in under 10ms\n\nOut of scope", "\n- A GUI\n\n## Project Constraints\n\n- No cgo\n"},
+		"TODO.md":   {"- [ ] Break the first goal in README.md into tasks"},
+	}
+	for _, f := range files {
+		for _, w := range want[f.Path] {
+			if !strings.Contains(string(f.Content), w) {
+				t.Errorf("%s missing %q:\n%s", f.Path, w, f.Content)
+			}
+		}
+		if f.Path == "README.md" && strings.Contains(string(f.Content), "[What are you trying to validate?") {
+			t.Error("README.md should replace the Goal placeholder")
+		}
+	}
+}
+
 func TestAllowNonEmptyDirectory(t *testing.T) {
 	dir := t.TempDir()
 	target := filepath.Join(dir, "project")
@@ -476,6 +571,73 @@ func TestGitignoreContent(t *testing.T) {
 			t.Error(".gitignore should contain Node-specific pattern 'node_modules/'")
 		}
 	})
+
+	t.Run("chosen sets without a dev container", func(t *testing.T) {
+		target := mustScaffold(t, TemplateData{
+			ProjectName: "test-gitignore-sets",
+			Description: "A test project",
+			Gitignore:   []string{"node", "python", "nextjs"},
+		})
+		raw, err := os.ReadFile(filepath.Join(target, ".gitignore"))
+		if err != nil {
+			t.Fatalf(".gitignore should exist: %v", err)
+		}
+		_, body, _ := parseStamp(raw)
+		content := string(body)
+		for _, want := range []string{"# Environment\n.env\n", "# Node\nnode_modules/\n", "# Python\n__pycache__/\n", "# Next.js\n.next/\n"} {
+			if !strings.Contains(content, want) {
+				t.Errorf(".gitignore should contain %q", want)
+			}
+		}
+		for _, unwanted := range []string{".DS_Store", ".idea/"} {
+			if strings.Contains(content, unwanted) {
+				t.Errorf(".gitignore should leave out unchosen set pattern %q", unwanted)
+			}
+		}
+		if strings.Count(content, "dist/\n") != 1 || strings.Count(content, "build/\n") != 1 {
+			t.Errorf("patterns shared by sets should be listed once:\n%s", content)
+		}
+	})
+
+	t.Run("extra patterns in their own section", func(t *testing.T) {
+		target := mustScaffold(t, TemplateData{
+			ProjectName:    "test-gitignore-extra",
+			Description:    "A test project",
+			GitignoreExtra: []string{"data/", "*.parquet"},
+		})
+		raw, err := os.ReadFile(filepath.Join(target, ".gitignore"))
+		if err != nil {
+			t.Fatalf(".gitignore should exist: %v", err)
+		}
+		if want := "\n\n# Project-specific\ndata/\n*.parquet\n"; !strings.HasSuffix(string(raw), want) {
+			t.Errorf(".gitignore should end with %q:\n%s", want, raw)
+		}
+	})
+}
+
+func TestLanguageWithoutDevContainer(t *testing.T) {
+	target := mustScaffold(t, TemplateData{
+		ProjectName: "test-language",
+		Description: "A test project",
+		Language:    "rust",
+	})
+	for _, want := range []struct{ file, text string }{
+		{".gitignore", "# Rust\ntarget/\n"},
+		{".editorconfig", "[*.rs]\nindent_size = 4\n"},
+		{"README.md", "## Quick Start\n\n```bash\ncargo build\ncargo test\n```\n"},
+	} {
+		raw, err := os.ReadFile(filepath.Join(target, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+	editorconfig, _ := os.ReadFile(filepath.Join(target, ".editorconfig"))
+	if strings.Contains(string(editorconfig), "[*.py]") {
+		t.Error(".editorconfig should only carry the chosen language's section")
+	}
+	if _, err := os.Stat(filepath.Join(target, ".devcontainer")); !os.IsNotExist(err) {
+		t.Error("a language alone should not generate a dev container")
+	}
 }
 
 func TestEditorconfigContent(t *testing.T) {
@@ -536,6 +698,60 @@ func TestLicenseApache(t *testing.T) {
 	if !strings.Contains(content, "2025") {
 		t.Error("LICENSE should contain the year")
 	}
+
+	for _, want := range []struct{ file, text string }{
+		{"NOTICE", "test-apache\nCopyright 2025 test-apache\n"},
+		{"CONTRIBUTING.md", "Copyright 2025 test-apache\n\nLicensed under the Apache License, Version 2.0"},
+		{"CONTRIBUTING.md", "[NOTICE](NOTICE)"},
+		{"AGENTS.md", "**License headers**"},
+	} {
+		raw, err := os.ReadFile(filepath.Join(target, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+}
+
+func TestLicenseDual(t *testing.T) {
+	target := mustScaffold(t, TemplateData{
+		ProjectName: "test-dual",
+		Description: "A test project",
+		License:     dualLicense,
+		Year:        2025,
+	})
+	for _, want := range []struct{ file, text string }{
+		{"LICENSE-MIT", "MIT License"},
+		{"LICENSE-APACHE", "Apache License"},
+		{"README.md", "## License\n\nLicensed under either of"},
+		{"README.md", "[LICENSE-APACHE](LICENSE-APACHE)"},
+		{"README.md", "[LICENSE-MIT](LICENSE-MIT)"},
+	} {
+		raw, err := os.ReadFile(filepath.Join(target, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+	for _, file := range []string{"LICENSE", "NOTICE", "CONTRIBUTING.md"} {
+		if _, err := os.Stat(filepath.Join(target, file)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be generated for a dual license", file)
+		}
+	}
+}
+
+func TestNoticeOnlyForApache(t *testing.T) {
+	target := mustScaffold(t, TemplateData{ProjectName: "test-mit-notice", Description: "A test project", License: "MIT"})
+	for _, file := range []string{"NOTICE", "CONTRIBUTING.md"} {
+		if _, err := os.Stat(filepath.Join(target, file)); !os.IsNotExist(err) {
+			t.Errorf("%s should only be generated for Apache-2.0", file)
+		}
+	}
+	agents, err := os.ReadFile(filepath.Join(target, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(agents), "License headers") {
+		t.Error("AGENTS.md should only mention license headers for Apache-2.0")
+	}
 }
 
 func TestLicenseNone(t *testing.T) {
@@ -690,6 +906,20 @@ func TestVSCodeExtensionsJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("generated with VS Code configs and no devcontainer", func(t *testing.T) {
+		target := mustScaffold(t, TemplateData{
+			ProjectName:      "test-vscode-config-ext",
+			Description:      "A test project",
+			VSCodeConfig:     true,
+			VSCodeExtensions: []string{"rust-lang.rust-analyzer"},
+		})
+
+		raw, err := os.ReadFile(filepath.Join(target, ".vscode", "extensions.json"))
+		if err != nil || !strings.Contains(string(raw), "rust-lang.rust-analyzer") {
+			t.Errorf(".vscode/extensions.json should recommend the chosen extensions (err %v):\n%s", err, raw)
+		}
+	})
+
 	t.Run("not generated when devcontainer not opted in", func(t *testing.T) {
 		target := mustScaffold(t, TemplateData{
 			ProjectName:      "test-vscode-no-dc",
@@ -796,3 +1026,760 @@ func TestEmptyDirectoryReuseSucceeds(t *testing.T) {
 		t.Error("README.md should exist in reused empty directory")
 	}
 }
+
+// readDevContainer parses the generated .devcontainer/devcontainer.json.
+func readDevContainer(t *testing.T, target string) DevContainer {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join(target, ".devcontainer", "devcontainer.json"))
+	if err != nil {
+		t.Fatalf("devcontainer.json should exist: %v", err)
+	}
+	var dc DevContainer
+	if err := json.Unmarshal(raw, &dc); err != nil {
+		t.Fatalf("devcontainer.json is not valid JSON: %v", err)
+	}
+	return dc
+}
+
+func TestDevContainerShell(t *testing.T) {
+	const commonUtils = "ghcr.io/devcontainers/features/common-utils:2"
+	tests := []struct {
+		shell       string
+		wantFeature bool
+		wantProfile string
+	}{
+		{shell: "", wantFeature: false, wantProfile: ""},
+		{shell: "bash", wantFeature: false, wantProfile: "bash"},
+		{shell: "zsh", wantFeature: true, wantProfile: "zsh"},
+	}
+	for _, tt := range tests {
+		t.Run("shell="+tt.shell, func(t *testing.T) {
+			dc := readDevContainer(t, mustScaffold(t, TemplateData{
+				ProjectName:         "test-shell",
+				Description:         "A test project",
+				IncludeDevContainer: true,
+				DevContainerImage:   "go:2-1.25-trixie",
+				Shell:               tt.shell,
+			}))
+
+			feature, ok := dc.Features[commonUtils]
+			if ok != tt.wantFeature {
+				t.Fatalf("common-utils feature present = %v, want %v", ok, tt.wantFeature)
+			}
+			if ok {
+				opts := feature.(map[string]interface{})
+				for _, key := range []string{"installZsh", "installOhMyZsh", "configureZshAsDefaultShell"} {
+					if opts[key] != true {
+						t.Errorf("common-utils option %s should be true, got %v", key, opts[key])
+					}
+				}
+			}
+
+			if tt.wantProfile == "" {
+				if dc.Customizations != nil {
+					t.Error("customizations should be absent without a shell choice or extensions")
+				}
+				return
+			}
+			if dc.Customizations == nil {
+				t.Fatal("customizations should hold the terminal profile")
+			}
+			if got := dc.Customizations.VSCode.Settings["terminal.integrated.defaultProfile.linux"]; got != tt.wantProfile {
+				t.Errorf("default terminal profile = %v, want %q", got, tt.wantProfile)
+			}
+		})
+	}
+}
+
+func TestDevContainerDotfiles(t *testing.T) {
+	t.Run("cloned in post-create", func(t *testing.T) {
+		dc := readDevContainer(t, mustScaffold(t, TemplateData{
+			ProjectName:         "test-dotfiles",
+			Description:         "A test project",
+			IncludeDevContainer: true,
+			DevContainerImage:   "go:2-1.25-trixie",
+			DotfilesRepo:        "https://github.com/octo/dotfiles",
+		}))
+		if !strings.Contains(dc.PostCreateCommand, "git clone --depth 1 'https://github.com/octo/dotfiles' ~/dotfiles") {
+			t.Errorf("postCreateCommand should clone the dotfiles: %q", dc.PostCreateCommand)
+		}
+	})
+
+	t.Run("runs alongside the chat continuity setup script", func(t *testing.T) {
+		dc := readDevContainer(t, mustScaffold(t, TemplateData{
+			ProjectName:         "test-dotfiles-chat",
+			Description:         "A test project",
+			IncludeDevContainer: true,
+			DevContainerImage:   "go:2-1.25-trixie",
+			ChatTools:           knownAITools,
+			DotfilesRepo:        "git@github.com:octo/dotfiles.git",
+		}))
+		if !strings.HasPrefix(dc.PostCreateCommand, "bash .devcontainer/setup.sh; ") {
+			t.Errorf("setup.sh should run before dotfiles: %q", dc.PostCreateCommand)
+		}
+	})
+
+	t.Run("absent by default", func(t *testing.T) {
+		dc := readDevContainer(t, mustScaffold(t, TemplateData{
+			ProjectName:         "test-no-dotfiles",
+			Description:         "A test project",
+			IncludeDevContainer: true,
+			DevContainerImage:   "go:2-1.25-trixie",
+		}))
+		if strings.Contains(dc.PostCreateCommand, "dotfiles") {
+			t.Errorf("no dotfiles step expected: %q", dc.PostCreateCommand)
+		}
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	if got, want := shellQuote("it's"), `'it'\''s'`; got != want {
+		t.Errorf("shellQuote = %s, want %s", got, want)
+	}
+}
+
+func TestDevContainerDockerAccess(t *testing.T) {
+	const (
+		dind   = "ghcr.io/devcontainers/features/docker-in-docker:2"
+		dood   = "ghcr.io/devcontainers/features/docker-outside-of-docker:1"
+		socket = "source=/var/run/docker.sock,target=/var/run/docker-host.sock,type=bind"
+	)
+	tests := []struct {
+		access         string
+		wantFeature    string
+		wantPrivileged bool
+		wantSocket     bool
+	}{
+		{access: ""},
+		{access: "none"},
+		{access: "docker-in-docker", wantFeature: dind, wantPrivileged: true},
+		{access: "docker-outside-of-docker", wantFeature: dood, wantSocket: true},
+	}
+	for _, tt := range tests {
+		t.Run("access="+tt.access, func(t *testing.T) {
+			dc := readDevContainer(t, mustScaffold(t, TemplateData{
+				ProjectName:         "test-docker",
+				Description:         "A test project",
+				IncludeDevContainer: true,
+				DevContainerImage:   "go:2-1.25-trixie",
+				DockerAccess:        tt.access,
+			}))
+
+			for _, feature := range []string{dind, dood} {
+				if _, ok := dc.Features[feature]; ok != (feature == tt.wantFeature) {
+					t.Errorf("feature %s present = %v", feature, ok)
+				}
+			}
+			if dc.Privileged != tt.wantPrivileged {
+				t.Errorf("privileged = %v, want %v", dc.Privileged, tt.wantPrivileged)
+			}
+			if got := slices.Contains(dc.Mounts, socket); got != tt.wantSocket {
+				t.Errorf("docker socket mount present = %v, want %v", got, tt.wantSocket)
+			}
+		})
+	}
+}
+
+func TestDevContainerHostRequirements(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		workload string
+		gpu      bool
+		cuda     bool
+		want     *DevContainerHostRequirements
+	}{
+		{name: "not chosen", image: "go:2-1.25-trixie", want: nil},
+		{name: "general", image: "go:2-1.25-trixie", workload: "general", want: &DevContainerHostRequirements{CPUs: 2, Memory: "4gb", Storage: "32gb"}},
+		{name: "compiled stack", image: "rust:1-bookworm", workload: "general", want: &DevContainerHostRequirements{CPUs: 4, Memory: "8gb", Storage: "32gb"}},
+		{name: "ml", image: "python:3-3.12", workload: "ml", want: &DevContainerHostRequirements{CPUs: 4, Memory: "16gb", Storage: "64gb"}},
+		{name: "ml with gpu", image: "python:3-3.12", workload: "ml", gpu: true, want: &DevContainerHostRequirements{CPUs: 4, Memory: "16gb", Storage: "64gb", GPU: "optional"}},
+		{name: "ml with cuda", image: "python:3-3.12", workload: "ml", gpu: true, cuda: true, want: &DevContainerHostRequirements{CPUs: 4, Memory: "16gb", Storage: "64gb", GPU: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := readDevContainer(t, mustScaffold(t, TemplateData{
+				ProjectName:         "test-host",
+				Description:         "A test project",
+				IncludeDevContainer: true,
+				DevContainerImage:   tt.image,
+				Workload:            tt.workload,
+				GPU:                 tt.gpu,
+				CUDA:                tt.cuda,
+			}))
+			if tt.want == nil {
+				if dc.HostRequirements != nil {
+					t.Errorf("hostRequirements should be absent, got %+v", *dc.HostRequirements)
+				}
+				return
+			}
+			if dc.HostRequirements == nil || *dc.HostRequirements != *tt.want {
+				t.Errorf("hostRequirements = %+v, want %+v", dc.HostRequirements, *tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretsWiring(t *testing.T) {
+	t.Run("names reach .env.example, README and containerEnv", func(t *testing.T) {
+		target := mustScaffold(t, TemplateData{
+			ProjectName:         "test-secrets",
+			Description:         "A test project",
+			IncludeDevContainer: true,
+			DevContainerImage:   "go:2-1.25-trixie",
+			Secrets:             []string{"OPENAI_API_KEY", "DATABASE_URL"},
+		})
+
+		envExample, err := os.ReadFile(filepath.Join(target, ".env.example"))
+		if err != nil {
+			t.Fatalf(".env.example should exist: %v", err)
+		}
+		readme, err := os.ReadFile(filepath.Join(target, "README.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dc := readDevContainer(t, target)
+		for _, name := range []string{"OPENAI_API_KEY", "DATABASE_URL"} {
+			if !strings.Contains(string(envExample), "\n"+name+"=\n") {
+				t.Errorf(".env.example should list %s with an empty value:\n%s", name, envExample)
+			}
+			if !strings.Contains(string(readme), "- `"+name+"`") {
+				t.Errorf("README should list %s", name)
+			}
+			if got := dc.ContainerEnv[name]; got != "${localEnv:"+name+"}" {
+				t.Errorf("containerEnv[%s] = %q, want a localEnv forward", name, got)
+			}
+		}
+	})
+
+	t.Run("nothing generated without secrets", func(t *testing.T) {
+		target := mustScaffold(t, TemplateData{ProjectName: "test-no-secrets", Description: "A test project"})
+		if _, err := os.Stat(filepath.Join(target, ".env.example")); !os.IsNotExist(err) {
+			t.Error(".env.example should not exist without secrets")
+		}
+		readme, err := os.ReadFile(filepath.Join(target, "README.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(readme), "## Secrets") {
+			t.Error("README should not have a Secrets section without secrets")
+		}
+	})
+}
+
+func TestNoExtensionsCache(t *testing.T) {
+	dc := readDevContainer(t, mustScaffold(t, TemplateData{
+		ProjectName:         "test-no-cache",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		NoExtensionsCache:   true,
+	}))
+	if len(dc.Mounts) != 0 {
+		t.Errorf("expected no extensions volume, got %v", dc.Mounts)
+	}
+	if dc.OnCreateCommand != "" || dc.PostCreateCommand != "" {
+		t.Errorf("expected no symlink commands, got onCreate %q, postCreate %q", dc.OnCreateCommand, dc.PostCreateCommand)
+	}
+}
+
+func TestExtensionsVolumeName(t *testing.T) {
+	a := extensionsVolumeName("myapp", "/home/me/work/myapp")
+	b := extensionsVolumeName("myapp", "/home/me/play/myapp")
+	if a == b {
+		t.Errorf("same name in different folders should get different volumes, both %q", a)
+	}
+	if a != extensionsVolumeName("myapp", "/home/me/work/myapp") {
+		t.Error("volume name should be stable for a path")
+	}
+	if !strings.HasPrefix(a, "myapp-") || !strings.HasSuffix(a, "-vscode-extensions") {
+		t.Errorf("unexpected volume name %q", a)
+	}
+	if got := extensionsVolumeName("My App!", "/x"); !strings.HasPrefix(got, "my-app-") || !volumeName.MatchString(got) {
+		t.Errorf("name should be slugged to a valid volume name, got %q", got)
+	}
+}
+
+func TestExtensionsVolumeOverride(t *testing.T) {
+	dc := readDevContainer(t, mustScaffold(t, TemplateData{
+		ProjectName:         "test-volume",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ExtensionsVolume:    "shared-extensions",
+	}))
+	if want := "source=shared-extensions,target=/home/vscode/.vscode-extensions-cache,type=volume"; !slices.Contains(dc.Mounts, want) {
+		t.Errorf("mounts %v should use the named volume", dc.Mounts)
+	}
+}
+
+func TestExtraMounts(t *testing.T) {
+	data := WizardData{
+		ProjectName:         "test-mounts",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "python:3-3.12",
+		ChatTools:           []string{"claude"},
+		Mounts:              []string{"~/models:/home/vscode/models"},
+	}
+	target := mustScaffold(t, data.ToTemplateData())
+
+	mounts := readDevContainer(t, target).Mounts
+	if got, want := mounts[len(mounts)-1], "source=${localEnv:HOME}/models,target=/home/vscode/models,type=bind"; got != want {
+		t.Errorf("last mount = %q, want the user mount %q", got, want)
+	}
+	if len(mounts) != 3 {
+		t.Errorf("expected seed's mounts plus one extra, got %v", mounts)
+	}
+}
+
+func TestForwardEnv(t *testing.T) {
+	target := mustScaffold(t, TemplateData{
+		ProjectName:         "test-forward-env",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ForwardEnv:          []string{"ANTHROPIC_API_KEY", "NPM_TOKEN"},
+	})
+
+	dc := readDevContainer(t, target)
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN", "ANTHROPIC_API_KEY", "NPM_TOKEN"} {
+		if got := dc.ContainerEnv[name]; got != "${localEnv:"+name+"}" {
+			t.Errorf("containerEnv[%s] = %q, want a localEnv forward", name, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(target, ".env.example")); !os.IsNotExist(err) {
+		t.Error("forwarded variables alone should not produce .env.example")
+	}
+}
+
+func TestChatToolsRelocated(t *testing.T) {
+	data := WizardData{
+		ProjectName:         "test-chat-relocated",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ChatTools:           []string{"claude"},
+		CustomChatTools:     []aiTool{{ID: "claude", Label: "Claude Code", StateDir: ".config/claude"}},
+	}
+	target := mustScaffold(t, data.ToTemplateData())
+
+	dc := readDevContainer(t, target)
+	if want := "source=${localEnv:HOME}/.config/claude,target=/home/vscode/.config/claude,type=bind,consistency=cached"; !slices.Contains(dc.Mounts, want) {
+		t.Errorf("mounts %v should use the relocated dir", dc.Mounts)
+	}
+	setup, err := os.ReadFile(filepath.Join(target, ".devcontainer", "setup.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(setup), `"$HOME/.config/claude/projects/$HOST_KEY"`) {
+		t.Errorf("setup.sh should use the relocated dir:\n%s", setup)
+	}
+	if !strings.Contains(dc.InitializeCommand, `"${localEnv:HOME}/.config/claude"`) {
+		t.Errorf("initializeCommand should create the mount source: %q", dc.InitializeCommand)
+	}
+
+	dockerfile, err := os.ReadFile(filepath.Join(target, ".devcontainer", "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dockerfile), "USER vscode\nRUN mkdir -p /home/vscode/.config/claude\nUSER root\n") {
+		t.Errorf("Dockerfile should create the mount point as vscode:\n%s", dockerfile)
+	}
+}
+
+func TestDockerfileWithoutChatTools(t *testing.T) {
+	target := mustScaffold(t, TemplateData{
+		ProjectName:         "test-dockerfile",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+	})
+	dockerfile, err := os.ReadFile(filepath.Join(target, ".devcontainer", "Dockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(dockerfile), "USER") || !strings.HasSuffix(string(dockerfile), "/home/vscode/.config\n") {
+		t.Errorf("Dockerfile should only pre-create the standard dirs:\n%s", dockerfile)
+	}
+}
+
+func TestChatToolsSelection(t *testing.T) {
+	data := WizardData{
+		ProjectName:         "test-chat-tools",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ChatTools:           []string{"gemini"},
+	}
+	target := mustScaffold(t, data.ToTemplateData())
+
+	dc := readDevContainer(t, target)
+	if dc.InitializeCommand != `for d in "${localEnv:HOME}/.gemini"; do [ -d "$d" ] || mkdir -p "$d"; done` {
+		t.Errorf("only the chosen tool's dir should be created, got %q", dc.InitializeCommand)
+	}
+	setup, err := os.ReadFile(filepath.Join(target, ".devcontainer", "setup.sh"))
+	if err != nil {
+		t.Fatalf("setup.sh should exist: %v", err)
+	}
+	for _, tool := range knownAITools {
+		mounted := slices.ContainsFunc(dc.Mounts, func(m string) bool { return strings.Contains(m, "/"+tool.StateDir+",") })
+		scripted := strings.Contains(string(setup), "$HOME/"+tool.StateDir)
+		if want := tool.ID == "gemini"; mounted != want || scripted != want {
+			t.Errorf("%s: mounted=%t scripted=%t, want %t", tool.Label, mounted, scripted, want)
+		}
+	}
+}
+
+func TestLocalChatContinuity(t *testing.T) {
+	data := WizardData{ProjectName: "test-local-chat", Description: "A test project", ChatTools: []string{"claude", "codex"}}
+	project := mustScaffold(t, data.ToTemplateData())
+
+	for _, want := range []struct{ file, text string }{
+		{".gitignore", ".ai-project-key"},
+		{"README.md", "## AI chat history"},
+		{"README.md", "AI coding tools (Claude Code, Codex)"},
+	} {
+		raw, err := os.ReadFile(filepath.Join(project, want.file))
+		if err != nil || !strings.Contains(string(raw), want.text) {
+			t.Errorf("%s should contain %q (err %v)", want.file, want.text, err)
+		}
+	}
+
+	home := t.TempDir()
+	if err := os.Mkdir(filepath.Join(home, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	run := func(dir string) {
+		t.Helper()
+		cmd := exec.Command("bash", filepath.Join(dir, "scripts", "link-ai-history.sh"))
+		cmd.Env = append(os.Environ(), "HOME="+home)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("script failed: %v\n%s", err, out)
+		}
+	}
+	key := func(dir string) string {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.ReplaceAll(resolved, "/", "-")
+	}
+
+	run(project)
+	projectKey := key(project)
+	recorded, err := os.ReadFile(filepath.Join(project, ".ai-project-key"))
+	if err != nil || strings.TrimSpace(string(recorded)) != projectKey {
+		t.Fatalf("first run should record the project key, got %q (%v)", recorded, err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".codex")); !os.IsNotExist(err) {
+		t.Error("tools that aren't installed should be skipped")
+	}
+
+	moved := filepath.Join(t.TempDir(), "moved")
+	if err := os.Rename(project, moved); err != nil {
+		t.Fatal(err)
+	}
+	run(moved)
+	link := filepath.Join(home, ".claude", "projects", key(moved))
+	if target, err := os.Readlink(link); err != nil || filepath.Base(target) != projectKey {
+		t.Errorf("moved project should link to the recorded history, got %q (%v)", target, err)
+	}
+}
+
+func TestLocalChatContinuityNotWithDevContainer(t *testing.T) {
+	data := WizardData{ProjectName: "test-dc-chat-only", Description: "A test project", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie", ChatTools: []string{"claude"}}
+	target := mustScaffold(t, data.ToTemplateData())
+	if _, err := os.Stat(filepath.Join(target, localContinuityScript)); !os.IsNotExist(err) {
+		t.Error("dev container projects use setup.sh, not the local script")
+	}
+}
+
+func TestChatStateCopy(t *testing.T) {
+	data := WizardData{
+		ProjectName:         "test-chat-copy",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		ChatTools:           []string{"claude"},
+		ChatState:           "copy",
+		ExtensionsVolume:    "test-chat-copy-1234abcd-vscode-extensions",
+	}
+	target := mustScaffold(t, data.ToTemplateData())
+
+	dc := readDevContainer(t, target)
+	for _, want := range []string{
+		"source=test-chat-copy-1234abcd-claude-state,target=/home/vscode/.claude,type=volume",
+		"source=${localEnv:HOME}/.claude,target=/home/vscode/.host-state/.claude,type=bind,readonly",
+	} {
+		if !slices.Contains(dc.Mounts, want) {
+			t.Errorf("mounts %v should include %q", dc.Mounts, want)
+		}
+	}
+	if slices.Contains(dc.Mounts, "source=${localEnv:HOME}/.claude,target=/home/vscode/.claude,type=bind,consistency=cached") {
+		t.Error("copy strategy should not bind-mount the host dir in place")
+	}
+
+	setup, err := os.ReadFile(filepath.Join(target, ".devcontainer", "setup.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`if [ ! -e "$HOME/.claude/.seed-copied" ]; then`,
+		`cp -a "/home/vscode/.host-state/.claude/." "$HOME/.claude/"`,
+		`ln -sfn "$HOME/.claude/projects/$HOST_KEY"`,
+	} {
+		if !strings.Contains(string(setup), want) {
+			t.Errorf("setup.sh should contain %q:\n%s", want, setup)
+		}
+	}
+}
+
+func TestVSCodeConfig(t *testing.T) {
+	target := mustScaffold(t, TemplateData{
+		ProjectName:  "test-vscode",
+		Description:  "A test project",
+		Language:     "go",
+		VSCodeConfig: true,
+	})
+	var settings map[string]any
+	var tasks struct {
+		Tasks []vscodeTask `json:"tasks"`
+	}
+	var launch struct {
+		Configurations []vscodeLaunch `json:"configurations"`
+	}
+	for file, v := range map[string]any{"settings.json": &settings, "tasks.json": &tasks, "launch.json": &launch} {
+		raw, err := os.ReadFile(filepath.Join(target, ".vscode", file))
+		if err != nil {
+			t.Fatalf(".vscode/%s should exist: %v", file, err)
+		}
+		if err := json.Unmarshal(raw, v); err != nil {
+			t.Fatalf(".vscode/%s should be valid JSON: %v", file, err)
+		}
+	}
+	if settings["editor.formatOnSave"] != true || settings["[go]"] == nil {
+		t.Errorf("settings should format on save with the Go formatter, got %v", settings)
+	}
+	if len(tasks.Tasks) != 2 || tasks.Tasks[0].Command != "go build ./..." || tasks.Tasks[1].Group.Kind != "test" {
+		t.Errorf("tasks should build and test with go, got %+v", tasks.Tasks)
+	}
+	if len(launch.Configurations) != 1 || launch.Configurations[0].Type != "go" {
+		t.Errorf("launch should hold a Go debug config, got %+v", launch.Configurations)
+	}
+
+	for _, s := range stacks {
+		files, err := renderVSCodeConfig(TemplateData{Stack: &s})
+		if err != nil || len(files) != 3 {
+			t.Errorf("%s: got %d files, err %v", s.ID, len(files), err)
+		}
+	}
+
+	// Without a language only settings.json is written
+	neutral := mustScaffold(t, TemplateData{ProjectName: "test-vscode-neutral", Description: "A test project", VSCodeConfig: true})
+	if _, err := os.Stat(filepath.Join(neutral, ".vscode", "settings.json")); err != nil {
+		t.Errorf("settings.json should exist: %v", err)
+	}
+	for _, file := range []string{"tasks.json", "launch.json"} {
+		if _, err := os.Stat(filepath.Join(neutral, ".vscode", file)); !os.IsNotExist(err) {
+			t.Errorf("%s needs a language", file)
+		}
+	}
+}
+
+func TestRunRenderJobs(t *testing.T) {
+	// Later jobs finish first; the result must still follow job order
+	var jobs []renderJob
+	for i := range 20 {
+		jobs = append(jobs, func() ([]RenderedFile, error) {
+			time.Sleep(time.Duration(20-i) * time.Millisecond)
+			return []RenderedFile{{Path: fmt.Sprintf("f%02d-a", i)}, {Path: fmt.Sprintf("f%02d-b", i)}}, nil
+		})
+	}
+	files, err := runRenderJobs(jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 40 {
+		t.Fatalf("got %d files, want 40", len(files))
+	}
+	if !slices.IsSortedFunc(files, func(a, b RenderedFile) int { return strings.Compare(a.Path, b.Path) }) {
+		t.Fatalf("files out of job order: %v", files)
+	}
+
+	jobs = append(jobs, func() ([]RenderedFile, error) { return nil, fmt.Errorf("boom") })
+	if _, err := runRenderJobs(jobs); err == nil || err.Error() != "boom" {
+		t.Fatalf("got error %v, want boom", err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "devcontainer.json")
+	if err := os.WriteFile(name, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(name, []byte("new"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Fatalf("content = %q, want %q", got, "new")
+	}
+	if info, _ := os.Stat(name); info.Mode().Perm()&0100 == 0 {
+		t.Fatalf("mode = %v, want executable", info.Mode())
+	}
+
+	// A failed rename (onto a non-empty directory) leaves no temp file behind
+	if err := os.MkdirAll(filepath.Join(dir, "sub", "child"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "sub"), []byte("x"), 0644); err == nil {
+		t.Fatal("expected an error replacing a directory")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected only devcontainer.json and sub/, got %v", entries)
+	}
+}
+
+func TestScaffolderOnProgress(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []ProgressEvent
+	s.OnProgress = func(e ProgressEvent) { events = append(events, e) }
+
+	files, err := s.ScaffoldFiles(filepath.Join(t.TempDir(), "proj"), TemplateData{ProjectName: "proj", Description: "d"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var phases []string
+	started := map[string]bool{}
+	bytes := map[string]int{}
+	for _, e := range events {
+		switch e.Kind {
+		case ProgressPhase:
+			phases = append(phases, e.Phase)
+		case ProgressFileStarted:
+			started[e.Path] = true
+		case ProgressFileFinished:
+			if !started[e.Path] {
+				t.Errorf("%s finished before it started", e.Path)
+			}
+			if e.Err != nil {
+				t.Errorf("%s: %v", e.Path, e.Err)
+			}
+			bytes[e.Path] = e.Bytes
+		}
+	}
+	if !slices.Equal(phases, []string{PhaseRender, PhaseWrite}) {
+		t.Fatalf("phases = %v", phases)
+	}
+	for _, f := range files {
+		if bytes[f.Path] != len(f.Content) {
+			t.Errorf("%s: reported %d bytes, want %d", f.Path, bytes[f.Path], len(f.Content))
+		}
+	}
+	if len(bytes) != len(files) {
+		t.Errorf("got %d finished files, want %d", len(bytes), len(files))
+	}
+}
+
+// largePack is a synthetic template pack of n README-sized templates.
+func largePack(n int) (*Scaffolder, []string) {
+	body, err := templatesFS.ReadFile("templates/README.md.tmpl")
+	if err != nil {
+		panic(err)
+	}
+	pack := fstest.MapFS{}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("doc%04d.md.tmpl", i)
+		pack["pack/"+names[i]] = &fstest.MapFile{Data: body}
+	}
+	return &Scaffolder{templates: templateSetFor(fmt.Sprintf("bench-%d", n), pack, "pack")}, names
+}
+
+// BenchmarkScaffoldLargePack renders, stamps and writes a 1,000-file pack
+// and builds its manifest, the same steps scaffoldProject takes. Target:
+// well under a second per op.
+func BenchmarkScaffoldLargePack(b *testing.B) {
+	s, names := largePack(1000)
+	data := TemplateData{ProjectName: "bench", Description: "A benchmark project.", Language: "go"}
+	data.Stack = stackFor(data.Language)
+	root := b.TempDir()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jobs := make([]renderJob, len(names))
+		for j, name := range names {
+			jobs[j] = func() ([]RenderedFile, error) {
+				file, err := s.renderFile(name, "docs/"+strings.TrimSuffix(name, ".tmpl"), data)
+				return []RenderedFile{file}, err
+			}
+		}
+		files, err := runRenderJobs(jobs)
+		if err != nil {
+			b.Fatal(err)
+		}
+		files = stampFiles(files)
+		if err := writeFiles(filepath.Join(root, strconv.Itoa(i)), files); err != nil {
+			b.Fatal(err)
+		}
+		newManifest(WizardData{ProjectName: "bench"}, 2026, files)
+	}
+}
+
+func TestAgentActionWorkflow(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		data   TemplateData
+		want   []string
+		absent []string
+	}{
+		{"with skills", TemplateData{ProjectName: "agent", Description: "Agent", AgentAction: true}, []string{"anthropics/claude-code-action@v1", "anthropic_api_key: ${{ secrets.ANTHROPIC_API_KEY }}", "contains(github.event.comment.body, '@claude')", "Read AGENTS.md", "skills/entropy-guard.md"}, nil},
+		{"without skills", TemplateData{ProjectName: "agent", Description: "Agent", AgentAction: true, NoSkills: true}, []string{"Read AGENTS.md"}, []string{"skills/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			workflow := renderedContent(files, agentWorkflowPath)
+			for _, want := range tt.want {
+				if !strings.Contains(workflow, want) {
+					t.Errorf("workflow missing %q:\n%s", want, workflow)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(workflow, unwanted) {
+					t.Errorf("workflow shouldn't contain %q:\n%s", unwanted, workflow)
+				}
+			}
+		})
+	}
+
+	files, err := s.Render(TemplateData{ProjectName: "agent", Description: "Agent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renderedContent(files, agentWorkflowPath) != "" {
+		t.Errorf("%s should only be rendered when chosen", agentWorkflowPath)
+	}
+}
@@ -44,13 +44,18 @@ cat > "${FAKE_BIN}/curl" <<'EOF'
 #!/bin/sh
 set -e
 OUT=""
+URL=""
 while [ "$#" -gt 0 ]; do
     case "$1" in
         -o)
             OUT="$2"
             shift 2
             ;;
+        -*)
+            shift
+            ;;
         *)
+            URL="$1"
             shift
             ;;
     esac
@@ -61,15 +66,38 @@ done
     exit 1
 }
 
-cp "${SEED_TEST_PAYLOAD}" "$OUT"
+case "$URL" in
+    */checksums.txt) cp "${SEED_TEST_CHECKSUMS}" "$OUT" ;;
+    *) cp "${SEED_TEST_PAYLOAD}" "$OUT" ;;
+esac
 EOF
 chmod +x "${FAKE_BIN}/curl"
 
+# A download that doesn't match checksums.txt must not be installed
+printf '%s  seed-linux-amd64\n' "$(printf 'tampered' | sha256sum | awk '{ print $1 }')" > "${TMPDIR}/checksums.txt"
+if HOME="${HOME_DIR}" \
+    SHELL="/bin/zsh" \
+    INSTALL_DIR="${HOME_DIR}/.local/bin" \
+    PATH="${FAKE_BIN}:${PATH}" \
+    SEED_TEST_PAYLOAD="${TMPDIR}/seed-payload" \
+    SEED_TEST_CHECKSUMS="${TMPDIR}/checksums.txt" \
+    /bin/sh "${INSTALLER}" >/dev/null 2>&1; then
+    printf 'Installer accepted a download with a mismatched checksum\n' >&2
+    exit 1
+fi
+if [ -e "${HOME_DIR}/.local/bin/seed" ]; then
+    printf 'Installer installed a download with a mismatched checksum\n' >&2
+    exit 1
+fi
+
+printf '%s  seed-linux-amd64\n' "$(sha256sum "${TMPDIR}/seed-payload" | awk '{ print $1 }')" > "${TMPDIR}/checksums.txt"
+
 HOME="${HOME_DIR}" \
 SHELL="/bin/zsh" \
 INSTALL_DIR="${HOME_DIR}/.local/bin" \
 PATH="${FAKE_BIN}:${PATH}" \
 SEED_TEST_PAYLOAD="${TMPDIR}/seed-payload" \
+SEED_TEST_CHECKSUMS="${TMPDIR}/checksums.txt" \
 /bin/sh "${INSTALLER}" >/dev/null
 
 INSTALLED_VERSION="$({
@@ -0,0 +1,176 @@
+// Package main - seedignore.go
+//
+// PURPOSE:
+// This file reads .seedignore, a gitignore-syntax list of paths seed leaves
+// alone in a target directory. It's responsible for:
+// - Parsing the patterns: comments, ! negation, trailing / for directories,
+//   leading or inner / to anchor at the root, *, ?, [...] and **
+// - Dropping ignored paths from what seed generates (templates, skills,
+//   --sync additions, status' "missing" files)
+// - Keeping ignored paths out of directory snapshots, so the review's
+//   "replaces" marks, the created-file report and rollback never see them,
+//   and large trees like node_modules/ aren't walked at all
+//
+// DESIGN PATTERNS:
+// - Same matching rules as git: the last matching pattern wins, and a path
+//   inside an ignored directory stays ignored whatever follows
+// - A missing .seedignore ignores nothing; an invalid one is an error
+//   naming the line, rather than a silently partial list
+//
+// USAGE:
+// files, err = dropIgnored(targetDir, files)
+// ignore, err := loadSeedIgnore(dir); ignore.Ignored("data/raw.csv", false)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// seedIgnoreFile lists paths seed leaves alone, in the target directory.
+const seedIgnoreFile = ".seedignore"
+
+// ignoreRule is one .seedignore pattern.
+type ignoreRule struct {
+	match   *regexp.Regexp
+	negate  bool // "!pattern": re-include
+	dirOnly bool // "pattern/": directories only
+}
+
+// seedIgnore is a parsed .seedignore, in file order.
+type seedIgnore []ignoreRule
+
+// loadSeedIgnore reads dir's .seedignore; without one nothing is ignored.
+func loadSeedIgnore(dir string) (seedIgnore, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, seedIgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", seedIgnoreFile, err)
+	}
+	return parseSeedIgnore(string(raw))
+}
+
+// parseSeedIgnore parses gitignore-syntax patterns.
+func parseSeedIgnore(content string) (seedIgnore, error) {
+	var rules seedIgnore
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			rule.negate, line = true, rest
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			rule.dirOnly, line = true, rest
+		}
+		// A slash anywhere but the end anchors the pattern at the root;
+		// otherwise it matches a name at any depth
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+		expr := "^"
+		if !anchored {
+			expr += "(?:.*/)?"
+		}
+		re, err := regexp.Compile(expr + globPattern(line) + "$")
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: invalid pattern %q", seedIgnoreFile, i+1, line)
+		}
+		rule.match = re
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// globPattern translates a gitignore glob to a regular expression.
+func globPattern(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Ignored reports whether the slash-separated path p (a directory when
+// isDir) is ignored, directly or through an ignored parent directory.
+func (ig seedIgnore) Ignored(p string, isDir bool) bool {
+	if len(ig) == 0 {
+		return false
+	}
+	parts := strings.Split(p, "/")
+	for i := 1; i < len(parts); i++ {
+		if ig.matches(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return ig.matches(p, isDir)
+}
+
+// matches applies the rules to p alone; the last one matching decides.
+func (ig seedIgnore) matches(p string, isDir bool) bool {
+	ignored := false
+	for _, rule := range ig {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.match.MatchString(p) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// dropIgnored returns files minus those dir's .seedignore excludes.
+func dropIgnored(dir string, files []RenderedFile) ([]RenderedFile, error) {
+	ignore, err := loadSeedIgnore(dir)
+	if err != nil || len(ignore) == 0 {
+		return files, err
+	}
+	kept := make([]RenderedFile, 0, len(files))
+	for _, f := range files {
+		if !ignore.Ignored(f.Path, false) {
+			kept = append(kept, f)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSeedIgnore(t *testing.T) {
+	ignore, err := parseSeedIgnore(`# Large content seed shouldn't touch
+node_modules/
+/data
+*.log
+!keep.log
+docs/**/draft-*.md
+build/**
+LEARNINGS.md
+\#literal
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"web/node_modules/react/index.js", false, true},
+		{"node_modules", false, false}, // Directory-only pattern
+		{"data/raw.csv", false, true},
+		{"src/data/raw.csv", false, false}, // Anchored at the root
+		{"debug.log", false, true},
+		{"logs/app/debug.log", false, true},
+		{"keep.log", false, false},
+		{"docs/draft-1.md", false, true},
+		{"docs/a/b/draft-2.md", false, true},
+		{"docs/final.md", false, false},
+		{"build/out/app", false, true},
+		{"LEARNINGS.md", false, true},
+		{"sub/LEARNINGS.md", false, true},
+		{"#literal", false, true},
+		{"README.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := ignore.Ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Ignored(%q, %t) = %t, want %t", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestSeedIgnoreNegationInsideIgnoredDir(t *testing.T) {
+	ignore, err := parseSeedIgnore("vendor/\n!vendor/keep.go\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ignore.Ignored("vendor/keep.go", false) {
+		t.Error("as in git, a file under an ignored directory can't be re-included")
+	}
+}
+
+func TestScaffoldRespectsSeedIgnore(t *testing.T) {
+	target := tempDir(t)
+	writeTestFile(t, filepath.Join(target, seedIgnoreFile), "LEARNINGS.md\ndata/\nskills/seed-ux-eval.md\n")
+	writeTestFile(t, filepath.Join(target, "data", "big.csv"), "a,b\n")
+	before, err := snapshotProjectFiles(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := before["data/big.csv"]; ok {
+		t.Error("snapshot should skip ignored directories")
+	}
+
+	answers := WizardData{ProjectName: "ignored", Description: "Seedignore test"}
+	report, err := scaffoldProject(target, answers, true, before, newPlainProgress(io.Discard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"LEARNINGS.md", "skills/seed-ux-eval.md"} {
+		if _, err := os.Stat(filepath.Join(target, p)); !os.IsNotExist(err) {
+			t.Errorf("%s is ignored and shouldn't be written", p)
+		}
+		if slices.Contains(report.Created, p) {
+			t.Errorf("%s shouldn't be reported as created", p)
+		}
+	}
+	if !slices.Contains(report.Created, "README.md") || !slices.Contains(report.Created, "skills/entropy-guard.md") {
+		t.Errorf("files not ignored should be created, got %v", report.Created)
+	}
+
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := projectStatus(s, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Available) != 0 {
+		t.Errorf("ignored files shouldn't be offered as missing: %v", status.Available)
+	}
+	plan, err := planSync(s, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Added) != 0 {
+		t.Errorf("--sync shouldn't add ignored files: %v", plan.Added)
+	}
+}
+
+func TestSeedIgnoreInvalidPattern(t *testing.T) {
+	if _, err := parseSeedIgnore("ok\n[z-a]\n"); err == nil {
+		t.Error("an invalid pattern should be an error")
+	}
+}
@@ -0,0 +1,185 @@
+// Package main - session.go
+//
+// PURPOSE:
+// This file keeps wizard answers that didn't make it to a project. It's
+// responsible for:
+// - Saving the answers entered so far when the wizard is cancelled or fails
+// - Offering to resume them on the next run for the same target
+// - Keeping a completed wizard's answers until the project they describe is
+//   written, so a failed, aborted or panicking run can be resumed
+// - Removing the session once the project is written, or when it's too old
+//
+// DESIGN PATTERNS:
+// - One file per target (the directory, or the archive for
+//   --output-archive), named by a hash of its absolute path, in seed's
+//   cache directory: losing it costs some typing, never a project
+// - Resumed answers go in as the wizard's preset, like a profile's, so every
+//   question is still asked with the saved answer selected
+// - Files are written 0600 in a 0700 directory; answers hold descriptions
+//   and secret names, never secret values
+//
+// USAGE:
+// session, err := runWizardSession(targetDir, rootName, opts.Disabled, preset)
+// defer session.Keep()
+// ... write the project from session.Answers ...
+// session.Done()
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// sessionMaxAge is how long a saved session is offered for.
+const sessionMaxAge = 7 * 24 * time.Hour
+
+// wizardSession is a saved, unfinished wizard run.
+type wizardSession struct {
+	Target  string     `json:"target"`  // Absolute path the wizard was run for
+	SavedAt time.Time  `json:"savedAt"` // When the wizard was cancelled
+	Answers WizardData `json:"answers"`
+}
+
+// seedCacheDir returns seed's per-user cache directory.
+func seedCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "seed"), nil
+}
+
+// sessionPath returns the session file for target.
+func sessionPath(target string) (string, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	dir, err := seedCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, "sessions", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// saveSession records answers as target's unfinished session, reporting
+// whether it did: answers without a description or brief aren't worth
+// keeping.
+func saveSession(target string, answers WizardData) (bool, error) {
+	if answers.Description == "" && len(answers.Goals) == 0 && len(answers.NonGoals) == 0 && len(answers.Constraints) == 0 {
+		return false, nil
+	}
+	path, err := sessionPath(target)
+	if err != nil {
+		return false, err
+	}
+	abs, _ := filepath.Abs(target)
+	raw, err := json.MarshalIndent(wizardSession{Target: abs, SavedAt: time.Now().UTC(), Answers: answers}, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, fmt.Errorf("failed to create session directory: %w", err)
+	}
+	if err := writeFileAtomic(path, append(raw, '\n'), 0600); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadSession returns target's unfinished session, if there's a recent one.
+// Stale or unreadable sessions are removed.
+func loadSession(target string) (wizardSession, bool) {
+	path, err := sessionPath(target)
+	if err != nil {
+		return wizardSession{}, false
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return wizardSession{}, false
+	}
+	var s wizardSession
+	if err := json.Unmarshal(raw, &s); err != nil || time.Since(s.SavedAt) > sessionMaxAge {
+		os.Remove(path)
+		return wizardSession{}, false
+	}
+	return s, true
+}
+
+// clearSession removes target's session, if any.
+func clearSession(target string) {
+	if path, err := sessionPath(target); err == nil {
+		os.Remove(path)
+	}
+}
+
+// wizardRun is a completed wizard's answers for target. They stay target's
+// session until Done; see Keep.
+type wizardRun struct {
+	target  string
+	Answers WizardData
+	done    bool
+}
+
+// Done records that the project was written, removing target's session.
+func (r *wizardRun) Done() {
+	r.done = true
+	clearSession(r.target)
+}
+
+// Keep saves the answers as target's session unless Done was called, so a
+// run that fails after the wizard can be resumed. Defer it directly: it
+// also saves them on a panic, then panics again.
+func (r *wizardRun) Keep() {
+	p := recover()
+	if !r.done {
+		if saved, err := saveSession(r.target, r.Answers); err != nil {
+			debugf("failed to save wizard session: %v", err)
+		} else if saved {
+			fmt.Fprintln(wizardOutput, dimStyle.Render(T("session.saved")))
+		}
+	}
+	if p != nil {
+		panic(p)
+	}
+}
+
+// runWizardSession runs the wizard for target, offering to resume an
+// unfinished session first and saving one if the wizard is cancelled. A
+// resumed session is kept until the returned run's Done.
+func runWizardSession(target, defaultName string, disabled []string, preset WizardData) (*wizardRun, error) {
+	if s, ok := loadSession(target); ok {
+		resume := true
+		err := huh.NewForm(huh.NewGroup(
+			huh.NewConfirm().
+				Title(T("session.resume", s.SavedAt.Local().Format("2006-01-02 15:04"))).
+				Description(T("session.resumeHint", s.Answers.ProjectName)).
+				Value(&resume),
+		)).WithOutput(wizardOutput).Run()
+		if err != nil {
+			return nil, err
+		}
+		if resume {
+			preset = s.Answers
+		} else {
+			clearSession(target)
+		}
+	}
+
+	data, err := RunWizard(defaultName, disabled, preset)
+	run := &wizardRun{target: target, Answers: data}
+	if err != nil {
+		run.Keep()
+		return nil, err
+	}
+	return run, nil
+}
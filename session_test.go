@@ -0,0 +1,115 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWizardSessionRoundTrip(t *testing.T) {
+	home := isolateConfig(t)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	target := filepath.Join(home, "api")
+	answers := WizardData{ProjectName: "api", Description: "A long description\n\nwith paragraphs.", Goals: []string{"Serve requests"}}
+
+	if _, ok := loadSession(target); ok {
+		t.Fatal("expected no session before one is saved")
+	}
+	saved, err := saveSession(target, answers)
+	if err != nil || !saved {
+		t.Fatalf("saveSession: %t, %v", saved, err)
+	}
+	path, _ := sessionPath(target)
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected a 0600 session file, got %v (%v)", info, err)
+	}
+
+	s, ok := loadSession(target)
+	if !ok || s.Answers.Description != answers.Description || len(s.Answers.Goals) != 1 {
+		t.Fatalf("loadSession: %+v, %t", s, ok)
+	}
+	// Another target has its own session; another spelling of the path
+	// finds the same one
+	if _, ok := loadSession(filepath.Join(home, "web")); ok {
+		t.Error("a session shouldn't be offered for another target")
+	}
+	if _, ok := loadSession(home + "/web/../api"); !ok {
+		t.Error("an unclean path should find the target's session")
+	}
+
+	clearSession(target)
+	if _, ok := loadSession(target); ok {
+		t.Fatal("expected the session to be cleared")
+	}
+}
+
+func TestWizardSessionSkipsAndExpires(t *testing.T) {
+	home := isolateConfig(t)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	target := filepath.Join(home, "api")
+
+	// Nothing typed beyond the defaults isn't worth a session
+	if saved, err := saveSession(target, WizardData{ProjectName: "api", License: "MIT"}); saved || err != nil {
+		t.Fatalf("expected nothing saved, got %t, %v", saved, err)
+	}
+
+	if _, err := saveSession(target, WizardData{ProjectName: "api", Description: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := sessionPath(target)
+	old := []byte(`{"target":"` + target + `","savedAt":"` + time.Now().Add(-sessionMaxAge-time.Hour).UTC().Format(time.RFC3339) + `","answers":{"projectName":"api","description":"x"}}`)
+	if err := os.WriteFile(path, old, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadSession(target); ok {
+		t.Fatal("a stale session shouldn't be offered")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("a stale session should be removed")
+	}
+}
+
+func TestWizardRunKeepsAnswersUntilDone(t *testing.T) {
+	home := isolateConfig(t)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	saved := wizardOutput
+	wizardOutput = io.Discard
+	t.Cleanup(func() { wizardOutput = saved })
+	target := filepath.Join(home, "api")
+	answers := WizardData{ProjectName: "api", Description: "Kept until written"}
+
+	// A run that fails after the wizard keeps the answers
+	func() {
+		run := &wizardRun{target: target, Answers: answers}
+		defer run.Keep()
+	}()
+	if s, ok := loadSession(target); !ok || s.Answers.Description != answers.Description {
+		t.Fatalf("expected the answers to be kept after a failed run, got %+v, %t", s, ok)
+	}
+
+	// One that writes the project removes them
+	func() {
+		run := &wizardRun{target: target, Answers: answers}
+		defer run.Keep()
+		run.Done()
+	}()
+	if _, ok := loadSession(target); ok {
+		t.Fatal("expected the session to be removed once the project is written")
+	}
+
+	// A panic keeps them and carries on panicking
+	recovered := func() (p any) {
+		defer func() { p = recover() }()
+		run := &wizardRun{target: target, Answers: answers}
+		defer run.Keep()
+		panic("boom")
+	}()
+	if recovered != "boom" {
+		t.Errorf("expected the panic to continue, got %v", recovered)
+	}
+	if _, ok := loadSession(target); !ok {
+		t.Error("expected the answers to be kept after a panic")
+	}
+}
@@ -0,0 +1,190 @@
+// Package main - sizeguard.go
+//
+// PURPOSE:
+// This file stops seed from writing an unexpectedly large project. It's
+// responsible for:
+// - Counting the files a project would get and their total size
+// - The limits: config maxFiles and maxSize, 500 files and 50MB by default
+// - Asking before writing past a limit on a terminal, and refusing
+//   elsewhere (batch, piped runs)
+//
+// DESIGN PATTERNS:
+// - The check runs on rendered files, before anything touches disk, so it
+//   covers whatever produced them: the embedded templates today, template
+//   packs from elsewhere once they can be loaded
+// - An org config can lower the limits but not raise them; the lower of
+//   the org's and the user's value wins
+// - Unset or unparseable values fall back to the defaults, as
+//   commandTimeout does
+//
+// USAGE:
+// if err := guardProjectSize(wizardData, true); err != nil { ... }
+// if err := confirmOutputSize(files, cfg); err != nil { ... }
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Default output limits.
+const (
+	defaultMaxFiles = 500
+	defaultMaxSize  = 50 << 20 // 50MB
+)
+
+// sizeUnits are the suffixes maxSize accepts, in bytes.
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// outputSize is how much a project would write.
+type outputSize struct {
+	Files int
+	Bytes int64
+}
+
+// outputLimits are the most a project may write without confirmation.
+type outputLimits struct {
+	Files int
+	Bytes int64
+}
+
+// measureOutput totals files.
+func measureOutput(files []RenderedFile) outputSize {
+	size := outputSize{Files: len(files)}
+	for _, f := range files {
+		size.Bytes += int64(len(f.Content))
+	}
+	return size
+}
+
+// parseSize parses a size such as "50MB", "512 KB" or a bare number of bytes.
+func parseSize(value string) (int64, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, false
+	}
+	unit := int64(1)
+	for _, u := range sizeUnits {
+		if number, ok := strings.CutSuffix(value, u.suffix); ok {
+			value, unit = strings.TrimSpace(number), u.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 || n > (1<<62)/unit {
+		return 0, false
+	}
+	return n * unit, true
+}
+
+// formatSize renders bytes in the largest unit it fills, e.g. "63.2MB".
+func formatSize(bytes int64) string {
+	for _, u := range sizeUnits[:len(sizeUnits)-1] {
+		if bytes >= u.bytes {
+			return strconv.FormatFloat(float64(bytes)/float64(u.bytes), 'f', 1, 64) + u.suffix
+		}
+	}
+	return fmt.Sprintf("%dB", bytes)
+}
+
+// outputLimitsFrom returns cfg's limits, with defaults for unset values.
+func outputLimitsFrom(cfg userConfig) outputLimits {
+	limits := outputLimits{Files: defaultMaxFiles, Bytes: defaultMaxSize}
+	if cfg.MaxFiles > 0 {
+		limits.Files = cfg.MaxFiles
+	}
+	if n, ok := parseSize(cfg.MaxSize); ok {
+		limits.Bytes = n
+	}
+	return limits
+}
+
+// lowerMaxSize returns whichever maxSize setting is smaller, ignoring unset
+// or unparseable ones.
+func lowerMaxSize(a, b string) string {
+	na, okA := parseSize(a)
+	nb, okB := parseSize(b)
+	if !okA || (okB && nb < na) {
+		return b
+	}
+	return a
+}
+
+// errOutputTooLarge is returned when a project is over a limit and nobody
+// could be asked.
+var errOutputTooLarge = errors.New("project exceeds the output limits")
+
+// exceeds describes each limit size goes over, or returns nil.
+func (limits outputLimits) exceeds(size outputSize) []string {
+	var over []string
+	if size.Files > limits.Files {
+		over = append(over, T("sizeGuard.files", size.Files, limits.Files))
+	}
+	if size.Bytes > limits.Bytes {
+		over = append(over, T("sizeGuard.bytes", formatSize(size.Bytes), formatSize(limits.Bytes)))
+	}
+	return over
+}
+
+// checkOutputSize fails when files go over cfg's limits.
+func checkOutputSize(files []RenderedFile, cfg userConfig) error {
+	if over := outputLimitsFrom(cfg).exceeds(measureOutput(files)); len(over) > 0 {
+		return fmt.Errorf("%w: %s (%s)", errOutputTooLarge, strings.Join(over, ", "), T("sizeGuard.raise"))
+	}
+	return nil
+}
+
+// confirmOutputSize asks before writing files past cfg's limits. Without
+// a terminal to ask on, it fails as checkOutputSize does.
+func confirmOutputSize(files []RenderedFile, cfg userConfig) error {
+	over := outputLimitsFrom(cfg).exceeds(measureOutput(files))
+	if len(over) == 0 {
+		return nil
+	}
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return checkOutputSize(files, cfg)
+	}
+	var confirm bool
+	err := huh.NewConfirm().
+		Title(T("sizeGuard.confirm", strings.Join(over, ", "))).
+		Description(T("sizeGuard.confirmHint")).
+		Value(&confirm).
+		Run()
+	if err != nil {
+		return fmt.Errorf("cancelled: %w", err)
+	}
+	if !confirm {
+		return fmt.Errorf("%w -> %s", errAborted, T("sizeGuard.declined"))
+	}
+	return nil
+}
+
+// guardProjectSize renders the project wizardData describes and checks it
+// against the configured limits, asking to go past them when ask is set.
+func guardProjectSize(wizardData WizardData, ask bool) error {
+	data := wizardData.ToTemplateData()
+	data.Year = seedNow().Year()
+	files, err := renderProjectFiles(data)
+	if err != nil {
+		return err
+	}
+	cfg, _ := loadConfig()
+	if ask {
+		return confirmOutputSize(files, cfg)
+	}
+	return checkOutputSize(files, cfg)
+}
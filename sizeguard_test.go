@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+		ok    bool
+	}{
+		{"50MB", 50 << 20, true},
+		{"512 kb", 512 << 10, true},
+		{"2GB", 2 << 30, true},
+		{"4096", 4096, true},
+		{"10B", 10, true},
+		{"", 0, false},
+		{"0MB", 0, false},
+		{"-1", 0, false},
+		{"1.5MB", 0, false},
+		{"lots", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSize(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseSize(%q) = %d, %t, want %d, %t", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCheckOutputSize(t *testing.T) {
+	files := []RenderedFile{{Path: "a", Content: make([]byte, 600)}, {Path: "b", Content: make([]byte, 600)}}
+	tests := []struct {
+		name string
+		cfg  userConfig
+		want string // "" when within limits
+	}{
+		{"defaults", userConfig{}, ""},
+		{"too many files", userConfig{MaxFiles: 1}, "2 files (limit 1)"},
+		{"too large", userConfig{MaxSize: "1KB"}, "1.2KB (limit 1.0KB)"},
+		{"unparseable size uses the default", userConfig{MaxSize: "huge"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOutputSize(files, tt.cfg)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("checkOutputSize() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, errOutputTooLarge) || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "maxFiles") {
+				t.Errorf("checkOutputSize() = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeConfigOutputLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		org, user userConfig
+		files     int
+		size      string
+	}{
+		{"org only", userConfig{MaxFiles: 100, MaxSize: "10MB"}, userConfig{}, 100, "10MB"},
+		{"user lowers", userConfig{MaxFiles: 100, MaxSize: "10MB"}, userConfig{MaxFiles: 50, MaxSize: "5MB"}, 50, "5MB"},
+		{"user can't raise", userConfig{MaxFiles: 100, MaxSize: "10MB"}, userConfig{MaxFiles: 900, MaxSize: "1GB"}, 100, "10MB"},
+		{"user only", userConfig{}, userConfig{MaxFiles: 900, MaxSize: "1GB"}, 900, "1GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeConfig(tt.org, tt.user)
+			if merged.MaxFiles != tt.files || merged.MaxSize != tt.size {
+				t.Errorf("merged limits = %d, %q, want %d, %q", merged.MaxFiles, merged.MaxSize, tt.files, tt.size)
+			}
+		})
+	}
+}
+
+func TestBatchRefusesOversizedProject(t *testing.T) {
+	isolateConfig(t)
+	if err := saveUserConfig(userConfig{MaxFiles: 3}); err != nil {
+		t.Fatal(err)
+	}
+	target := tempDir(t)
+	_, err := scaffoldBatchProject(BatchProject{Name: "big", Path: target, Answers: WizardData{ProjectName: "big", Description: "Too many files"}})
+	if !errors.Is(err, errOutputTooLarge) {
+		t.Fatalf("scaffoldBatchProject() = %v, want errOutputTooLarge", err)
+	}
+	if files, _ := snapshotProjectFiles(target); len(files) != 0 {
+		t.Errorf("nothing should be written, found %v", files)
+	}
+}
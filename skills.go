@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 )
@@ -40,6 +41,37 @@ func InstallSkills(targetDir string) error {
 	return err
 }
 
+// skillFiles returns every embedded skill as a RenderedFile under skills/,
+// sorted by name, followed by the skills/README.md index. Used when the project is rendered somewhere other than a
+// directory (e.g. an archive).
+func skillFiles() ([]RenderedFile, error) {
+	entries, err := fs.ReadDir(skillsFS, "skills")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded skills: %w", err)
+	}
+
+	var files []RenderedFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := skillsFS.ReadFile(path.Join("skills", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read skill %s: %w", entry.Name(), err)
+		}
+		files = append(files, RenderedFile{Path: "skills/" + entry.Name(), Content: content, Mode: 0644})
+	}
+	return stampFiles(append(files, skillsIndex(files))), nil
+}
+
+// projectSkillFiles returns the skills a project gets: none with --no-skills.
+func projectSkillFiles(data TemplateData) ([]RenderedFile, error) {
+	if data.NoSkills {
+		return nil, nil
+	}
+	return skillFiles()
+}
+
 // installSkillsWithReport performs skills installation and returns structured
 // reporting data so the caller can handle all user-facing output centrally.
 func installSkillsWithReport(targetDir string) (skillsInstallReport, error) {
@@ -60,31 +92,26 @@ func installSkillsWithReport(targetDir string) (skillsInstallReport, error) {
 		return report, fmt.Errorf("failed to create skills directory: %w", err)
 	}
 
-	// Walk embedded skills and copy each one
-	entries, err := fs.ReadDir(skillsFS, "skills")
+	// Copy each embedded skill the project's .seedignore doesn't exclude
+	files, err := skillFiles()
 	if err != nil {
-		return report, fmt.Errorf("failed to read embedded skills: %w", err)
+		return report, err
+	}
+	if files, err = dropIgnored(targetDir, files); err != nil {
+		return report, err
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		outputPath := filepath.Join(skillsDir, entry.Name())
+	for _, file := range files {
+		name := path.Base(file.Path)
+		outputPath := filepath.Join(skillsDir, name)
 
 		// Skip files that already exist to avoid clobbering user modifications
 		if _, err := os.Stat(outputPath); err == nil {
-			report.Skipped = append(report.Skipped, entry.Name())
+			report.Skipped = append(report.Skipped, name)
 			continue
 		}
 
-		content, err := skillsFS.ReadFile(filepath.Join("skills", entry.Name()))
-		if err != nil {
-			return report, fmt.Errorf("failed to read skill %s: %w", entry.Name(), err)
-		}
-
-		if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		if err := writeFileAtomic(outputPath, file.Content, file.Mode); err != nil {
 			return report, fmt.Errorf("failed to write %s: %w", outputPath, err)
 		}
 	}
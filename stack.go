@@ -0,0 +1,184 @@
+// Package main - stack.go
+//
+// PURPOSE:
+// This file describes the language stacks seed knows about, independently of
+// whether a dev container is generated. It's responsible for:
+// - The stack catalog: label, dev container image, build/test commands,
+//   .editorconfig section, VS Code settings/debug config/extensions and
+//   JetBrains backend per language
+// - Resolving the language of a project (answer, or the image for answers
+//   that predate the language question)
+// - The wizard's language and extension options (image options, with
+//   their sizes and tools, are in imagecatalog.go)
+//
+// DESIGN PATTERNS:
+// - One table drives .gitignore defaults, .editorconfig, README commands,
+//   .vscode/ configs and the dev container image; adding a stack is one
+//   entry here
+// - An empty language renders language-neutral output, exactly as before the
+//   question existed
+//
+// USAGE:
+// data.Stack = stackFor(data.Language)
+// lang := stackLanguage(w.Language, w.DevContainerImage)
+
+package main
+
+import "github.com/charmbracelet/huh"
+
+// stack is a language seed can tailor a project to.
+type stack struct {
+	ID           string         // Language answer; also its .gitignore set ID
+	Label        string         // Shown in the wizard
+	Image        string         // Dev container image tag (MCR defaults at time of release)
+	Commands     []string       // Typical setup, build and test commands, shown in README Quick Start
+	Setup        string         // First command after scaffolding, suggested in the next steps
+	Build, Test  string         // Commands behind the VS Code build and test tasks
+	EditorConfig string         // .editorconfig section for the language's files ("" for none)
+	Settings     map[string]any // Language entries for .vscode/settings.json
+	Launch       vscodeLaunch   // Debug configuration for .vscode/launch.json
+	Extensions   []string       // Curated VS Code extensions, preselected in the wizard
+	JetBrains    string         // JetBrains Gateway backend ("" for IntelliJ)
+}
+
+// stacks lists the supported languages in wizard order.
+// Image tags reference MCR defaults at time of release.
+// Check https://mcr.microsoft.com for current versions.
+var stacks = []stack{
+	{
+		ID: "go", Label: "Go", Image: "go:2-1.25-trixie",
+		Commands: []string{"go build ./...", "go test ./..."},
+		Setup:    "go mod tidy",
+		Build:    "go build ./...", Test: "go test ./...",
+		EditorConfig: "[*.go]\nindent_style = tab",
+		Settings:     map[string]any{"[go]": map[string]any{"editor.defaultFormatter": "golang.go"}},
+		Launch:       vscodeLaunch{Name: "Launch package", Type: "go", Request: "launch", Mode: "auto", Program: "${workspaceFolder}"},
+		Extensions:   []string{"golang.go"},
+		JetBrains:    "GoLand",
+	},
+	{
+		ID: "node", Label: "Node/TypeScript", Image: "typescript-node:20-bookworm",
+		Commands: []string{"npm install", "npm test"},
+		Setup:    "npm install",
+		Build:    "npm run build", Test: "npm test",
+		Settings: map[string]any{
+			"[javascript]": map[string]any{"editor.defaultFormatter": "esbenp.prettier-vscode"},
+			"[typescript]": map[string]any{"editor.defaultFormatter": "esbenp.prettier-vscode"},
+		},
+		Launch:     vscodeLaunch{Name: "npm start", Type: "node", Request: "launch", RuntimeExecutable: "npm", RuntimeArgs: []string{"start"}},
+		Extensions: []string{"dbaeumer.vscode-eslint", "esbenp.prettier-vscode"},
+		JetBrains:  "WebStorm",
+	},
+	{
+		ID: "python", Label: "Python", Image: "python:3-3.12",
+		Commands: []string{"python -m venv .venv && . .venv/bin/activate", "pip install -e .", "pytest"},
+		Setup:    "python -m venv .venv && . .venv/bin/activate",
+		Build:    "pip install -e .", Test: "pytest",
+		EditorConfig: "[*.py]\nindent_size = 4",
+		Settings: map[string]any{
+			"[python]":                     map[string]any{"editor.defaultFormatter": "charliermarsh.ruff"},
+			"python.testing.pytestEnabled": true,
+		},
+		Launch:     vscodeLaunch{Name: "Python: current file", Type: "debugpy", Request: "launch", Program: "${file}", Console: "integratedTerminal"},
+		Extensions: []string{"ms-python.python", "charliermarsh.ruff"},
+		JetBrains:  "PyCharm",
+	},
+	{
+		ID: "rust", Label: "Rust", Image: "rust:1-bookworm",
+		Commands: []string{"cargo build", "cargo test"},
+		Setup:    "cargo build",
+		Build:    "cargo build", Test: "cargo test",
+		EditorConfig: "[*.rs]\nindent_size = 4",
+		Settings:     map[string]any{"[rust]": map[string]any{"editor.defaultFormatter": "rust-lang.rust-analyzer"}},
+		Launch:       vscodeLaunch{Name: "Debug", Type: "lldb", Request: "launch", Cargo: &vscodeCargo{Args: []string{"build"}}},
+		Extensions:   []string{"rust-lang.rust-analyzer", "vadimcn.vscode-lldb"},
+		JetBrains:    "RustRover",
+	},
+	{
+		ID: "java", Label: "Java", Image: "java",
+		Commands: []string{"./gradlew build", "./gradlew test"},
+		Setup:    "./gradlew build",
+		Build:    "./gradlew build", Test: "./gradlew test",
+		EditorConfig: "[*.java]\nindent_size = 4",
+		Settings:     map[string]any{"java.configuration.updateBuildConfiguration": "automatic"},
+		Launch:       vscodeLaunch{Name: "Launch current file", Type: "java", Request: "launch", MainClass: "${file}"},
+		Extensions:   []string{"vscjava.vscode-java-pack"},
+	},
+	{
+		ID: "dotnet", Label: ".NET", Image: "dotnet",
+		Commands: []string{"dotnet build", "dotnet test"},
+		Setup:    "dotnet restore",
+		Build:    "dotnet build", Test: "dotnet test",
+		EditorConfig: "[*.cs]\nindent_size = 4",
+		Settings:     map[string]any{"[csharp]": map[string]any{"editor.defaultFormatter": "ms-dotnettools.csharp"}},
+		Launch:       vscodeLaunch{Name: "C#: Launch startup project", Type: "dotnet", Request: "launch"},
+		Extensions:   []string{"ms-dotnettools.csdevkit"},
+		JetBrains:    "Rider",
+	},
+	{
+		ID: "cpp", Label: "C++", Image: "cpp",
+		Commands: []string{"cmake -B build && cmake --build build", "ctest --test-dir build"},
+		Setup:    "cmake -B build",
+		Build:    "cmake -B build && cmake --build build", Test: "ctest --test-dir build",
+		EditorConfig: "[*.{c,cc,cpp,h,hpp}]\nindent_size = 4",
+		Settings:     map[string]any{"[cpp]": map[string]any{"editor.defaultFormatter": "ms-vscode.cpptools"}},
+		Launch:       vscodeLaunch{Name: "Debug", Type: "cppdbg", Request: "launch", Program: "${workspaceFolder}/build/${workspaceFolderBasename}", Cwd: "${workspaceFolder}", MIMode: "gdb"},
+		Extensions:   []string{"ms-vscode.cpptools", "ms-vscode.cmake-tools"},
+		JetBrains:    "CLion",
+	},
+}
+
+// stackFor returns the stack for a language answer, or nil for "" and
+// unknown languages.
+func stackFor(language string) *stack {
+	for i := range stacks {
+		if stacks[i].ID == language {
+			return &stacks[i]
+		}
+	}
+	return nil
+}
+
+// stackLanguage returns language, or for answers without one, the language
+// implied by the dev container image ("" if neither says).
+func stackLanguage(language, image string) string {
+	if language != "" {
+		return language
+	}
+	for _, s := range stacks {
+		if s.Image == image {
+			return s.ID
+		}
+	}
+	return ""
+}
+
+// languageOptions offers every stack plus "other".
+func languageOptions() []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(stacks)+1)
+	for _, s := range stacks {
+		options = append(options, huh.NewOption(s.Label, s.ID))
+	}
+	return append(options, huh.NewOption(T("wizard.language.other"), ""))
+}
+
+// agentExtensions are the AI agent extensions the wizard offers.
+var agentExtensions = []struct{ Label, ID string }{
+	{"Claude Code", "anthropics.claude-code"},
+	{"Codex", "openai.chatgpt"},
+}
+
+// extensionOptions offers the agent extensions, then the language's curated
+// set, preselected.
+func extensionOptions(language string) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, ext := range agentExtensions {
+		options = append(options, huh.NewOption(ext.Label, ext.ID))
+	}
+	if s := stackFor(language); s != nil {
+		for _, id := range s.Extensions {
+			options = append(options, huh.NewOption(id, id).Selected(true))
+		}
+	}
+	return options
+}
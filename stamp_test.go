@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStampFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		content   string
+		wantFirst string // expected prefix of the first line ("" = unstamped)
+	}{
+		{"markdown", "README.md", "# Title\n", "<!-- seed:generated "},
+		{"skill", "skills/entropy-guard.md", "# Skill\n", "<!-- seed:generated "},
+		{"dotfile", ".gitignore", "*.log\n", "# seed:generated "},
+		{"dockerfile", ".devcontainer/Dockerfile", "FROM x\n", "# seed:generated "},
+		{"shebang kept first", ".devcontainer/setup.sh", "#!/bin/bash\necho hi\n", "#!/bin/bash"},
+		{"json unstamped", ".devcontainer/devcontainer.json", "{}\n", ""},
+		{"license unstamped", "LICENSE", "MIT License\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stampFile(RenderedFile{Path: tt.path, Content: []byte(tt.content)})
+			if tt.wantFirst == "" {
+				if string(got.Content) != tt.content {
+					t.Errorf("expected unchanged content, got %q", got.Content)
+				}
+				return
+			}
+			if !strings.HasPrefix(string(got.Content), tt.wantFirst) {
+				t.Errorf("content should start with %q, got %q", tt.wantFirst, got.Content)
+			}
+
+			st, body, ok := parseStamp(got.Content)
+			if !ok {
+				t.Fatalf("stamp not found in %q", got.Content)
+			}
+			if string(body) != tt.content {
+				t.Errorf("body = %q, want %q", body, tt.content)
+			}
+			if st.Version != displayVersion() || st.Templates != templateVersion {
+				t.Errorf("stamp = %+v", st)
+			}
+			if st.SHA256 != hashContent(body)[:stampHashLen] {
+				t.Errorf("stamp hash %s doesn't cover the body", st.SHA256)
+			}
+		})
+	}
+}
+
+func TestSameGeneratedIgnoresStamp(t *testing.T) {
+	a := []byte("<!-- seed:generated version=1.0.0 templates=1 sha256=aaaaaaaaaaaa -->\n# Doc\n")
+	b := []byte("<!-- seed:generated version=1.1.0 templates=1 sha256=aaaaaaaaaaaa -->\n# Doc\n")
+	c := []byte("<!-- seed:generated version=1.1.0 templates=2 sha256=bbbbbbbbbbbb -->\n# Doc v2\n")
+
+	if !sameGenerated(a, b) {
+		t.Error("files differing only in stamp should be the same")
+	}
+	if sameGenerated(a, c) {
+		t.Error("files with different bodies should differ")
+	}
+}
@@ -0,0 +1,171 @@
+// Package main - standards.go
+//
+// PURPOSE:
+// This file describes the linters and formatters seed can set up per stack.
+// It's responsible for:
+// - The catalog: golangci-lint, Ruff+Black, ESLint+Prettier, Clippy+rustfmt
+// - Each tool's config files, lint/format commands and CI steps
+// - Rendering .github/workflows/lint.yml for the chosen tools
+// - The wizard's options for a language
+//
+// DESIGN PATTERNS:
+// - Data, not template branches (like gitignore.go): adding a tool is one
+//   catalog entry
+// - The same commands feed AGENTS.md, the VS Code lint/format tasks and CI,
+//   so the three never disagree
+// - Config files hold the tool's defaults plus what makes the pair agree
+//   (e.g. Ruff's line length matching Black); projects tune them from there
+//
+// USAGE:
+// for _, cs := range data.CodingStandards() { ... }
+
+package main
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/charmbracelet/huh"
+)
+
+// lintWorkflowPath is where the CI workflow for the chosen tools is written.
+const lintWorkflowPath = ".github/workflows/lint.yml"
+
+// codingStandard is a linter/formatter pair for one stack.
+type codingStandard struct {
+	ID        string
+	Label     string // Shown in the wizard and as the CI step names
+	Language  string // Stack ID it applies to
+	Files     []RenderedFile
+	Install   string // Installs the tools, locally and in CI
+	Lint      string // Reports problems
+	Format    string // Rewrites files in place
+	Check     string // Fails on unformatted files without changing them (CI)
+	Toolchain ciStep // CI step that sets up the language toolchain
+}
+
+// ciStep is a GitHub Actions `uses:` step. With values are written as-is,
+// so quote anything YAML would misread (e.g. "3.12").
+type ciStep struct {
+	Uses string
+	With map[string]string
+}
+
+// codingStandards lists the supported tools, in wizard order within a stack.
+var codingStandards = []codingStandard{
+	{
+		ID: "golangci-lint", Label: "golangci-lint", Language: "go",
+		Files: []RenderedFile{{Path: ".golangci.yml", Mode: 0644, Content: []byte(
+			"version: \"2\"\n\nlinters:\n  default: standard\n\nformatters:\n  enable:\n    - gofmt\n    - goimports\n")}},
+		Install:   "go install github.com/golangci/golangci-lint/v2/cmd/golangci-lint@latest",
+		Lint:      "golangci-lint run",
+		Format:    "golangci-lint fmt",
+		Check:     "golangci-lint fmt --diff",
+		Toolchain: ciStep{Uses: "actions/setup-go@v5", With: map[string]string{"go-version": "stable"}},
+	},
+	{
+		ID: "ruff-black", Label: "Ruff + Black", Language: "python",
+		Files: []RenderedFile{{Path: "ruff.toml", Mode: 0644, Content: []byte(
+			"# Black's line length, so the two agree\nline-length = 88\n\n[lint]\nselect = [\"E\", \"F\", \"I\", \"B\", \"UP\"]\n")}},
+		Install:   "pip install ruff black",
+		Lint:      "ruff check .",
+		Format:    "black .",
+		Check:     "black --check .",
+		Toolchain: ciStep{Uses: "actions/setup-python@v5", With: map[string]string{"python-version": `"3.12"`}},
+	},
+	{
+		ID: "eslint-prettier", Label: "ESLint + Prettier", Language: "node",
+		Files: []RenderedFile{
+			{Path: "eslint.config.mjs", Mode: 0644, Content: []byte(
+				"import js from \"@eslint/js\";\nimport prettier from \"eslint-config-prettier\";\nimport tseslint from \"typescript-eslint\";\n\n" +
+					"// Prettier last: it turns off the rules that would fight the formatter\n" +
+					"export default tseslint.config(js.configs.recommended, tseslint.configs.recommended, prettier);\n")},
+			{Path: ".prettierrc.json", Mode: 0644, Content: []byte("{}\n")},
+		},
+		Install:   "npm install --save-dev eslint @eslint/js typescript-eslint typescript eslint-config-prettier prettier",
+		Lint:      "npx eslint .",
+		Format:    "npx prettier --write .",
+		Check:     "npx prettier --check .",
+		Toolchain: ciStep{Uses: "actions/setup-node@v4", With: map[string]string{"node-version": "20"}},
+	},
+	{
+		ID: "clippy", Label: "Clippy + rustfmt", Language: "rust",
+		Files:     []RenderedFile{{Path: "rustfmt.toml", Mode: 0644, Content: []byte("edition = \"2021\"\n")}},
+		Install:   "rustup component add clippy rustfmt",
+		Lint:      "cargo clippy --all-targets -- -D warnings",
+		Format:    "cargo fmt",
+		Check:     "cargo fmt --check",
+		Toolchain: ciStep{Uses: "dtolnay/rust-toolchain@stable", With: map[string]string{"components": "clippy, rustfmt"}},
+	},
+}
+
+// codingStandardFor returns the catalog entry with id, or nil.
+func codingStandardFor(id string) *codingStandard {
+	for i := range codingStandards {
+		if codingStandards[i].ID == id {
+			return &codingStandards[i]
+		}
+	}
+	return nil
+}
+
+// CodingStandards returns the chosen linters and formatters, in answer order.
+func (d TemplateData) CodingStandards() []codingStandard {
+	var chosen []codingStandard
+	for _, id := range d.Standards {
+		if cs := codingStandardFor(id); cs != nil {
+			chosen = append(chosen, *cs)
+		}
+	}
+	return chosen
+}
+
+// validateStandards rejects unknown tools and tools for another language.
+func validateStandards(ids []string, language string) error {
+	for _, id := range ids {
+		cs := codingStandardFor(id)
+		if cs == nil {
+			return errors.New(T("validate.standard", id))
+		}
+		if cs.Language != language {
+			return errors.New(T("validate.standardLanguage", id, cs.Language))
+		}
+	}
+	return nil
+}
+
+// standardOptions offers the tools for language.
+func standardOptions(language string) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, cs := range codingStandards {
+		if cs.Language == language {
+			options = append(options, huh.NewOption(cs.Label, cs.ID))
+		}
+	}
+	return options
+}
+
+// hasStandards reports whether seed has tools for language.
+func hasStandards(language string) bool {
+	return slices.ContainsFunc(codingStandards, func(cs codingStandard) bool { return cs.Language == language })
+}
+
+// renderCodingStandards returns the chosen tools' config files, then the
+// lint workflow.
+func (s *Scaffolder) renderCodingStandards(data TemplateData) ([]RenderedFile, error) {
+	var files []RenderedFile
+	for _, cs := range data.CodingStandards() {
+		for _, f := range cs.Files {
+			f.Content = slices.Clone(f.Content)
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	workflow, err := s.renderFile("lint.yml.tmpl", lintWorkflowPath, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, workflow), nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderCodingStandards(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cs := range codingStandards {
+		t.Run(cs.ID, func(t *testing.T) {
+			files, err := s.Render(TemplateData{ProjectName: "lint", Description: "Lint test", Language: cs.Language, VSCodeConfig: true, Standards: []string{cs.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			byPath := map[string]string{}
+			for _, f := range files {
+				byPath[f.Path] = string(f.Content)
+			}
+			for _, f := range cs.Files {
+				if _, ok := byPath[f.Path]; !ok {
+					t.Errorf("missing %s", f.Path)
+				}
+			}
+
+			workflow := byPath[lintWorkflowPath]
+			for _, want := range []string{"uses: " + cs.Toolchain.Uses, "run: " + cs.Install, "run: " + cs.Check, "run: " + cs.Lint} {
+				if !strings.Contains(workflow, want) {
+					t.Errorf("%s missing %q:\n%s", lintWorkflowPath, want, workflow)
+				}
+			}
+			if !strings.Contains(byPath["AGENTS.md"], "- `"+cs.Lint+"` — lint") {
+				t.Errorf("AGENTS.md should list %q", cs.Lint)
+			}
+
+			var tasks struct {
+				Tasks []vscodeTask `json:"tasks"`
+			}
+			if err := json.Unmarshal([]byte(byPath[".vscode/tasks.json"]), &tasks); err != nil {
+				t.Fatal(err)
+			}
+			var commands []string
+			for _, task := range tasks.Tasks {
+				commands = append(commands, task.Command)
+			}
+			if got := strings.Join(commands, "|"); !strings.Contains(got, cs.Lint+"|"+cs.Format) {
+				t.Errorf("tasks.json commands = %s", got)
+			}
+		})
+	}
+}
+
+func TestNoCodingStandards(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := s.Render(TemplateData{ProjectName: "plain", Description: "No linters", Language: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if f.Path == lintWorkflowPath || f.Path == ".golangci.yml" {
+			t.Errorf("unexpected %s without standards", f.Path)
+		}
+	}
+}
+
+func TestValidateStandards(t *testing.T) {
+	if err := validateStandards([]string{"clippy"}, "rust"); err != nil {
+		t.Errorf("clippy for rust: %v", err)
+	}
+	if err := validateStandards([]string{"clippy"}, "go"); err == nil || !strings.Contains(err.Error(), "rust projects") {
+		t.Errorf("expected a language mismatch, got %v", err)
+	}
+	if err := validateStandards([]string{"pylint"}, "python"); err == nil {
+		t.Error("expected an unknown tool to be refused")
+	}
+	if hasStandards("java") || !hasStandards("node") {
+		t.Error("hasStandards disagrees with the catalog")
+	}
+}
@@ -0,0 +1,148 @@
+// Package main - status.go
+//
+// PURPOSE:
+// This file implements `seed status`, a git-status-like view of scaffolded
+// content. It's responsible for:
+// - Comparing files on disk with the hashes recorded in the manifest
+// - Re-rendering current templates and skills with the recorded answers to
+//   find files that a newer seed would generate differently (or newly)
+// - Formatting the result for the terminal
+//
+// DESIGN PATTERNS:
+// - Read-only: never writes to the project
+// - Uses Scaffolder.Render + skillFiles, the same sources scaffolding uses
+//
+// USAGE:
+// report, err := projectStatus(scaffolder, "/path/to/project")
+// fmt.Print(formatStatus(report))
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// statusReport groups manifest-tracked files by their state.
+type statusReport struct {
+	Manifest  Manifest
+	Modified  []string // On disk, content differs from what seed generated
+	Deleted   []string // Recorded in the manifest but gone from disk
+	Updated   []string // Current templates render differently from the recorded version
+	Available []string // Current seed would generate these, but they were never recorded
+	Unchanged int      // Files identical to what seed generated
+}
+
+// Clean reports whether nothing was modified, deleted or is waiting upstream.
+func (r statusReport) Clean() bool {
+	return len(r.Modified) == 0 && len(r.Deleted) == 0 && len(r.Updated) == 0 && len(r.Available) == 0
+}
+
+// projectStatus compares the project at dir against its manifest and against
+// what the current templates would generate from the recorded answers.
+func projectStatus(s *Scaffolder, dir string) (statusReport, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return statusReport{}, err
+	}
+	report := statusReport{Manifest: manifest}
+	ignore, err := loadSeedIgnore(dir)
+	if err != nil {
+		return report, err
+	}
+
+	// Local drift: disk vs. recorded hashes
+	for _, f := range manifest.Files {
+		content, err := readGenerated(dir, f.Path)
+		switch {
+		case os.IsNotExist(err):
+			report.Deleted = append(report.Deleted, f.Path)
+		case err != nil:
+			return report, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		case hashContent(content) != f.SHA256:
+			report.Modified = append(report.Modified, f.Path)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	// Upstream drift: current render vs. recorded hashes
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return report, err
+	}
+	for _, f := range current {
+		recorded, ok := manifest.File(f.Path)
+		if !ok {
+			if ignore.Ignored(f.Path, false) {
+				continue // Excluded by .seedignore, so never offered
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Path))); os.IsNotExist(err) {
+				report.Available = append(report.Available, f.Path)
+			}
+			continue
+		}
+		if recorded.Outdated(f.Content) {
+			report.Updated = append(report.Updated, f.Path)
+		}
+	}
+
+	for _, list := range [][]string{report.Modified, report.Deleted, report.Updated, report.Available} {
+		sort.Strings(list)
+	}
+	return report, nil
+}
+
+// renderCurrent renders what the running seed would generate for the
+// manifest's recorded answers: templates plus skills (for an adopted
+// project, only those adopt manages).
+func renderCurrent(s *Scaffolder, manifest Manifest) ([]RenderedFile, error) {
+	files, err := s.Render(manifest.TemplateData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to render current templates: %w", err)
+	}
+	skills, err := projectSkillFiles(manifest.TemplateData())
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, skills...)
+	if manifest.Answers.Adopted {
+		files = adoptedFiles(manifest, files)
+	}
+	return files, nil
+}
+
+// formatStatus renders a status report in the style of `git status`.
+func formatStatus(r statusReport) string {
+	var b strings.Builder
+	b.WriteString(T("status.generated", r.Manifest.SeedVersion, r.Manifest.GeneratedAt.Format("2006-01-02")) + "\n")
+
+	if r.Clean() {
+		b.WriteString("\n" + T("status.clean", r.Unchanged) + "\n")
+		return b.String()
+	}
+
+	section := func(title, hint, label string, paths []string) {
+		if len(paths) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s\n", title)
+		if hint != "" {
+			b.WriteString(dimStyle.Render("  ("+hint+")") + "\n")
+		}
+		for _, p := range paths {
+			fmt.Fprintf(&b, "  %-12s %s\n", label+":", p)
+		}
+	}
+
+	section(T("status.modified"), "", T("status.modifiedLabel"), r.Modified)
+	section(T("status.deleted"), "", T("status.deletedLabel"), r.Deleted)
+	section(T("status.updated"), T("status.updatedHint"), T("status.updatedLabel"), r.Updated)
+	section(T("status.available"), T("status.availableHint"), T("status.availableLabel"), r.Available)
+
+	b.WriteString("\n" + T("status.unchanged", r.Unchanged) + "\n")
+	return b.String()
+}
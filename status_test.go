@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// mustScaffoldProject runs the full scaffold flow (templates, skills,
+// manifest) into a fresh directory and returns its path.
+func mustScaffoldProject(t *testing.T) string {
+	t.Helper()
+	target := tempDir(t)
+	answers := WizardData{ProjectName: "statustest", Description: "Status test project", License: "MIT"}
+	if _, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatalf("scaffoldProject: %v", err)
+	}
+	return target
+}
+
+func TestScaffoldProjectRecordsExtensionsVolume(t *testing.T) {
+	target := tempDir(t)
+	answers := WizardData{ProjectName: "voltest", Description: "Volume test project", IncludeDevContainer: true, DevContainerImage: "go:2-1.25-trixie"}
+	report, err := scaffoldProject(target, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard))
+	if err != nil {
+		t.Fatalf("scaffoldProject: %v", err)
+	}
+	if want := extensionsVolumeName("voltest", target); report.ExtensionsVolume != want {
+		t.Errorf("report volume = %q, want %q", report.ExtensionsVolume, want)
+	}
+
+	m, err := readManifest(target)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	if m.Answers.ExtensionsVolume != report.ExtensionsVolume {
+		t.Errorf("manifest should record the volume so re-rendering elsewhere matches, got %q", m.Answers.ExtensionsVolume)
+	}
+}
+
+func TestScaffoldProjectWritesManifest(t *testing.T) {
+	dir := mustScaffoldProject(t)
+
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	if m.Answers.ProjectName != "statustest" || m.Answers.License != "MIT" {
+		t.Errorf("answers not recorded: %+v", m.Answers)
+	}
+	if m.SeedVersion != Version || m.Year == 0 {
+		t.Errorf("version/year not recorded: %q %d", m.SeedVersion, m.Year)
+	}
+
+	for _, want := range []string{"README.md", "LICENSE", "skills/entropy-guard.md"} {
+		f, ok := m.File(want)
+		if !ok {
+			t.Errorf("manifest missing %s", want)
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(want)))
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if hashContent(content) != f.SHA256 {
+			t.Errorf("%s: recorded hash doesn't match file on disk", want)
+		}
+	}
+	if _, ok := m.File(manifestPath); ok {
+		t.Error("manifest should not list itself")
+	}
+}
+
+func TestProjectStatusClean(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+
+	report, err := projectStatus(s, dir)
+	if err != nil {
+		t.Fatalf("projectStatus: %v", err)
+	}
+	if !report.Clean() {
+		t.Errorf("fresh project should be clean, got %+v", report)
+	}
+	if report.Unchanged != len(report.Manifest.Files) {
+		t.Errorf("Unchanged = %d, want %d", report.Unchanged, len(report.Manifest.Files))
+	}
+	if out := formatStatus(report); !strings.Contains(out, "match what seed generated") {
+		t.Errorf("clean output unexpected:\n%s", out)
+	}
+}
+
+func TestProjectStatusDetectsDrift(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+
+	// Local edits: modify one file, delete another
+	writeTestFile(t, filepath.Join(dir, "README.md"), "# rewritten\n")
+	if err := os.Remove(filepath.Join(dir, "TODO.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate an older seed: LEARNINGS.md was generated with different
+	// content, and .editorconfig didn't exist yet
+	old := "old template output\n"
+	writeTestFile(t, filepath.Join(dir, "LEARNINGS.md"), old)
+	m.SetFile(RenderedFile{Path: "LEARNINGS.md", Content: []byte(old)})
+	m.Files = slices.DeleteFunc(m.Files, func(f ManifestFile) bool { return f.Path == ".editorconfig" })
+	if err := os.Remove(filepath.Join(dir, ".editorconfig")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+
+	report, err := projectStatus(s, dir)
+	if err != nil {
+		t.Fatalf("projectStatus: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want string
+	}{
+		{"modified", report.Modified, "README.md"},
+		{"deleted", report.Deleted, "TODO.md"},
+		{"updated", report.Updated, "LEARNINGS.md"},
+		{"available", report.Available, ".editorconfig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, []string{tt.want}) {
+				t.Errorf("got %v, want [%s]", tt.got, tt.want)
+			}
+		})
+	}
+
+	out := formatStatus(report)
+	for _, want := range []string{"modified:    README.md", "deleted:     TODO.md", "updated:     LEARNINGS.md", "missing:     .editorconfig"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	useLocale(t, "es")
+	out = formatStatus(report)
+	for _, want := range []string{"modificado:  README.md", "falta:       .editorconfig", "archivos generados sin cambios."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Spanish output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProjectStatusWithoutManifest(t *testing.T) {
+	s, _ := NewScaffolder()
+	if _, err := projectStatus(s, t.TempDir()); !errors.Is(err, errNoManifest) {
+		t.Errorf("err = %v, want errNoManifest", err)
+	}
+}
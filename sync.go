@@ -0,0 +1,125 @@
+// Package main - sync.go
+//
+// PURPOSE:
+// This file implements `seed --sync <dir>`, an idempotent re-run of
+// scaffolding against an existing project. It's responsible for:
+// - Rendering the current templates and skills from the recorded answers
+// - Creating only the files that seed has never generated there
+// - Recording them in the manifest and reporting exactly what was added
+//
+// DESIGN PATTERNS:
+// - Never touches an existing file, so running it twice is a no-op; template
+//   changes to existing files are `seed upgrade`'s job
+// - Files the manifest records but that are gone were deleted on purpose, as
+//   in an upgrade: they're reported, not recreated (`seed regen` restores one)
+// - Plan/apply split like upgrade.go: planSync is read-only
+//
+// USAGE:
+// plan, err := planSync(scaffolder, dir)
+// err = applySync(dir, plan)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// syncPlan is what a sync would add to a project.
+type syncPlan struct {
+	Manifest Manifest
+	Added    []RenderedFile // Files seed would generate that don't exist yet
+	Deleted  []string       // Recorded in the manifest but deleted locally; left alone
+}
+
+// planSync renders the current templates and skills from the project's
+// recorded answers and picks out the files that are missing and were never
+// generated.
+func planSync(s *Scaffolder, dir string) (syncPlan, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return syncPlan{}, err
+	}
+	if err := enforceSeedVersion(checkProjectSeedVersion(manifest)); err != nil {
+		return syncPlan{}, err
+	}
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return syncPlan{}, err
+	}
+	if current, err = dropIgnored(dir, current); err != nil {
+		return syncPlan{}, err
+	}
+
+	plan := syncPlan{Manifest: manifest}
+	for _, f := range current {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		switch {
+		case err == nil:
+			continue // Never touch an existing file
+		case !os.IsNotExist(err):
+			return plan, fmt.Errorf("failed to check %s: %w", f.Path, err)
+		}
+		if _, tracked := manifest.File(f.Path); tracked {
+			plan.Deleted = append(plan.Deleted, f.Path)
+			continue
+		}
+		plan.Added = append(plan.Added, f)
+	}
+	return plan, nil
+}
+
+// applySync writes the added files and records them in the manifest.
+func applySync(dir string, plan syncPlan) error {
+	if len(plan.Added) == 0 {
+		return nil
+	}
+	if err := writeFiles(dir, plan.Added); err != nil {
+		return err
+	}
+	m := plan.Manifest
+	for _, f := range plan.Added {
+		m.SetFile(f)
+	}
+	return writeManifest(dir, m)
+}
+
+// runSync handles `seed --sync <dir>`: it adds the files the current seed
+// would generate that the project doesn't have yet, and lists them.
+func runSync(opts cliOptions) error {
+	scaffolder, err := NewScaffolder()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scaffolder: %w", err)
+	}
+	plan, err := planSync(scaffolder, opts.TargetDir)
+	if err != nil {
+		return err
+	}
+	if err := applySync(opts.TargetDir, plan); err != nil {
+		return err
+	}
+	fmt.Print(formatSync(plan, opts.TargetDir))
+	return nil
+}
+
+// formatSync reports what a sync added and what it left alone.
+func formatSync(plan syncPlan, dir string) string {
+	var b strings.Builder
+	if len(plan.Added) == 0 {
+		b.WriteString(T("sync.nothing", dir, displayVersion()) + "\n")
+	} else {
+		b.WriteString(T("sync.added", len(plan.Added), displayVersion()) + "\n")
+		for _, f := range plan.Added {
+			fmt.Fprintf(&b, "  %s %s\n", successStyle.Render("+"), f.Path)
+		}
+	}
+	if len(plan.Deleted) > 0 {
+		b.WriteString("\n" + T("sync.deleted") + "\n")
+		for _, path := range plan.Deleted {
+			b.WriteString(dimStyle.Render("  "+path) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncAddsOnlyMissingFiles(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	// As if an older seed had generated the project: no LEARNINGS.md or
+	// entropy-guard skill yet, and an edited README
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"LEARNINGS.md", "skills/entropy-guard.md"} {
+		m.RemoveFile(path)
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "TODO.md")); err != nil { // Deleted on purpose
+		t.Fatal(err)
+	}
+	readme := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(readme, []byte("# Mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := planSync(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var added []string
+	for _, f := range plan.Added {
+		added = append(added, f.Path)
+	}
+	if strings.Join(added, ",") != "LEARNINGS.md,skills/entropy-guard.md" {
+		t.Errorf("added = %v", added)
+	}
+	if strings.Join(plan.Deleted, ",") != "TODO.md" {
+		t.Errorf("left deleted = %v", plan.Deleted)
+	}
+	if err := applySync(dir, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	if raw, _ := os.ReadFile(readme); string(raw) != "# Mine\n" {
+		t.Errorf("sync touched README.md: %q", raw)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "TODO.md")); !os.IsNotExist(err) {
+		t.Error("sync recreated a deleted file")
+	}
+	m, err = readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.File("LEARNINGS.md"); !ok {
+		t.Error("added files should be recorded in the manifest")
+	}
+	out := formatSync(plan, dir)
+	if !strings.Contains(out, "Added 2 files") || !strings.Contains(out, "seed regen") {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+
+	// A second run has nothing to do
+	again, err := planSync(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Added) != 0 {
+		t.Errorf("second sync would add %d files", len(again.Added))
+	}
+	if !strings.Contains(formatSync(again, dir), "Nothing to add") {
+		t.Error("expected a nothing-to-add report")
+	}
+}
@@ -0,0 +1,162 @@
+// Package main - telemetry.go
+//
+// PURPOSE:
+// This file implements strictly opt-in, anonymous usage telemetry. It's
+// responsible for:
+// - Asking for consent once, on the first interactive run, and remembering it
+// - Honouring SEED_TELEMETRY=off (and DO_NOT_TRACK=1) over any saved choice
+// - Sending one coarse event per scaffold: version, mode, stack and which
+//   components were enabled. Never names, descriptions, paths or file contents
+//
+// DESIGN PATTERNS:
+// - Dormant unless built with an endpoint (-X main.TelemetryEndpoint=...):
+//   builds without one never prompt and never send
+// - Best effort: a short timeout, and failures are ignored silently
+//
+// USAGE:
+// recordScaffold("wizard", wizardData, true)
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// TelemetryEndpoint receives telemetry events. Set at build time via
+// ldflags; empty disables telemetry entirely.
+var TelemetryEndpoint = ""
+
+// Values for userConfig.Telemetry.
+const (
+	telemetryOn  = "on"
+	telemetryOff = "off"
+)
+
+// telemetryTimeout bounds how long sending an event may delay exit.
+const telemetryTimeout = 2 * time.Second
+
+// telemetryEvent is everything telemetry ever sends.
+type telemetryEvent struct {
+	Event          string   `json:"event"`   // "scaffold"
+	Version        string   `json:"version"` // seed version
+	Mode           string   `json:"mode"`    // wizard, archive, print, batch
+	Stack          string   `json:"stack"`   // Dev container image, or "none"
+	License        string   `json:"license"`
+	InitGit        bool     `json:"initGit"`
+	DevContainer   bool     `json:"devContainer"`
+	ChatContinuity bool     `json:"chatContinuity"`
+	Extensions     []string `json:"extensions,omitempty"` // Public extension IDs
+	OS             string   `json:"os"`
+	Arch           string   `json:"arch"`
+}
+
+// newScaffoldEvent reduces wizard answers to the coarse fields telemetry
+// records. Project name and description are deliberately dropped.
+func newScaffoldEvent(mode string, data WizardData) telemetryEvent {
+	stack := "none"
+	if data.IncludeDevContainer && data.DevContainerImage != "" {
+		stack = data.DevContainerImage
+		if isImageReference(stack) {
+			stack = customImageOption // Custom references can name private registries
+		}
+	}
+	license := data.License
+	if license == "" {
+		license = "none"
+	}
+	return telemetryEvent{
+		Event:          "scaffold",
+		Version:        displayVersion(),
+		Mode:           mode,
+		Stack:          stack,
+		License:        license,
+		InitGit:        data.InitGit,
+		DevContainer:   data.IncludeDevContainer,
+		ChatContinuity: len(data.chatTools()) > 0,
+		Extensions:     data.AgentExtensions,
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+	}
+}
+
+// telemetryEnvOff reports whether the environment vetoes telemetry.
+func telemetryEnvOff() bool {
+	switch strings.ToLower(os.Getenv("SEED_TELEMETRY")) {
+	case "off", "0", "false", "no":
+		return true
+	}
+	return os.Getenv("DO_NOT_TRACK") == "1"
+}
+
+// telemetryEnabled reports whether an event may be sent: the build has an
+// endpoint, the environment doesn't veto it, and the user said yes.
+func telemetryEnabled(cfg userConfig) bool {
+	return TelemetryEndpoint != "" && !telemetryEnvOff() && cfg.Telemetry == telemetryOn
+}
+
+// recordScaffold sends a scaffold event if the user has opted in. When
+// interactive and the user hasn't been asked yet, it asks first. Errors are
+// swallowed: telemetry must never break or slow down scaffolding noticeably.
+func recordScaffold(mode string, data WizardData, interactive bool) {
+	if TelemetryEndpoint == "" || telemetryEnvOff() {
+		return
+	}
+	cfg, err := loadUserConfig()
+	if err != nil {
+		return
+	}
+	if cfg.Telemetry == "" && interactive && checkTerminal().Status == checkPass {
+		cfg.Telemetry = askTelemetryConsent()
+		_ = saveUserConfig(cfg)
+	}
+	if telemetryEnabled(cfg) {
+		_ = sendTelemetry(TelemetryEndpoint, newScaffoldEvent(mode, data))
+	}
+}
+
+// askTelemetryConsent shows the one-time consent prompt. Anything other than
+// an explicit yes (including cancelling) is a no.
+func askTelemetryConsent() string {
+	var yes bool
+	err := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().
+			Title(T("telemetry.prompt")).
+			Description(T("telemetry.promptHint")).
+			Affirmative(T("telemetry.yes")).
+			Negative(T("telemetry.no")).
+			Value(&yes),
+	)).WithOutput(wizardOutput).Run()
+	if err != nil || !yes {
+		return telemetryOff
+	}
+	return telemetryOn
+}
+
+// sendTelemetry posts ev as JSON to endpoint.
+func sendTelemetry(endpoint string, ev telemetryEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	client, err := httpClient(telemetryTimeout)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return nil
+}
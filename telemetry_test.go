@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScaffoldEventIsAnonymous(t *testing.T) {
+	data := WizardData{
+		ProjectName:         "secret-project",
+		Description:         "Internal billing rewrite",
+		License:             "MIT",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+		AgentExtensions:     []string{"anthropics.claude-code"},
+	}
+	raw, err := json.Marshal(newScaffoldEvent("wizard", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"secret-project", "billing"} {
+		if strings.Contains(string(raw), leaked) {
+			t.Errorf("event leaks %q: %s", leaked, raw)
+		}
+	}
+	for _, want := range []string{`"stack":"go:2-1.25-trixie"`, `"license":"MIT"`, `"mode":"wizard"`} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("event missing %s: %s", want, raw)
+		}
+	}
+}
+
+func TestTelemetryEnabled(t *testing.T) {
+	original := TelemetryEndpoint
+	t.Cleanup(func() { TelemetryEndpoint = original })
+
+	tests := []struct {
+		name     string
+		endpoint string
+		env      string
+		consent  string
+		want     bool
+	}{
+		{"opted in", "https://example.invalid", "", telemetryOn, true},
+		{"not asked", "https://example.invalid", "", "", false},
+		{"opted out", "https://example.invalid", "", telemetryOff, false},
+		{"env veto wins", "https://example.invalid", "off", telemetryOn, false},
+		{"no endpoint", "", "", telemetryOn, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TelemetryEndpoint = tt.endpoint
+			t.Setenv("SEED_TELEMETRY", tt.env)
+			t.Setenv("DO_NOT_TRACK", "")
+			if got := telemetryEnabled(userConfig{Telemetry: tt.consent}); got != tt.want {
+				t.Errorf("telemetryEnabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordScaffoldSendsOnlyWithConsent(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv("SEED_TELEMETRY", "")
+	t.Setenv("DO_NOT_TRACK", "")
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	original := TelemetryEndpoint
+	TelemetryEndpoint = server.URL
+	t.Cleanup(func() { TelemetryEndpoint = original })
+
+	data := WizardData{ProjectName: "x", Description: "y"}
+
+	// Not asked yet, non-interactive: nothing sent, nothing saved
+	recordScaffold("batch", data, false)
+	if len(received) != 0 {
+		t.Fatalf("sent without consent: %v", received)
+	}
+	if cfg, _ := loadUserConfig(); cfg.Telemetry != "" {
+		t.Errorf("non-interactive run should not record a choice, got %q", cfg.Telemetry)
+	}
+
+	if err := saveUserConfig(userConfig{Telemetry: telemetryOn}); err != nil {
+		t.Fatal(err)
+	}
+	recordScaffold("batch", data, false)
+	if len(received) != 1 || !strings.Contains(received[0], `"mode":"batch"`) {
+		t.Fatalf("expected one batch event, got %v", received)
+	}
+}
@@ -0,0 +1,267 @@
+// Package main - templateset.go
+//
+// PURPOSE:
+// This file loads a template pack (a directory of .tmpl files) on demand.
+// It's responsible for:
+// - Parsing each template the first time it's rendered, not all of them up front
+// - Caching parsed templates per pack for the life of the process
+// - Reporting parse errors with the template's file and line
+// - Reading each template's optional header, which declares how its output
+//   is written (e.g. the file mode) and, with "when", whether and where it's
+//   rendered
+//
+// DESIGN PATTERNS:
+// - One templateSet per pack, shared by every Scaffolder using it, so
+//   NewScaffolder costs nothing however many templates a pack holds
+// - Safe for the concurrent rendering in Render()
+// - Templates don't include each other, so each parses on its own
+// - The header is a template comment starting "{{/* seed", one "key: value"
+//   per line, so a template with one still parses as plain text/template;
+//   it's removed (with its line break) before parsing
+// - "when" and "path" values are text/template pipelines over the same data
+//   as the template, so a pack adds conditional files without Go changes
+//
+// USAGE:
+// set := templateSetFor("embedded", templatesFS, "templates")
+// err := set.ExecuteTemplate(&buf, "README.md.tmpl", data)
+// meta, err := set.Meta("check-docs.sh.tmpl") // meta.Mode == 0755
+// path, ok, err := set.Output("NOTICE.tmpl", data) // "NOTICE", License is Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// templateHeaderStart opens a template's header comment, followed by a line
+// break (\n, or \r\n in a pack checked out on Windows).
+const templateHeaderStart = "{{/* seed"
+
+// templateMeta is what a template's header declares about its output.
+type templateMeta struct {
+	Mode os.FileMode // "mode: 0755"; 0 means the default, 0644
+	Link string      // "link: AGENTS.md": the output is a symbolic link to this (assets.go)
+	When string      // "when: .Maintainer": Render includes the template whenever this holds
+	Path string      // "path: .github/workflows/agent.yml": the output path; default the name minus .tmpl
+
+	when, path *template.Template // When and Path, parsed
+}
+
+// parsedTemplate is a parsed template and its header.
+type parsedTemplate struct {
+	tmpl *template.Template
+	meta templateMeta
+}
+
+// templateSet is a template pack whose templates are parsed on first use.
+type templateSet struct {
+	fsys fs.FS
+	dir  string
+
+	mu     sync.Mutex
+	parsed map[string]parsedTemplate
+}
+
+// templateSets caches one templateSet per pack name (string → *templateSet).
+var templateSets sync.Map
+
+// templateSetFor returns the cached template set for the pack called name,
+// creating it over dir in fsys the first time the name is seen.
+func templateSetFor(name string, fsys fs.FS, dir string) *templateSet {
+	set, _ := templateSets.LoadOrStore(name, &templateSet{
+		fsys:   fsys,
+		dir:    dir,
+		parsed: map[string]parsedTemplate{},
+	})
+	return set.(*templateSet)
+}
+
+// lookup returns the parsed template name, parsing it on first use.
+func (ts *templateSet) lookup(name string) (parsedTemplate, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if t, ok := ts.parsed[name]; ok {
+		return t, nil
+	}
+
+	file := path.Join(ts.dir, name)
+	raw, err := fs.ReadFile(ts.fsys, file)
+	if err != nil {
+		return parsedTemplate{}, fmt.Errorf("template %s not found: %w", file, err)
+	}
+	meta, body, headerLines, err := splitTemplateHeader(file, string(raw))
+	if err != nil {
+		return parsedTemplate{}, err
+	}
+	t, err := template.New(name).Parse(body)
+	if err != nil {
+		return parsedTemplate{}, newTemplateParseError(file, err, headerLines)
+	}
+	ts.parsed[name] = parsedTemplate{tmpl: t, meta: meta}
+	return ts.parsed[name], nil
+}
+
+// ExecuteTemplate renders the template name with data into w.
+func (ts *templateSet) ExecuteTemplate(w io.Writer, name string, data any) error {
+	t, err := ts.lookup(name)
+	if err != nil {
+		return err
+	}
+	return t.tmpl.Execute(w, data)
+}
+
+// Meta returns what the template name's header declares.
+func (ts *templateSet) Meta(name string) (templateMeta, error) {
+	t, err := ts.lookup(name)
+	return t.meta, err
+}
+
+// Declared returns the names of the pack's templates whose header has a when
+// condition, in name order.
+func (ts *templateSet) Declared() ([]string, error) {
+	entries, err := fs.ReadDir(ts.fsys, ts.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates in %s: %w", ts.dir, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), templateSuffix) {
+			continue
+		}
+		meta, err := ts.Meta(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if meta.When != "" {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// Output evaluates the template name's when condition and path with data,
+// returning where its output goes and whether it's included. Templates
+// without a condition are always included.
+func (ts *templateSet) Output(name string, data any) (string, bool, error) {
+	meta, err := ts.Meta(name)
+	if err != nil {
+		return "", false, err
+	}
+	var buf strings.Builder
+	if meta.when != nil {
+		if err := meta.when.Execute(&buf, data); err != nil {
+			return "", false, fmt.Errorf("failed to evaluate when in %s: %w", name, err)
+		}
+		if buf.String() == "" {
+			return "", false, nil
+		}
+	}
+	if meta.path == nil {
+		return strings.TrimSuffix(name, templateSuffix), true, nil
+	}
+	buf.Reset()
+	if err := meta.path.Execute(&buf, data); err != nil {
+		return "", false, fmt.Errorf("failed to evaluate path in %s: %w", name, err)
+	}
+	p := strings.TrimSpace(buf.String())
+	if p == "" || path.IsAbs(p) || p != path.Clean(p) || strings.HasPrefix(p, "../") {
+		return "", false, fmt.Errorf("template %s: path %q must be a clean relative path inside the project", name, p)
+	}
+	return p, true, nil
+}
+
+// splitTemplateHeader parses and removes raw's header, if it has one,
+// returning the body and how many lines the header took.
+func splitTemplateHeader(file, raw string) (templateMeta, string, int, error) {
+	var meta templateMeta
+	rest, ok := strings.CutPrefix(raw, templateHeaderStart)
+	if !ok || !strings.HasPrefix(strings.TrimPrefix(rest, "\r"), "\n") {
+		return meta, raw, 0, nil
+	}
+	header, body, ok := strings.Cut(rest, "*/}}")
+	if !ok {
+		return meta, "", 0, &templateParseError{File: file, Line: 1, Msg: "header comment isn't closed with */}}"}
+	}
+	body = strings.TrimPrefix(strings.TrimPrefix(body, "\r"), "\n")
+	for i, line := range strings.Split(header, "\n")[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fail := func(msg string) error { return &templateParseError{File: file, Line: i + 2, Msg: msg} }
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return meta, "", 0, fail(fmt.Sprintf("header line %q isn't key: value", line))
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil || mode == 0 || mode > 0777 {
+				return meta, "", 0, fail(fmt.Sprintf("invalid mode %q (want octal permission bits, e.g. 0755)", value))
+			}
+			meta.Mode = os.FileMode(mode)
+		case "link":
+			if value == "" {
+				return meta, "", 0, fail("link needs a target")
+			}
+			meta.Link = value
+		case "when":
+			t, err := template.New(file + ":when").Parse("{{if " + value + "}}true{{end}}")
+			if err != nil || value == "" {
+				return meta, "", 0, fail(fmt.Sprintf("invalid when condition %q", value))
+			}
+			meta.When, meta.when = value, t
+		case "path":
+			t, err := template.New(file + ":path").Parse(value)
+			if err != nil || value == "" {
+				return meta, "", 0, fail(fmt.Sprintf("invalid path %q", value))
+			}
+			meta.Path, meta.path = value, t
+		default:
+			return meta, "", 0, fail(fmt.Sprintf("unknown header key %q", key))
+		}
+	}
+	return meta, body, strings.Count(raw[:len(raw)-len(body)], "\n"), nil
+}
+
+// templateParseError is a template that failed to parse.
+type templateParseError struct {
+	File string // Path within the pack, e.g. "templates/README.md.tmpl"
+	Line int    // 1-based line of the error (0 when unknown)
+	Msg  string // The parser's message without its "template: name:line:" prefix
+	Err  error
+}
+
+func (e *templateParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Msg)
+}
+
+func (e *templateParseError) Unwrap() error { return e.Err }
+
+// parseErrorPrefix matches text/template's "template: name:line: " prefix.
+var parseErrorPrefix = regexp.MustCompile(`^template: [^:]*:(\d+): `)
+
+// newTemplateParseError extracts the line from a text/template parse error,
+// adding offset for the header lines removed before parsing.
+func newTemplateParseError(file string, err error, offset int) error {
+	e := &templateParseError{File: file, Msg: err.Error(), Err: err}
+	if m := parseErrorPrefix.FindStringSubmatch(e.Msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Line += offset
+		e.Msg = e.Msg[len(m[0]):]
+	}
+	return e
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateSetLazyAndCached(t *testing.T) {
+	pack := fstest.MapFS{
+		"pack/good.tmpl": {Data: []byte("hello {{.}}")},
+		"pack/bad.tmpl":  {Data: []byte("line one\nline two {{if}}\n")},
+	}
+	set := templateSetFor("test-pack", pack, "pack")
+	if templateSetFor("test-pack", pack, "pack") != set {
+		t.Fatal("expected the same set for the same pack")
+	}
+
+	// A broken template doesn't stop the others from rendering
+	var buf bytes.Buffer
+	if err := set.ExecuteTemplate(&buf, "good.tmpl", "world"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "hello world" {
+		t.Fatalf("got %q", buf.String())
+	}
+	first := set.parsed["good.tmpl"]
+	if err := set.ExecuteTemplate(&buf, "good.tmpl", "again"); err != nil {
+		t.Fatal(err)
+	}
+	if set.parsed["good.tmpl"] != first {
+		t.Fatal("expected the parsed template to be cached")
+	}
+
+	err := set.ExecuteTemplate(&buf, "bad.tmpl", nil)
+	var parseErr *templateParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a templateParseError, got %v", err)
+	}
+	if parseErr.File != "pack/bad.tmpl" || parseErr.Line != 2 {
+		t.Fatalf("got %s:%d, want pack/bad.tmpl:2", parseErr.File, parseErr.Line)
+	}
+
+	if err := set.ExecuteTemplate(&buf, "missing.tmpl", nil); err == nil {
+		t.Fatal("expected an error for a missing template")
+	}
+}
+
+func TestTemplateHeader(t *testing.T) {
+	pack := fstest.MapFS{
+		"pack/script.sh.tmpl":  {Data: []byte("{{/* seed\nmode: 0755\n*/}}\n#!/bin/sh\necho {{.}}\n")},
+		"pack/plain.tmpl":      {Data: []byte("{{/* an ordinary comment */}}plain\n")},
+		"pack/bad-mode.tmpl":   {Data: []byte("{{/* seed\nmode: 9\n*/}}\nx\n")},
+		"pack/bad-key.tmpl":    {Data: []byte("{{/* seed\nmode: 0600\nowner: root\n*/}}\nx\n")},
+		"pack/unclosed.tmpl":   {Data: []byte("{{/* seed\nmode: 0600\n")},
+		"pack/bad-body.tmpl":   {Data: []byte("{{/* seed\nmode: 0600\n*/}}\nline one\n{{if}}\n")},
+		"pack/secret.env.tmpl": {Data: []byte("{{/* seed\r\nmode: 0600\r\n*/}}\r\nKEY=\r\n")},
+	}
+	set := templateSetFor("header-pack", pack, "pack")
+
+	var buf bytes.Buffer
+	if err := set.ExecuteTemplate(&buf, "script.sh.tmpl", "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "#!/bin/sh\necho hi\n" {
+		t.Errorf("header should be removed with its line break, got %q", buf.String())
+	}
+	tests := []struct {
+		name string
+		mode os.FileMode
+	}{
+		{"script.sh.tmpl", 0755},
+		{"plain.tmpl", 0},
+		{"secret.env.tmpl", 0600},
+	}
+	for _, tt := range tests {
+		meta, err := set.Meta(tt.name)
+		if err != nil || meta.Mode != tt.mode {
+			t.Errorf("Meta(%s) = %o, %v, want mode %o", tt.name, meta.Mode, err, tt.mode)
+		}
+	}
+
+	errorLines := map[string]int{"bad-mode.tmpl": 2, "bad-key.tmpl": 3, "unclosed.tmpl": 1, "bad-body.tmpl": 5}
+	for name, line := range errorLines {
+		var parseErr *templateParseError
+		if _, err := set.Meta(name); !errors.As(err, &parseErr) || parseErr.Line != line {
+			t.Errorf("Meta(%s) error = %v, want a parse error on line %d", name, err, line)
+		}
+	}
+}
+
+func TestTemplateWhen(t *testing.T) {
+	pack := fstest.MapFS{
+		"pack/README.md.tmpl":   {Data: []byte("# {{.ProjectName}}\n")},
+		"pack/NOTICE.tmpl":      {Data: []byte("{{/* seed\nwhen: eq .License \"Apache-2.0\"\n*/}}\nnotice\n")},
+		"pack/ci.yml.tmpl":      {Data: []byte("{{/* seed\nwhen: .AgentAction\npath: .github/workflows/{{.ProjectName}}.yml\n*/}}\non: push\n")},
+		"pack/always.md.tmpl":   {Data: []byte("{{/* seed\nwhen: true\n*/}}\nalways\n")},
+		"pack/escape.md.tmpl":   {Data: []byte("{{/* seed\nwhen: true\npath: ../outside.md\n*/}}\nx\n")},
+		"pack/sub/nested.tmpl":  {Data: []byte("{{/* seed\nwhen: true\n*/}}\nx\n")},
+		"pack/bad-when.md.tmpl": {Data: []byte("{{/* seed\nwhen: .License ==\n*/}}\nx\n")},
+	}
+	set := templateSetFor("when-pack", pack, "pack")
+
+	var parseErr *templateParseError
+	if _, err := set.Declared(); !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Fatalf("expected bad-when.md.tmpl's condition to fail on line 2, got %v", err)
+	}
+	delete(pack, "pack/bad-when.md.tmpl")
+	set = templateSetFor("when-pack-valid", pack, "pack")
+	declared, err := set.Declared()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"NOTICE.tmpl", "always.md.tmpl", "ci.yml.tmpl", "escape.md.tmpl"}; !slices.Equal(declared, want) {
+		t.Fatalf("Declared() = %v, want %v", declared, want)
+	}
+
+	tests := []struct {
+		name     string
+		data     TemplateData
+		wantPath string
+		wantOK   bool
+	}{
+		{"NOTICE.tmpl", TemplateData{License: "Apache-2.0"}, "NOTICE", true},
+		{"NOTICE.tmpl", TemplateData{License: "MIT"}, "", false},
+		{"ci.yml.tmpl", TemplateData{ProjectName: "api", AgentAction: true}, ".github/workflows/api.yml", true},
+		{"ci.yml.tmpl", TemplateData{ProjectName: "api"}, "", false},
+		{"always.md.tmpl", TemplateData{}, "always.md", true},
+		{"README.md.tmpl", TemplateData{}, "README.md", true},
+	}
+	for _, tt := range tests {
+		p, ok, err := set.Output(tt.name, tt.data)
+		if err != nil || p != tt.wantPath || ok != tt.wantOK {
+			t.Errorf("Output(%s) = %q, %t, %v; want %q, %t", tt.name, p, ok, err, tt.wantPath, tt.wantOK)
+		}
+	}
+	if _, _, err := set.Output("escape.md.tmpl", TemplateData{}); err == nil {
+		t.Error("expected a path leading out of the project to be refused")
+	}
+}
+
+// TestDeclaredTemplates checks that the embedded templates' when conditions
+// pick the files the wizard's answers call for.
+func TestDeclaredTemplates(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data TemplateData
+		want []string
+		not  []string
+	}{
+		{"mit", TemplateData{ProjectName: "a", License: "MIT"}, []string{"LICENSE"}, []string{"NOTICE", "LICENSE-MIT", "CONTRIBUTING.md", "SECURITY.md"}},
+		{"apache", TemplateData{ProjectName: "a", License: "Apache-2.0"}, []string{"LICENSE", "NOTICE", "CONTRIBUTING.md"}, []string{"LICENSE-APACHE"}},
+		{"dual", TemplateData{ProjectName: "a", License: dualLicense}, []string{"LICENSE-MIT", "LICENSE-APACHE"}, []string{"LICENSE", "NOTICE"}},
+		{"none", TemplateData{ProjectName: "a", License: "none", IssueTracker: "https://example.com"}, []string{"CONTRIBUTING.md"}, []string{"LICENSE"}},
+		{"extras", TemplateData{ProjectName: "a", Maintainer: "@a", Secrets: []string{"KEY"}, AgentAction: true, IncludeDevContainer: true, DevContainerImage: "go:2"},
+			[]string{"SECURITY.md", ".env.example", agentWorkflowPath, ".devcontainer/Dockerfile"}, nil},
+		{"bare", TemplateData{ProjectName: "a"}, nil, []string{".env.example", agentWorkflowPath, ".devcontainer/Dockerfile"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := s.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range tt.want {
+				if renderedContent(files, p) == "" {
+					t.Errorf("expected %s", p)
+				}
+			}
+			for _, p := range tt.not {
+				if renderedContent(files, p) != "" {
+					t.Errorf("didn't expect %s", p)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateModes(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := WizardData{ProjectName: "modes", Description: "Mode test", DocHealth: true}
+	files, err := s.Render(w.ToTemplateData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := executablePaths(files); !slices.Equal(got, []string{docHealthScriptPath}) {
+		t.Errorf("executable files = %v, want just %s", got, docHealthScriptPath)
+	}
+}
+
+func TestInitGitRepoRecordsExecutables(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "Seed Test")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "seed@example.com")
+	}
+	dir := tempDir(t)
+	// As on a filesystem that drops the executable bit
+	writeTestFile(t, filepath.Join(dir, "scripts", "check.sh"), "#!/bin/sh\n")
+	if _, err := initGitRepo(dir, "modes", "", []string{"scripts/check.sh"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runCommand(dir, commandTimeout(defaultCommandTimeout), "git", "ls-files", "-s", "scripts/check.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "100755 ") {
+		t.Errorf("git should record the script as executable, got %q", out)
+	}
+}
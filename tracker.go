@@ -0,0 +1,48 @@
+// Package main - tracker.go
+//
+// PURPOSE:
+// This file connects a project to its issue tracker. It's responsible for:
+// - Recognizing GitHub, GitLab and Jira tracker URLs
+// - The example task ID TODO.md's linking convention shows (#123, PROJ-123)
+//
+// DESIGN PATTERNS:
+// - The URL is the only input: the ID style is derived from it, so there's
+//   nothing extra to ask or keep in sync
+// - Unrecognized trackers still work, with a generic example ID
+//
+// USAGE:
+// {{with .TaskRef}}- [ ] {{.}} Fix the login redirect{{end}}
+
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// jiraProject finds the project key in a Jira board, project or issue URL.
+var jiraProject = regexp.MustCompile(`/(?:projects|browse)/([A-Z][A-Z0-9_]+)(?:[/-]|$)`)
+
+// TaskRef returns an example tracker ID for TODO.md tasks: "#123" for
+// GitHub and GitLab issues, "<KEY>-123" for a Jira project, "ABC-123"
+// otherwise, or "" without a tracker.
+func (d TemplateData) TaskRef() string {
+	if d.IssueTracker == "" {
+		return ""
+	}
+	u, err := url.Parse(d.IssueTracker)
+	if err != nil {
+		return "ABC-123"
+	}
+	host := strings.ToLower(u.Host)
+	switch {
+	case host == "github.com" || host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return "#123"
+	case strings.HasSuffix(host, ".atlassian.net") || strings.HasPrefix(host, "jira."):
+		if m := jiraProject.FindStringSubmatch(u.Path); m != nil {
+			return m[1] + "-123"
+		}
+	}
+	return "ABC-123"
+}
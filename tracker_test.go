@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskRef(t *testing.T) {
+	tests := []struct {
+		tracker, want string
+	}{
+		{"", ""},
+		{"https://github.com/acme/tool/issues", "#123"},
+		{"https://gitlab.example.com/acme/tool/-/issues", "#123"},
+		{"https://acme.atlassian.net/jira/software/projects/TOOL/boards/1", "TOOL-123"},
+		{"https://jira.acme.com/browse/OPS", "OPS-123"},
+		{"https://acme.atlassian.net/jira/your-work", "ABC-123"},
+		{"https://linear.app/acme/team/ENG", "ABC-123"},
+	}
+	for _, tt := range tests {
+		if got := (TemplateData{IssueTracker: tt.tracker}).TaskRef(); got != tt.want {
+			t.Errorf("TaskRef(%q) = %q, want %q", tt.tracker, got, tt.want)
+		}
+	}
+}
+
+func TestRenderIssueTracker(t *testing.T) {
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const tracker = "https://acme.atlassian.net/browse/TOOL"
+	files, err := s.Render(TemplateData{ProjectName: "tracked", Description: "Tracker test", IssueTracker: tracker, LinkTasks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo := renderedContent(files, "TODO.md")
+	for _, want := range []string{"[issue tracker](" + tracker + ")", "`- [ ] TOOL-123 Fix the login redirect`"} {
+		if !strings.Contains(todo, want) {
+			t.Errorf("TODO.md missing %q:\n%s", want, todo)
+		}
+	}
+	contributing := renderedContent(files, "CONTRIBUTING.md")
+	if want := "# Contributing to tracked\n\n## Issues\n\nReport bugs and propose changes in the [issue tracker](" + tracker + ")."; !strings.Contains(contributing, want) {
+		t.Errorf("CONTRIBUTING.md missing %q:\n%s", want, contributing)
+	}
+	if !strings.Contains(contributing, "(e.g. `TOOL-123`)") {
+		t.Errorf("CONTRIBUTING.md should show the ID style:\n%s", contributing)
+	}
+
+	// Without the convention, TODO.md only links the tracker
+	files, err = s.Render(TemplateData{ProjectName: "tracked", Description: "Tracker test", IssueTracker: tracker})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todo := renderedContent(files, "TODO.md"); strings.Contains(todo, "TOOL-123") {
+		t.Errorf("unexpected linking convention:\n%s", todo)
+	}
+	if err := (WizardData{ProjectName: "p", Description: "d", LinkTasks: true}).Validate(); err == nil || !strings.Contains(err.Error(), "issueTracker") {
+		t.Errorf("expected linkTasks without a tracker to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package main - upgrade.go
+//
+// PURPOSE:
+// This file implements `seed upgrade`: bringing a scaffolded project up to
+// the current templates without discarding local edits. It's responsible for:
+// - Classifying each generated file (untouched, locally modified, deleted, new)
+// - Overwriting untouched files, three-way merging modified ones
+// - Advancing the manifest so the next upgrade merges from the new base
+//
+// DESIGN PATTERNS:
+// - Plan/apply split: planUpgrade is read-only, applyUpgrade writes
+// - The manifest's stored content is the merge base (see merge.go)
+//
+// USAGE:
+// plan, err := planUpgrade(scaffolder, ".", upgradeOptions{})
+// err = applyUpgrade(".", plan)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// upgradeAction describes what an upgrade does to one file.
+type upgradeAction string
+
+const (
+	upgradeUpdate   upgradeAction = "update"   // Untouched locally; overwritten with the new template
+	upgradeMerge    upgradeAction = "merge"    // Modified locally; template changes merged in cleanly
+	upgradeConflict upgradeAction = "conflict" // Modified locally; merged with conflict markers
+	upgradeAdd      upgradeAction = "add"      // New in this seed version
+	upgradeSkip     upgradeAction = "skip"     // Deleted locally, or exists but wasn't generated by seed
+)
+
+// upgradeChange is the planned upgrade for one generated file.
+type upgradeChange struct {
+	Path      string
+	Action    upgradeAction
+	Reason    string       // Why a file is skipped
+	Content   []byte       // What will be written (nil for skip)
+	Generated RenderedFile // The newly generated version, recorded in the manifest
+	Conflicts int
+}
+
+// upgradePlan is every change an upgrade would make.
+type upgradePlan struct {
+	Manifest Manifest
+	Changes  []upgradeChange
+}
+
+// upgradeOptions adjusts the copyright line an upgrade renders.
+type upgradeOptions struct {
+	Holder string // New copyright holder; "" keeps the recorded one
+	Year   int    // Current year the copyright range extends to; 0 for now
+}
+
+// planUpgrade compares the project at dir with the current templates rendered
+// from its recorded answers. The copyright range is extended to this year
+// (and the holder replaced, if given), so license files pick that up through
+// the same merge as any other template change. Files whose template output
+// hasn't changed since generation are left out.
+func planUpgrade(s *Scaffolder, dir string, opts upgradeOptions) (upgradePlan, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return upgradePlan{}, err
+	}
+	if err := enforceSeedVersion(checkProjectSeedVersion(manifest)); err != nil {
+		return upgradePlan{}, err
+	}
+	license := manifest.LicenseInfo()
+	if opts.Year == 0 {
+		opts.Year = seedNow().Year()
+	}
+	if opts.Year > license.LastYear {
+		license.LastYear = opts.Year
+	}
+	if opts.Holder != "" {
+		license.Holder = opts.Holder
+	}
+	manifest.License = &license
+
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return upgradePlan{}, err
+	}
+	return planUpgradeFrom(dir, manifest, current)
+}
+
+// planUpgradeFrom plans bringing the project at dir from what manifest
+// records to the current rendering.
+func planUpgradeFrom(dir string, manifest Manifest, current []RenderedFile) (upgradePlan, error) {
+	plan := upgradePlan{Manifest: manifest}
+	for _, f := range current {
+		newHash := hashContent(f.Content)
+		recorded, tracked := manifest.File(f.Path)
+		if tracked && !recorded.Outdated(f.Content) {
+			continue // template output unchanged
+		}
+
+		local, err := readGenerated(dir, f.Path)
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return plan, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+
+		change := upgradeChange{Path: f.Path, Generated: f}
+		switch {
+		case !tracked && !exists:
+			change.Action, change.Content = upgradeAdd, f.Content
+		case !tracked && hashContent(local) == newHash:
+			continue // already identical to what seed would add
+		case !tracked:
+			change.Action, change.Reason = upgradeSkip, T("upgrade.skipUntracked")
+		case !exists:
+			change.Action, change.Reason = upgradeSkip, T("upgrade.skipDeleted")
+		case hashContent(local) == recorded.SHA256:
+			change.Action, change.Content = upgradeUpdate, f.Content
+		case isBinary(local) || isBinary(f.Content):
+			change.Action, change.Reason = upgradeSkip, T("upgrade.skipBinary")
+		default:
+			merged, conflicts := merge3([]byte(recorded.Content), local, f.Content, "local", "seed "+displayVersion())
+			change.Content, change.Conflicts = merged, conflicts
+			change.Action = upgradeMerge
+			if conflicts > 0 {
+				change.Action = upgradeConflict
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	return plan, nil
+}
+
+// applyUpgrade writes the planned files and records the newly generated
+// versions in the manifest, so they become the base for the next upgrade.
+func applyUpgrade(dir string, plan upgradePlan) error {
+	m := plan.Manifest
+	if err := applyChanges(dir, &m, plan.Changes); err != nil {
+		return err
+	}
+	m.SeedVersion = Version
+	if cfg, _ := loadConfig(); cfg.MinSeedVersion != "" {
+		m.MinSeedVersion = newerVersion(m.MinSeedVersion, cfg.MinSeedVersion)
+	}
+	return writeManifest(dir, m)
+}
+
+// applyChanges writes the planned files and records their newly generated
+// versions in m. Skipped files that seed generated are recorded too (the
+// user chose to delete them); files seed never generated stay untracked.
+func applyChanges(dir string, m *Manifest, changes []upgradeChange) error {
+	for _, c := range changes {
+		if c.Content != nil {
+			file := c.Generated
+			file.Content = c.Content
+			if err := writeFiles(dir, []RenderedFile{file}); err != nil {
+				return err
+			}
+		}
+		if _, tracked := m.File(c.Path); tracked || c.Action != upgradeSkip {
+			m.SetFile(c.Generated)
+		}
+	}
+	return nil
+}
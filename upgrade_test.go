@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ageManifest rewrites the manifest as though an older seed had generated
+// path with oldContent, and puts oldContent (plus localEdit) on disk.
+func ageManifest(t *testing.T, dir, path, oldContent, localEdit string) {
+	t.Helper()
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	m.SetFile(RenderedFile{Path: path, Content: []byte(oldContent)})
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+	writeTestFile(t, filepath.Join(dir, path), oldContent+localEdit)
+}
+
+func TestUpgradeActions(t *testing.T) {
+	dir := mustScaffoldProject(t)
+	s, _ := NewScaffolder()
+	current, _ := os.ReadFile(filepath.Join(dir, "LEARNINGS.md"))
+	_, body, _ := parseStamp(current)
+	lines := strings.SplitAfter(string(body), "\n")
+	olderLearnings := strings.Join(lines[1:], "") // older template lacked the heading
+
+	ageManifest(t, dir, "README.md", "old readme\n", "")
+	ageManifest(t, dir, "LEARNINGS.md", olderLearnings, "my learning\n")
+	ageManifest(t, dir, "TODO.md", "old todo\n", "my todo\n")
+	ageManifest(t, dir, ".editorconfig", "root = true\n", "")
+	if err := os.Remove(filepath.Join(dir, ".editorconfig")); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := planUpgrade(s, dir, upgradeOptions{})
+	if err != nil {
+		t.Fatalf("planUpgrade: %v", err)
+	}
+	got := map[string]upgradeAction{}
+	for _, c := range plan.Changes {
+		got[c.Path] = c.Action
+	}
+	want := map[string]upgradeAction{
+		"README.md":     upgradeUpdate,
+		"LEARNINGS.md":  upgradeMerge,
+		"TODO.md":       upgradeConflict,
+		".editorconfig": upgradeSkip,
+	}
+	for path, action := range want {
+		if got[path] != action {
+			t.Errorf("%s: action = %q, want %q", path, got[path], action)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected changes: %v", got)
+	}
+
+	if err := applyUpgrade(dir, plan); err != nil {
+		t.Fatalf("applyUpgrade: %v", err)
+	}
+
+	learnings, _ := os.ReadFile(filepath.Join(dir, "LEARNINGS.md"))
+	if string(learnings) != string(current)+"my learning\n" {
+		t.Errorf("LEARNINGS.md merge unexpected:\n%s", learnings)
+	}
+	todo, _ := os.ReadFile(filepath.Join(dir, "TODO.md"))
+	if !strings.Contains(string(todo), "<<<<<<< local\n") || !strings.Contains(string(todo), "my todo\n") {
+		t.Errorf("TODO.md should carry conflict markers:\n%s", todo)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".editorconfig")); !os.IsNotExist(err) {
+		t.Error("deleted .editorconfig should not be restored")
+	}
+
+	again, err := planUpgrade(s, dir, upgradeOptions{})
+	if err != nil {
+		t.Fatalf("planUpgrade: %v", err)
+	}
+	if len(again.Changes) != 0 {
+		t.Errorf("second upgrade should be a no-op, got %+v", again.Changes)
+	}
+}
+
+func TestUpgradeBinaryNotMerged(t *testing.T) {
+	dir := tempDir(t)
+	old := RenderedFile{Path: "logo.png", Content: []byte("\x89PNG\r\n\x1a\n\x00old")}
+	m := newManifest(WizardData{ProjectName: "assets"}, 2026, []RenderedFile{old})
+	writeTestFile(t, filepath.Join(dir, "logo.png"), "\x89PNG\r\n\x1a\n\x00edited")
+
+	plan, err := planUpgradeFrom(dir, m, []RenderedFile{{Path: "logo.png", Content: []byte("\x89PNG\r\n\x1a\n\x00new")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != upgradeSkip {
+		t.Errorf("a locally edited binary should be left alone, got %+v", plan.Changes)
+	}
+}
+
+func TestUpgradeLicenseYearsAndHolder(t *testing.T) {
+	dir := mustScaffoldProject(t) // MIT
+	s, _ := NewScaffolder()
+	m, _ := readManifest(dir)
+	first := m.LicenseInfo().FirstYear
+
+	// A local edit elsewhere in LICENSE survives the copyright update
+	license, _ := os.ReadFile(filepath.Join(dir, "LICENSE"))
+	writeTestFile(t, filepath.Join(dir, "LICENSE"), string(license)+"\nSee also NOTES.\n")
+
+	plan, err := planUpgrade(s, dir, upgradeOptions{Holder: "Example Corp", Year: first + 1})
+	if err != nil {
+		t.Fatalf("planUpgrade: %v", err)
+	}
+	if err := applyUpgrade(dir, plan); err != nil {
+		t.Fatalf("applyUpgrade: %v", err)
+	}
+
+	raw, _ := os.ReadFile(filepath.Join(dir, "LICENSE"))
+	want := fmt.Sprintf("Copyright (c) %d-%d Example Corp\n", first, first+1)
+	if !strings.Contains(string(raw), want) || !strings.HasSuffix(string(raw), "\nSee also NOTES.\n") {
+		t.Errorf("LICENSE should carry %q and keep the local edit:\n%s", want, raw)
+	}
+	m, _ = readManifest(dir)
+	if got := m.LicenseInfo(); got != (LicenseInfo{Holder: "Example Corp", FirstYear: first, LastYear: first + 1}) {
+		t.Errorf("manifest license = %+v", got)
+	}
+
+	// Same year, recorded holder: nothing to do
+	again, err := planUpgrade(s, dir, upgradeOptions{Year: first + 1})
+	if err != nil {
+		t.Fatalf("planUpgrade: %v", err)
+	}
+	if len(again.Changes) != 0 {
+		t.Errorf("second upgrade should be a no-op, got %+v", again.Changes)
+	}
+}
+
+func TestLicenseInfoLegacyManifest(t *testing.T) {
+	m := Manifest{Year: 2024, Answers: WizardData{ProjectName: "legacy"}}
+	if got := m.LicenseInfo(); got != (LicenseInfo{Holder: "legacy", FirstYear: 2024, LastYear: 2024}) || got.Years() != "2024" {
+		t.Errorf("legacy license info = %+v (%s)", got, got.Years())
+	}
+	if got := (LicenseInfo{FirstYear: 2025, LastYear: 2026}).Years(); got != "2025-2026" {
+		t.Errorf("Years() = %q, want 2025-2026", got)
+	}
+}
@@ -0,0 +1,105 @@
+// Package main - verify.go
+//
+// PURPOSE:
+// This file implements `seed verify`, which proves a generated dev container
+// actually builds. It's responsible for:
+// - Running `devcontainer build` (or `devcontainer up` with --up) from the
+//   devcontainers CLI against the project's .devcontainer/ config
+// - Turning the CLI's JSON result and log tail into a readable outcome
+//
+// DESIGN PATTERNS:
+// - Opt-in and separate from scaffolding: a build pulls images and can take
+//   minutes, so it never runs implicitly
+// - Runs through runCommand for its stderr capture (the CLI logs to stderr
+//   and prints its result as JSON on stdout), but with its own timeout:
+//   SEED_COMMAND_TIMEOUT is sized for git and would cut image pulls short
+//
+// USAGE:
+// result, err := verifyDevContainer("/path/to/project", false)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// verifyTimeout bounds a dev container build; first builds pull base images.
+const verifyTimeout = 20 * time.Minute
+
+// verifyBuildTimeout returns SEED_VERIFY_TIMEOUT, else verifyTimeout.
+func verifyBuildTimeout() time.Duration {
+	if d, ok := parseTimeout(os.Getenv("SEED_VERIFY_TIMEOUT")); ok {
+		return d
+	}
+	return verifyTimeout
+}
+
+// devcontainerResult is the JSON the devcontainers CLI prints on stdout.
+type devcontainerResult struct {
+	Outcome     string `json:"outcome"` // "success" or "error"
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	ContainerID string `json:"containerId"` // `up` only
+}
+
+// verifyDevContainer builds (or, with up, starts) the dev container for the
+// project at dir.
+func verifyDevContainer(dir string, up bool) (devcontainerResult, error) {
+	var result devcontainerResult
+	if _, err := os.Stat(filepath.Join(dir, ".devcontainer", "devcontainer.json")); err != nil {
+		return result, fmt.Errorf("no .devcontainer/devcontainer.json in %s (scaffold with a dev container first)", dir)
+	}
+	if _, err := exec.LookPath("devcontainer"); err != nil {
+		return result, errors.New("devcontainer CLI not found (install it with: npm install -g @devcontainers/cli)")
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return result, err
+	}
+
+	action := "build"
+	if up {
+		action = "up"
+	}
+	timeout := verifyBuildTimeout()
+	stdout, runErr := runCommand("", timeout, "devcontainer", action, "--workspace-folder", absDir)
+	if errors.Is(runErr, errCommandTimeout) {
+		return result, fmt.Errorf("devcontainer %s timed out after %s (set SEED_VERIFY_TIMEOUT to allow longer)", action, timeout)
+	}
+	result = parseDevcontainerResult(stdout)
+	if runErr == nil && result.Outcome != "error" {
+		return result, nil
+	}
+
+	message := result.Message
+	if message == "" {
+		message = "unknown error"
+	}
+	if result.Description != "" && result.Description != message {
+		message += ": " + result.Description
+	}
+	if runErr != nil {
+		return result, fmt.Errorf("devcontainer %s failed: %s\n%w", action, message, runErr)
+	}
+	return result, fmt.Errorf("devcontainer %s failed: %s", action, message)
+}
+
+// parseDevcontainerResult finds the CLI's JSON result: the last stdout line
+// that parses as one.
+func parseDevcontainerResult(stdout string) devcontainerResult {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		var result devcontainerResult
+		if json.Unmarshal([]byte(lines[i]), &result) == nil && result.Outcome != "" {
+			return result
+		}
+	}
+	return devcontainerResult{}
+}
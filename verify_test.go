@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeDevcontainerCLI puts a `devcontainer` script on PATH that prints
+// stdout, writes stderr and exits with code.
+func fakeDevcontainerCLI(t *testing.T, stdout, stderr string, code int) {
+	t.Helper()
+	bin := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nprintf '%%s\\n' %s\nprintf '%%s\\n' %s >&2\nexit %d\n", shellQuote(stdout), shellQuote(stderr), code)
+	writeTestFile(t, filepath.Join(bin, "devcontainer"), script)
+	if err := os.Chmod(filepath.Join(bin, "devcontainer"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// projectWithDevContainer scaffolds a project that includes a dev container.
+func projectWithDevContainer(t *testing.T) string {
+	t.Helper()
+	return mustScaffold(t, TemplateData{
+		ProjectName:         "verify-me",
+		Description:         "A test project",
+		IncludeDevContainer: true,
+		DevContainerImage:   "go:2-1.25-trixie",
+	})
+}
+
+func TestVerifyDevContainer(t *testing.T) {
+	t.Run("build succeeds", func(t *testing.T) {
+		fakeDevcontainerCLI(t, `{"outcome":"success","imageName":["vsc-verify-me"]}`, "[1 ms] @devcontainers/cli", 0)
+		if _, err := verifyDevContainer(projectWithDevContainer(t), false); err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+	})
+
+	t.Run("up reports the container", func(t *testing.T) {
+		fakeDevcontainerCLI(t, `{"outcome":"success","containerId":"abc123"}`, "", 0)
+		result, err := verifyDevContainer(projectWithDevContainer(t), true)
+		if err != nil || result.ContainerID != "abc123" {
+			t.Fatalf("got %+v, %v", result, err)
+		}
+	})
+
+	t.Run("build failure includes message and log tail", func(t *testing.T) {
+		fakeDevcontainerCLI(t, `{"outcome":"error","message":"Command failed: docker buildx build","description":"An error occurred building the image."}`,
+			"ERROR: failed to solve: mcr.microsoft.com/devcontainers/nope: not found", 1)
+		_, err := verifyDevContainer(projectWithDevContainer(t), false)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, want := range []string{"devcontainer build failed", "Command failed: docker buildx build", "An error occurred building the image.", "failed to solve"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error should contain %q, got:\n%v", want, err)
+			}
+		}
+	})
+
+	t.Run("no dev container", func(t *testing.T) {
+		fakeDevcontainerCLI(t, "", "", 0)
+		dir := mustScaffold(t, TemplateData{ProjectName: "plain", Description: "No container"})
+		if _, err := verifyDevContainer(dir, false); err == nil || !strings.Contains(err.Error(), "no .devcontainer/devcontainer.json") {
+			t.Errorf("expected a missing config error, got %v", err)
+		}
+	})
+
+	t.Run("own timeout", func(t *testing.T) {
+		bin := t.TempDir()
+		writeTestFile(t, filepath.Join(bin, "devcontainer"), "#!/bin/sh\nsleep 0.5\necho '{\"outcome\":\"success\"}'\n")
+		if err := os.Chmod(filepath.Join(bin, "devcontainer"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+		dir := projectWithDevContainer(t)
+
+		// The general command timeout is sized for git, not builds
+		t.Setenv("SEED_COMMAND_TIMEOUT", "100ms")
+		if _, err := verifyDevContainer(dir, false); err != nil {
+			t.Fatalf("SEED_COMMAND_TIMEOUT shouldn't apply to builds, got %v", err)
+		}
+		t.Setenv("SEED_VERIFY_TIMEOUT", "100ms")
+		if _, err := verifyDevContainer(dir, false); err == nil || !strings.Contains(err.Error(), "SEED_VERIFY_TIMEOUT") {
+			t.Errorf("expected a timeout naming SEED_VERIFY_TIMEOUT, got %v", err)
+		}
+	})
+
+	t.Run("CLI missing", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		if _, err := verifyDevContainer(projectWithDevContainer(t), false); err == nil || !strings.Contains(err.Error(), "npm install -g @devcontainers/cli") {
+			t.Errorf("expected an install hint, got %v", err)
+		}
+	})
+}
+
+func TestParseDevcontainerResult(t *testing.T) {
+	got := parseDevcontainerResult("some log line\n{\"outcome\":\"success\",\"containerId\":\"c1\"}\n")
+	if got.Outcome != "success" || got.ContainerID != "c1" {
+		t.Errorf("got %+v", got)
+	}
+	if got := parseDevcontainerResult("no json here"); got.Outcome != "" {
+		t.Errorf("expected an empty result, got %+v", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Package main - verifymanifest.go
+//
+// PURPOSE:
+// This file implements `seed verify --manifest`, a CI gate that checks a
+// project against its .seed/manifest.json. It's responsible for:
+// - Checking the manifest itself: every recorded file's content must still
+//   hash to its recorded sha256
+// - Re-hashing each generated file on disk: unchanged, modified or missing
+// - Applying the org's project rules (requiredFiles, allowedRegistries) to
+//   the files the project has now, not just when it was scaffolded
+// - Reporting the result as text or JSON (--json), failing on any problem
+//
+// DESIGN PATTERNS:
+// - Read-only and offline: unlike `seed status` it renders nothing, so the
+//   result depends only on the project and the config, not on which seed
+//   (or template set) runs it
+// - Modified files fail the check unless --allow-modified: orgs that let
+//   projects edit generated files can still gate on presence and integrity
+// - The JSON report is the contract CI reads; field names don't change
+//   without a new "format"
+//
+// USAGE:
+// report, err := verifyManifest("/path/to/project", cfg, false)
+// fmt.Print(formatManifestCheck(report))
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// manifestCheckFormat versions the JSON report.
+const manifestCheckFormat = 1
+
+// States of a file in a manifest check.
+const (
+	fileIntact   = "ok"       // On disk and identical to what seed generated
+	fileModified = "modified" // On disk, content differs
+	fileMissing  = "missing"  // Recorded but not on disk
+	fileCorrupt  = "corrupt"  // The manifest's copy doesn't match its own hash
+)
+
+// fileCheck is one recorded file's state.
+type fileCheck struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	SHA256 string `json:"sha256,omitempty"` // On disk, when it differs from the recorded hash
+}
+
+// ruleCheck is a project rule the project breaks.
+type ruleCheck struct {
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// manifestCheck is the result of verifyManifest.
+type manifestCheck struct {
+	Format        int         `json:"format"`
+	Project       string      `json:"project"`
+	SeedVersion   string      `json:"seedVersion"` // Seed that last generated the project
+	OK            bool        `json:"ok"`
+	AllowModified bool        `json:"allowModified"`
+	Counts        fileCounts  `json:"counts"`
+	Files         []fileCheck `json:"files"`
+	Policy        []ruleCheck `json:"policy"`
+}
+
+// fileCounts tallies files by state.
+type fileCounts struct {
+	OK       int `json:"ok"`
+	Modified int `json:"modified"`
+	Missing  int `json:"missing"`
+	Corrupt  int `json:"corrupt"`
+}
+
+// Problems returns how many findings fail the check.
+func (c manifestCheck) Problems() int {
+	n := c.Counts.Missing + c.Counts.Corrupt + len(c.Policy)
+	if !c.AllowModified {
+		n += c.Counts.Modified
+	}
+	return n
+}
+
+// verifyManifest checks the project at dir against its manifest and cfg's
+// project rules. Modified files only fail the check without allowModified.
+func verifyManifest(dir string, cfg userConfig, allowModified bool) (manifestCheck, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return manifestCheck{}, err
+	}
+	check := manifestCheck{
+		Format:        manifestCheckFormat,
+		Project:       manifest.Answers.ProjectName,
+		SeedVersion:   manifest.SeedVersion,
+		AllowModified: allowModified,
+		Files:         []fileCheck{},
+		Policy:        []ruleCheck{},
+	}
+
+	for _, f := range manifest.Files {
+		fc := fileCheck{Path: f.Path, Status: fileIntact}
+		content, err := readGenerated(dir, f.Path)
+		switch {
+		case f.Content != "" && hashContent([]byte(f.Content)) != f.SHA256:
+			fc.Status = fileCorrupt
+			check.Counts.Corrupt++
+		case os.IsNotExist(err):
+			fc.Status = fileMissing
+			check.Counts.Missing++
+		case err != nil:
+			return check, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		case hashContent(content) != f.SHA256:
+			fc.Status, fc.SHA256 = fileModified, hashContent(content)
+			check.Counts.Modified++
+		default:
+			check.Counts.OK++
+		}
+		check.Files = append(check.Files, fc)
+	}
+	slices.SortFunc(check.Files, func(a, b fileCheck) int { return strings.Compare(a.Path, b.Path) })
+
+	onDisk, err := snapshotProjectFiles(dir)
+	if err != nil {
+		return check, fmt.Errorf("failed to list project files: %w", err)
+	}
+	paths := make([]string, 0, len(onDisk))
+	for p := range onDisk {
+		paths = append(paths, p)
+	}
+	for _, v := range checkProjectPolicy(cfg, manifest.TemplateData(), paths) {
+		check.Policy = append(check.Policy, ruleCheck{Rule: v.Rule, Detail: v.Detail})
+	}
+
+	check.OK = check.Problems() == 0
+	return check, nil
+}
+
+// formatManifestCheck renders a manifest check for the terminal: problems
+// first, then a one-line summary.
+func formatManifestCheck(c manifestCheck) string {
+	var b strings.Builder
+	for _, f := range c.Files {
+		switch {
+		case f.Status == fileIntact:
+		case f.Status == fileModified && c.AllowModified:
+			fmt.Fprintf(&b, "  %s %s\n", dimStyle.Render(T("verifyManifest.modified")), f.Path)
+		default:
+			fmt.Fprintf(&b, "  %s %s\n", warnStyle.Render(T("verifyManifest."+f.Status)), f.Path)
+		}
+	}
+	for _, v := range c.Policy {
+		fmt.Fprintf(&b, "  %s %s (%s)\n", warnStyle.Render(T("verifyManifest.policy")), v.Detail, v.Rule)
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	summary := T("verifyManifest.summary", c.Counts.OK, c.Counts.Modified, c.Counts.Missing, c.Counts.Corrupt, len(c.Policy))
+	if c.OK {
+		fmt.Fprintf(&b, "%s %s\n", successStyle.Render("✓"), summary)
+	} else {
+		fmt.Fprintf(&b, "%s %s\n", warnStyle.Render("✗"), summary)
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyManifest(t *testing.T) {
+	isolateConfig(t)
+	dir := tempDir(t)
+	answers := WizardData{ProjectName: "gated", Description: "A governed project", License: "MIT"}
+	if _, err := scaffoldProject(dir, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := verifyManifest(dir, userConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check.OK || check.Counts.OK == 0 || check.Counts.OK != len(check.Files) || check.Project != "gated" {
+		t.Fatalf("expected a fresh project to pass: %+v", check.Counts)
+	}
+
+	// Edit one file, delete another, and corrupt the manifest's copy of a third
+	writeTestFile(t, filepath.Join(dir, "README.md"), "# Ours\n")
+	if err := os.Remove(filepath.Join(dir, "TODO.md")); err != nil {
+		t.Fatal(err)
+	}
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range m.Files {
+		if m.Files[i].Path == "LICENSE" {
+			m.Files[i].Content += "tampered"
+		}
+	}
+	if err := writeManifest(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	cfg := userConfig{RequiredFiles: []string{"SECURITY.md"}}
+
+	check, err = verifyManifest(dir, cfg, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := map[string]string{}
+	for _, f := range check.Files {
+		status[f.Path] = f.Status
+	}
+	for path, want := range map[string]string{"README.md": fileModified, "TODO.md": fileMissing, "LICENSE": fileCorrupt, "AGENTS.md": fileIntact} {
+		if status[path] != want {
+			t.Errorf("%s: got %q, want %q", path, status[path], want)
+		}
+	}
+	if len(check.Policy) != 1 || check.Policy[0].Rule != "requiredFiles" {
+		t.Errorf("expected the missing required file, got %+v", check.Policy)
+	}
+	if check.OK || check.Problems() != 4 {
+		t.Errorf("expected 4 problems, got %d (ok=%t)", check.Problems(), check.OK)
+	}
+	out := formatManifestCheck(check)
+	for _, want := range []string{"README.md", "TODO.md", "LICENSE", "SECURITY.md"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in the report:\n%s", want, out)
+		}
+	}
+
+	// Edits can be allowed; missing and corrupt files still fail
+	check, _ = verifyManifest(dir, cfg, true)
+	if check.OK || check.Problems() != 3 {
+		t.Errorf("with --allow-modified: got %d problems", check.Problems())
+	}
+
+	// The JSON report is what CI reads
+	raw, err := json.Marshal(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]any
+	json.Unmarshal(raw, &report)
+	for _, key := range []string{"format", "ok", "counts", "files", "policy", "seedVersion"} {
+		if _, ok := report[key]; !ok {
+			t.Errorf("JSON report is missing %q: %s", key, raw)
+		}
+	}
+
+	if _, err := verifyManifest(t.TempDir(), cfg, false); !errors.Is(err, errNoManifest) {
+		t.Errorf("expected errNoManifest, got %v", err)
+	}
+}
+
+func TestRunVerifyUsage(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--manifest", "--up"}, "can't be combined"},
+		{[]string{"--json"}, "require --manifest"},
+		{[]string{"--allow-modified", "."}, "require --manifest"},
+		{[]string{"--manifest", "a", "b"}, T("args.tooMany")},
+	}
+	for _, tt := range tests {
+		var usage usageError
+		err := runVerify(tt.args)
+		if !errors.As(err, &usage) || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("runVerify(%q) = %v, want a usage error containing %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestVerifyManifestBinaryAsset(t *testing.T) {
+	dir := tempDir(t)
+	// A PNG signature and IHDR chunk: NUL bytes and invalid UTF-8
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89")
+	files := []RenderedFile{
+		{Path: "README.md", Mode: 0644, Content: []byte("# Assets\n")},
+		{Path: "docs/logo.png", Mode: 0644, Content: png},
+	}
+	for _, f := range files {
+		writeTestFile(t, filepath.Join(dir, f.Path), string(f.Content))
+	}
+	if err := writeManifest(dir, newManifest(WizardData{ProjectName: "assets"}, 2026, files)); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logo, ok := m.File("docs/logo.png")
+	if !ok || logo.Content != "" || logo.SHA256 != hashContent(png) {
+		t.Errorf("a binary file should be recorded by hash alone, got %+v", logo)
+	}
+	check, err := verifyManifest(dir, userConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check.OK || check.Counts.OK != 2 {
+		t.Errorf("an untouched binary asset should verify, got %+v", check.Files)
+	}
+
+	// An edited image is modified, not corrupt
+	writeTestFile(t, filepath.Join(dir, "docs", "logo.png"), string(png)+"\x00")
+	if check, _ := verifyManifest(dir, userConfig{}, false); check.Counts.Modified != 1 || check.Counts.Corrupt != 0 {
+		t.Errorf("expected the image to be modified, got %+v", check.Counts)
+	}
+}
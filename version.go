@@ -0,0 +1,151 @@
+// Package main - version.go
+//
+// PURPOSE:
+// This file lets an organization (or a project) pin the oldest seed allowed
+// to work on it. It's responsible for:
+// - Comparing seed versions (v1.2.3, with an optional -prerelease)
+// - Refusing, or with seedVersionCheck "warn" only warning, when this binary
+//   is older than config "minSeedVersion" or than what a project's manifest
+//   asks for
+//
+// DESIGN PATTERNS:
+// - Checked once in run() against the effective config, before any command
+//   but doctor, telemetry and bundle (which help fix the problem)
+// - A project carries its own pin: the manifest records the minimum in effect
+//   when it was generated, and upgrade and regen refuse a seed older than it
+//   or than the seed that last generated the project, which would take its
+//   templates backwards
+// - Development builds ("dev") have no version to compare and are never refused
+//
+// USAGE:
+// err := enforceSeedVersion(checkSeedVersion(cfg.MinSeedVersion, T("version.orgPin")))
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// seedVersionWarn is the seedVersionCheck value that only warns.
+const seedVersionWarn = "warn"
+
+// seedVersionError reports a seed older than some source requires.
+type seedVersionError struct {
+	Have   string // This seed's version
+	Need   string // The minimum required
+	Source string // What requires it
+}
+
+func (e seedVersionError) Error() string {
+	return T("version.tooOld", e.Have, e.Need, e.Source)
+}
+
+// semver is a parsed version: the numeric core and any prerelease suffix.
+type semver struct {
+	Core       [3]int
+	Prerelease string
+}
+
+// parseVersion parses "1.2.3", "v1.2" or "1.2.3-rc.1". Build metadata
+// (+...) is ignored.
+func parseVersion(s string) (semver, bool) {
+	var v semver
+	s, _, _ = strings.Cut(strings.TrimPrefix(strings.TrimSpace(s), "v"), "+")
+	s, v.Prerelease, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.Core[i] = n
+	}
+	return v, true
+}
+
+// compareVersions returns -1, 0 or 1 as a is older than, the same as or
+// newer than b. A prerelease is older than its release.
+func compareVersions(a, b semver) int {
+	for i := range a.Core {
+		if a.Core[i] != b.Core[i] {
+			if a.Core[i] < b.Core[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
+		return 1
+	case b.Prerelease == "":
+		return -1
+	}
+	return strings.Compare(a.Prerelease, b.Prerelease)
+}
+
+// newerVersion returns whichever of a and b is newer, treating "" and
+// unparseable versions as older than anything.
+func newerVersion(a, b string) string {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || (okB && compareVersions(vb, va) > 0) {
+		return b
+	}
+	return a
+}
+
+// checkSeedVersion returns a seedVersionError when this seed is older than
+// need, which source requires. need "" and development builds always pass.
+func checkSeedVersion(need, source string) error {
+	if strings.TrimSpace(need) == "" {
+		return nil
+	}
+	want, ok := parseVersion(need)
+	if !ok {
+		return fmt.Errorf("%s: invalid minSeedVersion %q (expected a version like 1.4.0)", source, need)
+	}
+	have, ok := parseVersion(Version)
+	if !ok {
+		debugf("seed version %q isn't a release; skipping the minimum version check (%s)", Version, need)
+		return nil
+	}
+	if compareVersions(have, want) < 0 {
+		return seedVersionError{Have: displayVersion(), Need: strings.TrimPrefix(need, "v"), Source: source}
+	}
+	return nil
+}
+
+// checkProjectSeedVersion checks this seed against the project m describes:
+// its recorded minimum and the seed that last generated it.
+func checkProjectSeedVersion(m Manifest) error {
+	if err := checkSeedVersion(m.MinSeedVersion, T("version.projectPin", manifestPath)); err != nil {
+		return err
+	}
+	if _, ok := parseVersion(m.SeedVersion); !ok {
+		return nil // Generated by a development build
+	}
+	return checkSeedVersion(m.SeedVersion, T("version.projectGenerated"))
+}
+
+// enforceSeedVersion passes err through, except that with seedVersionCheck
+// "warn" a seedVersionError is printed as a warning instead.
+func enforceSeedVersion(err error) error {
+	var tooOld seedVersionError
+	if !errors.As(err, &tooOld) {
+		return err
+	}
+	if cfg, _ := loadConfig(); cfg.SeedVersionCheck == seedVersionWarn {
+		fmt.Fprintln(os.Stderr, warnStyle.Render(T("version.warning", err)))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// useVersion sets the seed version for the rest of the test.
+func useVersion(t *testing.T, v string) {
+	t.Helper()
+	old := Version
+	Version = v
+	t.Cleanup(func() { Version = old })
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.4.0-rc.1", "1.4.0", -1},
+		{"1.4.0-rc.2", "1.4.0-rc.1", 1},
+		{"1.4.0+build.7", "1.4.0", 0},
+	}
+	for _, tt := range tests {
+		a, okA := parseVersion(tt.a)
+		b, okB := parseVersion(tt.b)
+		if !okA || !okB {
+			t.Fatalf("parseVersion(%q, %q) failed", tt.a, tt.b)
+		}
+		if got := compareVersions(a, b); got != tt.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "dev", "1.x", "1.2.3.4"} {
+		if _, ok := parseVersion(bad); ok {
+			t.Errorf("parseVersion(%q) should fail", bad)
+		}
+	}
+	if got := newerVersion("1.2.0", "1.10.0"); got != "1.10.0" {
+		t.Errorf("newerVersion = %s", got)
+	}
+	if got := newerVersion("", "1.0.0"); got != "1.0.0" {
+		t.Errorf("newerVersion with empty = %s", got)
+	}
+}
+
+func TestCheckSeedVersion(t *testing.T) {
+	useVersion(t, "v1.3.0")
+	var tooOld seedVersionError
+	if err := checkSeedVersion("1.4.0", "org"); !errors.As(err, &tooOld) || tooOld.Have != "1.3.0" || tooOld.Need != "1.4.0" {
+		t.Errorf("expected 1.3.0 to be refused for 1.4.0, got %v", err)
+	}
+	for _, need := range []string{"", "1.3.0", "v1.2.9"} {
+		if err := checkSeedVersion(need, "org"); err != nil {
+			t.Errorf("checkSeedVersion(%q): %v", need, err)
+		}
+	}
+	if err := checkSeedVersion("latest", "org"); err == nil || errors.As(err, &tooOld) {
+		t.Errorf("expected an invalid version error, got %v", err)
+	}
+
+	useVersion(t, "dev")
+	if err := checkSeedVersion("99.0.0", "org"); err != nil {
+		t.Errorf("development builds shouldn't be refused: %v", err)
+	}
+}
+
+func TestEnforceSeedVersionWarn(t *testing.T) {
+	isolateConfig(t)
+	useVersion(t, "1.0.0")
+	if err := saveUserConfig(userConfig{MinSeedVersion: "2.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := loadConfig()
+	if err := enforceSeedVersion(checkSeedVersion(cfg.MinSeedVersion, "org")); err == nil {
+		t.Error("expected an older seed to be refused")
+	}
+	if r := checkSeedVersionPin(); r.Status != checkFail {
+		t.Errorf("doctor status = %v, want fail", r.Status)
+	}
+
+	if err := saveUserConfig(userConfig{MinSeedVersion: "2.0.0", SeedVersionCheck: seedVersionWarn}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enforceSeedVersion(checkSeedVersion(cfg.MinSeedVersion, "org")); err != nil {
+		t.Errorf("seedVersionCheck warn should only warn: %v", err)
+	}
+	if r := checkSeedVersionPin(); r.Status != checkWarn {
+		t.Errorf("doctor status = %v, want warn", r.Status)
+	}
+}
+
+func TestUpgradeRefusesOlderSeed(t *testing.T) {
+	isolateConfig(t)
+	useVersion(t, "1.5.0")
+	if err := saveUserConfig(userConfig{MinSeedVersion: "1.2.0"}); err != nil {
+		t.Fatal(err)
+	}
+	dir := mustScaffoldProject(t)
+	m, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.SeedVersion != "1.5.0" || m.MinSeedVersion != "1.2.0" {
+		t.Fatalf("manifest records seed %q, minimum %q", m.SeedVersion, m.MinSeedVersion)
+	}
+	s, _ := NewScaffolder()
+
+	// The project's own pin applies without the config that set it
+	if err := saveUserConfig(userConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	useVersion(t, "1.1.0")
+	var tooOld seedVersionError
+	if _, err := planUpgrade(s, dir, upgradeOptions{}); !errors.As(err, &tooOld) || tooOld.Need != "1.2.0" {
+		t.Errorf("expected the project's minimum to refuse 1.1.0, got %v", err)
+	}
+
+	// Nor may an older seed take templates backwards
+	useVersion(t, "1.4.0")
+	if _, err := planRegen(s, dir, "README.md"); !errors.As(err, &tooOld) || tooOld.Need != "1.5.0" {
+		t.Errorf("expected seed 1.4.0 to be refused for a project generated by 1.5.0, got %v", err)
+	}
+
+	useVersion(t, "1.6.0")
+	if _, err := planUpgrade(s, dir, upgradeOptions{}); err != nil {
+		t.Errorf("planUpgrade with a newer seed: %v", err)
+	}
+}
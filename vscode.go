@@ -0,0 +1,118 @@
+// Package main - vscode.go
+//
+// PURPOSE:
+// This file generates the optional VS Code workspace configs. It's
+// responsible for:
+// - .vscode/settings.json: format on save plus the language's formatter
+// - .vscode/tasks.json: build and test tasks running the stack's commands,
+//   plus lint and format tasks for the chosen linters (standards.go)
+// - .vscode/launch.json: a debug configuration for the stack
+//
+// DESIGN PATTERNS:
+// - Marshaled with encoding/json like devcontainer.json, so output is always
+//   valid JSON (settings is a map, so its keys come out sorted)
+// - Opt-in (vscodeConfig answer) and independent of the dev container
+// - Without a language, only the language-neutral settings.json is written
+//
+// USAGE:
+// files, err := renderVSCodeConfig(data)
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// vscodeLaunch is one .vscode/launch.json configuration. Only the fields a
+// stack needs are set; the rest are omitted.
+type vscodeLaunch struct {
+	Name              string       `json:"name"`
+	Type              string       `json:"type"`
+	Request           string       `json:"request"`
+	Mode              string       `json:"mode,omitempty"`
+	Program           string       `json:"program,omitempty"`
+	MainClass         string       `json:"mainClass,omitempty"`
+	Cwd               string       `json:"cwd,omitempty"`
+	Console           string       `json:"console,omitempty"`
+	RuntimeExecutable string       `json:"runtimeExecutable,omitempty"`
+	RuntimeArgs       []string     `json:"runtimeArgs,omitempty"`
+	Cargo             *vscodeCargo `json:"cargo,omitempty"`
+	MIMode            string       `json:"MIMode,omitempty"`
+}
+
+// vscodeCargo is CodeLLDB's "cargo" launch field: build with these args and
+// debug the resulting binary.
+type vscodeCargo struct {
+	Args []string `json:"args"`
+}
+
+// vscodeTask is one .vscode/tasks.json task.
+type vscodeTask struct {
+	Label          string           `json:"label"`
+	Type           string           `json:"type"`
+	Command        string           `json:"command"`
+	Group          *vscodeTaskGroup `json:"group,omitempty"`
+	ProblemMatcher []string         `json:"problemMatcher"`
+}
+
+// vscodeTaskGroup marks a task as the default build or test task.
+type vscodeTaskGroup struct {
+	Kind      string `json:"kind"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// vscodeSettings are written for every project that opts in.
+var vscodeSettings = map[string]any{
+	"editor.formatOnSave":          true,
+	"files.insertFinalNewline":     true,
+	"files.trimTrailingWhitespace": true,
+}
+
+// vscodeFile is a .vscode/ file before marshaling.
+type vscodeFile struct {
+	path    string
+	content any
+}
+
+// renderVSCodeConfig generates settings.json, and with a language also
+// tasks.json and launch.json.
+func renderVSCodeConfig(data TemplateData) ([]RenderedFile, error) {
+	settings := maps.Clone(vscodeSettings)
+	if data.Stack != nil {
+		maps.Copy(settings, data.Stack.Settings)
+	}
+	configs := []vscodeFile{{".vscode/settings.json", settings}}
+
+	if s := data.Stack; s != nil {
+		tasks := struct {
+			Version string       `json:"version"`
+			Tasks   []vscodeTask `json:"tasks"`
+		}{"2.0.0", []vscodeTask{
+			{Label: "build", Type: "shell", Command: s.Build, Group: &vscodeTaskGroup{"build", true}, ProblemMatcher: []string{}},
+			{Label: "test", Type: "shell", Command: s.Test, Group: &vscodeTaskGroup{"test", true}, ProblemMatcher: []string{}},
+		}}
+		// Ungrouped, so they don't compete with the default build and test tasks
+		for _, cs := range data.CodingStandards() {
+			tasks.Tasks = append(tasks.Tasks,
+				vscodeTask{Label: "lint (" + cs.Label + ")", Type: "shell", Command: cs.Lint, ProblemMatcher: []string{}},
+				vscodeTask{Label: "format (" + cs.Label + ")", Type: "shell", Command: cs.Format, ProblemMatcher: []string{}})
+		}
+		launch := struct {
+			Version        string         `json:"version"`
+			Configurations []vscodeLaunch `json:"configurations"`
+		}{"0.2.0", []vscodeLaunch{s.Launch}}
+		configs = append(configs, vscodeFile{".vscode/tasks.json", tasks}, vscodeFile{".vscode/launch.json", launch})
+	}
+
+	files := make([]RenderedFile, 0, len(configs))
+	for _, c := range configs {
+		raw, err := json.MarshalIndent(c.content, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", c.path, err)
+		}
+		files = append(files, RenderedFile{Path: c.path, Content: append(raw, '\n'), Mode: 0644})
+	}
+	return files, nil
+}
@@ -14,15 +14,24 @@
 // - Separation of concerns: wizard doesn't know about templates or file I/O
 //
 // USAGE:
-// data, err := RunWizard()
-// if err != nil { handle error }
+// data, err := RunWizard("my-project", nil, WizardData{})
+// if err != nil { handle error } // data holds the answers entered so far
 // // data is now ready to pass to scaffolder
 
 package main
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/huh"
 )
@@ -30,17 +39,88 @@ import (
 // WizardData holds the user's responses from the wizard.
 // This is a temporary struct used during wizard execution.
 // After collection, it's converted to TemplateData for rendering.
+//
+// The JSON tags define the answers format used by non-interactive modes
+// (e.g. batch spec files), so field names there match the wizard questions.
 type WizardData struct {
-	ProjectName         string
-	Description         string
-	License             string   // "none", "MIT", or "Apache-2.0"
-	InitGit             bool     // Whether to run git init + initial commit
-	IncludeDevContainer bool     // Whether to scaffold .devcontainer/
-	DevContainerImage   string   // MCR image tag, e.g. "go:2-1.25-trixie"
-	AIChatContinuity    bool     // Whether to enable AI chat continuity
-	AgentExtensions     []string // Selected extension IDs (e.g. "anthropics.claude-code")
+	ProjectName         string   `json:"projectName"`
+	Description         string   `json:"description"`
+	License             string   `json:"license,omitempty"`             // "none", "MIT", "Apache-2.0", or "MIT OR Apache-2.0"
+	LicenseHeaders      bool     `json:"licenseHeaders,omitempty"`      // Add SPDX headers to generated source files
+	Gitignore           []string `json:"gitignore,omitempty"`           // .gitignore pattern sets, e.g. ["os","editor","node"]; empty for the stack's defaults
+	GitignoreExtra      []string `json:"gitignoreExtra,omitempty"`      // Extra .gitignore patterns, e.g. ["data/","*.parquet"]
+	InitGit             bool     `json:"initGit,omitempty"`             // Whether to run git init + initial commit
+	IncludeDevContainer bool     `json:"includeDevContainer,omitempty"` // Whether to scaffold .devcontainer/
+	DevContainerImage   string   `json:"devContainerImage,omitempty"`   // MCR image tag, e.g. "go:2-1.25-trixie", or a full custom reference
+	Language            string   `json:"language,omitempty"`            // Stack ID from stack.go, e.g. "go"; "" for language-neutral output
+	VSCodeConfig        bool     `json:"vscodeConfig,omitempty"`        // Generate .vscode/settings.json, tasks.json and launch.json
+	AIChatContinuity    bool     `json:"aiChatContinuity,omitempty"`    // Former yes/no chat continuity (Claude Code and Codex); kept for older manifests
+	ChatTools           []string `json:"chatTools,omitempty"`           // AI tools whose chat history persists (across rebuilds, or moves without a container): "claude", "codex", "gemini", "aider" or a custom ID
+	CustomChatTools     []aiTool `json:"customChatTools,omitempty"`     // Chosen tools defined or relocated by config, so re-rendering doesn't need it
+	ChatState           string   `json:"chatState,omitempty"`           // Container chat state: "bind" (host dirs, live) or "copy" (volume seeded from the host once)
+	AgentExtensions     []string `json:"agentExtensions,omitempty"`     // Selected VS Code extension IDs, agent and language (e.g. "anthropics.claude-code", "golang.go")
+	IDE                 string   `json:"ide,omitempty"`                 // Dev container IDE: "" (VS Code), "jetbrains" (Gateway) or "none"
+	Shell               string   `json:"shell,omitempty"`               // Container login shell: "bash" or "zsh" (with oh-my-zsh)
+	DotfilesRepo        string   `json:"dotfilesRepo,omitempty"`        // Dotfiles to install in the container: owner/repo or a git URL
+	DockerAccess        string   `json:"dockerAccess,omitempty"`        // Docker inside the container: "none", "docker-in-docker" or "docker-outside-of-docker"
+	Workload            string   `json:"workload,omitempty"`            // Machine sizing: "general" or "ml"
+	GPU                 bool     `json:"gpu,omitempty"`                 // Ask for a GPU when one is available
+	CUDA                bool     `json:"cuda,omitempty"`                // Install CUDA and require an NVIDIA GPU (--gpus all)
+	Secrets             []string `json:"secrets,omitempty"`             // Names of environment variables the project needs (never values)
+	ForwardEnv          []string `json:"forwardEnv,omitempty"`          // Extra host variables forwarded into the container (beyond GH_TOKEN/GITHUB_TOKEN)
+	Mounts              []string `json:"mounts,omitempty"`              // Extra container mounts: "source:target" or a full devcontainer mount string
+	NoExtensionsCache   bool     `json:"noExtensionsCache,omitempty"`   // Skip the VS Code extensions cache volume
+	ExtensionsVolume    string   `json:"extensionsVolume,omitempty"`    // Extensions cache volume name; derived from the name and path when empty
+	NoSkills            bool     `json:"noSkills,omitempty"`            // Leave out skills/ (--no-skills)
+	ReadmeOmit          []string `json:"readmeOmit,omitempty"`          // README sections left out, e.g. ["badges","goals"] (readme.go)
+	Commands            []string `json:"commands,omitempty"`            // Build, test and lint commands for AGENTS.md (seed adopt detects them)
+	Adopted             bool     `json:"adopted,omitempty"`             // Set up by seed adopt: only the files it wrote are managed (adopt.go)
+
+	// Workspace packages added with seed add package, listed in the root AGENTS.md (workspaceagents.go)
+	Packages []workspacePackage `json:"packages,omitempty"`
+
+	// Repository metadata
+	Visibility       string   `json:"visibility,omitempty"`       // GitHub repository visibility: "private" or "public" ("" is private)
+	Topics           []string `json:"topics,omitempty"`           // GitHub topics, also used as README and package keywords
+	BranchProtection bool     `json:"branchProtection,omitempty"` // Protect the default branch (protection.go): documented, applied from the next steps
+	DocHealth        bool     `json:"docHealth,omitempty"`        // Scheduled doc health check workflow (dochealth.go)
+	DocGuard         bool     `json:"docGuard,omitempty"`         // Pre-commit hook keeping AGENTS.md, DECISIONS.md and LEARNINGS.md (docguard.go)
+	HookFramework    string   `json:"hookFramework,omitempty"`    // Runs the hook: "pre-commit", "lefthook", or "" for a plain git hook
+	AgentAction      bool     `json:"agentAction,omitempty"`      // Claude Code GitHub Action answering @claude mentions
+
+	// Project links: README, package manifests and the GitHub repository's website
+	Homepage      string `json:"homepage,omitempty"`      // Project homepage URL
+	Documentation string `json:"documentation,omitempty"` // Documentation URL
+	IssueTracker  string `json:"issueTracker,omitempty"`  // Issue tracker URL (GitHub issues, a Jira project, ...)
+	LinkTasks     bool   `json:"linkTasks,omitempty"`     // TODO.md convention: tasks start with their tracker ID
+
+	// Ownership: README, CODEOWNERS and SECURITY.md (ownership.go)
+	Team       string `json:"team,omitempty"`       // Owning team: a name or a GitHub @org/team
+	Maintainer string `json:"maintainer,omitempty"` // Primary maintainer: a GitHub @handle or an email address
+
+	// Intended audience and maturity from maturity.go: "prototype", "internal", "library" or "service"
+	Maturity string `json:"maturity,omitempty"`
+
+	// Commit message convention from commits.go: "conventional", "gitmoji", or "" / "none"
+	CommitConvention string `json:"commitConvention,omitempty"`
+
+	// Project brief, one entry per line, written to README.md and AGENTS.md
+	Goals       []string `json:"goals,omitempty"`       // What must be true for the project to succeed
+	NonGoals    []string `json:"nonGoals,omitempty"`    // What it deliberately won't do
+	Constraints []string `json:"constraints,omitempty"` // Rules the work must respect
+
+	// Linters and formatters from standards.go, e.g. ["golangci-lint"]
+	Standards []string `json:"standards,omitempty"`
+
+	// Set from config, not asked; recorded so re-rendering doesn't need it
+	Footer        string `json:"footer,omitempty"`        // Branding footer for generated docs (branding.go)
+	ImageRegistry string `json:"imageRegistry,omitempty"` // Mirror dev container images come from ("" for MCR)
 }
 
+// wizardOutput is where the wizard TUI is drawn. Modes that reserve stdout
+// for data (e.g. --print) redirect it to stderr.
+var wizardOutput io.Writer = os.Stdout
+
 // RunWizard launches the interactive TUI wizard and collects user input.
 // It displays a form with fields:
 // 1. Project Name (text input with validation)
@@ -50,12 +130,32 @@ type WizardData struct {
 // - WizardData: Collected and validated user input
 // - error: If user cancels (Ctrl+C) or validation fails unexpectedly
 //
+// On a cancel, the returned WizardData holds the answers entered so far.
+//
 // Validation:
 // - Project Name: 1-100 chars, non-empty when trimmed
 // - Description: 1-500 chars, non-empty when trimmed
-func RunWizard(defaultName string) (WizardData, error) {
-	var data WizardData
-	data.ProjectName = defaultName
+//
+// preset holds the starting answers (a profile's, from --profile); pass a
+// zero WizardData for seed's defaults.
+func RunWizard(defaultName string, disabled []string, preset WizardData) (WizardData, error) {
+	tools := detectTools()
+	cfg, _ := loadConfig()
+	aiTools := configAITools(cfg)
+	data := wizardDefaults(defaultName, preset, cfg)
+	secrets := strings.Join(data.Secrets, ", ")
+	topics := strings.Join(data.Topics, ", ")
+	goals := strings.Join(data.Goals, "\n")
+	nonGoals := strings.Join(data.NonGoals, "\n")
+	constraints := strings.Join(data.Constraints, "\n")
+	gitignoreExtra := strings.Join(data.GitignoreExtra, ", ")
+	extraIDs := selectedExtras(data)
+	extensionsCache := !data.NoExtensionsCache
+	readmeKept := keptSections(data.ReadmeOmit)
+	gpu := gpuChoice(data)
+	catalog := loadImageCatalog(cfg)
+	var customImage string
+	data.DevContainerImage, customImage = splitImageChoice(data.DevContainerImage, catalog)
 
 	// Create the form with input groups
 	// Huh's NewForm accepts one or more Groups
@@ -64,89 +164,455 @@ func RunWizard(defaultName string) (WizardData, error) {
 		// Group 1: Core project info
 		huh.NewGroup(
 			huh.NewInput().
-				Title("Project name").
+				Title(T("wizard.projectName")).
 				Value(&data.ProjectName).
 				Validate(validateProjectName),
 
 			huh.NewText().
-				Title("Description").
+				Title(T("wizard.description")).
+				Description(T("wizard.descriptionHint")).
 				CharLimit(500).
 				Value(&data.Description).
 				Validate(validateDescription),
+
+			huh.NewInput().
+				Title(T("wizard.topics")).
+				Description(T("wizard.topicsHint")).
+				Value(&topics).
+				Validate(func(s string) error { return validateTopics(splitTopics(s)) }),
+
+			huh.NewInput().
+				Title(T("wizard.homepage")).
+				Description(T("wizard.homepageHint")).
+				Value(&data.Homepage).
+				Validate(func(s string) error { return validateProjectURL("homepage", s) }),
+
+			huh.NewInput().
+				Title(T("wizard.documentation")).
+				Description(T("wizard.documentationHint")).
+				Value(&data.Documentation).
+				Validate(func(s string) error { return validateProjectURL("documentation", s) }),
+
+			huh.NewInput().
+				Title(T("wizard.issueTracker")).
+				Description(T("wizard.issueTrackerHint")).
+				Value(&data.IssueTracker).
+				Validate(func(s string) error { return validateProjectURL("issueTracker", s) }),
+
+			huh.NewInput().
+				Title(T("wizard.team")).
+				Description(T("wizard.teamHint")).
+				Value(&data.Team).
+				Validate(validateTeam),
+
+			huh.NewInput().
+				Title(T("wizard.maintainer")).
+				Description(T("wizard.maintainerHint")).
+				Value(&data.Maintainer).
+				Validate(validateMaintainer),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.maturity")).
+				Description(T("wizard.maturityHint")).
+				Options(maturityOptions()...).
+				Value(&data.Maturity),
 		),
 
-		// Group 2: Project setup options
+		// Group 1b: Project brief, the first thing an agent reads
 		huh.NewGroup(
-			huh.NewConfirm().
-				Title("Initialize git repository?").
-				Value(&data.InitGit),
+			briefField(T("wizard.goals"), T("wizard.goalsHint"), "goals", &goals),
+			briefField(T("wizard.nonGoals"), T("wizard.nonGoalsHint"), "nonGoals", &nonGoals),
+			briefField(T("wizard.constraints"), T("wizard.constraintsHint"), "constraints", &constraints),
+		),
 
+		// Group 1c: TODO.md convention for the tracker (only with one)
+		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Include a dev container?").
-				Value(&data.IncludeDevContainer),
+				Title(T("wizard.linkTasks")).
+				Description(T("wizard.linkTasksHint")).
+				Value(&data.LinkTasks),
+		).WithHideFunc(func() bool {
+			return strings.TrimSpace(data.IssueTracker) == ""
+		}),
+
+		// Group 2: Project setup options (adapted to the tools installed)
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(T("wizard.language")).
+				Description(T("wizard.languageHint")).
+				Options(languageOptions()...).
+				Value(&data.Language),
+
+			// Optional components in one list; applied as they change, so
+			// the groups below can show or hide on them
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.extras")).
+				Description(extrasHint(cfg.Require, tools)).
+				Options(slices.DeleteFunc(extraOptions(cfg.Require, tools), func(o huh.Option[string]) bool {
+					return slices.Contains(disabled, o.Value)
+				})...).
+				Value(&extraIDs).
+				Validate(func(ids []string) error {
+					applyExtras(&data, ids, cfg.Require)
+					return nil
+				}),
+
+			huh.NewInput().
+				Title(T("wizard.secrets")).
+				Description(T("wizard.secretsHint")).
+				Value(&secrets).
+				Validate(func(s string) error { return validateEnvNames(splitEnvNames(s)) }),
 		),
 
-		// Group 3: Dev container details (only shown if opted in)
+		// Group 2b: Where the repository will be published (only with git)
 		huh.NewGroup(
-			// Image tags reference MCR defaults at time of release.
-			// Check https://mcr.microsoft.com for current versions.
 			huh.NewSelect[string]().
-				Title("Tech stack").
+				Title(T("wizard.visibility")).
+				Description(T("wizard.visibilityHint")).
 				Options(
-					huh.NewOption("Go", "go:2-1.25-trixie"),
-					huh.NewOption("Node/TypeScript", "typescript-node:20-bookworm"),
-					huh.NewOption("Python", "python:3-3.12"),
-					huh.NewOption("Rust", "rust:1-bookworm"),
-					huh.NewOption("Java", "java"),
-					huh.NewOption(".NET", "dotnet"),
-					huh.NewOption("C++", "cpp"),
-					huh.NewOption("Universal (all languages)", "universal"),
+					huh.NewOption(T("wizard.visibility.private"), "private"),
+					huh.NewOption(T("wizard.visibility.public"), "public"),
 				).
+				Value(&data.Visibility),
+			huh.NewSelect[string]().
+				Title(T("wizard.commits")).
+				Description(T("wizard.commitsHint")).
+				Options(commitConventionOptions()...).
+				Value(&data.CommitConvention),
+		).WithHideFunc(func() bool {
+			return !data.InitGit
+		}),
+
+		// Group 2b': How the doc guard hook runs (only with it)
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(T("wizard.hooks")).
+				Description(T("wizard.hooksHint")).
+				Options(hookFrameworkOptions()...).
+				Value(&data.HookFramework),
+		).WithHideFunc(func() bool {
+			return !data.DocGuard
+		}),
+
+		// Group 2c: Linters and formatters (only for languages seed has tools for)
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.standards")).
+				Description(T("wizard.standardsHint")).
+				OptionsFunc(func() []huh.Option[string] {
+					return standardOptions(data.Language)
+				}, &data.Language).
+				Value(&data.Standards),
+		).WithHideFunc(func() bool {
+			return !hasStandards(data.Language)
+		}),
+
+		// Group 3: Dev container image (only shown if opted in). The
+		// language's image comes first, so it's preselected; the highlighted
+		// image's tools are shown as its description
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(T("wizard.stack")).
+				OptionsFunc(func() []huh.Option[string] {
+					return imageOptions(data.Language, catalog)
+				}, &data.Language).
+				DescriptionFunc(func() string {
+					return imageDescription(data.DevContainerImage, catalog)
+				}, &data.DevContainerImage).
 				Value(&data.DevContainerImage),
+		).WithHideFunc(func() bool {
+			return !data.IncludeDevContainer
+		}),
 
-			huh.NewConfirm().
-				Title("Enable AI chat continuity?").
-				Value(&data.AIChatContinuity),
+		// Group 3': A custom image reference (only when chosen)
+		huh.NewGroup(
+			huh.NewInput().
+				Title(T("wizard.customImage")).
+				Description(T("wizard.customImageHint")).
+				Value(&customImage).
+				Validate(validateImageRef),
+		).WithHideFunc(func() bool {
+			return !data.IncludeDevContainer || data.DevContainerImage != customImageOption
+		}),
+
+		// Group 3'': Dev container details
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(T("wizard.ide")).
+				Description(T("wizard.ideHint")).
+				Options(ideOptions()...).
+				Value(&data.IDE),
 
 			huh.NewMultiSelect[string]().
-				Title("Agent extensions").
+				Title(T("wizard.chatContinuity")).
+				Description(T("wizard.chatContinuityHint")).
+				Options(chatToolOptions(aiTools)...).
+				Value(&data.ChatTools),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.chatState")).
+				Description(T("wizard.chatStateHint")).
 				Options(
-					huh.NewOption("Claude Code", "anthropics.claude-code"),
-					huh.NewOption("Codex", "openai.chatgpt"),
+					huh.NewOption(T("wizard.chatState.bind"), "bind"),
+					huh.NewOption(T("wizard.chatState.copy"), "copy"),
 				).
-				Value(&data.AgentExtensions),
+				Value(&data.ChatState),
+
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.forwardEnv")).
+				Description(T("wizard.forwardEnvHint")).
+				Options(forwardEnvOptions(data.ForwardEnv)...).
+				Value(&data.ForwardEnv),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.docker")).
+				Description(T("wizard.dockerHint")).
+				Options(
+					huh.NewOption(T("wizard.docker.none"), "none"),
+					huh.NewOption(T("wizard.docker.dind"), "docker-in-docker"),
+					huh.NewOption(T("wizard.docker.socket"), "docker-outside-of-docker"),
+				).
+				Value(&data.DockerAccess),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.workload")).
+				Description(T("wizard.workloadHint")).
+				Options(
+					huh.NewOption(T("wizard.workload.general"), "general"),
+					huh.NewOption(T("wizard.workload.ml"), "ml"),
+				).
+				Value(&data.Workload),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.gpu")).
+				Description(T("wizard.gpuHint")).
+				Options(gpuOptions()...).
+				Value(&gpu),
+
+			huh.NewSelect[string]().
+				Title(T("wizard.shell")).
+				Options(
+					huh.NewOption("bash", "bash"),
+					huh.NewOption(T("wizard.shell.zsh"), "zsh"),
+				).
+				Value(&data.Shell),
+
+			huh.NewInput().
+				Title(T("wizard.dotfiles")).
+				Description(T("wizard.dotfilesHint")).
+				Value(&data.DotfilesRepo).
+				Validate(func(s string) error {
+					if err := validateDotfilesRepo(s); err != nil {
+						return err
+					}
+					return checkSourceAllowed(cfg, sourceDotfiles, dotfilesURL(s))
+				}),
 		).WithHideFunc(func() bool {
 			return !data.IncludeDevContainer
 		}),
 
+		// Group 3a: VS Code's extensions cache (not for other IDEs)
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(T("wizard.extensionsCache")).
+				Description(T("wizard.extensionsCacheHint")).
+				Value(&extensionsCache),
+		).WithHideFunc(func() bool {
+			return !data.IncludeDevContainer || !usesVSCode(data.IDE)
+		}),
+
+		// Group 3b: Chat continuity without a dev container (a script that
+		// follows the project when it moves)
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.chatContinuityLocal")).
+				Description(T("wizard.chatContinuityLocalHint")).
+				Options(chatToolOptions(aiTools)...).
+				Value(&data.ChatTools),
+		).WithHideFunc(func() bool {
+			return data.IncludeDevContainer
+		}),
+
+		// Group 3b': VS Code extensions, for the dev container and/or
+		// .vscode/extensions.json (the language's set preselected); agent
+		// plugins only for a JetBrains dev container
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.agentExtensions")).
+				Description(T("wizard.agentExtensionsHint")).
+				OptionsFunc(func() []huh.Option[string] {
+					if data.IncludeDevContainer && data.IDE == ideJetBrains && !data.VSCodeConfig {
+						return jetbrainsAgentOptions()
+					}
+					return extensionOptions(stackLanguage(data.Language, data.DevContainerImage))
+				}, []*string{&data.Language, &data.DevContainerImage, &data.IDE}).
+				Value(&data.AgentExtensions),
+		).WithHideFunc(func() bool {
+			return !data.VSCodeConfig && (!data.IncludeDevContainer || data.IDE == ideNone)
+		}),
+
+		// Group 3c: .gitignore pattern sets (the stack's language preselected)
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.gitignore")).
+				Description(T("wizard.gitignoreHint")).
+				OptionsFunc(func() []huh.Option[string] {
+					return gitignoreOptions(defaultGitignore(stackLanguage(data.Language, data.DevContainerImage), data.Workload))
+				}, []*string{&data.Language, &data.DevContainerImage, &data.Workload}).
+				Value(&data.Gitignore),
+
+			huh.NewInput().
+				Title(T("wizard.gitignoreExtra")).
+				Description(T("wizard.gitignoreExtraHint")).
+				Value(&gitignoreExtra).
+				Validate(func(s string) error { return validatePatterns(splitPatterns(s)) }),
+		),
+
+		// Group 3d: README sections (all kept unless config or a profile
+		// leaves some out)
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(T("wizard.readmeSections")).
+				Description(T("wizard.readmeSectionsHint")).
+				Options(readmeSectionOptions(data.ReadmeOmit)...).
+				Value(&readmeKept),
+		),
+
 		// Group 4: License selection (kept last intentionally)
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("License").
-				Options(
-					huh.NewOption("None", "none"),
-					huh.NewOption("MIT", "MIT"),
-					huh.NewOption("Apache-2.0", "Apache-2.0"),
-				).
+				Title(T("wizard.license")).
+				Options(licenseOptions(slices.Contains(cfg.Require, "license"))...).
 				Value(&data.License),
 		),
+
+		// Group 5: SPDX headers (only once a license is chosen)
+		huh.NewGroup(
+			requiredField(cfg, "licenseHeaders", T("wizard.licenseHeaders"), &data.LicenseHeaders, huh.NewConfirm().
+				Title(T("wizard.licenseHeaders")).
+				Description(T("wizard.licenseHeadersHint")).
+				Value(&data.LicenseHeaders)),
+		).WithHideFunc(func() bool {
+			return licenseSPDX(data.License) == ""
+		}),
 	)
 
 	// Run the form and wait for user to complete or cancel
-	// form.Run() blocks until user submits (Enter) or cancels (Ctrl+C/Esc)
-	if err := form.Run(); err != nil {
-		// User cancelled (Ctrl+C) or unexpected error
-		return WizardData{}, err
-	}
+	// runWizardForm blocks until the user submits (Enter) or quits (Ctrl+C,
+	// confirmed once anything's been typed); Esc goes back a group
+	formErr := runWizardForm(form)
 
 	// Trim whitespace from text inputs
 	// This ensures "  myproject  " becomes "myproject"
 	data.ProjectName = strings.TrimSpace(data.ProjectName)
 	data.Description = strings.TrimSpace(data.Description)
+	data.DotfilesRepo = strings.TrimSpace(data.DotfilesRepo)
+	data.Secrets = splitEnvNames(secrets)
+	data.Topics = splitTopics(topics)
+	data.Goals = splitBrief(goals)
+	data.NonGoals = splitBrief(nonGoals)
+	data.Constraints = splitBrief(constraints)
+	data.GitignoreExtra = splitPatterns(gitignoreExtra)
+	data.NoExtensionsCache = !extensionsCache
+	data.ReadmeOmit = omittedSections(readmeKept)
+	data.GPU, data.CUDA = gpuFlags(gpu)
+	data.DevContainerImage = joinImageChoice(data.DevContainerImage, customImage)
+	data.CustomChatTools = customChatTools(aiTools, data.ChatTools)
+	applyExtras(&data, extraIDs, cfg.Require)
+	if formErr != nil {
+		// User cancelled (Ctrl+C) or unexpected error; the answers so far
+		// are returned for the session file (session.go)
+		return data, formErr
+	}
+	if err := requireComponents(&data, cfg.Require); err != nil {
+		return WizardData{}, err
+	}
+	if err := disableComponents(&data, disabled, cfg.Require); err != nil {
+		return WizardData{}, err
+	}
+	noteAnswers(data)
+	debugf("wizard complete (tools: git=%t docker=%t)", tools.Git, tools.Docker)
 
 	return data, nil
 }
 
+// wizardDefaults returns the answers the wizard starts from: preset, with
+// seed's defaults where it has none and config's settings added.
+func wizardDefaults(defaultName string, preset WizardData, cfg userConfig) WizardData {
+	data := preset
+	if data.ProjectName == "" {
+		data.ProjectName = defaultName
+	}
+	for _, d := range []struct {
+		value *string
+		def   string
+	}{
+		{&data.Shell, "bash"},
+		{&data.Workload, "general"},
+		{&data.ChatState, "bind"},
+		{&data.Visibility, "private"},
+		{&data.License, cfg.License},
+	} {
+		if *d.value == "" {
+			*d.value = d.def
+		}
+	}
+	data.Footer = brandFooter(cfg)
+	data.ImageRegistry = cfg.ImageRegistry
+	data.ForwardEnv = unionStrings(configForwardEnv(cfg), preset.ForwardEnv)
+	data.Mounts = unionStrings(configMounts(cfg), preset.Mounts)
+	data.ReadmeOmit = unionStrings(cfg.ReadmeOmit, preset.ReadmeOmit)
+	return data
+}
+
+// toolAvailability records which external tools the wizard's options depend on.
+type toolAvailability struct {
+	Git    bool // Needed to initialize a repository
+	Docker bool // Needed to open the generated dev container
+}
+
+// detectTools checks PATH for the tools behind the wizard's setup options.
+func detectTools() toolAvailability {
+	_, gitErr := exec.LookPath("git")
+	_, dockerErr := exec.LookPath("docker")
+	return toolAvailability{Git: gitErr == nil, Docker: dockerErr == nil}
+}
+
+// requiredField returns field, or, when config requires component, a note
+// saying so in its place (with the answer turned on).
+func requiredField(cfg userConfig, component, title string, value *bool, field huh.Field) huh.Field {
+	if !slices.Contains(cfg.Require, component) {
+		return field
+	}
+	*value = true
+	return huh.NewNote().
+		Title(title).
+		Description(T("wizard.required"))
+}
+
+// licenseOptions offers the licenses, leaving out "none" when config
+// requires one.
+func licenseOptions(required bool) []huh.Option[string] {
+	var options []huh.Option[string]
+	if !required {
+		options = append(options, huh.NewOption(T("wizard.license.none"), "none"))
+	}
+	return append(options,
+		huh.NewOption("MIT", "MIT"),
+		huh.NewOption("Apache-2.0", "Apache-2.0"),
+		huh.NewOption(T("wizard.license.dual"), dualLicense),
+	)
+}
+
+// devContainerHint annotates the extras when Docker is missing. The dev
+// container config is still useful (e.g. for Codespaces), so it's still offered.
+func devContainerHint(tools toolAvailability) string {
+	if tools.Docker {
+		return ""
+	}
+	return T("wizard.dockerMissingHint")
+}
+
 // validateProjectName validates the project name input.
 // Called automatically by Huh during form input.
 //
@@ -163,12 +629,12 @@ func validateProjectName(s string) error {
 
 	// Check minimum length (required field)
 	if len(trimmed) == 0 {
-		return errors.New("project name is required")
+		return errors.New(T("validate.nameRequired"))
 	}
 
 	// Check maximum length (sensible bound)
 	if len(trimmed) > 100 {
-		return errors.New("project name is too long (max 100 characters)")
+		return errors.New(T("validate.nameTooLong"))
 	}
 
 	return nil
@@ -190,15 +656,522 @@ func validateDescription(s string) error {
 
 	// Check minimum length (required field)
 	if len(trimmed) == 0 {
-		return errors.New("description is required")
+		return errors.New(T("validate.descriptionRequired"))
 	}
 
 	// Check maximum length (sensible bound)
 	// 500 chars is enough for 2-3 sentences
 	if len(trimmed) > 500 {
-		return errors.New("description is too long (max 500 characters)")
+		return errors.New(T("validate.descriptionTooLong"))
+	}
+
+	return nil
+}
+
+// dotfilesShorthand matches a GitHub "owner/repo" reference.
+var dotfilesShorthand = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// validateDotfilesRepo accepts an empty value (no dotfiles), a GitHub
+// owner/repo shorthand, or an https/ssh git URL.
+func validateDotfilesRepo(s string) error {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "", dotfilesShorthand.MatchString(s):
+		return nil
+	case strings.ContainsAny(s, " \t'\"`$;&|<>"):
+		return errors.New(T("validate.dotfilesInvalid"))
+	case strings.HasPrefix(s, "https://"), strings.HasPrefix(s, "ssh://"), strings.HasPrefix(s, "git@"):
+		return nil
+	}
+	return errors.New(T("validate.dotfilesInvalid"))
+}
+
+// dotfilesURL expands a GitHub owner/repo shorthand to a clone URL; other
+// values are returned unchanged.
+func dotfilesURL(repo string) string {
+	repo = strings.TrimSpace(repo)
+	if dotfilesShorthand.MatchString(repo) {
+		return "https://github.com/" + repo
+	}
+	return repo
+}
+
+// validateProjectURL accepts "" or an absolute http(s) URL for field.
+func validateProjectURL(field, s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || strings.ContainsAny(s, " \t\n") {
+		return errors.New(T("validate.url", field, s))
+	}
+	return nil
+}
+
+// envName matches a conventional environment variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// splitEnvNames parses a comma- or space-separated list of variable names,
+// dropping empties and duplicates.
+func splitEnvNames(s string) []string {
+	var names []string
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// topicName matches a GitHub topic: lowercase letters, digits and hyphens,
+// starting with a letter or digit.
+var topicName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// maxTopics is how many topics GitHub allows on a repository.
+const maxTopics = 20
+
+// splitTopics parses a comma- or space-separated list of topics, lowercased,
+// dropping empties and duplicates.
+func splitTopics(s string) []string {
+	var topics []string
+	for _, topic := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// validateTopics checks topics against GitHub's rules.
+func validateTopics(topics []string) error {
+	if len(topics) > maxTopics {
+		return errors.New(T("validate.topicCount", maxTopics))
+	}
+	for _, topic := range topics {
+		if !topicName.MatchString(topic) {
+			return errors.New(T("validate.topic", topic))
+		}
+	}
+	return nil
+}
+
+// maxBriefLine bounds one goal, non-goal or constraint.
+const maxBriefLine = 200
+
+// briefField is a multi-line input for one list of the project brief.
+func briefField(title, hint, field string, value *string) *huh.Text {
+	return huh.NewText().
+		Title(title).
+		Description(hint).
+		Lines(3).
+		CharLimit(2000).
+		Value(value).
+		Validate(func(s string) error { return validateBrief(field, splitBrief(s)) })
+}
+
+// splitBrief parses one entry per line, dropping blank lines and any list
+// marker ("- ", "* ") the user typed.
+func splitBrief(s string) []string {
+	var entries []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*•"))
+		if line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+// validateBrief rejects entries that wouldn't render as one bullet.
+func validateBrief(field string, entries []string) error {
+	for _, entry := range entries {
+		if strings.ContainsAny(entry, "\r\n") || utf8.RuneCountInString(entry) > maxBriefLine {
+			return errors.New(T("validate.briefLine", field, maxBriefLine))
+		}
+	}
+	return nil
+}
+
+// commonForwardEnv are the host variables the wizard offers to forward into
+// the dev container (GH_TOKEN and GITHUB_TOKEN are always forwarded).
+var commonForwardEnv = []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "NPM_TOKEN"}
+
+// configForwardEnv returns the forwardEnv default from config.json, skipping
+// entries that aren't valid variable names.
+func configForwardEnv(cfg userConfig) []string {
+	var names []string
+	for _, name := range cfg.ForwardEnv {
+		name = strings.TrimSpace(name)
+		if envName.MatchString(name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// forwardEnvOptions lists the common variables followed by any configured
+// defaults that aren't among them.
+func forwardEnvOptions(defaults []string) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, name := range commonForwardEnv {
+		options = append(options, huh.NewOption(name, name))
 	}
+	for _, name := range defaults {
+		if !slices.Contains(commonForwardEnv, name) {
+			options = append(options, huh.NewOption(name, name))
+		}
+	}
+	return options
+}
+
+// aiToolID matches an AI tool identifier.
+var aiToolID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// stateDirPath matches a relative path under $HOME that is safe to put in
+// mount strings and setup.sh.
+var stateDirPath = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*$`)
+
+// validateAITool checks a tool definition from config or answers.
+func validateAITool(tool aiTool) error {
+	if !aiToolID.MatchString(tool.ID) {
+		return errors.New(T("validate.aiToolID", tool.ID))
+	}
+	parts := strings.Split(tool.StateDir, "/")
+	if !stateDirPath.MatchString(tool.StateDir) || slices.Contains(parts, "..") || slices.Contains(parts, ".") {
+		return errors.New(T("validate.stateDir", tool.StateDir))
+	}
+	return nil
+}
+
+// mergeAITools applies tool definitions to knownAITools: one with a known ID
+// relocates that tool (keeping its label unless one is given), others are
+// added after the known tools.
+func mergeAITools(defs []aiTool) []aiTool {
+	tools := slices.Clone(knownAITools)
+	for _, def := range defs {
+		if i := slices.IndexFunc(tools, func(t aiTool) bool { return t.ID == def.ID }); i >= 0 {
+			tools[i].StateDir = def.StateDir
+			if def.Label != "" {
+				tools[i].Label = def.Label
+			}
+			continue
+		}
+		if def.Label == "" {
+			def.Label = def.ID
+		}
+		tools = append(tools, def)
+	}
+	return tools
+}
+
+// configAITools returns the tools the wizard offers, with config.json's
+// aiTools applied. Invalid definitions are skipped.
+func configAITools(cfg userConfig) []aiTool {
+	var defs []aiTool
+	for _, def := range cfg.AITools {
+		if validateAITool(def) == nil {
+			defs = append(defs, def)
+		} else {
+			debugf("ignoring invalid config aiTools entry")
+		}
+	}
+	return mergeAITools(defs)
+}
+
+// customChatTools returns the chosen tools whose definition differs from
+// seed's built-in one, for recording in the answers.
+func customChatTools(tools []aiTool, chosen []string) []aiTool {
+	var custom []aiTool
+	for _, tool := range tools {
+		if slices.Contains(chosen, tool.ID) && !slices.Contains(knownAITools, tool) {
+			custom = append(custom, tool)
+		}
+	}
+	return custom
+}
+
+// chatToolOptions lists the AI tools chat continuity can persist.
+func chatToolOptions(tools []aiTool) []huh.Option[string] {
+	options := make([]huh.Option[string], 0, len(tools))
+	for _, tool := range tools {
+		options = append(options, huh.NewOption(tool.Label, tool.ID))
+	}
+	return options
+}
 
+// chatTools resolves the chat continuity answers to tools, in knownAITools
+// order followed by custom tools. Answers from before chatTools existed have
+// a single yes/no, which covered Claude Code and Codex in the dev container.
+func (w WizardData) chatTools() []aiTool {
+	ids := w.ChatTools
+	if len(ids) == 0 && w.AIChatContinuity && w.IncludeDevContainer {
+		ids = legacyChatTools
+	}
+	var tools []aiTool
+	for _, tool := range mergeAITools(w.CustomChatTools) {
+		if slices.Contains(ids, tool.ID) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// volumeName matches a Docker named volume.
+var volumeName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// mountSpec expands an extra mount to devcontainer.json's mount string. A
+// full mount string ("source=...,target=...,type=bind") is checked and kept
+// as is; a "source:target" shorthand becomes a bind mount when the source is
+// a path (absolute, ~/ or ${localEnv:...}) and a named volume otherwise.
+func mountSpec(mount string) (spec, target string, err error) {
+	mount = strings.TrimSpace(mount)
+	if mount == "" || strings.ContainsAny(mount, "\"'`\n") {
+		return "", "", errors.New(T("validate.mountInvalid", mount))
+	}
+
+	if !strings.Contains(mount, "=") {
+		i := strings.LastIndex(mount, ":")
+		if i < 0 {
+			return "", "", errors.New(T("validate.mountInvalid", mount))
+		}
+		source, target := mount[:i], mount[i+1:]
+		kind := "bind"
+		switch {
+		case strings.HasPrefix(source, "~/"):
+			source = "${localEnv:HOME}" + source[1:]
+		case strings.HasPrefix(source, "/"), strings.HasPrefix(source, "${"):
+		case volumeName.MatchString(source):
+			kind = "volume"
+		default:
+			return "", "", errors.New(T("validate.mountInvalid", mount))
+		}
+		if !strings.HasPrefix(target, "/") {
+			return "", "", errors.New(T("validate.mountInvalid", mount))
+		}
+		return fmt.Sprintf("source=%s,target=%s,type=%s", source, target, kind), target, nil
+	}
+
+	fields := map[string]string{}
+	for _, part := range strings.Split(mount, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	target = fields["target"]
+	if target == "" {
+		target = fields["dst"]
+	}
+	if target == "" {
+		target = fields["destination"]
+	}
+	source := fields["source"]
+	if source == "" {
+		source = fields["src"]
+	}
+	switch {
+	case !strings.HasPrefix(target, "/"),
+		!slices.Contains([]string{"bind", "volume", "tmpfs"}, fields["type"]),
+		source == "" && fields["type"] != "tmpfs":
+		return "", "", errors.New(T("validate.mountInvalid", mount))
+	}
+	return mount, target, nil
+}
+
+// reservedMountTargets are container paths seed's own mounts may use,
+// given the AI tools whose state is mounted.
+func reservedMountTargets(tools []aiTool) []string {
+	targets := []string{"/home/vscode/.vscode-extensions-cache", "/var/run/docker-host.sock"}
+	for _, tool := range tools {
+		targets = append(targets, "/home/vscode/"+tool.StateDir, hostStateStaging+"/"+tool.StateDir)
+	}
+	return targets
+}
+
+// validateMounts checks each extra mount and rejects targets that clash
+// with each other or with seed's own mounts (including tools' state dirs).
+func validateMounts(mounts []string, tools []aiTool) error {
+	seen := reservedMountTargets(tools)
+	for _, mount := range mounts {
+		_, target, err := mountSpec(mount)
+		if err != nil {
+			return err
+		}
+		target = strings.TrimSuffix(target, "/")
+		if slices.Contains(seen, target) {
+			return errors.New(T("validate.mountTarget", target))
+		}
+		seen = append(seen, target)
+	}
+	return nil
+}
+
+// mountSpecs expands validated mounts for devcontainer.json.
+func mountSpecs(mounts []string) []string {
+	var specs []string
+	for _, mount := range mounts {
+		if spec, _, err := mountSpec(mount); err == nil {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// configMounts returns the mounts default from config.json, skipping any
+// that don't validate.
+func configMounts(cfg userConfig) []string {
+	var mounts []string
+	for _, mount := range cfg.Mounts {
+		if validateMounts(append(slices.Clone(mounts), mount), knownAITools) == nil {
+			mounts = append(mounts, strings.TrimSpace(mount))
+		} else {
+			debugf("ignoring invalid config mount")
+		}
+	}
+	return mounts
+}
+
+// validateEnvNames rejects anything that isn't a plain variable name, which
+// also keeps values ("KEY=value") out of generated files.
+func validateEnvNames(names []string) error {
+	for _, name := range names {
+		if !envName.MatchString(name) {
+			return errors.New(T("validate.envName", name))
+		}
+	}
+	return nil
+}
+
+// Validate checks answers that didn't come through the interactive form
+// (e.g. from a batch spec), applying the same rules the wizard enforces.
+func (w WizardData) Validate() error {
+	if err := validateProjectName(w.ProjectName); err != nil {
+		return err
+	}
+	if err := validateDescription(w.Description); err != nil {
+		return err
+	}
+	switch w.License {
+	case "", "none", "MIT", "Apache-2.0", dualLicense:
+	default:
+		return fmt.Errorf("unknown license %q (use none, MIT, Apache-2.0 or %q)", w.License, dualLicense)
+	}
+	switch w.Shell {
+	case "", "bash", "zsh":
+	default:
+		return fmt.Errorf("unknown shell %q (use bash or zsh)", w.Shell)
+	}
+	switch w.DockerAccess {
+	case "", "none", "docker-in-docker", "docker-outside-of-docker":
+	default:
+		return fmt.Errorf("unknown dockerAccess %q (use none, docker-in-docker or docker-outside-of-docker)", w.DockerAccess)
+	}
+	switch w.Workload {
+	case "", "general", "ml":
+	default:
+		return fmt.Errorf("unknown workload %q (use general or ml)", w.Workload)
+	}
+	if err := validateEnvNames(w.Secrets); err != nil {
+		return err
+	}
+	if err := validateEnvNames(w.ForwardEnv); err != nil {
+		return err
+	}
+	switch w.Visibility {
+	case "", "private", "public":
+	default:
+		return errors.New(T("validate.visibility", w.Visibility))
+	}
+	if err := validateHookFramework(w.HookFramework); err != nil {
+		return err
+	}
+	if err := validateTopics(w.Topics); err != nil {
+		return err
+	}
+	if err := validateProjectURL("homepage", w.Homepage); err != nil {
+		return err
+	}
+	if err := validateProjectURL("documentation", w.Documentation); err != nil {
+		return err
+	}
+	if err := validateProjectURL("issueTracker", w.IssueTracker); err != nil {
+		return err
+	}
+	if w.LinkTasks && strings.TrimSpace(w.IssueTracker) == "" {
+		return errors.New(T("validate.linkTasks"))
+	}
+	if err := validateTeam(w.Team); err != nil {
+		return err
+	}
+	if err := validateMaintainer(w.Maintainer); err != nil {
+		return err
+	}
+	if err := validateMaturity(w.Maturity); err != nil {
+		return err
+	}
+	if err := validateCommitConvention(w.CommitConvention); err != nil {
+		return err
+	}
+	if err := validateBrief("goals", w.Goals); err != nil {
+		return err
+	}
+	if err := validateBrief("nonGoals", w.NonGoals); err != nil {
+		return err
+	}
+	if err := validateBrief("constraints", w.Constraints); err != nil {
+		return err
+	}
+	for _, def := range w.CustomChatTools {
+		if err := validateAITool(def); err != nil {
+			return err
+		}
+	}
+	aiTools := mergeAITools(w.CustomChatTools)
+	for _, id := range w.ChatTools {
+		if !slices.ContainsFunc(aiTools, func(tool aiTool) bool { return tool.ID == id }) {
+			return errors.New(T("validate.chatTool", id))
+		}
+	}
+	switch w.ChatState {
+	case "", "bind", "copy":
+	default:
+		return errors.New(T("validate.chatState", w.ChatState))
+	}
+	if w.Language != "" && stackFor(w.Language) == nil {
+		return errors.New(T("validate.language", w.Language))
+	}
+	if err := validateStandards(w.Standards, w.Language); err != nil {
+		return err
+	}
+	if err := validateReadmeOmit(w.ReadmeOmit); err != nil {
+		return err
+	}
+	if err := validateGitignore(w.Gitignore); err != nil {
+		return err
+	}
+	if err := validatePatterns(w.GitignoreExtra); err != nil {
+		return err
+	}
+	if err := validateMounts(w.Mounts, w.chatTools()); err != nil {
+		return err
+	}
+	if err := validateIDE(w.IDE); err != nil {
+		return err
+	}
+	if w.ExtensionsVolume != "" && !volumeName.MatchString(w.ExtensionsVolume) {
+		return errors.New(T("validate.volumeName", w.ExtensionsVolume))
+	}
+	if err := validateDotfilesRepo(w.DotfilesRepo); err != nil {
+		return err
+	}
+	if w.IncludeDevContainer && strings.TrimSpace(w.DevContainerImage) == "" {
+		return errors.New("devContainerImage is required when includeDevContainer is true")
+	}
+	if strings.Contains(w.DevContainerImage, "/") {
+		if err := validateImageRef(w.DevContainerImage); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -213,9 +1186,52 @@ func (w WizardData) ToTemplateData() TemplateData {
 		ProjectName:         w.ProjectName,
 		Description:         w.Description,
 		License:             w.License,
+		LicenseHeaders:      w.LicenseHeaders,
+		Gitignore:           w.Gitignore,
+		GitignoreExtra:      w.GitignoreExtra,
 		IncludeDevContainer: w.IncludeDevContainer,
 		DevContainerImage:   w.DevContainerImage,
-		AIChatContinuity:    w.AIChatContinuity,
+		Language:            w.Language,
+		VSCodeConfig:        w.VSCodeConfig,
+		ChatTools:           w.chatTools(),
+		ChatState:           w.ChatState,
 		VSCodeExtensions:    w.AgentExtensions,
+		IDE:                 w.IDE,
+		Shell:               w.Shell,
+		DotfilesRepo:        dotfilesURL(w.DotfilesRepo),
+		DockerAccess:        w.DockerAccess,
+		Workload:            w.Workload,
+		GPU:                 w.GPU || w.CUDA,
+		CUDA:                w.CUDA,
+		Secrets:             w.Secrets,
+		ForwardEnv:          w.ForwardEnv,
+		Mounts:              mountSpecs(w.Mounts),
+		NoExtensionsCache:   w.NoExtensionsCache,
+		ExtensionsVolume:    w.ExtensionsVolume,
+		NoSkills:            w.NoSkills,
+		ReadmeOmit:          w.ReadmeOmit,
+		Commands:            w.Commands,
+		Packages:            w.Packages,
+		Footer:              w.Footer,
+		ImageRegistry:       w.ImageRegistry,
+		Visibility:          w.Visibility,
+		Topics:              w.Topics,
+		Homepage:            strings.TrimSpace(w.Homepage),
+		Documentation:       strings.TrimSpace(w.Documentation),
+		IssueTracker:        strings.TrimSpace(w.IssueTracker),
+		LinkTasks:           w.LinkTasks,
+		Maturity:            w.Maturity,
+		Team:                strings.TrimSpace(w.Team),
+		Maintainer:          strings.TrimSpace(w.Maintainer),
+		CommitConvention:    w.CommitConvention,
+		BranchProtection:    w.BranchProtection,
+		DocHealth:           w.DocHealth,
+		DocGuard:            w.DocGuard,
+		HookFramework:       w.HookFramework,
+		AgentAction:         w.AgentAction,
+		Goals:               w.Goals,
+		NonGoals:            w.NonGoals,
+		Constraints:         w.Constraints,
+		Standards:           w.Standards,
 	}
 }
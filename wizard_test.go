@@ -1,8 +1,11 @@
 package main
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/huh"
 )
 
 func TestValidateProjectName(t *testing.T) {
@@ -99,8 +102,8 @@ func TestToTemplateData(t *testing.T) {
 	if td.DevContainerImage != wd.DevContainerImage {
 		t.Errorf("DevContainerImage: got %q, want %q", td.DevContainerImage, wd.DevContainerImage)
 	}
-	if td.AIChatContinuity != wd.AIChatContinuity {
-		t.Errorf("AIChatContinuity: got %v, want %v", td.AIChatContinuity, wd.AIChatContinuity)
+	if len(td.ChatTools) != len(legacyChatTools) {
+		t.Errorf("AIChatContinuity should map to the legacy tools, got %+v", td.ChatTools)
 	}
 	if len(td.VSCodeExtensions) != len(wd.AgentExtensions) {
 		t.Errorf("VSCodeExtensions length: got %d, want %d", len(td.VSCodeExtensions), len(wd.AgentExtensions))
@@ -111,3 +114,331 @@ func TestToTemplateData(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDotfilesRepo(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+		wantURL string
+	}{
+		{"", false, ""},
+		{"octo/dotfiles", false, "https://github.com/octo/dotfiles"},
+		{"https://gitlab.com/octo/dotfiles.git", false, "https://gitlab.com/octo/dotfiles.git"},
+		{"git@github.com:octo/dotfiles.git", false, "git@github.com:octo/dotfiles.git"},
+		{"ssh://git@example.com/dotfiles", false, "ssh://git@example.com/dotfiles"},
+		{"dotfiles", true, ""},
+		{"http://example.com/dotfiles", true, ""},
+		{"https://example.com/a b", true, ""},
+		{"octo/dotfiles; rm -rf ~", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := validateDotfilesRepo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateDotfilesRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && dotfilesURL(tt.input) != tt.wantURL {
+				t.Errorf("dotfilesURL(%q) = %q, want %q", tt.input, dotfilesURL(tt.input), tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestSplitEnvNames(t *testing.T) {
+	got := splitEnvNames(" OPENAI_API_KEY, DATABASE_URL\nOPENAI_API_KEY  NPM_TOKEN,,")
+	want := []string{"OPENAI_API_KEY", "DATABASE_URL", "NPM_TOKEN"}
+	if !slices.Equal(got, want) {
+		t.Errorf("splitEnvNames = %v, want %v", got, want)
+	}
+	if got := splitEnvNames("  "); got != nil {
+		t.Errorf("blank input should give no names, got %v", got)
+	}
+}
+
+func TestSplitTopics(t *testing.T) {
+	got := splitTopics(" CLI, data-pipeline\ncli  go,,")
+	want := []string{"cli", "data-pipeline", "go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("splitTopics = %v, want %v", got, want)
+	}
+	if err := validateTopics(make([]string, maxTopics+1)); err == nil {
+		t.Errorf("expected more than %d topics to be refused", maxTopics)
+	}
+}
+
+func TestValidateProjectURL(t *testing.T) {
+	for _, ok := range []string{"", "https://example.com", " http://docs.example.com/v1/ "} {
+		if err := validateProjectURL("homepage", ok); err != nil {
+			t.Errorf("validateProjectURL(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"example.com", "ftp://example.com", "https://", "https://exa mple.com", "javascript:alert(1)"} {
+		if err := validateProjectURL("homepage", bad); err == nil {
+			t.Errorf("validateProjectURL(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSplitBrief(t *testing.T) {
+	got := splitBrief("Ship a CLI\n\n  - Under 10ms startup \n* Works offline\n")
+	want := []string{"Ship a CLI", "Under 10ms startup", "Works offline"}
+	if !slices.Equal(got, want) {
+		t.Errorf("splitBrief = %q, want %q", got, want)
+	}
+	if got := splitBrief(" \n "); got != nil {
+		t.Errorf("blank input should give no entries, got %q", got)
+	}
+}
+
+func TestForwardEnvDefaults(t *testing.T) {
+	defaults := configForwardEnv(userConfig{ForwardEnv: []string{" NPM_TOKEN", "HF_TOKEN", "not valid", "HF_TOKEN"}})
+	if want := []string{"NPM_TOKEN", "HF_TOKEN"}; !slices.Equal(defaults, want) {
+		t.Fatalf("configForwardEnv = %v, want %v", defaults, want)
+	}
+
+	var values []string
+	for _, option := range forwardEnvOptions(defaults) {
+		values = append(values, option.Value)
+	}
+	if want := []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "NPM_TOKEN", "HF_TOKEN"}; !slices.Equal(values, want) {
+		t.Errorf("forwardEnvOptions = %v, want %v", values, want)
+	}
+}
+
+func TestMountSpec(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "~/models:/home/vscode/models", want: "source=${localEnv:HOME}/models,target=/home/vscode/models,type=bind"},
+		{in: "/srv/data:/data", want: "source=/srv/data,target=/data,type=bind"},
+		{in: "hf-cache:/home/vscode/.cache/huggingface", want: "source=hf-cache,target=/home/vscode/.cache/huggingface,type=volume"},
+		{in: "source=/srv/data,target=/data,type=bind,readonly", want: "source=/srv/data,target=/data,type=bind,readonly"},
+		{in: "target=/scratch,type=tmpfs", want: "target=/scratch,type=tmpfs"},
+		{in: "/srv/data", wantErr: true},
+		{in: "/srv/data:data", wantErr: true},
+		{in: "source=/srv/data,target=/data", wantErr: true},
+		{in: "source=/srv/data,target=/data,type=npipe", wantErr: true},
+		{in: `/srv/"data":/data`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, _, err := mountSpec(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mountSpec(%q) error = %v, wantErr %t", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mountSpec(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMounts(t *testing.T) {
+	if err := validateMounts([]string{"/a:/data", "b:/cache"}, knownAITools); err != nil {
+		t.Errorf("distinct targets should validate: %v", err)
+	}
+	for _, mounts := range [][]string{
+		{"/a:/data", "/b:/data/"},
+		{"~/.claude:/home/vscode/.claude"},
+	} {
+		if err := validateMounts(mounts, knownAITools); err == nil || !strings.Contains(err.Error(), "already used") {
+			t.Errorf("validateMounts(%v) should reject the clashing target, got %v", mounts, err)
+		}
+	}
+
+	got := configMounts(userConfig{Mounts: []string{"/a:/data", "nonsense", "/b:/data"}})
+	if !slices.Equal(got, []string{"/a:/data"}) {
+		t.Errorf("configMounts should keep only valid, non-clashing mounts, got %v", got)
+	}
+}
+
+func TestConfigAITools(t *testing.T) {
+	tools := configAITools(userConfig{AITools: []aiTool{
+		{ID: "claude", StateDir: ".config/claude"},
+		{ID: "cursor", Label: "Cursor", StateDir: ".cursor"},
+		{ID: "bad", StateDir: "/etc"},
+		{ID: "worse", StateDir: ".config/../.ssh"},
+	}})
+
+	if len(tools) != len(knownAITools)+1 {
+		t.Fatalf("expected the known tools plus cursor, got %+v", tools)
+	}
+	if tools[0] != (aiTool{ID: "claude", Label: "Claude Code", StateDir: ".config/claude"}) {
+		t.Errorf("claude should be relocated and keep its label, got %+v", tools[0])
+	}
+	if last := tools[len(tools)-1]; last.ID != "cursor" || last.Label != "Cursor" {
+		t.Errorf("cursor should be added last, got %+v", last)
+	}
+
+	custom := customChatTools(tools, []string{"claude", "codex", "cursor"})
+	if len(custom) != 2 || custom[0].ID != "claude" || custom[1].ID != "cursor" {
+		t.Errorf("only chosen tools that differ from the built-ins should be recorded, got %+v", custom)
+	}
+}
+
+func TestChatToolsFromAnswers(t *testing.T) {
+	w := WizardData{
+		ChatTools:       []string{"cursor", "claude"},
+		CustomChatTools: []aiTool{{ID: "claude", Label: "Claude Code", StateDir: ".config/claude"}, {ID: "cursor", Label: "Cursor", StateDir: ".cursor"}},
+	}
+	got := w.chatTools()
+	if len(got) != 2 || got[0].StateDir != ".config/claude" || got[1].StateDir != ".cursor" {
+		t.Errorf("chatTools() = %+v, want relocated claude then cursor", got)
+	}
+}
+
+func TestWizardDataValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    WizardData
+		wantErr string
+	}{
+		{"minimal valid", WizardData{ProjectName: "x", Description: "y"}, ""},
+		{"devcontainer with image", WizardData{ProjectName: "x", Description: "y", IncludeDevContainer: true, DevContainerImage: "python:3-3.12"}, ""},
+		{"missing name", WizardData{Description: "y"}, "project name is required"},
+		{"missing description", WizardData{ProjectName: "x"}, "description is required"},
+		{"unknown license", WizardData{ProjectName: "x", Description: "y", License: "GPL-3.0"}, "unknown license"},
+		{"devcontainer without image", WizardData{ProjectName: "x", Description: "y", IncludeDevContainer: true}, "devContainerImage is required"},
+		{"zsh shell", WizardData{ProjectName: "x", Description: "y", Shell: "zsh", DotfilesRepo: "octo/dotfiles"}, ""},
+		{"unknown shell", WizardData{ProjectName: "x", Description: "y", Shell: "fish"}, "unknown shell"},
+		{"docker-in-docker", WizardData{ProjectName: "x", Description: "y", DockerAccess: "docker-in-docker"}, ""},
+		{"ml workload with gpu", WizardData{ProjectName: "x", Description: "y", Workload: "ml", GPU: true}, ""},
+		{"unknown workload", WizardData{ProjectName: "x", Description: "y", Workload: "huge"}, "unknown workload"},
+		{"secret names", WizardData{ProjectName: "x", Description: "y", Secrets: []string{"OPENAI_API_KEY", "db_url"}}, ""},
+		{"secret with a value", WizardData{ProjectName: "x", Description: "y", Secrets: []string{"OPENAI_API_KEY=sk-123"}}, "not a valid environment variable name"},
+		{"forwarded variable", WizardData{ProjectName: "x", Description: "y", ForwardEnv: []string{"NPM-TOKEN"}}, "not a valid environment variable name"},
+		{"extensions volume", WizardData{ProjectName: "x", Description: "y", ExtensionsVolume: "bad volume"}, "Invalid volume name"},
+		{"chat tools", WizardData{ProjectName: "x", Description: "y", ChatTools: []string{"claude", "aider"}}, ""},
+		{"unknown chat tool", WizardData{ProjectName: "x", Description: "y", ChatTools: []string{"cursor"}}, "is unknown"},
+		{"custom chat tool", WizardData{ProjectName: "x", Description: "y", ChatTools: []string{"cursor"}, CustomChatTools: []aiTool{{ID: "cursor", StateDir: ".cursor"}}}, ""},
+		{"custom chat tool outside home", WizardData{ProjectName: "x", Description: "y", CustomChatTools: []aiTool{{ID: "cursor", StateDir: "../.ssh"}}}, "Invalid state directory"},
+		{"mount over a chat tool", WizardData{ProjectName: "x", Description: "y", ChatTools: []string{"claude"}, Mounts: []string{"/a:/home/vscode/.claude"}}, "already used"},
+		{"unknown chat state", WizardData{ProjectName: "x", Description: "y", ChatState: "sync"}, "Unknown chatState"},
+		{"unknown language", WizardData{ProjectName: "x", Description: "y", Language: "cobol"}, "Unknown language"},
+		{"unknown docker access", WizardData{ProjectName: "x", Description: "y", DockerAccess: "podman"}, "unknown dockerAccess"},
+		{"unsafe dotfiles", WizardData{ProjectName: "x", Description: "y", DotfilesRepo: "https://x.example/$(rm -rf ~)"}, "dotfiles repository"},
+		{"public with topics", WizardData{ProjectName: "x", Description: "y", Visibility: "public", Topics: []string{"cli", "data-pipeline"}}, ""},
+		{"unknown visibility", WizardData{ProjectName: "x", Description: "y", Visibility: "internal"}, "unknown visibility"},
+		{"invalid topic", WizardData{ProjectName: "x", Description: "y", Topics: []string{"Data_Pipeline"}}, "not a valid topic"},
+		{"project brief", WizardData{ProjectName: "x", Description: "y", Goals: []string{"Ship a CLI"}, NonGoals: []string{"GUI"}, Constraints: []string{"No cgo"}}, ""},
+		{"multi-line constraint", WizardData{ProjectName: "x", Description: "y", Constraints: []string{"No cgo\n## Injected"}}, "constraints entries must be single lines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.data.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+			} else {
+				if err == nil {
+					t.Errorf("expected error containing %q, got nil", tt.wantErr)
+				} else if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestWizardAdaptsToMissingTools(t *testing.T) {
+	t.Run("git missing drops git from the extras", func(t *testing.T) {
+		for _, option := range extraOptions(nil, toolAvailability{Git: false}) {
+			if option.Value == "git" || option.Value == "branchProtection" {
+				t.Errorf("unexpected %s option without git", option.Value)
+			}
+		}
+		if hint := extrasHint(nil, toolAvailability{Git: false, Docker: true}); !strings.Contains(hint, "git init") {
+			t.Errorf("hint should explain the missing git: %q", hint)
+		}
+	})
+
+	t.Run("git present is offered", func(t *testing.T) {
+		if !slices.ContainsFunc(extraOptions(nil, toolAvailability{Git: true}), func(o huh.Option[string]) bool { return o.Value == "git" }) {
+			t.Error("expected a git option")
+		}
+	})
+
+	t.Run("docker missing annotates dev container question", func(t *testing.T) {
+		if hint := devContainerHint(toolAvailability{Docker: false}); !strings.Contains(hint, "Docker not found") {
+			t.Errorf("unexpected hint %q", hint)
+		}
+		if hint := devContainerHint(toolAvailability{Docker: true}); hint != "" {
+			t.Errorf("expected no hint with Docker, got %q", hint)
+		}
+	})
+
+	t.Run("detection follows PATH", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		if tools := detectTools(); tools.Git || tools.Docker {
+			t.Errorf("expected nothing found, got %+v", tools)
+		}
+	})
+}
+
+func TestDefaultGitignore(t *testing.T) {
+	tests := []struct {
+		language, image string
+		want            []string
+	}{
+		{"", "", []string{"os", "editor"}},
+		{"", "universal", []string{"os", "editor"}},
+		{"", "go:2-1.25-trixie", []string{"os", "editor", "go"}},
+		{"rust", "", []string{"os", "editor", "rust"}},
+		{"python", "go:2-1.25-trixie", []string{"os", "editor", "python"}},
+	}
+	for _, tt := range tests {
+		if got := defaultGitignore(stackLanguage(tt.language, tt.image), ""); !slices.Equal(got, tt.want) {
+			t.Errorf("defaultGitignore(%q, %q) = %v, want %v", tt.language, tt.image, got, tt.want)
+		}
+	}
+	if err := validateGitignore([]string{"os", "terraform"}); err != nil {
+		t.Errorf("known sets should validate: %v", err)
+	}
+	if err := validateGitignore([]string{"cobol"}); err == nil {
+		t.Error("unknown set should fail validation")
+	}
+
+	// The picker is localized; the headings written to .gitignore aren't
+	useLocale(t, "es")
+	options := gitignoreOptions([]string{"os"})
+	if options[0].Key != catalogs["es"]["wizard.gitignore.os"] || options[0].Key == catalogs[defaultLocale]["wizard.gitignore.os"] {
+		t.Errorf("expected the Spanish label for os, got %q", options[0].Key)
+	}
+	if got := gitignoreSections(TemplateData{Gitignore: []string{"os"}})[0].Label; got != "OS" {
+		t.Errorf("expected the English heading in .gitignore, got %q", got)
+	}
+}
+
+func TestSplitPatterns(t *testing.T) {
+	got := splitPatterns(" data/, *.parquet\n*.parquet  !keep.csv ")
+	if want := []string{"data/", "*.parquet", "!keep.csv"}; !slices.Equal(got, want) {
+		t.Errorf("splitPatterns = %v, want %v", got, want)
+	}
+	if err := validatePatterns(got); err != nil {
+		t.Errorf("validatePatterns(%v): %v", got, err)
+	}
+	for _, bad := range []string{"#comment", "two\nlines", " "} {
+		if err := validatePatterns([]string{bad}); err == nil {
+			t.Errorf("validatePatterns(%q) should fail", bad)
+		}
+	}
+}
+
+func TestExtensionOptions(t *testing.T) {
+	neutral := extensionOptions("")
+	if len(neutral) != len(agentExtensions) {
+		t.Errorf("without a language only agent extensions are offered, got %d options", len(neutral))
+	}
+	python := extensionOptions("python")
+	var values []string
+	for _, o := range python {
+		values = append(values, o.Value)
+	}
+	if want := []string{"anthropics.claude-code", "openai.chatgpt", "ms-python.python", "charliermarsh.ruff"}; !slices.Equal(values, want) {
+		t.Errorf("python options = %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Package main - wizardnav.go
+//
+// PURPOSE:
+// This file wraps the wizard's huh form with seed's own navigation. It's
+// responsible for:
+// - A footer that always shows how to go back and how to quit
+// - Esc going back one group instead of ending the wizard
+// - Asking before Ctrl+C throws away answers the user has typed
+//
+// DESIGN PATTERNS:
+// - A Bubble Tea model around *huh.Form, like previewModel: it handles Esc
+//   and Ctrl+C itself and passes every other message to the form
+// - Esc still belongs to a field that uses it (clearing a select's filter);
+//   it only goes back when no focused binding claims it
+// - Quitting before anything is typed needs no confirmation, so running
+//   seed by mistake is still one keystroke to undo
+//
+// USAGE:
+// if err := runWizardForm(form); err != nil { return err } // huh.ErrUserAborted on quit
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// wizardModel is the Bubble Tea model running the wizard's form.
+type wizardModel struct {
+	form       *huh.Form
+	edited     bool // The user has typed or toggled something
+	confirming bool // Ctrl+C was pressed; waiting for y to quit
+	aborted    bool
+}
+
+// newWizardModel wraps form, which quits the program when it completes.
+func newWizardModel(form *huh.Form) wizardModel {
+	form.SubmitCmd = tea.Quit
+	form.CancelCmd = tea.Quit
+	return wizardModel{form: form}
+}
+
+func (m wizardModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m wizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		if m.confirming {
+			m.confirming = false
+			if k.String() == "y" || k.String() == "Y" || k.String() == "ctrl+c" {
+				m.aborted = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		switch {
+		case k.String() == "ctrl+c":
+			if !m.edited {
+				m.aborted = true
+				return m, tea.Quit
+			}
+			m.confirming = true
+			return m, nil
+		case k.String() == "esc" && !m.fieldUsesEsc():
+			return m, m.form.PrevGroup()
+		case k.Type == tea.KeyRunes, k.Type == tea.KeySpace, k.Type == tea.KeyBackspace, k.Type == tea.KeyDelete:
+			m.edited = true
+		}
+	}
+	form, cmd := m.form.Update(msg)
+	m.form = form.(*huh.Form)
+	return m, cmd
+}
+
+// fieldUsesEsc reports whether the focused field has an enabled Esc binding.
+func (m wizardModel) fieldUsesEsc() bool {
+	for _, b := range m.form.KeyBinds() {
+		if b.Enabled() && slices.Contains(b.Keys(), "esc") {
+			return true
+		}
+	}
+	return false
+}
+
+func (m wizardModel) View() string {
+	if m.form.State != huh.StateNormal || m.aborted {
+		return ""
+	}
+	footer := dimStyle.Render(T("wizard.keys"))
+	if m.confirming {
+		footer = warnStyle.Render(T("wizard.quitConfirm"))
+	}
+	return m.form.View() + "\n\n" + footer + "\n"
+}
+
+// runWizardForm runs form with seed's navigation, returning
+// huh.ErrUserAborted if the user quits.
+func runWizardForm(form *huh.Form) error {
+	final, err := tea.NewProgram(newWizardModel(form), tea.WithOutput(wizardOutput), tea.WithReportFocus()).Run()
+	if err != nil {
+		return fmt.Errorf("huh: %w", err)
+	}
+	if final.(wizardModel).aborted {
+		return huh.ErrUserAborted
+	}
+	return nil
+}
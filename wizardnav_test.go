@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+func newTestWizardModel() wizardModel {
+	var name, description string
+	form := huh.NewForm(
+		huh.NewGroup(huh.NewInput().Title("Name").Value(&name)),
+		huh.NewGroup(huh.NewInput().Title("Description").Value(&description)),
+	)
+	m := newWizardModel(form)
+	m.Init()
+	return m
+}
+
+func press(m wizardModel, keys ...tea.KeyMsg) (wizardModel, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var next tea.Model
+		next, cmd = m.Update(k)
+		m = next.(wizardModel)
+	}
+	return m, cmd
+}
+
+var (
+	ctrlC  = tea.KeyMsg{Type: tea.KeyCtrlC}
+	escKey = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func runes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestWizardQuit(t *testing.T) {
+	// Nothing typed yet: Ctrl+C quits straight away
+	m, cmd := press(newTestWizardModel(), ctrlC)
+	if !m.aborted || !isQuit(cmd) {
+		t.Fatal("Ctrl+C before typing should quit")
+	}
+
+	// After typing, Ctrl+C asks first; any key but y keeps the answers
+	m, cmd = press(newTestWizardModel(), runes("api"), ctrlC)
+	if m.aborted || !m.confirming || isQuit(cmd) {
+		t.Fatal("Ctrl+C after typing should ask before quitting")
+	}
+	if !strings.Contains(m.View(), T("wizard.quitConfirm")) {
+		t.Errorf("expected the quit prompt in the view:\n%s", m.View())
+	}
+	m, cmd = press(m, runes("n"))
+	if m.aborted || m.confirming || isQuit(cmd) {
+		t.Fatal("n should go back to the wizard")
+	}
+	if !strings.Contains(m.View(), T("wizard.keys")) {
+		t.Errorf("expected the key footer in the view:\n%s", m.View())
+	}
+
+	// y, or a second Ctrl+C, quits
+	for _, confirm := range []tea.KeyMsg{runes("y"), ctrlC} {
+		m, cmd = press(m, ctrlC, confirm)
+		if !m.aborted || !isQuit(cmd) {
+			t.Fatalf("%s should confirm quitting", confirm)
+		}
+		m.aborted = false
+	}
+}
+
+func TestWizardEscGoesBack(t *testing.T) {
+	m, cmd := press(newTestWizardModel(), runes("api"), escKey)
+	if m.aborted || m.confirming || isQuit(cmd) {
+		t.Fatal("Esc shouldn't quit the wizard")
+	}
+	if m.form.State != huh.StateNormal {
+		t.Fatalf("Esc shouldn't end the form, state %v", m.form.State)
+	}
+}
@@ -0,0 +1,490 @@
+// Package main - workspace.go
+//
+// PURPOSE:
+// This file implements `seed add package <name>` for monorepos.
+// It's responsible for:
+// - Detecting the enclosing workspace (go.work, npm/yarn, pnpm, Cargo)
+// - Rendering package-scoped docs (no LICENSE, .editorconfig or other root files)
+// - Writing a minimal package manifest so the workspace tooling accepts it
+// - Registering the new package in the workspace file
+// - Listing it in the root AGENTS.md (workspaceagents.go)
+//
+// DESIGN PATTERNS:
+// - Workspace files are edited textually (append/insert) rather than
+//   re-marshaled, so user formatting and key order survive
+// - Registration is skipped when an existing glob already covers the package
+//
+// USAGE:
+// ws, err := detectWorkspace(cwd)
+// report, err := addPackage(ws, packageOptions{Name: "api", Description: "..."})
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// workspaceKind identifies a monorepo workspace flavour.
+type workspaceKind string
+
+const (
+	workspaceGo    workspaceKind = "go"
+	workspaceNPM   workspaceKind = "npm"
+	workspacePNPM  workspaceKind = "pnpm"
+	workspaceCargo workspaceKind = "cargo"
+)
+
+// workspace describes a detected workspace root and its registration file.
+type workspace struct {
+	Kind workspaceKind
+	Root string // Absolute workspace root directory
+	File string // Absolute path of the file packages are registered in
+}
+
+// packageOptions describes the package to add.
+type packageOptions struct {
+	Name        string // Package name (also the default directory name)
+	Description string // Short description for the package docs
+	Dir         string // Directory relative to the workspace root (default: packages/<name>)
+}
+
+// addPackageReport lists what addPackage did.
+type addPackageReport struct {
+	Dir        string           // Package directory relative to the workspace root
+	Created    []string         // Files created, relative to the workspace root
+	Registered bool             // Whether the workspace file was modified
+	CoveredBy  string           // Existing workspace glob that already includes the package
+	RootAgents rootAgentsUpdate // What happened to the root AGENTS.md's package list
+}
+
+// packageTemplates are the docs rendered into a workspace package. Root-level
+// files (LICENSE, .editorconfig, .gitignore, devcontainer, skills) belong to
+// the workspace, not the package.
+var packageTemplates = []struct {
+	Template string
+	Output   string
+}{
+	{"package-README.md.tmpl", "README.md"},
+	{"package-AGENTS.md.tmpl", "AGENTS.md"},
+}
+
+var packageNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// validatePackageName requires a lowercase, path-safe name that every
+// supported workspace tool accepts as a package identifier.
+func validatePackageName(name string) error {
+	if !packageNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid package name %q (use lowercase letters, digits, '.', '-' or '_')", name)
+	}
+	return nil
+}
+
+// detectWorkspace walks up from dir looking for a workspace definition.
+// The nearest directory containing one wins; within a directory the order is
+// go.work, pnpm-workspace.yaml, package.json (with "workspaces"), Cargo.toml
+// (with [workspace]).
+func detectWorkspace(dir string) (workspace, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return workspace{}, err
+	}
+
+	for current := abs; ; current = filepath.Dir(current) {
+		if ws, ok := workspaceAt(current); ok {
+			return ws, nil
+		}
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+	return workspace{}, errors.New("no workspace found (looked for go.work, pnpm-workspace.yaml, package.json workspaces, Cargo.toml [workspace])")
+}
+
+func workspaceAt(dir string) (workspace, bool) {
+	if fileExists(filepath.Join(dir, "go.work")) {
+		return workspace{Kind: workspaceGo, Root: dir, File: filepath.Join(dir, "go.work")}, true
+	}
+	if fileExists(filepath.Join(dir, "pnpm-workspace.yaml")) {
+		return workspace{Kind: workspacePNPM, Root: dir, File: filepath.Join(dir, "pnpm-workspace.yaml")}, true
+	}
+	if raw, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg map[string]json.RawMessage
+		if json.Unmarshal(raw, &pkg) == nil {
+			if _, ok := pkg["workspaces"]; ok {
+				return workspace{Kind: workspaceNPM, Root: dir, File: filepath.Join(dir, "package.json")}, true
+			}
+		}
+	}
+	if raw, err := os.ReadFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+		if regexp.MustCompile(`(?m)^\[workspace\]`).Match(raw) {
+			return workspace{Kind: workspaceCargo, Root: dir, File: filepath.Join(dir, "Cargo.toml")}, true
+		}
+	}
+	return workspace{}, false
+}
+
+func fileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
+
+// addPackage creates the package directory, renders its docs and manifest,
+// and registers it with the workspace.
+func addPackage(s *Scaffolder, ws workspace, opts packageOptions) (addPackageReport, error) {
+	var report addPackageReport
+
+	if err := validatePackageName(opts.Name); err != nil {
+		return report, err
+	}
+	if err := validateDescription(opts.Description); err != nil {
+		return report, err
+	}
+
+	rel := opts.Dir
+	if rel == "" {
+		rel = path.Join("packages", opts.Name)
+	}
+	rel = path.Clean(filepath.ToSlash(rel))
+	if path.IsAbs(rel) || rel == "." || strings.HasPrefix(rel, "../") {
+		return report, fmt.Errorf("package directory %s must be inside the workspace", rel)
+	}
+	report.Dir = rel
+
+	pkgDir := filepath.Join(ws.Root, filepath.FromSlash(rel))
+	if entries, err := os.ReadDir(pkgDir); err == nil && len(entries) > 0 {
+		return report, fmt.Errorf("%s already exists and is not empty", rel)
+	}
+
+	// Render package-scoped docs plus the manifest the workspace tool needs
+	data := TemplateData{
+		ProjectName:   opts.Name,
+		Description:   opts.Description,
+		WorkspaceRoot: strings.TrimSuffix(strings.Repeat("../", strings.Count(rel, "/")+1), "/"),
+	}
+	var files []RenderedFile
+	for _, t := range packageTemplates {
+		file, err := s.renderFile(t.Template, t.Output, data)
+		if err != nil {
+			return report, err
+		}
+		files = append(files, stampFile(file))
+	}
+	manifest, err := packageManifestFiles(ws, opts.Name, rel)
+	if err != nil {
+		return report, err
+	}
+	files = append(files, manifest...)
+
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return report, fmt.Errorf("failed to create %s: %w", rel, err)
+	}
+	if err := writeFiles(pkgDir, files); err != nil {
+		return report, err
+	}
+	for _, f := range files {
+		report.Created = append(report.Created, path.Join(rel, f.Path))
+	}
+
+	registered, coveredBy, err := registerPackage(ws, rel)
+	if err != nil {
+		return report, fmt.Errorf("package created but not registered in %s: %w", filepath.Base(ws.File), err)
+	}
+	report.Registered = registered
+	report.CoveredBy = coveredBy
+
+	// Link the package's AGENTS.md from the root's
+	report.RootAgents, err = indexPackage(s, ws.Root, workspacePackage{Name: opts.Name, Dir: rel, Description: opts.Description})
+	if err != nil {
+		return report, fmt.Errorf("package created but not listed in %s: %w", rootAgentsFile, err)
+	}
+	return report, nil
+}
+
+// packageManifestFiles returns the minimal manifest that makes the directory
+// a valid member of the workspace. When seed scaffolded the root, its
+// license, topics and links carry over.
+func packageManifestFiles(ws workspace, name, rel string) ([]RenderedFile, error) {
+	var root WizardData
+	if m, err := readManifest(ws.Root); err == nil {
+		root = m.Answers
+	}
+	switch ws.Kind {
+	case workspaceGo:
+		module := name
+		goVersion := ""
+		if raw, err := os.ReadFile(ws.File); err == nil {
+			if m := regexp.MustCompile(`(?m)^go\s+(\S+)`).FindSubmatch(raw); m != nil {
+				goVersion = string(m[1])
+			}
+		}
+		if raw, err := os.ReadFile(filepath.Join(ws.Root, "go.mod")); err == nil {
+			if m := regexp.MustCompile(`(?m)^module\s+(\S+)`).FindSubmatch(raw); m != nil {
+				module = string(m[1]) + "/" + rel
+			}
+		}
+		content := "module " + module + "\n"
+		if goVersion != "" {
+			content += "\ngo " + goVersion + "\n"
+		}
+		return []RenderedFile{{Path: "go.mod", Content: []byte(content), Mode: 0644}}, nil
+
+	case workspaceNPM, workspacePNPM:
+		pkg := struct {
+			Name     string   `json:"name"`
+			Version  string   `json:"version"`
+			Private  bool     `json:"private"`
+			Keywords []string `json:"keywords,omitempty"`
+			Homepage string   `json:"homepage,omitempty"`
+		}{Name: name, Version: "0.0.0", Private: true, Keywords: root.Topics, Homepage: root.ToTemplateData().Website()}
+		raw, err := json.MarshalIndent(pkg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []RenderedFile{{Path: "package.json", Content: append(raw, '\n'), Mode: 0644}}, nil
+
+	case workspaceCargo:
+		cargo := fmt.Sprintf("[package]\nname = %q\nversion = \"0.1.0\"\nedition = \"2021\"\n", name)
+		if spdx := licenseSPDX(root.License); spdx != "" {
+			cargo += fmt.Sprintf("license = %q\n", spdx) // e.g. "MIT OR Apache-2.0"
+		}
+		if keywords := cargoKeywords(root.Topics); keywords != "" {
+			cargo += "keywords = " + keywords + "\n"
+		}
+		if root.Homepage != "" {
+			cargo += fmt.Sprintf("homepage = %q\n", root.Homepage)
+		}
+		if root.Documentation != "" {
+			cargo += fmt.Sprintf("documentation = %q\n", root.Documentation)
+		}
+		return []RenderedFile{
+			{Path: "Cargo.toml", Content: []byte(cargo), Mode: 0644},
+			{Path: "src/lib.rs", Content: []byte{}, Mode: 0644},
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported workspace kind %q", ws.Kind)
+}
+
+// cargoKeywords returns topics as a TOML array of the keywords crates.io
+// accepts (at most 5, each up to 20 characters), or "" for none.
+func cargoKeywords(topics []string) string {
+	var quoted []string
+	for _, topic := range topics {
+		if len(topic) <= 20 && len(quoted) < 5 {
+			quoted = append(quoted, strconv.Quote(topic))
+		}
+	}
+	if len(quoted) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// registerPackage adds rel to the workspace file. Returns registered=false and
+// the matching glob when an existing pattern (e.g. "packages/*") already
+// includes the package.
+func registerPackage(ws workspace, rel string) (bool, string, error) {
+	raw, err := os.ReadFile(ws.File)
+	if err != nil {
+		return false, "", err
+	}
+	content := string(raw)
+
+	var updated string
+	switch ws.Kind {
+	case workspaceGo:
+		entry := "./" + rel
+		if regexp.MustCompile(`(?m)^\s*(use\s+)?` + regexp.QuoteMeta(entry) + `\s*$`).MatchString(content) {
+			return false, entry, nil
+		}
+		// go.work accepts any number of use directives, so appending is safe
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		updated = content + "\nuse " + entry + "\n"
+
+	case workspaceNPM:
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if err := json.Unmarshal(raw, &pkg); err != nil {
+			return false, "", err
+		}
+		var globs []string
+		if json.Unmarshal(pkg.Workspaces, &globs) != nil {
+			var yarn struct {
+				Packages []string `json:"packages"`
+			}
+			if err := json.Unmarshal(pkg.Workspaces, &yarn); err != nil {
+				return false, "", errors.New("unrecognized \"workspaces\" format")
+			}
+			globs = yarn.Packages
+		}
+		if glob, ok := matchWorkspaceGlob(globs, rel); ok {
+			return false, glob, nil
+		}
+		keyIdx := strings.Index(content, `"workspaces"`)
+		updated, err = insertIntoJSONArray(content, keyIdx, rel)
+		if err != nil {
+			return false, "", err
+		}
+
+	case workspacePNPM:
+		if flow := regexp.MustCompile(`(?m)^packages:[ \t]*\[`).FindStringIndex(content); flow != nil {
+			// Flow sequence: packages: ['apps/*', 'packages/*']
+			closeIdx := strings.Index(content[flow[1]:], "]")
+			if closeIdx < 0 {
+				return false, "", errors.New("unterminated packages list; add the package by hand")
+			}
+			var globs []string
+			quote := "'"
+			for _, item := range strings.Split(content[flow[1]:flow[1]+closeIdx], ",") {
+				item = strings.TrimSpace(item)
+				if strings.HasPrefix(item, `"`) {
+					quote = `"`
+				}
+				if item = strings.Trim(item, `'"`); item != "" {
+					globs = append(globs, item)
+				}
+			}
+			if glob, ok := matchWorkspaceGlob(globs, rel); ok {
+				return false, glob, nil
+			}
+			updated, err = insertIntoArray(content, flow[0], quote+rel+quote)
+			if err != nil {
+				return false, "", err
+			}
+			break
+		}
+		if regexp.MustCompile(`(?m)^packages:[ \t]*[^\s#]`).MatchString(content) {
+			return false, "", errors.New("packages isn't a list seed can edit; add the package by hand")
+		}
+		lines := strings.Split(content, "\n")
+		inPackages, insertAt := false, -1
+		var globs []string
+		itemPattern := regexp.MustCompile(`^\s+-\s*['"]?([^'"]+?)['"]?\s*$`)
+		for i, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "packages:"):
+				inPackages, insertAt = true, i
+			case inPackages && itemPattern.MatchString(line):
+				globs = append(globs, itemPattern.FindStringSubmatch(line)[1])
+				insertAt = i
+			case inPackages && trimmed != "" && !strings.HasPrefix(trimmed, "#"):
+				inPackages = false
+			}
+		}
+		if glob, ok := matchWorkspaceGlob(globs, rel); ok {
+			return false, glob, nil
+		}
+		if insertAt < 0 {
+			if !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			updated = content + "packages:\n  - '" + rel + "'\n"
+		} else {
+			lines = append(lines[:insertAt+1], append([]string{"  - '" + rel + "'"}, lines[insertAt+1:]...)...)
+			updated = strings.Join(lines, "\n")
+		}
+
+	case workspaceCargo:
+		membersIdx := regexp.MustCompile(`(?m)^members\s*=`).FindStringIndex(content)
+		if membersIdx == nil {
+			updated = strings.Replace(content, "[workspace]", "[workspace]\nmembers = [\""+rel+"\"]", 1)
+			break
+		}
+		open := strings.Index(content[membersIdx[0]:], "[")
+		closeIdx := strings.Index(content[membersIdx[0]:], "]")
+		if open < 0 || closeIdx < open {
+			return false, "", errors.New("could not parse workspace members")
+		}
+		var globs []string
+		for _, m := range regexp.MustCompile(`"([^"]+)"`).FindAllStringSubmatch(content[membersIdx[0]+open:membersIdx[0]+closeIdx], -1) {
+			globs = append(globs, m[1])
+		}
+		if glob, ok := matchWorkspaceGlob(globs, rel); ok {
+			return false, glob, nil
+		}
+		updated, err = insertIntoJSONArray(content, membersIdx[0], rel)
+		if err != nil {
+			return false, "", err
+		}
+	}
+
+	if err := os.WriteFile(ws.File, []byte(updated), 0644); err != nil {
+		return false, "", err
+	}
+	return true, "", nil
+}
+
+// matchWorkspaceGlob reports the first glob (e.g. "packages/*") that matches rel.
+func matchWorkspaceGlob(globs []string, rel string) (string, bool) {
+	for _, glob := range globs {
+		clean := strings.TrimPrefix(glob, "./")
+		if clean == rel {
+			return glob, true
+		}
+		if ok, _ := path.Match(clean, rel); ok {
+			return glob, true
+		}
+		if strings.HasSuffix(clean, "/**") && strings.HasPrefix(rel, strings.TrimSuffix(clean, "**")) {
+			return glob, true
+		}
+	}
+	return "", false
+}
+
+// insertIntoJSONArray appends a quoted string to the first [...] array that
+// starts at or after from, preserving the surrounding formatting. Works for
+// JSON and for TOML arrays of strings.
+func insertIntoJSONArray(content string, from int, value string) (string, error) {
+	return insertIntoArray(content, from, fmt.Sprintf("%q", value))
+}
+
+// insertIntoArray appends item, already quoted, to the first [...] array that
+// starts at or after from. YAML flow sequences use it to keep their quotes.
+func insertIntoArray(content string, from int, quoted string) (string, error) {
+	if from < 0 {
+		return "", errors.New("array not found")
+	}
+	open := strings.Index(content[from:], "[")
+	if open < 0 {
+		return "", errors.New("array not found")
+	}
+	open += from
+	closeIdx := strings.Index(content[open:], "]")
+	if closeIdx < 0 {
+		return "", errors.New("unterminated array")
+	}
+	closeIdx += open
+
+	body := content[open+1 : closeIdx]
+	trimmed := strings.TrimRight(body, " \t\r\n")
+	switch {
+	case strings.TrimSpace(body) == "":
+		return content[:open+1] + quoted + content[closeIdx:], nil
+	case strings.Contains(body, "\n"):
+		// Multi-line array: put the new entry on its own line with the same indent
+		lastLine := trimmed[strings.LastIndex(trimmed, "\n")+1:]
+		indent := lastLine[:len(lastLine)-len(strings.TrimLeft(lastLine, " \t"))]
+		sep := ","
+		if strings.HasSuffix(trimmed, ",") {
+			sep = ""
+		}
+		return content[:open+1] + trimmed + sep + "\n" + indent + quoted + body[len(trimmed):] + content[closeIdx:], nil
+	default:
+		sep := ", "
+		if strings.HasSuffix(trimmed, ",") {
+			sep = " "
+		}
+		return content[:open+1] + trimmed + sep + quoted + body[len(trimmed):] + content[closeIdx:], nil
+	}
+}
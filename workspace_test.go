@@ -0,0 +1,292 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func mustAddPackage(t *testing.T, root string, opts packageOptions) (workspace, addPackageReport) {
+	t.Helper()
+	ws, err := detectWorkspace(root)
+	if err != nil {
+		t.Fatalf("detectWorkspace: %v", err)
+	}
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatalf("NewScaffolder: %v", err)
+	}
+	report, err := addPackage(s, ws, opts)
+	if err != nil {
+		t.Fatalf("addPackage: %v", err)
+	}
+	return ws, report
+}
+
+func TestDetectWorkspace(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  workspaceKind
+	}{
+		{"go.work", map[string]string{"go.work": "go 1.23\n"}, workspaceGo},
+		{"pnpm", map[string]string{"pnpm-workspace.yaml": "packages:\n  - 'packages/*'\n"}, workspacePNPM},
+		{"npm", map[string]string{"package.json": `{"name": "root", "workspaces": ["packages/*"]}`}, workspaceNPM},
+		{"cargo", map[string]string{"Cargo.toml": "[workspace]\nmembers = []\n"}, workspaceCargo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				writeTestFile(t, filepath.Join(root, name), content)
+			}
+			// Detection walks up from nested directories
+			nested := filepath.Join(root, "services", "deep")
+			os.MkdirAll(nested, 0755)
+
+			ws, err := detectWorkspace(nested)
+			if err != nil {
+				t.Fatalf("detectWorkspace: %v", err)
+			}
+			if ws.Kind != tt.want {
+				t.Errorf("kind: got %q, want %q", ws.Kind, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectWorkspaceIgnoresPlainPackageJSON(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "package.json"), `{"name": "not-a-workspace"}`)
+	if _, err := detectWorkspace(root); err == nil {
+		t.Error("package.json without workspaces should not count as a workspace")
+	}
+}
+
+func TestAddPackageGoWork(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.23\n\nuse ./cmd\n")
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/mono\n\ngo 1.23\n")
+	writeTestFile(t, filepath.Join(root, "LICENSE"), "root license")
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "api", Description: "The API package"})
+
+	if !report.Registered {
+		t.Error("package should be registered in go.work")
+	}
+	goWork, _ := os.ReadFile(filepath.Join(root, "go.work"))
+	if !strings.Contains(string(goWork), "use ./packages/api") {
+		t.Errorf("go.work should use the new package, got:\n%s", goWork)
+	}
+	goMod, _ := os.ReadFile(filepath.Join(root, "packages", "api", "go.mod"))
+	if !strings.Contains(string(goMod), "module example.com/mono/packages/api") || !strings.Contains(string(goMod), "go 1.23") {
+		t.Errorf("unexpected package go.mod:\n%s", goMod)
+	}
+
+	// Package-scoped docs, and no root-level files
+	for _, name := range []string{"README.md", "AGENTS.md"} {
+		if _, err := os.Stat(filepath.Join(root, "packages", "api", name)); err != nil {
+			t.Errorf("expected packages/api/%s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"LICENSE", ".editorconfig", ".gitignore", "skills"} {
+		if _, err := os.Stat(filepath.Join(root, "packages", "api", name)); !os.IsNotExist(err) {
+			t.Errorf("packages/api/%s should not be created", name)
+		}
+	}
+
+	agents, _ := os.ReadFile(filepath.Join(root, "packages", "api", "AGENTS.md"))
+	if !strings.Contains(string(agents), "../../AGENTS.md") {
+		t.Error("package AGENTS.md should link to the workspace root AGENTS.md")
+	}
+}
+
+func TestAddPackageNPMCoveredByGlob(t *testing.T) {
+	root := t.TempDir()
+	original := "{\n  \"name\": \"root\",\n  \"workspaces\": [\"packages/*\"]\n}\n"
+	writeTestFile(t, filepath.Join(root, "package.json"), original)
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "web", Description: "Web app"})
+
+	if report.Registered || report.CoveredBy != "packages/*" {
+		t.Errorf("expected package to be covered by packages/*, got registered=%v coveredBy=%q", report.Registered, report.CoveredBy)
+	}
+	raw, _ := os.ReadFile(filepath.Join(root, "package.json"))
+	if string(raw) != original {
+		t.Error("package.json should be untouched when a glob already covers the package")
+	}
+	if _, err := os.Stat(filepath.Join(root, "packages", "web", "package.json")); err != nil {
+		t.Errorf("package manifest should exist: %v", err)
+	}
+}
+
+func TestAddPackageNPMRegistersOutsideGlob(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "package.json"), "{\n  \"name\": \"root\",\n  \"workspaces\": [\n    \"packages/*\"\n  ]\n}\n")
+
+	mustAddPackage(t, root, packageOptions{Name: "docs", Description: "Docs site", Dir: "apps/docs"})
+
+	raw, _ := os.ReadFile(filepath.Join(root, "package.json"))
+	want := "{\n  \"name\": \"root\",\n  \"workspaces\": [\n    \"packages/*\",\n    \"apps/docs\"\n  ]\n}\n"
+	if string(raw) != want {
+		t.Errorf("package.json mismatch:\n got: %q\nwant: %q", raw, want)
+	}
+}
+
+func TestAddPackagePNPM(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "pnpm-workspace.yaml"), "packages:\n  - 'apps/*'\n\ncatalog:\n  react: ^18\n")
+
+	mustAddPackage(t, root, packageOptions{Name: "ui", Description: "Shared UI"})
+
+	raw, _ := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	want := "packages:\n  - 'apps/*'\n  - 'packages/ui'\n\ncatalog:\n  react: ^18\n"
+	if string(raw) != want {
+		t.Errorf("pnpm-workspace.yaml mismatch:\n got: %q\nwant: %q", raw, want)
+	}
+}
+
+func TestAddPackagePNPMFlowList(t *testing.T) {
+	root := t.TempDir()
+	original := "packages: ['packages/*', 'apps/*']\n"
+	writeTestFile(t, filepath.Join(root, "pnpm-workspace.yaml"), original)
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "ui", Description: "Shared UI"})
+	if report.Registered || report.CoveredBy != "packages/*" {
+		t.Errorf("expected package to be covered by packages/*, got registered=%v coveredBy=%q", report.Registered, report.CoveredBy)
+	}
+	raw, _ := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if string(raw) != original {
+		t.Errorf("pnpm-workspace.yaml should be untouched, got %q", raw)
+	}
+
+	mustAddPackage(t, root, packageOptions{Name: "docs", Description: "Docs site", Dir: "sites/docs"})
+	raw, _ = os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if want := "packages: ['packages/*', 'apps/*', 'sites/docs']\n"; string(raw) != want {
+		t.Errorf("pnpm-workspace.yaml mismatch:\n got: %q\nwant: %q", raw, want)
+	}
+}
+
+func TestAddPackagePNPMUnknownList(t *testing.T) {
+	root := t.TempDir()
+	original := "packages: &pkgs\n  - 'apps/*'\n"
+	writeTestFile(t, filepath.Join(root, "pnpm-workspace.yaml"), original)
+
+	ws, err := detectWorkspace(root)
+	if err != nil {
+		t.Fatalf("detectWorkspace: %v", err)
+	}
+	if _, _, err := registerPackage(ws, "packages/ui"); err == nil || !strings.Contains(err.Error(), "by hand") {
+		t.Errorf("expected an error asking for a manual edit, got %v", err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if string(raw) != original {
+		t.Errorf("pnpm-workspace.yaml should be untouched, got %q", raw)
+	}
+}
+
+func TestAddPackageCargo(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "Cargo.toml"), "[workspace]\nmembers = [\"crates/core\"]\n")
+
+	mustAddPackage(t, root, packageOptions{Name: "cli", Description: "Command line", Dir: "crates/cli"})
+
+	raw, _ := os.ReadFile(filepath.Join(root, "Cargo.toml"))
+	if want := "[workspace]\nmembers = [\"crates/core\", \"crates/cli\"]\n"; string(raw) != want {
+		t.Errorf("Cargo.toml mismatch:\n got: %q\nwant: %q", raw, want)
+	}
+	if _, err := os.Stat(filepath.Join(root, "crates", "cli", "src", "lib.rs")); err != nil {
+		t.Errorf("cargo package should have src/lib.rs: %v", err)
+	}
+}
+
+func TestAddPackageCargoLicense(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "Cargo.toml"), "[workspace]\nmembers = []\n")
+	if err := writeManifest(root, Manifest{Answers: WizardData{License: dualLicense}}); err != nil {
+		t.Fatal(err)
+	}
+
+	mustAddPackage(t, root, packageOptions{Name: "core", Description: "Core", Dir: "crates/core"})
+
+	raw, _ := os.ReadFile(filepath.Join(root, "crates", "core", "Cargo.toml"))
+	if !strings.Contains(string(raw), "license = \"MIT OR Apache-2.0\"\n") {
+		t.Errorf("crate Cargo.toml should carry the workspace license, got:\n%s", raw)
+	}
+}
+
+func TestAddPackageKeywords(t *testing.T) {
+	topics := []string{"cli", "a-topic-longer-than-twenty", "go", "rust", "tools", "dev", "extra"}
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "Cargo.toml"), "[workspace]\nmembers = []\n")
+	if err := writeManifest(root, Manifest{Answers: WizardData{Topics: topics, Homepage: "https://tools.dev", Documentation: "https://docs.rs/core"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// crates.io takes at most five keywords of up to 20 characters
+	mustAddPackage(t, root, packageOptions{Name: "core", Description: "Core", Dir: "crates/core"})
+	raw, _ := os.ReadFile(filepath.Join(root, "crates", "core", "Cargo.toml"))
+	if want := `keywords = ["cli", "go", "rust", "tools", "dev"]` + "\n"; !strings.Contains(string(raw), want) {
+		t.Errorf("crate Cargo.toml should have %q, got:\n%s", want, raw)
+	}
+	if want := "homepage = \"https://tools.dev\"\ndocumentation = \"https://docs.rs/core\"\n"; !strings.Contains(string(raw), want) {
+		t.Errorf("crate Cargo.toml should link the project, got:\n%s", raw)
+	}
+
+	os.Remove(filepath.Join(root, "Cargo.toml"))
+	writeTestFile(t, filepath.Join(root, "package.json"), `{"workspaces": ["packages/*"]}`)
+	mustAddPackage(t, root, packageOptions{Name: "web", Description: "Web", Dir: "packages/web"})
+	raw, _ = os.ReadFile(filepath.Join(root, "packages", "web", "package.json"))
+	if !strings.Contains(string(raw), `"keywords": [`) || !strings.Contains(string(raw), `"a-topic-longer-than-twenty"`) {
+		t.Errorf("package.json should list every topic as a keyword, got:\n%s", raw)
+	}
+	if !strings.Contains(string(raw), `"homepage": "https://tools.dev"`) {
+		t.Errorf("package.json should have the homepage, got:\n%s", raw)
+	}
+}
+
+func TestAddPackageErrors(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.23\n")
+	writeTestFile(t, filepath.Join(root, "packages", "taken", "main.go"), "package main\n")
+
+	ws, err := detectWorkspace(root)
+	if err != nil {
+		t.Fatalf("detectWorkspace: %v", err)
+	}
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatalf("NewScaffolder: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    packageOptions
+		wantErr string
+	}{
+		{"invalid name", packageOptions{Name: "Bad Name", Description: "x"}, "invalid package name"},
+		{"missing description", packageOptions{Name: "ok"}, "description is required"},
+		{"outside workspace", packageOptions{Name: "ok", Description: "x", Dir: "../elsewhere"}, "inside the workspace"},
+		{"non-empty target", packageOptions{Name: "taken", Description: "x"}, "not empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := addPackage(s, ws, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package main - workspaceagents.go
+//
+// PURPOSE:
+// This file links a workspace's AGENTS.md files into a hierarchy, following
+// the nested AGENTS.md convention: the root file covers the whole
+// repository, and each package's covers its own directory. It's responsible
+// for:
+// - Recording the packages `seed add package` creates in the root project's
+//   answers
+// - Re-rendering the root AGENTS.md so its Packages section lists each
+//   package's scoped AGENTS.md (the package's own links back up)
+//
+// DESIGN PATTERNS:
+// - The package list is an answer like any other, so `seed upgrade`,
+//   `status` and `regen` render the same root AGENTS.md
+// - The root file is updated like relicense.go updates license files:
+//   planned as an upgrade, merged with local edits, and left alone (for
+//   `seed upgrade` to resolve) when the edits conflict
+// - A root seed didn't scaffold is never touched; seed says what to link
+//
+// USAGE:
+// update, err := indexPackage(s, ws.Root, workspacePackage{Name: "api", Dir: "packages/api", Description: "..."})
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// rootAgentsFile is the workspace's top-level agent context.
+const rootAgentsFile = "AGENTS.md"
+
+// workspacePackage is a package added with `seed add package`, as the root
+// AGENTS.md lists it.
+type workspacePackage struct {
+	Name        string `json:"name"`
+	Dir         string `json:"dir"` // Slash-separated, relative to the workspace root
+	Description string `json:"description"`
+}
+
+// Summary returns the package description's first sentence.
+func (p workspacePackage) Summary() string {
+	return TemplateData{Description: p.Description}.Tagline()
+}
+
+// rootAgentsUpdate says what indexPackage did to the root AGENTS.md.
+type rootAgentsUpdate string
+
+const (
+	rootAgentsUpdated   rootAgentsUpdate = "updated"   // Rewritten, or merged with local edits
+	rootAgentsUnchanged rootAgentsUpdate = "unchanged" // Already lists the package
+	rootAgentsConflict  rootAgentsUpdate = "conflict"  // Local edits conflict; left for seed upgrade
+	rootAgentsUnmanaged rootAgentsUpdate = "unmanaged" // Not seed's to update (no manifest, or not generated)
+)
+
+// withPackage returns packages with pkg added (replacing one in the same
+// directory), sorted by directory.
+func withPackage(packages []workspacePackage, pkg workspacePackage) []workspacePackage {
+	out := slices.DeleteFunc(slices.Clone(packages), func(p workspacePackage) bool { return p.Dir == pkg.Dir })
+	out = append(out, pkg)
+	slices.SortFunc(out, func(a, b workspacePackage) int { return strings.Compare(a.Dir, b.Dir) })
+	return out
+}
+
+// indexPackage records pkg in the manifest of the workspace root at root
+// and brings the root AGENTS.md's Packages section up to date.
+func indexPackage(s *Scaffolder, root string, pkg workspacePackage) (rootAgentsUpdate, error) {
+	manifest, err := readManifest(root)
+	if errors.Is(err, errNoManifest) {
+		return rootAgentsUnmanaged, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	manifest.Answers.Packages = withPackage(manifest.Answers.Packages, pkg)
+
+	current, err := renderCurrent(s, manifest)
+	if err != nil {
+		return "", err
+	}
+	plan, err := planUpgradeFrom(root, manifest, current)
+	if err != nil {
+		return "", err
+	}
+	changes := filterChanges(plan.Changes, []string{rootAgentsFile})
+
+	update := rootAgentsUnchanged
+	if len(changes) > 0 {
+		switch changes[0].Action {
+		case upgradeUpdate, upgradeMerge, upgradeAdd:
+			update = rootAgentsUpdated
+		case upgradeConflict:
+			update, changes = rootAgentsConflict, nil
+		default:
+			update = rootAgentsUnmanaged
+		}
+	}
+	m := plan.Manifest
+	if err := applyChanges(root, &m, changes); err != nil {
+		return "", err
+	}
+	if err := writeManifest(root, m); err != nil {
+		return "", fmt.Errorf("failed to record package %s: %w", pkg.Dir, err)
+	}
+	return update, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mustScaffoldWorkspace scaffolds a seed project and makes it a Go workspace.
+func mustScaffoldWorkspace(t *testing.T) string {
+	t.Helper()
+	root := tempDir(t)
+	answers := WizardData{ProjectName: "mono", Description: "A monorepo", License: "MIT"}
+	if _, err := scaffoldProject(root, answers, false, map[string]struct{}{}, newPlainProgress(io.Discard)); err != nil {
+		t.Fatalf("scaffoldProject: %v", err)
+	}
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.23\n")
+	return root
+}
+
+func TestAddPackageListsItInRootAgents(t *testing.T) {
+	root := mustScaffoldWorkspace(t)
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "web", Description: "The web front end. Built with htmx."})
+	if report.RootAgents != rootAgentsUpdated {
+		t.Fatalf("expected the root AGENTS.md to be updated, got %q", report.RootAgents)
+	}
+	_, report = mustAddPackage(t, root, packageOptions{Name: "api", Description: "The HTTP API."})
+	if report.RootAgents != rootAgentsUpdated {
+		t.Fatalf("expected the root AGENTS.md to be updated again, got %q", report.RootAgents)
+	}
+
+	agents, err := os.ReadFile(filepath.Join(root, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := strings.Index(string(agents), "- [packages/api/](packages/api/AGENTS.md) - The HTTP API.")
+	web := strings.Index(string(agents), "- [packages/web/](packages/web/AGENTS.md) - The web front end.")
+	if api < 0 || web < 0 || api > web {
+		t.Fatalf("expected both packages listed, sorted by directory:\n%s", agents)
+	}
+	if strings.Contains(string(agents), "htmx") {
+		t.Error("only the first sentence of a package's description belongs in the list")
+	}
+
+	// The package's AGENTS.md links back up
+	pkgAgents, err := os.ReadFile(filepath.Join(root, "packages", "api", "AGENTS.md"))
+	if err != nil || !strings.Contains(string(pkgAgents), "(../../AGENTS.md)") {
+		t.Errorf("expected packages/api/AGENTS.md to link to the root's, got %v:\n%s", err, pkgAgents)
+	}
+
+	// The list is an answer, so status sees nothing to upgrade
+	s, err := NewScaffolder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := projectStatus(s, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Clean() {
+		t.Errorf("expected a clean status after adding packages:\n%s", formatStatus(status))
+	}
+	m, err := readManifest(root)
+	if err != nil || len(m.Answers.Packages) != 2 {
+		t.Errorf("expected two packages in the manifest, got %+v (%v)", m.Answers.Packages, err)
+	}
+}
+
+func TestAddPackageMergesEditedRootAgents(t *testing.T) {
+	root := mustScaffoldWorkspace(t)
+	path := filepath.Join(root, "AGENTS.md")
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(original), "## Testing\n", "## Testing\n\nRun the race detector.\n", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "api", Description: "The HTTP API."})
+	if report.RootAgents != rootAgentsUpdated {
+		t.Fatalf("expected a clean merge, got %q", report.RootAgents)
+	}
+	merged, _ := os.ReadFile(path)
+	if !strings.Contains(string(merged), "Run the race detector.") || !strings.Contains(string(merged), "packages/api/AGENTS.md") {
+		t.Errorf("expected the local edit and the package link:\n%s", merged)
+	}
+}
+
+func TestAddPackageLeavesUnmanagedRootAlone(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.23\n")
+	writeTestFile(t, filepath.Join(root, "AGENTS.md"), "# Ours\n")
+
+	_, report := mustAddPackage(t, root, packageOptions{Name: "api", Description: "The HTTP API."})
+	if report.RootAgents != rootAgentsUnmanaged {
+		t.Errorf("expected an unmanaged root, got %q", report.RootAgents)
+	}
+	if got, _ := os.ReadFile(filepath.Join(root, "AGENTS.md")); string(got) != "# Ours\n" {
+		t.Errorf("a root seed didn't scaffold must not change, got %q", got)
+	}
+}